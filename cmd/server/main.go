@@ -3,54 +3,321 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // the runtime image ships without a zoneinfo database
 
 	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/audit"
+	"github.com/marciomarinho/show-service/internal/changefeed"
 	"github.com/marciomarinho/show-service/internal/config"
 	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/dedupe"
 	"github.com/marciomarinho/show-service/internal/handlers"
+	"github.com/marciomarinho/show-service/internal/health"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	"github.com/marciomarinho/show-service/internal/outbox"
 	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/sanitize"
+	"github.com/marciomarinho/show-service/internal/search"
+	"github.com/marciomarinho/show-service/internal/server"
 	"github.com/marciomarinho/show-service/internal/service"
+	"github.com/marciomarinho/show-service/internal/stream"
+	"github.com/marciomarinho/show-service/internal/suggest"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+	"github.com/marciomarinho/show-service/internal/webhook"
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("config: %v", err)
+		return fmt.Errorf("config: %w", err)
 	}
 	if cfg.Env == config.EnvDev {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// SIGTERM from ECS task stops, SIGINT from a terminal
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	// Telemetry
+	shutdownTracing, err := telemetry.Setup(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("telemetry shutdown: %v", err)
+		}
+	}()
+
 	// Infra
-	dyn, err := database.NewDynamo(context.Background(), cfg)
+	dyn, err := database.NewDynamo(ctx, cfg)
 	if err != nil {
-		log.Fatalf("dynamo: %v", err)
+		return fmt.Errorf("dynamo: %w", err)
 	}
+	dyn = database.NewTracedDynamo(dyn)
+
+	// Audit
+	auditSink, err := audit.Open(cfg, dyn)
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	if closer, ok := auditSink.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				log.Printf("audit close: %v", err)
+			}
+		}()
+	}
+	auditReader, _ := auditSink.(audit.Reader)
 
 	// Repo
 	repo := repository.NewShowRepository(dyn)
+	seasonRepo := repository.NewSeasonRepository(dyn)
+	channelRepo := repository.NewChannelRepository(dyn)
+	webhookRepo := repository.NewWebhookRepository(dyn)
+
+	// Change events are written to the outbox with every show write and
+	// published in the background until the server stops
+	publisher, err := outbox.Open(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+	if closer, ok := publisher.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				log.Printf("outbox close: %v", err)
+			}
+		}()
+	}
+	// Webhooks hear of changes through the outbox too, whatever else it
+	// publishes to, and their deliveries are sent by a dispatcher of their own
+	if cfg.Webhooks.Enabled {
+		publisher = outbox.Multi(publisher, webhook.NewFanout(webhookRepo, repo))
+	}
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	var dispatching sync.WaitGroup
+	defer func() {
+		stopDispatch()
+		dispatching.Wait()
+	}()
+	if publisher != nil {
+		dispatcher := outbox.NewDispatcher(repository.NewOutboxRepository(dyn), publisher, outbox.DispatcherOptions{
+			Interval:   cfg.Outbox.Interval,
+			BatchSize:  cfg.Outbox.BatchSize,
+			Lease:      cfg.Outbox.Lease,
+			MinBackoff: cfg.Outbox.MinBackoff,
+			MaxBackoff: cfg.Outbox.MaxBackoff,
+		})
+		dispatching.Go(func() { dispatcher.Run(dispatchCtx) })
+	}
+	if cfg.Webhooks.Enabled {
+		dispatcher := webhook.NewDispatcher(webhookRepo, webhook.NewClient(cfg.Webhooks.Timeout), webhook.DispatcherOptions{
+			Interval:    cfg.Webhooks.Interval,
+			BatchSize:   cfg.Webhooks.BatchSize,
+			Lease:       cfg.Webhooks.Lease,
+			MinBackoff:  cfg.Webhooks.MinBackoff,
+			MaxBackoff:  cfg.Webhooks.MaxBackoff,
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+		})
+		dispatching.Go(func() { dispatcher.Run(dispatchCtx) })
+	}
+	// The search and duplicate indexes and suggestions live in memory, so
+	// each instance keeps its own
+	index := search.New()
+	suggestions := suggest.New(suggest.Options{MaxShows: cfg.Suggest.MaxShows})
+	duplicates := dedupe.New(dedupe.Options{Threshold: cfg.Duplicates.Threshold})
+
+	// Writes can reach the table from outside the service, so handlers that
+	// must see every change read them from the table's stream
+	if cfg.Changefeed.Enabled {
+		streams, err := database.NewStreams(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("changefeed: %w", err)
+		}
+		consumer := changefeed.NewConsumer(streams, dyn, repository.NewCheckpointRepository(dyn), changefeed.Options{
+			Name:      cfg.Changefeed.Consumer,
+			StreamARN: cfg.Changefeed.StreamARN,
+			Interval:  cfg.Changefeed.Interval,
+			BatchSize: cfg.Changefeed.BatchSize,
+			Lease:     cfg.Changefeed.Lease,
+		})
+		if cfg.Changefeed.Audit && auditSink != nil {
+			consumer.Register(changefeed.AuditHandler(auditSink), changefeed.AuditKinds...)
+		}
+		dispatching.Go(func() { consumer.Run(dispatchCtx) })
+
+		// Every instance must see every change to its own indexes, so it
+		// reads the whole stream under a consumer of its own. Checkpoints
+		// are kept in memory, like the indexes, which are rebuilt on start.
+		if cfg.Changefeed.Indexes {
+			indexer := changefeed.NewConsumer(streams, dyn, changefeed.NewMemoryCheckpoints(), changefeed.Options{
+				Name:      cfg.Changefeed.Consumer + "-indexes",
+				StreamARN: cfg.Changefeed.StreamARN,
+				Interval:  cfg.Changefeed.Interval,
+				BatchSize: cfg.Changefeed.BatchSize,
+				Lease:     cfg.Changefeed.Lease,
+			})
+			indexer.Register(changefeed.IndexHandler(index, suggestions, duplicates), changefeed.IndexKinds...)
+			dispatching.Go(func() { indexer.Run(dispatchCtx) })
+		}
+	}
 
 	// App
-	svc := service.NewShowService(repo)
+	fallback := make([]string, 0, len(cfg.Locales.Fallback))
+	for _, l := range cfg.Locales.Fallback {
+		tag, ok := normalize.Language(l)
+		if !ok {
+			return fmt.Errorf("locales: unknown fallback locale %q", l)
+		}
+		fallback = append(fallback, tag)
+	}
+	// Changes the service stores are streamed live to dashboards
+	changes := stream.NewBroker(cfg.Stream.Replay, cfg.Stream.Buffer)
+	svc := service.NewShowService(repo, channelRepo, service.ShowOptions{
+		AutoCreateChannels: cfg.Channels.AutoCreate,
+		FallbackLocales:    fallback,
+		OnChange:           changes.Publish,
+		Index:              index,
+		Suggestions:        suggestions,
+		Duplicates:         duplicates,
+		StrictDuplicates:   cfg.Duplicates.Strict,
+	})
+	// The indexes are built from the table on start and kept in step by the
+	// service's writes from then on, and by the changefeed with writes made
+	// elsewhere. Without it, an instance misses other instances' writes
+	// until the next reindex. Suggestions are also rebuilt periodically.
+	n, err := svc.Reindex(ctx)
+	if err != nil {
+		log.Printf("search index: %v", err)
+	} else {
+		log.Printf("search index: %d shows", n)
+	}
+	indexChecker := health.NewIndexChecker(svc.Reindex, err == nil)
+	if !cfg.Changefeed.Enabled || !cfg.Changefeed.Indexes {
+		log.Printf("search index: changefeed is off, so writes by other instances are only seen after a reindex")
+	}
+	if n, err := svc.RebuildSuggestions(ctx); err != nil {
+		log.Printf("suggest: %v", err)
+	} else {
+		log.Printf("suggest: %d shows", n)
+	}
+	if cfg.Suggest.Refresh > 0 {
+		dispatching.Go(func() { suggest.Refresh(dispatchCtx, cfg.Suggest.Refresh, svc.RebuildSuggestions) })
+	}
+	seasonSvc := service.NewSeasonService(seasonRepo)
+	channelSvc := service.NewChannelService(channelRepo)
+	webhookSvc := service.NewWebhookService(webhookRepo)
+
+	htmlPolicy, err := sanitize.NewPolicy(cfg.Sanitize.Allow)
+	if err != nil {
+		return fmt.Errorf("sanitize: %w", err)
+	}
+	feedZone, err := time.LoadLocation(cfg.Schedule.TimeZone)
+	if err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+	normalizer := normalize.New(normalize.Options{
+		RejectUnknown: cfg.Normalize.RejectUnknown,
+		HTML:          htmlPolicy,
+		StrictHTML:    cfg.Sanitize.Strict,
+		PlainText:     cfg.Sanitize.PlainText,
+		Location:      feedZone,
+	})
 
 	// HTTP
-	h := handlers.NewShowHandler(svc)
+	h := handlers.NewShowHandler(svc, normalizer)
+	sh := handlers.NewSeasonHandler(seasonSvc, normalizer)
+	ch := handlers.NewChannelHandler(channelSvc, normalizer)
+	ah := handlers.NewAuditHandler(auditReader)
+	wh := handlers.NewWebhookHandler(webhookSvc)
+	eh := handlers.NewEventsHandler(changes, cfg.Stream.Heartbeat)
 	r := gin.Default()
+	// Client IPs, as audited, come from X-Forwarded-For only when the peer
+	// is a trusted proxy; gin otherwise trusts every peer
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("server: trusted proxies: %w", err)
+	}
 
-	// Apply authentication middleware for non-local environments
-	r.Use(handlers.AuthMiddleware(cfg))
+	// Trace and audit every request, including those rejected by auth, and
+	// render recorded errors as application/problem+json. Auditing wraps
+	// error rendering so that it records the status sent.
+	r.Use(handlers.TracingMiddleware())
+	r.Use(handlers.RequestIDMiddleware())
+	if auditSink != nil {
+		r.Use(handlers.AuditMiddleware(auditSink))
+	}
+	r.Use(handlers.ErrorMiddleware(cfg.Validation.MaxErrors))
+	r.NoRoute(handlers.NoRoute)
 
-	// Health check endpoint (no auth required)
+	// Health probes are registered before the auth middleware so load
+	// balancers and ECS can reach them without a token
+	checkers := []health.Checker{health.NewDynamoChecker(dyn, repository.IndexDRMEpisode, repository.IndexAiring), indexChecker}
+	if cfg.Env != config.EnvLocal {
+		checkers = append(checkers, health.NewJWKSChecker(cfg.Cognito.JWKSEndpoint(), &http.Client{Timeout: cfg.Health.CheckTimeout}))
+	}
+	prober := health.NewProber(cfg.Health.CacheTTL, cfg.Health.CheckTimeout, checkers...)
+	hh := handlers.NewHealthHandler(prober)
 	r.GET("/v1/health", handlers.HealthCheck)
+	r.GET("/v1/health/live", hh.Live)
+	r.GET("/v1/health/ready", hh.Ready)
+
+	// Apply authentication middleware for non-local environments
+	r.Use(handlers.AuthMiddleware(cfg, handlers.NewCognitoVerifier(cfg.Cognito, &http.Client{Timeout: cfg.Health.CheckTimeout})))
+	r.Use(handlers.ViewerMiddleware(cfg))
 
 	// Protected endpoints
 	r.POST("/v1/shows", h.PostShows)
 	r.GET("/v1/shows", h.GetShows)
+	r.GET("/v1/shows/airing-soon", h.GetAiringSoon)
+	r.GET("/v1/shows/events", eh.GetShowEvents)
+	r.GET("/v1/shows/search", h.GetShowSearch)
+	r.POST("/v1/shows/search/reindex", h.PostShowReindex)
+	r.GET("/v1/shows/suggest", h.GetShowSuggest)
+	r.GET("/v1/shows/duplicates", h.GetShowDuplicates)
+	r.GET("/v1/shows/:handle", h.GetShow)
+	r.PUT("/v1/shows/:handle/slug", h.PutShowSlug)
+	r.PUT("/v1/shows/:handle/status", h.PutShowStatus)
+	r.GET("/v1/shows/:handle/transitions", h.GetShowTransitions)
+	r.GET("/v1/shows/:handle/revisions", h.GetShowRevisions)
+	r.GET("/v1/shows/:handle/revisions/:revision", h.GetShowRevision)
+	r.POST("/v1/shows/:handle/revisions/:revision/revert", h.PostShowRevert)
+	r.POST("/v1/shows/:handle/seasons", sh.PostSeason)
+	r.GET("/v1/shows/:handle/seasons", sh.GetSeasons)
+	r.POST("/v1/shows/:handle/seasons/:season/episodes", sh.PostEpisode)
+	r.GET("/v1/shows/:handle/seasons/:season/episodes", sh.GetEpisodes)
+	r.POST("/v1/channels", ch.PostChannel)
+	r.GET("/v1/channels", ch.GetChannels)
+	r.GET("/v1/channels/:id", ch.GetChannel)
+	r.PUT("/v1/channels/:id", ch.PutChannel)
+	r.DELETE("/v1/channels/:id", ch.DeleteChannel)
+	r.GET("/v1/genres", handlers.GetGenres)
+	r.GET("/v1/audit", ah.GetAudit)
+	r.POST("/v1/webhooks", wh.PostWebhook)
+	r.GET("/v1/webhooks", wh.GetWebhooks)
+	r.GET("/v1/webhooks/:id", wh.GetWebhook)
+	r.PUT("/v1/webhooks/:id", wh.PutWebhook)
+	r.DELETE("/v1/webhooks/:id", wh.DeleteWebhook)
+	r.GET("/v1/webhooks/:id/deliveries", wh.GetWebhookDeliveries)
+	r.POST("/v1/webhooks/:id/deliveries/:delivery/redeliver", wh.PostWebhookRedeliver)
 
-	port := 8080
-	log.Printf("env=%s table=%s listening=:%d", cfg.Env, dyn.TableName(), port)
-	if err := r.Run(fmt.Sprintf(":%d", port)); err != nil {
-		log.Fatal(err)
-	}
+	srv := server.New(cfg.Server, r)
+	srv.OnDrain(func() { prober.SetDraining(true) })
+	srv.OnShutdown(changes.Close)
+
+	log.Printf("env=%s table=%s listening=:%d", cfg.Env, dyn.TableName(), cfg.Server.Port)
+	return srv.Run(ctx)
 }
@@ -0,0 +1,230 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Sentinel errors returned by lower layers and mapped to HTTP statuses by From
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrDuplicateSlug = errors.New("slug already exists")
+	ErrThrottled     = errors.New("throttled")
+	ErrConflict      = errors.New("changed concurrently")
+)
+
+// Machine-readable error codes rendered in the problem document
+const (
+	CodeInvalidJSON       = "invalid_json"
+	CodeValidationFailed  = "validation_failed"
+	CodeDuplicateSlug     = "duplicate_slug"
+	CodeNotFound          = "not_found"
+	CodeThrottled         = "throttled"
+	CodeConflict          = "conflict"
+	CodeUnauthorized      = "unauthorized"
+	CodeInsufficientScope = "insufficient_scope"
+	CodeForbidden         = "forbidden"
+	CodeNotImplemented    = "not_implemented"
+	CodeInternal          = "internal_error"
+)
+
+// FieldError points at a single offending value with a JSON pointer
+type FieldError struct {
+	Path    string `json:"path"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error is the central application error. It carries everything needed to
+// render an RFC 7807 problem document; Err keeps the cause for logs only.
+type Error struct {
+	Status int
+	Code   string
+	Title  string
+	Detail string
+	Fields []FieldError
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Code, e.Err)
+	}
+	if e.Detail != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Detail)
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(status int, code, detail string, cause error) *Error {
+	return &Error{
+		Status: status,
+		Code:   code,
+		Title:  http.StatusText(status),
+		Detail: detail,
+		Err:    cause,
+	}
+}
+
+// InvalidJSON reports a body that could not be decoded. Type mismatches are
+// reported against the offending field.
+func InvalidJSON(err error) *Error {
+	e := New(http.StatusBadRequest, CodeInvalidJSON, "request body is not valid JSON", err)
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		e.Detail = "request body has a value of the wrong type"
+		e.Fields = []FieldError{{
+			Path:    "/" + strings.ReplaceAll(typeErr.Field, ".", "/"),
+			Code:    "invalid_type",
+			Message: fmt.Sprintf("must be %s", typeErr.Type),
+		}}
+		return e
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		e.Detail = fmt.Sprintf("request body is not valid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())
+	}
+	return e
+}
+
+// Validation converts ozzo-validation errors into a 400 with one FieldError
+// per offending path, sorted by path
+func Validation(err error) *Error {
+	fields := FieldErrors(err)
+	e := New(http.StatusBadRequest, CodeValidationFailed, "request failed validation", err)
+	e.Fields = fields
+	if len(fields) == 1 {
+		e.Detail = fields[0].Message
+	} else if len(fields) > 1 {
+		e.Detail = fmt.Sprintf("request has %d validation errors", len(fields))
+	}
+	return e
+}
+
+// LimitFields returns a copy of e keeping at most max field errors. The
+// detail keeps the full count so clients know the list was cut short.
+func (e *Error) LimitFields(max int) *Error {
+	if max <= 0 || len(e.Fields) <= max {
+		return e
+	}
+	limited := *e
+	limited.Fields = e.Fields[:max:max]
+	limited.Detail = fmt.Sprintf("request has %d validation errors; showing the first %d", len(e.Fields), max)
+	return &limited
+}
+
+func Unauthorized(detail string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, detail, nil)
+}
+
+// InsufficientScope denies a token that lacks the OAuth scope for a route
+func InsufficientScope(detail string) *Error {
+	return New(http.StatusForbidden, CodeInsufficientScope, detail, nil)
+}
+
+// Forbidden denies a user whose roles or groups do not allow the action
+func Forbidden(detail string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, detail, nil)
+}
+
+func NotFound(detail string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, detail, nil)
+}
+
+// From maps any error to an *Error. Unknown errors become a 500 whose detail
+// does not leak the cause.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	switch {
+	case errors.Is(err, ErrDuplicateSlug):
+		return New(http.StatusConflict, CodeDuplicateSlug, err.Error(), err)
+	case errors.Is(err, ErrNotFound):
+		return New(http.StatusNotFound, CodeNotFound, err.Error(), err)
+	case errors.Is(err, ErrConflict):
+		return New(http.StatusConflict, CodeConflict, err.Error()+", retry", err)
+	case errors.Is(err, ErrThrottled):
+		return New(http.StatusServiceUnavailable, CodeThrottled, "the data store is throttling requests, retry later", err)
+	}
+
+	var ve validation.Error
+	var ves validation.Errors
+	if errors.As(err, &ves) || errors.As(err, &ve) {
+		return Validation(err)
+	}
+
+	return New(http.StatusInternalServerError, CodeInternal, "an unexpected error occurred", err)
+}
+
+// FieldErrors flattens nested validation.Errors into JSON-pointer paths
+func FieldErrors(err error) []FieldError {
+	var out []FieldError
+	collectFieldErrors("", err, &out)
+	return out
+}
+
+func collectFieldErrors(path string, err error, out *[]FieldError) {
+	if err == nil {
+		return
+	}
+
+	// errors.Join lets independent stages report together
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			collectFieldErrors(path, e, out)
+		}
+		return
+	}
+
+	var ves validation.Errors
+	if errors.As(err, &ves) {
+		keys := make([]string, 0, len(ves))
+		for k := range ves {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+		for _, k := range keys {
+			collectFieldErrors(path+"/"+escapePointer(k), ves[k], out)
+		}
+		return
+	}
+
+	var ve validation.Error
+	if errors.As(err, &ve) {
+		*out = append(*out, FieldError{Path: path, Code: ve.Code(), Message: ve.Message()})
+		return
+	}
+
+	*out = append(*out, FieldError{Path: path, Code: "invalid", Message: err.Error()})
+}
+
+// lessKey orders array indexes numerically so /payload/2 precedes /payload/10
+func lessKey(a, b string) bool {
+	ai, aErr := strconv.Atoi(a)
+	bi, bErr := strconv.Atoi(b)
+	if aErr == nil && bErr == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+// escapePointer escapes a JSON pointer reference token (RFC 6901)
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,174 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "app error passes through",
+			err:            fmt.Errorf("wrapped: %w", Unauthorized("no token")),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   CodeUnauthorized,
+		},
+		{
+			name:           "missing scope",
+			err:            InsufficientScope("Insufficient scope"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   CodeInsufficientScope,
+		},
+		{
+			name:           "missing role",
+			err:            fmt.Errorf("wrapped: %w", Forbidden("publishing requires the publisher role")),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   CodeForbidden,
+		},
+		{
+			name:           "duplicate slug",
+			err:            fmt.Errorf("show show/a: %w", ErrDuplicateSlug),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeDuplicateSlug,
+		},
+		{
+			name:           "not found",
+			err:            fmt.Errorf("show show/a: %w", ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeNotFound,
+		},
+		{
+			name:           "conflict",
+			err:            fmt.Errorf("show show/a: %w", ErrConflict),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeConflict,
+		},
+		{
+			name:           "throttled",
+			err:            fmt.Errorf("%w: ThrottlingException", ErrThrottled),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   CodeThrottled,
+		},
+		{
+			name:           "validation errors",
+			err:            validation.Errors{"title": validation.NewError("title_required", "title is required")},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+		},
+		{
+			name:           "unknown error",
+			err:            errors.New("connection reset by peer"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := From(tt.err)
+			require.Equal(t, tt.expectedStatus, got.Status)
+			require.Equal(t, tt.expectedCode, got.Code)
+			require.Equal(t, http.StatusText(tt.expectedStatus), got.Title)
+		})
+	}
+}
+
+func TestFrom_UnknownErrorDoesNotLeakCause(t *testing.T) {
+	got := From(errors.New("dial tcp 10.0.0.1:443: secret internals"))
+	require.NotContains(t, got.Detail, "secret")
+	require.ErrorContains(t, got, "secret", "cause is kept for logs")
+}
+
+func TestFieldErrors(t *testing.T) {
+	err := validation.Errors{
+		"payload": validation.Errors{
+			"0": validation.Errors{
+				"title": validation.NewError("title_required", "title is required"),
+				"a/b~c": validation.NewError("odd", "odd key"),
+			},
+		},
+		"take": validation.NewError("take_invalid", "take must be between 1 and 100"),
+	}
+
+	require.Equal(t, []FieldError{
+		{Path: "/payload/0/a~1b~0c", Code: "odd", Message: "odd key"},
+		{Path: "/payload/0/title", Code: "title_required", Message: "title is required"},
+		{Path: "/take", Code: "take_invalid", Message: "take must be between 1 and 100"},
+	}, FieldErrors(err))
+}
+
+func TestFieldErrors_OrdersIndexesNumerically(t *testing.T) {
+	items := validation.Errors{}
+	for _, i := range []string{"10", "2", "1"} {
+		items[i] = validation.Errors{"title": validation.NewError("title_required", "title is required")}
+	}
+
+	var paths []string
+	for _, f := range FieldErrors(validation.Errors{"payload": items}) {
+		paths = append(paths, f.Path)
+	}
+	require.Equal(t, []string{"/payload/1/title", "/payload/2/title", "/payload/10/title"}, paths)
+}
+
+func TestError_LimitFields(t *testing.T) {
+	errs := validation.Errors{}
+	for _, field := range []string{"a", "b", "c", "d"} {
+		errs[field] = validation.NewError("invalid", "invalid")
+	}
+	full := Validation(errs)
+
+	limited := full.LimitFields(2)
+	require.Len(t, limited.Fields, 2)
+	require.Equal(t, "request has 4 validation errors; showing the first 2", limited.Detail)
+	require.Len(t, full.Fields, 4, "original is left untouched")
+
+	require.Same(t, full, full.LimitFields(0))
+	require.Same(t, full, full.LimitFields(4))
+}
+
+func TestValidation_Detail(t *testing.T) {
+	single := Validation(validation.Errors{"take": validation.NewError("take_invalid", "take must be between 1 and 100")})
+	require.Equal(t, "take must be between 1 and 100", single.Detail)
+
+	multiple := Validation(validation.Errors{
+		"skip": validation.NewError("skip_invalid", "skip must be >= 0"),
+		"take": validation.NewError("take_invalid", "take must be between 1 and 100"),
+	})
+	require.Equal(t, "request has 2 validation errors", multiple.Detail)
+	require.Len(t, multiple.Fields, 2)
+}
+
+func TestInvalidJSON(t *testing.T) {
+	t.Run("syntax error", func(t *testing.T) {
+		var v map[string]any
+		err := json.Unmarshal([]byte(`{"payload": [}`), &v)
+
+		got := InvalidJSON(err)
+		require.Equal(t, http.StatusBadRequest, got.Status)
+		require.Equal(t, CodeInvalidJSON, got.Code)
+		require.Contains(t, got.Detail, "offset")
+		require.Empty(t, got.Fields)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var v struct {
+			Take int `json:"take"`
+		}
+		err := json.Unmarshal([]byte(`{"take": "ten"}`), &v)
+
+		got := InvalidJSON(err)
+		require.Equal(t, CodeInvalidJSON, got.Code)
+		require.Equal(t, []FieldError{{Path: "/take", Code: "invalid_type", Message: "must be int"}}, got.Fields)
+	})
+}
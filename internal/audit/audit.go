@@ -0,0 +1,92 @@
+// Package audit records who changed what. Every mutating request becomes a
+// Record handed to a Sink; sinks only ever append.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/config"
+	"github.com/marciomarinho/show-service/internal/database"
+)
+
+const (
+	SinkNone     = "none"
+	SinkStdout   = "stdout"
+	SinkFile     = "file"
+	SinkDynamoDB = "dynamodb"
+)
+
+// Outcomes of an audited request
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure" // answered with a 4xx or 5xx
+)
+
+// Record is one mutating request
+type Record struct {
+	At        time.Time `json:"at" dynamodbav:"at"`
+	RequestID string    `json:"requestId" dynamodbav:"requestId"`
+	Actor     string    `json:"actor,omitempty" dynamodbav:"actor,omitempty"`       // Sub; "local" with authentication off
+	Sub       string    `json:"sub,omitempty" dynamodbav:"sub,omitempty"`           // the token's sub claim
+	Method    string    `json:"method" dynamodbav:"method"`                         // HTTP method
+	Route     string    `json:"route" dynamodbav:"route"`                           // route pattern, e.g. /v1/shows/:handle/status
+	Path      string    `json:"path" dynamodbav:"path"`                             // request path as received
+	Slugs     []string  `json:"slugs,omitempty" dynamodbav:"slugs,omitempty"`       // show slugs or channel IDs affected
+	Status    int       `json:"status" dynamodbav:"status"`                         // HTTP status answered
+	Outcome   string    `json:"outcome" dynamodbav:"outcome"`                       // success or failure
+	SourceIP  string    `json:"sourceIp,omitempty" dynamodbav:"sourceIp,omitempty"` // client address, from X-Forwarded-For only behind server.trustedProxies
+}
+
+// Sink stores records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, r Record) error
+}
+
+// Query selects records at or after From and before To, optionally made by
+// one actor. Limit caps the records returned; 0 means no cap.
+type Query struct {
+	Actor    string
+	From, To time.Time
+	Limit    int
+}
+
+// Matches reports whether r falls within q, ignoring Limit
+func (q Query) Matches(r Record) bool {
+	return !r.At.Before(q.From) && r.At.Before(q.To) && (q.Actor == "" || r.Actor == q.Actor)
+}
+
+// Reader is implemented by sinks that can read their records back. Records
+// are returned oldest first.
+type Reader interface {
+	Query(ctx context.Context, q Query) ([]Record, error)
+}
+
+// Open returns the sink named by cfg.Audit. The dynamodb sink writes through
+// db to the audit table; nil is returned for "none".
+func Open(cfg *config.Config, db database.DynamoAPI) (Sink, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	switch cfg.Audit.Sink {
+	case "", SinkNone:
+		return nil, nil
+	case SinkStdout:
+		return NewWriterSink(os.Stdout), nil
+	case SinkFile:
+		if cfg.Audit.File == "" {
+			return nil, fmt.Errorf("the file audit sink requires a file")
+		}
+		return OpenFile(cfg.Audit.File)
+	case SinkDynamoDB:
+		if cfg.Audit.Table == "" {
+			return nil, fmt.Errorf("the dynamodb audit sink requires a table")
+		}
+		return NewDynamoSink(db, cfg.Audit.Table), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q", cfg.Audit.Sink)
+	}
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/config"
+	dbMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+)
+
+func TestOpen(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		want        any
+		expectError bool
+	}{
+		{name: "nil config", expectError: true},
+		{name: "none", cfg: &config.Config{Audit: config.Audit{Sink: SinkNone}}},
+		{name: "empty sink defaults to none", cfg: &config.Config{}},
+		{name: "stdout", cfg: &config.Config{Audit: config.Audit{Sink: SinkStdout}}, want: &WriterSink{}},
+		{name: "file", cfg: &config.Config{Audit: config.Audit{Sink: SinkFile, File: file}}, want: &FileSink{}},
+		{name: "file without a path", cfg: &config.Config{Audit: config.Audit{Sink: SinkFile}}, expectError: true},
+		{name: "dynamodb", cfg: &config.Config{Audit: config.Audit{Sink: SinkDynamoDB, Table: "audit"}}, want: &DynamoSink{}},
+		{name: "dynamodb without a table", cfg: &config.Config{Audit: config.Audit{Sink: SinkDynamoDB}}, expectError: true},
+		{name: "unknown sink", cfg: &config.Config{Audit: config.Audit{Sink: "syslog"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := Open(tt.cfg, dbMocks.NewMockDynamoAPI(t))
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				require.Nil(t, sink)
+				return
+			}
+			require.IsType(t, tt.want, sink)
+			if f, ok := sink.(*FileSink); ok {
+				require.NoError(t, f.Close())
+			}
+		})
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	noon := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	q := Query{Actor: "u1", From: noon, To: noon.Add(time.Hour)}
+
+	tests := []struct {
+		name string
+		r    Record
+		want bool
+	}{
+		{name: "at from", r: Record{At: noon, Actor: "u1"}, want: true},
+		{name: "before from", r: Record{At: noon.Add(-time.Nanosecond), Actor: "u1"}},
+		{name: "at to", r: Record{At: noon.Add(time.Hour), Actor: "u1"}},
+		{name: "another actor", r: Record{At: noon, Actor: "u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, q.Matches(tt.r))
+		})
+	}
+
+	anyone := Query{From: noon, To: noon.Add(time.Hour)}
+	require.True(t, anyone.Matches(Record{At: noon, Actor: "u2"}))
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marciomarinho/show-service/internal/database"
+)
+
+// Audit table keys. Records are partitioned by UTC day and sorted by id,
+// the record's time at a fixed width followed by its request ID, so that a
+// time range is a key range.
+const (
+	dayLayout = "2006-01-02"
+	idLayout  = "2006-01-02T15:04:05.000000000Z"
+)
+
+// DynamoSink writes records to their own table, keyed by day and id
+type DynamoSink struct {
+	db    database.DynamoAPI
+	table string
+}
+
+var (
+	_ Sink   = (*DynamoSink)(nil)
+	_ Reader = (*DynamoSink)(nil)
+)
+
+func NewDynamoSink(db database.DynamoAPI, table string) *DynamoSink {
+	return &DynamoSink{db: db, table: table}
+}
+
+type dynamoItem struct {
+	Day string `dynamodbav:"day"`
+	ID  string `dynamodbav:"id"`
+	Record
+}
+
+// Write puts r, refusing to replace an existing record
+func (s *DynamoSink) Write(ctx context.Context, r Record) error {
+	at := r.At.UTC()
+	item, err := attributevalue.MarshalMap(dynamoItem{
+		Day:    at.Format(dayLayout),
+		ID:     at.Format(idLayout) + "#" + r.RequestID,
+		Record: r,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(id)"),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("audit record %s already written", r.RequestID)
+	}
+	return err
+}
+
+// Query reads q's range one day partition at a time. Filtering by actor
+// happens server side after the key range is read.
+func (s *DynamoSink) Query(ctx context.Context, q Query) ([]Record, error) {
+	out := []Record{}
+	from, to := q.From.UTC(), q.To.UTC()
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.AddDate(0, 0, 1) {
+		in := &dynamodb.QueryInput{
+			TableName:                aws.String(s.table),
+			KeyConditionExpression:   aws.String("#day = :day AND id BETWEEN :from AND :to"),
+			ExpressionAttributeNames: map[string]string{"#day": "day"},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":day":  &types.AttributeValueMemberS{Value: day.Format(dayLayout)},
+				":from": &types.AttributeValueMemberS{Value: from.Format(idLayout)},
+				":to":   &types.AttributeValueMemberS{Value: to.Format(idLayout)},
+			},
+		}
+		if q.Actor != "" {
+			in.FilterExpression = aws.String("actor = :actor")
+			in.ExpressionAttributeValues[":actor"] = &types.AttributeValueMemberS{Value: q.Actor}
+		}
+
+		for {
+			page, err := s.db.Query(ctx, in)
+			if err != nil {
+				return nil, err
+			}
+			var records []Record
+			if err := attributevalue.UnmarshalListOfMaps(page.Items, &records); err != nil {
+				return nil, err
+			}
+			for _, r := range records {
+				out = append(out, r)
+				if q.Limit > 0 && len(out) == q.Limit {
+					return out, nil
+				}
+			}
+			if len(page.LastEvaluatedKey) == 0 {
+				break
+			}
+			in.ExclusiveStartKey = page.LastEvaluatedKey
+		}
+	}
+	return out, nil
+}
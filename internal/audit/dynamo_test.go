@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+)
+
+func TestDynamoSink_Write(t *testing.T) {
+	at := time.Date(2024, 6, 15, 12, 0, 0, 5000, time.FixedZone("AEST", 10*3600))
+
+	t.Run("appends under the UTC day", func(t *testing.T) {
+		mockDB := dbMocks.NewMockDynamoAPI(t)
+		mockDB.EXPECT().PutItem(mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			var item struct {
+				Day       string   `dynamodbav:"day"`
+				ID        string   `dynamodbav:"id"`
+				RequestID string   `dynamodbav:"requestId"`
+				Slugs     []string `dynamodbav:"slugs"`
+			}
+			require.NoError(t, attributevalue.UnmarshalMap(in.Item, &item))
+			return aws.ToString(in.TableName) == "audit" &&
+				aws.ToString(in.ConditionExpression) == "attribute_not_exists(id)" &&
+				item.Day == "2024-06-15" &&
+				item.ID == "2024-06-15T02:00:00.000005000Z#r1" &&
+				item.RequestID == "r1" &&
+				len(item.Slugs) == 1
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		sink := NewDynamoSink(mockDB, "audit")
+		require.NoError(t, sink.Write(context.Background(), Record{At: at, RequestID: "r1", Slugs: []string{"show/a"}}))
+	})
+
+	t.Run("never overwrites", func(t *testing.T) {
+		mockDB := dbMocks.NewMockDynamoAPI(t)
+		mockDB.EXPECT().PutItem(mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		err := NewDynamoSink(mockDB, "audit").Write(context.Background(), Record{At: at, RequestID: "r1"})
+		require.ErrorContains(t, err, "already written")
+	})
+}
+
+func TestDynamoSink_Query(t *testing.T) {
+	from := time.Date(2024, 6, 14, 23, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	item := func(id string) map[string]types.AttributeValue {
+		m, err := attributevalue.MarshalMap(dynamoItem{ID: id, Record: Record{RequestID: id, Actor: "u1"}})
+		require.NoError(t, err)
+		return m
+	}
+	queried := func(day string) func(*dynamodb.QueryInput) bool {
+		return func(in *dynamodb.QueryInput) bool {
+			return in.ExpressionAttributeValues[":day"].(*types.AttributeValueMemberS).Value == day
+		}
+	}
+
+	t.Run("walks each day, following pages", func(t *testing.T) {
+		mockDB := dbMocks.NewMockDynamoAPI(t)
+		mockDB.EXPECT().Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return queried("2024-06-14")(in) &&
+				aws.ToString(in.KeyConditionExpression) == "#day = :day AND id BETWEEN :from AND :to" &&
+				in.ExpressionAttributeValues[":from"].(*types.AttributeValueMemberS).Value == "2024-06-14T23:00:00.000000000Z" &&
+				in.ExpressionAttributeValues[":to"].(*types.AttributeValueMemberS).Value == "2024-06-16T00:00:00.000000000Z" &&
+				aws.ToString(in.FilterExpression) == "actor = :actor"
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item("a")}}, nil).Once()
+		mockDB.EXPECT().Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return queried("2024-06-15")(in) && in.ExclusiveStartKey == nil
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]types.AttributeValue{item("b")},
+			LastEvaluatedKey: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "b"}},
+		}, nil).Once()
+		mockDB.EXPECT().Query(mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return queried("2024-06-15")(in) && in.ExclusiveStartKey != nil
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item("c")}}, nil).Once()
+
+		records, err := NewDynamoSink(mockDB, "audit").Query(context.Background(), Query{Actor: "u1", From: from, To: to})
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		require.Equal(t, "a", records[0].RequestID)
+		require.Equal(t, "c", records[2].RequestID)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		mockDB := dbMocks.NewMockDynamoAPI(t)
+		mockDB.EXPECT().Query(mock.Anything, mock.MatchedBy(queried("2024-06-14"))).
+			Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item("a"), item("b")}}, nil).Once()
+
+		records, err := NewDynamoSink(mockDB, "audit").Query(context.Background(), Query{From: from, To: to, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockDB := dbMocks.NewMockDynamoAPI(t)
+		mockDB.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err := NewDynamoSink(mockDB, "audit").Query(context.Background(), Query{From: from, To: to})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterSink writes each record as one line of JSON
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ Sink = (*WriterSink)(nil)
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Write(_ context.Context, r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(line)
+	return err
+}
+
+// FileSink appends JSON lines to a file and reads them back for queries
+type FileSink struct {
+	*WriterSink
+	file *os.File
+}
+
+var (
+	_ Sink   = (*FileSink)(nil)
+	_ Reader = (*FileSink)(nil)
+)
+
+// OpenFile opens path for appending, creating it if needed
+func OpenFile(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("audit file: %w", err)
+	}
+	return &FileSink{WriterSink: NewWriterSink(f), file: f}, nil
+}
+
+// Query scans the whole file. Records are appended as requests finish, so
+// the order is close to, but not strictly, chronological.
+func (s *FileSink) Query(ctx context.Context, q Query) ([]Record, error) {
+	f, err := os.Open(s.file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("audit file: %w", err)
+	}
+	defer f.Close()
+
+	out := []Record{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", s.file.Name(), line, err)
+		}
+		if !q.Matches(r) {
+			continue
+		}
+		out = append(out, r)
+		if q.Limit > 0 && len(out) == q.Limit {
+			break
+		}
+	}
+	return out, scanner.Err()
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.file.Sync(), s.file.Close())
+}
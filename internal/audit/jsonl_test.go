@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+
+	at := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, sink.Write(context.Background(), Record{
+		At: at, RequestID: "r1", Actor: "u1", Sub: "u1", Method: "POST", Route: "/v1/shows", Path: "/v1/shows",
+		Slugs: []string{"show/a"}, Status: 201, Outcome: OutcomeSuccess, SourceIP: "10.0.0.1",
+	}))
+	require.NoError(t, sink.Write(context.Background(), Record{At: at, RequestID: "r2", Method: "DELETE", Status: 404, Outcome: OutcomeFailure}))
+
+	require.Equal(t,
+		`{"at":"2024-06-15T12:00:00Z","requestId":"r1","actor":"u1","sub":"u1","method":"POST","route":"/v1/shows","path":"/v1/shows","slugs":["show/a"],"status":201,"outcome":"success","sourceIp":"10.0.0.1"}`+"\n"+
+			`{"at":"2024-06-15T12:00:00Z","requestId":"r2","method":"DELETE","route":"","path":"","status":404,"outcome":"failure"}`+"\n",
+		buf.String())
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	noon := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	sink, err := OpenFile(path)
+	require.NoError(t, err)
+	for i, actor := range []string{"u1", "u2", "u1", "u1"} {
+		require.NoError(t, sink.Write(context.Background(), Record{At: noon.Add(time.Duration(i) * time.Hour), RequestID: string(rune('a' + i)), Actor: actor}))
+	}
+	require.NoError(t, sink.Close())
+
+	// Reopening appends rather than truncates
+	sink, err = OpenFile(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sink.Close() })
+	require.NoError(t, sink.Write(context.Background(), Record{At: noon.Add(4 * time.Hour), RequestID: "e", Actor: "u1"}))
+
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{name: "everything", q: Query{From: noon, To: noon.Add(24 * time.Hour)}, want: []string{"a", "b", "c", "d", "e"}},
+		{name: "one actor", q: Query{Actor: "u1", From: noon, To: noon.Add(24 * time.Hour)}, want: []string{"a", "c", "d", "e"}},
+		{name: "time range", q: Query{From: noon.Add(time.Hour), To: noon.Add(3 * time.Hour)}, want: []string{"b", "c"}},
+		{name: "limited", q: Query{Actor: "u1", From: noon, To: noon.Add(24 * time.Hour), Limit: 2}, want: []string{"a", "c"}},
+		{name: "nothing", q: Query{From: noon.Add(-time.Hour), To: noon}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := sink.Query(context.Background(), tt.q)
+			require.NoError(t, err)
+			ids := []string{}
+			for _, r := range records {
+				ids = append(ids, r.RequestID)
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestFileSink_Query_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"requestId\":\"a\"}\nnot json\n"), 0o600))
+
+	sink, err := OpenFile(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sink.Close() })
+
+	_, err = sink.Query(context.Background(), Query{To: time.Now()})
+	require.ErrorContains(t, err, "audit.jsonl:2")
+}
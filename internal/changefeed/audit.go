@@ -0,0 +1,73 @@
+package changefeed
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marciomarinho/show-service/internal/audit"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// Audit records of stream changes
+const (
+	AuditActor = "dynamodb-stream" // actor of changes DynamoDB did not make itself
+	AuditRoute = "dynamodb-stream"
+)
+
+// AuditKinds are the kinds of item AuditHandler is meant for
+var AuditKinds = []string{
+	repository.KindShow, repository.KindAlias, repository.KindSeason,
+	repository.KindEpisode, repository.KindChannel,
+}
+
+// AuditHandler appends a record of each change made outside the service to
+// sink, so that the audit log covers those writes too. The service's own
+// writes, which its audit middleware records, are told apart by their write
+// ID and skipped. The record's method is the operation, its path the item's
+// key and its request ID the stream event ID, which a change handled twice
+// keeps.
+func AuditHandler(sink audit.Sink) Handler {
+	return HandlerFunc(func(ctx context.Context, c Change) error {
+		if madeByService(c) {
+			return nil
+		}
+		actor := AuditActor
+		if c.Principal != "" {
+			actor = c.Principal
+		}
+		slug := c.Slug
+		if c.Kind == repository.KindChannel {
+			slug = channelID(c)
+		}
+		return sink.Write(ctx, audit.Record{
+			At:        c.At,
+			RequestID: c.ID,
+			Actor:     actor,
+			Method:    strings.ToUpper(string(c.Op)),
+			Route:     AuditRoute,
+			Path:      c.Slug + "/" + c.SK,
+			Slugs:     []string{slug},
+			Outcome:   audit.OutcomeSuccess,
+		})
+	})
+}
+
+// madeByService tells whether c is one of the service's writes: one setting
+// a new write ID, or a delete of an item it marked as deleting
+func madeByService(c Change) bool {
+	if c.Op == OpRemove {
+		v, ok := c.Old[repository.DeletingAttr].(*types.AttributeValueMemberBOOL)
+		return ok && v.Value
+	}
+	id := str(c.New[repository.WriteIDAttr])
+	return id != "" && id != str(c.Old[repository.WriteIDAttr])
+}
+
+func channelID(c Change) string {
+	if id := str(c.New["id"]); id != "" {
+		return id
+	}
+	return str(c.Old["id"])
+}
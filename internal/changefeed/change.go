@@ -0,0 +1,144 @@
+// Package changefeed reads the shows table's DynamoDB stream, so that writes
+// made outside the service, such as backfills or the aws CLI, are seen as
+// well as its own. A Consumer polls each shard of the stream, turns its
+// records into typed Changes and hands each to the handlers registered for
+// its kind, checkpointing in DynamoDB how far it has read.
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// Op is what a change did to its item
+type Op string
+
+const (
+	OpInsert Op = "insert"
+	OpModify Op = "modify"
+	OpRemove Op = "remove"
+)
+
+// Change is one write to the shows table, read from its stream
+type Change struct {
+	ID       string // the stream record's event ID
+	Sequence string // the record's sequence number within its shard
+	Op       Op
+	Kind     string    // one of the repository Kind constants
+	Slug     string    // partition key
+	SK       string    // sort key
+	At       time.Time // approximate, to the second
+	// Principal is set when DynamoDB itself made the change, e.g. a delete
+	// on TTL expiry
+	Principal string
+	// Old is the item before the change, nil for inserts; New is the item
+	// after it, nil for removes
+	Old, New map[string]types.AttributeValue
+}
+
+// Handler reacts to changes. Changes are delivered at least once, in order
+// per item, so handlers should be idempotent.
+type Handler interface {
+	HandleChange(ctx context.Context, c Change) error
+}
+
+// HandlerFunc adapts a function to a Handler
+type HandlerFunc func(ctx context.Context, c Change) error
+
+func (f HandlerFunc) HandleChange(ctx context.Context, c Change) error {
+	return f(ctx, c)
+}
+
+// Images decodes c's item before and after the change into T, as the
+// repository reads items, returning nil for an image c lacks. T is the
+// domain type of c.Kind, e.g. domain.Show for repository.KindShow.
+func Images[T any](c Change) (before, after *T, err error) {
+	decode := func(item map[string]types.AttributeValue) (*T, error) {
+		if item == nil {
+			return nil, nil
+		}
+		var v T
+		if err := repository.UnmarshalItem(item, &v); err != nil {
+			return nil, fmt.Errorf("change %s: %w", c.ID, err)
+		}
+		return &v, nil
+	}
+	if before, err = decode(c.Old); err != nil {
+		return nil, nil, err
+	}
+	if after, err = decode(c.New); err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// changeOf converts a stream record
+func changeOf(r streamstypes.Record) (Change, error) {
+	c := Change{ID: value(r.EventID)}
+	switch r.EventName {
+	case streamstypes.OperationTypeInsert:
+		c.Op = OpInsert
+	case streamstypes.OperationTypeModify:
+		c.Op = OpModify
+	case streamstypes.OperationTypeRemove:
+		c.Op = OpRemove
+	default:
+		return Change{}, fmt.Errorf("record %s: unknown event %q", c.ID, r.EventName)
+	}
+	if r.UserIdentity != nil {
+		c.Principal = value(r.UserIdentity.PrincipalId)
+	}
+	if r.Dynamodb == nil {
+		return Change{}, fmt.Errorf("record %s: no stream record", c.ID)
+	}
+	c.Sequence = value(r.Dynamodb.SequenceNumber)
+	if r.Dynamodb.ApproximateCreationDateTime != nil {
+		c.At = r.Dynamodb.ApproximateCreationDateTime.UTC()
+	}
+
+	keys, err := attributevalue.FromDynamoDBStreamsMap(r.Dynamodb.Keys)
+	if err != nil {
+		return Change{}, fmt.Errorf("record %s keys: %w", c.ID, err)
+	}
+	if c.Old, err = image(r.Dynamodb.OldImage); err != nil {
+		return Change{}, fmt.Errorf("record %s old image: %w", c.ID, err)
+	}
+	if c.New, err = image(r.Dynamodb.NewImage); err != nil {
+		return Change{}, fmt.Errorf("record %s new image: %w", c.ID, err)
+	}
+	c.Slug, c.SK = str(keys["slug"]), str(keys["sk"])
+
+	// A rename turns a show item into an alias, so either image will do
+	_, aliasOld := c.Old["aliasOf"]
+	_, aliasNew := c.New["aliasOf"]
+	c.Kind = repository.KindOf(c.Slug, c.SK, aliasOld || aliasNew)
+	return c, nil
+}
+
+func image(item map[string]streamstypes.AttributeValue) (map[string]types.AttributeValue, error) {
+	if item == nil {
+		return nil, nil
+	}
+	return attributevalue.FromDynamoDBStreamsMap(item)
+}
+
+func str(v types.AttributeValue) string {
+	if s, ok := v.(*types.AttributeValueMemberS); ok {
+		return s.Value
+	}
+	return ""
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,174 @@
+package changefeed
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/audit"
+	auditMocks "github.com/marciomarinho/show-service/internal/audit/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/search"
+)
+
+func TestChangeOf(t *testing.T) {
+	at := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("a show modified", func(t *testing.T) {
+		r := record("7", streamstypes.OperationTypeModify, "show/a", "SHOW")
+		r.Dynamodb.ApproximateCreationDateTime = &at
+		r.Dynamodb.OldImage = map[string]streamstypes.AttributeValue{
+			"slug":  &streamstypes.AttributeValueMemberS{Value: "show/a"},
+			"title": &streamstypes.AttributeValueMemberS{Value: "Old"},
+		}
+		r.Dynamodb.NewImage = map[string]streamstypes.AttributeValue{
+			"slug":  &streamstypes.AttributeValueMemberS{Value: "show/a"},
+			"title": &streamstypes.AttributeValueMemberS{Value: "New"},
+		}
+
+		c, err := changeOf(r)
+		require.NoError(t, err)
+		require.Equal(t, "event-7", c.ID)
+		require.Equal(t, "7", c.Sequence)
+		require.Equal(t, OpModify, c.Op)
+		require.Equal(t, repository.KindShow, c.Kind)
+		require.Equal(t, "show/a", c.Slug)
+		require.Equal(t, "SHOW", c.SK)
+		require.Equal(t, at, c.At)
+
+		before, after, err := Images[domain.Show](c)
+		require.NoError(t, err)
+		require.Equal(t, "Old", before.Title)
+		require.Equal(t, "New", after.Title)
+	})
+
+	t.Run("a show renamed away becomes an alias", func(t *testing.T) {
+		r := record("8", streamstypes.OperationTypeModify, "show/old", "SHOW")
+		r.Dynamodb.NewImage["aliasOf"] = &streamstypes.AttributeValueMemberS{Value: "show/new"}
+
+		c, err := changeOf(r)
+		require.NoError(t, err)
+		require.Equal(t, repository.KindAlias, c.Kind)
+	})
+
+	t.Run("a removal by TTL", func(t *testing.T) {
+		r := record("9", streamstypes.OperationTypeRemove, "show/a", "SEASON#0001")
+		r.Dynamodb.OldImage, r.Dynamodb.NewImage = r.Dynamodb.NewImage, nil
+		r.UserIdentity = &streamstypes.Identity{PrincipalId: aws.String("dynamodb.amazonaws.com"), Type: aws.String("Service")}
+
+		c, err := changeOf(r)
+		require.NoError(t, err)
+		require.Equal(t, OpRemove, c.Op)
+		require.Equal(t, "dynamodb.amazonaws.com", c.Principal)
+		before, after, err := Images[domain.Season](c)
+		require.NoError(t, err)
+		require.NotNil(t, before)
+		require.Nil(t, after)
+	})
+
+	t.Run("an unknown event", func(t *testing.T) {
+		_, err := changeOf(record("10", "TRUNCATE", "show/a", "SHOW"))
+		require.EqualError(t, err, `record event-10: unknown event "TRUNCATE"`)
+	})
+}
+
+func TestAuditHandler(t *testing.T) {
+	at := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC)
+	sink := auditMocks.NewMockSink(t)
+	sink.EXPECT().Write(mock.Anything, audit.Record{
+		At:        at,
+		RequestID: "event-1",
+		Actor:     AuditActor,
+		Method:    "INSERT",
+		Route:     AuditRoute,
+		Path:      "show/a/SEASON#0001",
+		Slugs:     []string{"show/a"},
+		Outcome:   audit.OutcomeSuccess,
+	}).Return(nil).Once()
+	sink.EXPECT().Write(mock.Anything, mock.MatchedBy(func(r audit.Record) bool {
+		return r.Method == "REMOVE" && r.Path == "channels/CHANNEL#abc" && r.Slugs[0] == "abc"
+	})).Return(nil).Once()
+
+	h := AuditHandler(sink)
+	require.NoError(t, h.HandleChange(context.Background(), Change{
+		ID: "event-1", Op: OpInsert, Kind: repository.KindSeason, Slug: "show/a", SK: "SEASON#0001", At: at,
+	}))
+	channel, err := changeOf(record("2", streamstypes.OperationTypeRemove, "channels", "CHANNEL#abc"))
+	require.NoError(t, err)
+	channel.Old, channel.New = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "abc"}}, nil
+	require.NoError(t, h.HandleChange(context.Background(), channel))
+
+	t.Run("the service's own writes are skipped", func(t *testing.T) {
+		writeID := func(id string) map[string]types.AttributeValue {
+			return map[string]types.AttributeValue{repository.WriteIDAttr: &types.AttributeValueMemberS{Value: id}}
+		}
+		h := AuditHandler(auditMocks.NewMockSink(t))
+		for _, c := range []Change{
+			{ID: "event-3", Op: OpInsert, Kind: repository.KindEpisode, New: writeID("w1")},
+			{ID: "event-4", Op: OpModify, Kind: repository.KindShow, Old: writeID("w1"), New: writeID("w2")},
+			{ID: "event-5", Op: OpRemove, Kind: repository.KindChannel, Old: map[string]types.AttributeValue{
+				repository.DeletingAttr: &types.AttributeValueMemberBOOL{Value: true},
+			}},
+		} {
+			require.NoError(t, h.HandleChange(context.Background(), c))
+		}
+	})
+
+	t.Run("a write outside the service keeps the write ID", func(t *testing.T) {
+		sink := auditMocks.NewMockSink(t)
+		sink.EXPECT().Write(mock.Anything, mock.MatchedBy(func(r audit.Record) bool {
+			return r.RequestID == "event-6" && r.Method == "MODIFY"
+		})).Return(nil).Once()
+		item := map[string]types.AttributeValue{repository.WriteIDAttr: &types.AttributeValueMemberS{Value: "w1"}}
+		require.NoError(t, AuditHandler(sink).HandleChange(context.Background(), Change{
+			ID: "event-6", Op: OpModify, Kind: repository.KindShow, Slug: "show/a", SK: "SHOW", Old: item, New: item,
+		}))
+	})
+}
+
+func TestIndexHandler(t *testing.T) {
+	ix := search.New()
+	ix.Put(domain.Show{Slug: "show/b", Title: "Bluey"})
+	h := IndexHandler(ix)
+	handle := func(op streamstypes.OperationType, slug string, image map[string]streamstypes.AttributeValue) {
+		t.Helper()
+		r := record("1", op, slug, "SHOW")
+		r.Dynamodb.NewImage = image
+		c, err := changeOf(r)
+		require.NoError(t, err)
+		require.True(t, slices.Contains(IndexKinds, c.Kind))
+		require.NoError(t, h.HandleChange(context.Background(), c))
+	}
+	found := func(q string) []string {
+		out := []string{}
+		for _, hit := range ix.Search(q, 0) {
+			out = append(out, hit.Show.Slug)
+		}
+		return out
+	}
+
+	handle(streamstypes.OperationTypeInsert, "show/a", map[string]streamstypes.AttributeValue{
+		"slug":  &streamstypes.AttributeValueMemberS{Value: "show/a"},
+		"title": &streamstypes.AttributeValueMemberS{Value: "The Taste"},
+	})
+	require.Equal(t, []string{"show/a"}, found("taste"))
+
+	handle(streamstypes.OperationTypeModify, "show/a", map[string]streamstypes.AttributeValue{
+		"slug":    &streamstypes.AttributeValueMemberS{Value: "show/a"},
+		"title":   &streamstypes.AttributeValueMemberS{Value: "The Taste"},
+		"aliasOf": &streamstypes.AttributeValueMemberS{Value: "show/the-taste"},
+	})
+	require.Empty(t, found("taste"))
+
+	handle(streamstypes.OperationTypeRemove, "show/b", nil)
+	require.Empty(t, found("bluey"))
+	require.Zero(t, ix.Len())
+}
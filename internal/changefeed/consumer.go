@@ -0,0 +1,310 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+type Options struct {
+	Name      string        // consumer name; instances sharing it split the shards between them
+	StreamARN string        // stream to read; empty for the table's latest stream
+	Interval  time.Duration // time between polls
+	BatchSize int           // records read per shard per poll
+	Lease     time.Duration // how long a consumer holds a shard without renewing
+}
+
+// Consumer reads every shard of the stream, parents before children, from
+// its checkpoint or else from the oldest record still in the stream, which
+// keeps 24 hours of changes. A shard is leased before it is read; a consumer
+// that stops leaves its lease to expire and another consumer with the same
+// name resumes from the last checkpoint, so changes are handled at least
+// once.
+//
+// A handler error stops its shard at the failing change, which is retried
+// on the next poll, by every handler registered for it.
+type Consumer struct {
+	streams     database.StreamsAPI
+	db          database.DynamoAPI
+	checkpoints repository.CheckpointRepository
+	opts        Options
+	owner       string
+	now         func() time.Time
+	handlers    []registration
+
+	// State kept between polls, which must not run concurrently
+	arn       string
+	held      map[string]domain.ShardCheckpoint // shards leased, by ID
+	iterators map[string]string                 // next iterator of each held shard
+	finished  map[string]bool                   // shards read to their end
+}
+
+type registration struct {
+	handler Handler
+	kinds   []string
+}
+
+func NewConsumer(streams database.StreamsAPI, db database.DynamoAPI, checkpoints repository.CheckpointRepository, opts Options) *Consumer {
+	return &Consumer{
+		streams:     streams,
+		db:          db,
+		checkpoints: checkpoints,
+		opts:        opts,
+		owner:       domain.NewID(),
+		now:         time.Now,
+		arn:         opts.StreamARN,
+		held:        map[string]domain.ShardCheckpoint{},
+		iterators:   map[string]string{},
+		finished:    map[string]bool{},
+	}
+}
+
+// Register hands h the changes to items of the given kinds, or of every kind
+// if none are given. Checkpoints are never handed out. Handlers registered
+// for a change are called in registration order.
+func (c *Consumer) Register(h Handler, kinds ...string) {
+	c.handlers = append(c.handlers, registration{handler: h, kinds: kinds})
+}
+
+// Run polls every Interval until ctx is done
+func (c *Consumer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("changefeed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads up to BatchSize records from each shard it can lease and
+// returns how many changes were handled
+func (c *Consumer) Poll(ctx context.Context) (int, error) {
+	shards, err := c.shards(ctx)
+	if err != nil {
+		return 0, err
+	}
+	listed := map[string]bool{}
+	for _, s := range shards {
+		listed[value(s.ShardId)] = true
+	}
+
+	handled := 0
+	var errs []error
+	for _, s := range shards {
+		if ctx.Err() != nil {
+			break
+		}
+		id, parent := value(s.ShardId), value(s.ParentShardId)
+		if c.finished[id] {
+			continue
+		}
+		// Children wait for their parent, so each item's changes stay in
+		// order. A parent no longer listed has been trimmed.
+		if parent != "" && listed[parent] && !c.finished[parent] {
+			continue
+		}
+		n, err := c.read(ctx, id)
+		handled += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", id, err))
+		}
+	}
+	return handled, errors.Join(errs...)
+}
+
+// shards lists the stream's shards, discovering the stream on first use
+func (c *Consumer) shards(ctx context.Context) ([]streamstypes.Shard, error) {
+	if c.arn == "" {
+		out, err := c.db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(c.db.TableName())})
+		if err != nil {
+			return nil, err
+		}
+		if out.Table == nil || value(out.Table.LatestStreamArn) == "" {
+			return nil, fmt.Errorf("table %s has no stream", c.db.TableName())
+		}
+		c.arn = *out.Table.LatestStreamArn
+	}
+
+	var shards []streamstypes.Shard
+	var start *string
+	for {
+		out, err := c.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             &c.arn,
+			ExclusiveStartShardId: start,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if out.StreamDescription == nil {
+			return shards, nil
+		}
+		shards = append(shards, out.StreamDescription.Shards...)
+		if out.StreamDescription.LastEvaluatedShardId == nil {
+			return shards, nil
+		}
+		start = out.StreamDescription.LastEvaluatedShardId
+	}
+}
+
+// read leases shard id if need be and handles its next batch of records
+func (c *Consumer) read(ctx context.Context, id string) (int, error) {
+	cp, ok, err := c.lease(ctx, id)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	iterator := c.iterators[id]
+	if iterator == "" {
+		in := &dynamodbstreams.GetShardIteratorInput{
+			StreamArn:         &c.arn,
+			ShardId:           &id,
+			ShardIteratorType: streamstypes.ShardIteratorTypeTrimHorizon,
+		}
+		if cp.SequenceNumber != "" {
+			in.ShardIteratorType = streamstypes.ShardIteratorTypeAfterSequenceNumber
+			in.SequenceNumber = &cp.SequenceNumber
+		}
+		out, err := c.streams.GetShardIterator(ctx, in)
+		if err != nil {
+			return 0, err
+		}
+		iterator = value(out.ShardIterator)
+	}
+
+	limit := int32(c.opts.BatchSize)
+	out, err := c.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: &iterator, Limit: &limit})
+	if err != nil {
+		// Start again from the checkpoint next time
+		delete(c.iterators, id)
+		var expired *streamstypes.ExpiredIteratorException
+		if errors.As(err, &expired) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	handled := 0
+	progressed := false
+	for _, r := range out.Records {
+		change, err := changeOf(r)
+		if err == nil && change.Kind == repository.KindCheckpoint {
+			// Checkpoints are not saved for these alone, or saving one
+			// would make another record to read
+			continue
+		}
+		if err == nil {
+			err = c.dispatch(ctx, change)
+		}
+		if err != nil {
+			delete(c.iterators, id)
+			return handled, errors.Join(err, c.save(ctx, id, cp, progressed))
+		}
+		cp.SequenceNumber = value(r.Dynamodb.SequenceNumber)
+		progressed = true
+		handled++
+	}
+
+	if out.NextShardIterator == nil {
+		// The shard is closed and has been read to its end
+		cp.Finished = true
+		if err := c.save(ctx, id, cp, true); err != nil {
+			return handled, err
+		}
+		c.finished[id] = true
+		delete(c.held, id)
+		delete(c.iterators, id)
+		return handled, nil
+	}
+	c.iterators[id] = *out.NextShardIterator
+	return handled, c.save(ctx, id, cp, progressed)
+}
+
+// lease returns the checkpoint of shard id, claiming the shard first unless
+// this consumer holds it for at least another half lease. It reports false
+// if the shard is finished or leased elsewhere.
+func (c *Consumer) lease(ctx context.Context, id string) (domain.ShardCheckpoint, bool, error) {
+	now := c.now()
+	cp, held := c.held[id]
+	if held && cp.LeaseUntil > now.Add(c.opts.Lease/2).UTC().Format(domain.SortableTime) {
+		return cp, true, nil
+	}
+
+	stored, err := c.checkpoints.Get(ctx, c.opts.Name, id)
+	switch {
+	case errors.Is(err, apperror.ErrNotFound):
+		stored = &domain.ShardCheckpoint{Consumer: c.opts.Name, ShardID: id}
+	case err != nil:
+		return domain.ShardCheckpoint{}, false, err
+	}
+	if stored.Finished {
+		c.finished[id] = true
+		return domain.ShardCheckpoint{}, false, nil
+	}
+	if held && stored.SequenceNumber != cp.SequenceNumber {
+		// Another consumer read the shard meanwhile
+		delete(c.iterators, id)
+	}
+
+	claimed, err := c.checkpoints.Claim(ctx, *stored, c.owner, now, now.Add(c.opts.Lease))
+	if errors.Is(err, apperror.ErrConflict) {
+		delete(c.held, id)
+		delete(c.iterators, id)
+		return domain.ShardCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return domain.ShardCheckpoint{}, false, err
+	}
+	c.held[id] = *claimed
+	return *claimed, true, nil
+}
+
+// save stores cp if progressed, and forgets the shard if its lease was lost
+func (c *Consumer) save(ctx context.Context, id string, cp domain.ShardCheckpoint, progressed bool) error {
+	if !progressed {
+		return nil
+	}
+	cp.UpdatedAt = c.now().UTC().Format(time.RFC3339)
+	err := c.checkpoints.Save(context.WithoutCancel(ctx), cp)
+	if errors.Is(err, apperror.ErrConflict) {
+		delete(c.held, id)
+		delete(c.iterators, id)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.held[id] = cp
+	return nil
+}
+
+// dispatch hands change to each handler registered for its kind
+func (c *Consumer) dispatch(ctx context.Context, change Change) error {
+	for _, r := range c.handlers {
+		if len(r.kinds) > 0 && !slices.Contains(r.kinds, change.Kind) {
+			continue
+		}
+		if err := r.handler.HandleChange(ctx, change); err != nil {
+			return fmt.Errorf("change %s to %s %s: %w", change.ID, change.Slug, change.SK, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamstypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+const testARN = "arn:aws:dynamodb:ddblocal:000000000000:table/shows-local/stream/2024-06-15T00:00:00.000"
+
+var testNow = time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+func record(seq string, op streamstypes.OperationType, slug, sk string) streamstypes.Record {
+	keys := map[string]streamstypes.AttributeValue{
+		"slug": &streamstypes.AttributeValueMemberS{Value: slug},
+		"sk":   &streamstypes.AttributeValueMemberS{Value: sk},
+	}
+	return streamstypes.Record{
+		EventID:   aws.String("event-" + seq),
+		EventName: op,
+		Dynamodb: &streamstypes.StreamRecord{
+			Keys:           keys,
+			NewImage:       keys,
+			SequenceNumber: aws.String(seq),
+		},
+	}
+}
+
+func shard(id, parent string) streamstypes.Shard {
+	s := streamstypes.Shard{ShardId: aws.String(id)}
+	if parent != "" {
+		s.ParentShardId = aws.String(parent)
+	}
+	return s
+}
+
+type fixture struct {
+	streams     *dynamoMocks.MockStreamsAPI
+	checkpoints *repoMocks.MockCheckpointRepository
+	consumer    *Consumer
+	handled     []string // Kind:Slug of each change handled
+}
+
+func newFixture(t *testing.T, shards ...streamstypes.Shard) *fixture {
+	f := &fixture{
+		streams:     dynamoMocks.NewMockStreamsAPI(t),
+		checkpoints: repoMocks.NewMockCheckpointRepository(t),
+	}
+	f.consumer = NewConsumer(f.streams, nil, f.checkpoints, Options{Name: "show-service", StreamARN: testARN, BatchSize: 100, Lease: time.Minute})
+	f.consumer.owner = "me"
+	f.consumer.now = func() time.Time { return testNow }
+	f.consumer.Register(HandlerFunc(func(_ context.Context, c Change) error {
+		f.handled = append(f.handled, c.Kind+":"+c.Slug)
+		return nil
+	}))
+	f.streams.On("DescribeStream", mock.Anything, mock.MatchedBy(func(in *dynamodbstreams.DescribeStreamInput) bool {
+		return *in.StreamArn == testARN
+	})).Return(&dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamstypes.StreamDescription{Shards: shards}}, nil).Maybe()
+	return f
+}
+
+// claims expects shard id to be claimed from stored, or from scratch if nil
+func (f *fixture) claims(id string, stored *domain.ShardCheckpoint) {
+	if stored == nil {
+		f.checkpoints.EXPECT().Get(mock.Anything, "show-service", id).Return(nil, apperror.ErrNotFound).Once()
+		stored = &domain.ShardCheckpoint{Consumer: "show-service", ShardID: id}
+	} else {
+		f.checkpoints.EXPECT().Get(mock.Anything, "show-service", id).Return(stored, nil).Once()
+	}
+	claimed := *stored
+	claimed.Owner = "me"
+	claimed.LeaseUntil = "2024-06-15T00:01:00Z"
+	f.checkpoints.EXPECT().Claim(mock.Anything, *stored, "me", testNow, testNow.Add(time.Minute)).Return(&claimed, nil).Once()
+}
+
+func (f *fixture) iterator(id string, kind streamstypes.ShardIteratorType, after, iterator string) {
+	f.streams.On("GetShardIterator", mock.Anything, mock.MatchedBy(func(in *dynamodbstreams.GetShardIteratorInput) bool {
+		return *in.ShardId == id && in.ShardIteratorType == kind && aws.ToString(in.SequenceNumber) == after
+	})).Return(&dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String(iterator)}, nil).Once()
+}
+
+func (f *fixture) records(iterator string, next *string, records ...streamstypes.Record) {
+	f.streams.On("GetRecords", mock.Anything, mock.MatchedBy(func(in *dynamodbstreams.GetRecordsInput) bool {
+		return *in.ShardIterator == iterator && *in.Limit == 100
+	})).Return(&dynamodbstreams.GetRecordsOutput{Records: records, NextShardIterator: next}, nil).Once()
+}
+
+func (f *fixture) saves(id, seq string, finished bool) {
+	f.checkpoints.EXPECT().Save(mock.Anything, mock.MatchedBy(func(cp domain.ShardCheckpoint) bool {
+		return cp.ShardID == id && cp.SequenceNumber == seq && cp.Finished == finished && cp.Owner == "me"
+	})).Return(nil).Once()
+}
+
+func TestConsumer_Poll(t *testing.T) {
+	t.Run("hands changes to handlers and checkpoints them", func(t *testing.T) {
+		f := newFixture(t, shard("s1", ""))
+		var shows []string
+		f.consumer.Register(HandlerFunc(func(_ context.Context, c Change) error {
+			shows = append(shows, c.Slug)
+			return nil
+		}), repository.KindShow)
+		f.claims("s1", nil)
+		f.iterator("s1", streamstypes.ShardIteratorTypeTrimHorizon, "", "it1")
+		f.records("it1", aws.String("it2"),
+			record("1", streamstypes.OperationTypeInsert, "show/a", "SHOW"),
+			record("2", streamstypes.OperationTypeModify, "channels", "CHANNEL#abc"),
+			record("3", streamstypes.OperationTypeModify, "changefeed/show-service", "SHARD#s1"),
+		)
+		f.saves("s1", "2", false)
+
+		n, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []string{"show:show/a", "channel:channels"}, f.handled)
+		require.Equal(t, []string{"show/a"}, shows)
+
+		// The next poll carries on from the iterator it was given, and does
+		// not checkpoint for checkpoints alone
+		f.records("it2", aws.String("it3"), record("4", streamstypes.OperationTypeModify, "changefeed/show-service", "SHARD#s1"))
+		n, err = f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("resumes after the checkpoint", func(t *testing.T) {
+		f := newFixture(t, shard("s1", ""))
+		f.claims("s1", &domain.ShardCheckpoint{Consumer: "show-service", ShardID: "s1", SequenceNumber: "5", Owner: "gone", LeaseUntil: "2024-06-14T00:00:00Z"})
+		f.iterator("s1", streamstypes.ShardIteratorTypeAfterSequenceNumber, "5", "it6")
+		f.records("it6", aws.String("it7"))
+
+		n, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("reads a closed parent to its end before its child", func(t *testing.T) {
+		f := newFixture(t, shard("parent", ""), shard("child", "parent"))
+		f.claims("parent", nil)
+		f.iterator("parent", streamstypes.ShardIteratorTypeTrimHorizon, "", "p1")
+		f.records("p1", nil, record("1", streamstypes.OperationTypeRemove, "show/a", "SEASON#0001"))
+		f.saves("parent", "1", true)
+		f.claims("child", nil)
+		f.iterator("child", streamstypes.ShardIteratorTypeTrimHorizon, "", "c1")
+		f.records("c1", aws.String("c2"), record("2", streamstypes.OperationTypeInsert, "show/a", "SEASON#0001"))
+		f.saves("child", "2", false)
+
+		n, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []string{"season:show/a", "season:show/a"}, f.handled)
+
+		// The parent is done with for good
+		f.records("c2", aws.String("c3"))
+		_, err = f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("children wait while their parent is leased elsewhere", func(t *testing.T) {
+		f := newFixture(t, shard("parent", ""), shard("child", "parent"))
+		stored := domain.ShardCheckpoint{Consumer: "show-service", ShardID: "parent", Owner: "other", LeaseUntil: "2024-06-15T00:00:30Z"}
+		f.checkpoints.EXPECT().Get(mock.Anything, "show-service", "parent").Return(&stored, nil).Once()
+		f.checkpoints.EXPECT().Claim(mock.Anything, stored, "me", testNow, testNow.Add(time.Minute)).Return(nil, apperror.ErrConflict).Once()
+
+		n, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("a parent no longer listed does not hold its child back", func(t *testing.T) {
+		f := newFixture(t, shard("child", "trimmed"))
+		f.claims("child", nil)
+		f.iterator("child", streamstypes.ShardIteratorTypeTrimHorizon, "", "c1")
+		f.records("c1", aws.String("c2"))
+
+		_, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("a handler error stops the shard at the failing change", func(t *testing.T) {
+		f := newFixture(t, shard("s1", ""))
+		fail := true
+		f.consumer.Register(HandlerFunc(func(_ context.Context, c Change) error {
+			if c.Sequence == "2" && fail {
+				return errors.New("index unavailable")
+			}
+			return nil
+		}))
+		f.claims("s1", nil)
+		f.iterator("s1", streamstypes.ShardIteratorTypeTrimHorizon, "", "it1")
+		f.records("it1", aws.String("it2"),
+			record("1", streamstypes.OperationTypeInsert, "show/a", "SHOW"),
+			record("2", streamstypes.OperationTypeInsert, "show/b", "SHOW"),
+		)
+		f.saves("s1", "1", false)
+
+		n, err := f.consumer.Poll(context.Background())
+		require.ErrorContains(t, err, "index unavailable")
+		require.Equal(t, 1, n)
+
+		// The failed change is read again from the checkpoint
+		fail = false
+		f.iterator("s1", streamstypes.ShardIteratorTypeAfterSequenceNumber, "1", "retry")
+		f.records("retry", aws.String("it3"), record("2", streamstypes.OperationTypeInsert, "show/b", "SHOW"))
+		f.saves("s1", "2", false)
+		n, err = f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		require.Equal(t, []string{"show:show/a", "show:show/b", "show:show/b"}, f.handled)
+	})
+
+	t.Run("renews the lease after half of it", func(t *testing.T) {
+		f := newFixture(t, shard("s1", ""))
+		f.claims("s1", nil)
+		f.iterator("s1", streamstypes.ShardIteratorTypeTrimHorizon, "", "it1")
+		f.records("it1", aws.String("it2"))
+		_, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+
+		later := testNow.Add(31 * time.Second)
+		f.consumer.now = func() time.Time { return later }
+		held := domain.ShardCheckpoint{Consumer: "show-service", ShardID: "s1", Owner: "me", LeaseUntil: "2024-06-15T00:01:00Z"}
+		f.checkpoints.EXPECT().Get(mock.Anything, "show-service", "s1").Return(&held, nil).Once()
+		f.checkpoints.EXPECT().Claim(mock.Anything, held, "me", later, later.Add(time.Minute)).Return(&held, nil).Once()
+		f.records("it2", aws.String("it3"))
+		_, err = f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("an expired iterator is fetched again next time", func(t *testing.T) {
+		f := newFixture(t, shard("s1", ""))
+		f.claims("s1", nil)
+		f.iterator("s1", streamstypes.ShardIteratorTypeTrimHorizon, "", "it1")
+		f.streams.On("GetRecords", mock.Anything, mock.Anything).Return(nil, &streamstypes.ExpiredIteratorException{}).Once()
+		_, err := f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+
+		f.iterator("s1", streamstypes.ShardIteratorTypeTrimHorizon, "", "fresh")
+		f.records("fresh", aws.String("it2"))
+		_, err = f.consumer.Poll(context.Background())
+		require.NoError(t, err)
+	})
+}
+
+func TestConsumer_Poll_Discovery(t *testing.T) {
+	t.Run("reads the table's latest stream", func(t *testing.T) {
+		db := dynamoMocks.NewMockDynamoAPI(t)
+		streams := dynamoMocks.NewMockStreamsAPI(t)
+		db.On("TableName").Return("shows-local")
+		db.On("DescribeTable", mock.Anything, mock.Anything).Return(&dynamodb.DescribeTableOutput{
+			Table: &types.TableDescription{LatestStreamArn: aws.String(testARN)},
+		}, nil).Once()
+		streams.On("DescribeStream", mock.Anything, mock.MatchedBy(func(in *dynamodbstreams.DescribeStreamInput) bool {
+			return *in.StreamArn == testARN
+		})).Return(&dynamodbstreams.DescribeStreamOutput{StreamDescription: &streamstypes.StreamDescription{}}, nil).Twice()
+
+		c := NewConsumer(streams, db, repoMocks.NewMockCheckpointRepository(t), Options{Name: "show-service"})
+		for range 2 {
+			_, err := c.Poll(context.Background())
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("a table without a stream", func(t *testing.T) {
+		db := dynamoMocks.NewMockDynamoAPI(t)
+		db.On("TableName").Return("shows-local")
+		db.On("DescribeTable", mock.Anything, mock.Anything).Return(&dynamodb.DescribeTableOutput{Table: &types.TableDescription{}}, nil)
+
+		c := NewConsumer(dynamoMocks.NewMockStreamsAPI(t), db, repoMocks.NewMockCheckpointRepository(t), Options{Name: "show-service"})
+		_, err := c.Poll(context.Background())
+		require.EqualError(t, err, "table shows-local has no stream")
+	})
+}
+
+func TestMemoryCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryCheckpoints()
+	_, err := m.Get(ctx, "show-service", "s1")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+
+	cp := domain.ShardCheckpoint{Consumer: "show-service", ShardID: "s1"}
+	require.ErrorIs(t, m.Save(ctx, cp), apperror.ErrConflict)
+	claimed, err := m.Claim(ctx, cp, "me", testNow, testNow.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, "2024-06-15T00:01:00Z", claimed.LeaseUntil)
+
+	_, err = m.Claim(ctx, cp, "other", testNow.Add(time.Minute), testNow.Add(2*time.Minute))
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	claimed.SequenceNumber = "7"
+	require.NoError(t, m.Save(ctx, *claimed))
+
+	stolen, err := m.Claim(ctx, *claimed, "other", testNow.Add(2*time.Minute), testNow.Add(3*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, "7", stolen.SequenceNumber)
+	require.ErrorIs(t, m.Save(ctx, *claimed), apperror.ErrConflict)
+	got, err := m.Get(ctx, "show-service", "s1")
+	require.NoError(t, err)
+	require.Equal(t, "other", got.Owner)
+}
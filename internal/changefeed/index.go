@@ -0,0 +1,40 @@
+package changefeed
+
+import (
+	"context"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// IndexKinds are the kinds of item IndexHandler is meant for. A renamed
+// show's former slug is an alias, so that its show is dropped there.
+var IndexKinds = []string{repository.KindShow, repository.KindAlias}
+
+// Index is an in-memory index of shows by slug, such as search.Index,
+// suggest.Trie or dedupe.Index
+type Index interface {
+	Put(show domain.Show)
+	Delete(slug string)
+}
+
+// IndexHandler puts each show changed into indexes, and deletes each show
+// removed, so that they see writes made outside this instance. Indexes
+// ignore alias stubs put in them. Changes handled twice or out of date are
+// put again, and the latest change to an item always comes last.
+func IndexHandler(indexes ...Index) Handler {
+	return HandlerFunc(func(_ context.Context, c Change) error {
+		_, after, err := Images[domain.Show](c)
+		if err != nil {
+			return err
+		}
+		for _, ix := range indexes {
+			if after == nil {
+				ix.Delete(c.Slug)
+			} else {
+				ix.Put(*after)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// MemoryCheckpoints keeps checkpoints in memory, for a consumer whose
+// handlers keep state that is lost with the process, such as IndexHandler.
+// Such a consumer reads the stream from its oldest record on every start,
+// and does not share shards with other instances.
+type MemoryCheckpoints struct {
+	mu     sync.Mutex
+	shards map[string]domain.ShardCheckpoint // by consumer and shard ID
+}
+
+var _ repository.CheckpointRepository = (*MemoryCheckpoints)(nil)
+
+func NewMemoryCheckpoints() *MemoryCheckpoints {
+	return &MemoryCheckpoints{shards: map[string]domain.ShardCheckpoint{}}
+}
+
+func (m *MemoryCheckpoints) Get(_ context.Context, consumer, shardID string) (*domain.ShardCheckpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp, ok := m.shards[consumer+"/"+shardID]
+	if !ok {
+		return nil, fmt.Errorf("shard %s: %w", shardID, apperror.ErrNotFound)
+	}
+	return &cp, nil
+}
+
+// Claim leases cp's shard to owner until then, failing with ErrConflict if
+// another owner holds an unexpired lease
+func (m *MemoryCheckpoints) Claim(_ context.Context, cp domain.ShardCheckpoint, owner string, now, until time.Time) (*domain.ShardCheckpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := cp.Consumer + "/" + cp.ShardID
+	if held, ok := m.shards[key]; ok && held.Owner != owner && held.LeaseUntil >= now.UTC().Format(domain.SortableTime) {
+		return nil, fmt.Errorf("shard %s: %w", cp.ShardID, apperror.ErrConflict)
+	}
+	cp.Owner = owner
+	cp.LeaseUntil = until.UTC().Format(domain.SortableTime)
+	cp.UpdatedAt = now.UTC().Format(time.RFC3339)
+	m.shards[key] = cp
+	return &cp, nil
+}
+
+// Save stores cp's progress, failing with ErrConflict if cp.Owner lost the
+// shard to another consumer
+func (m *MemoryCheckpoints) Save(_ context.Context, cp domain.ShardCheckpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := cp.Consumer + "/" + cp.ShardID
+	if held, ok := m.shards[key]; !ok || held.Owner != cp.Owner {
+		return fmt.Errorf("shard %s: %w", cp.ShardID, apperror.ErrConflict)
+	}
+	m.shards[key] = cp
+	return nil
+}
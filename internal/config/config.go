@@ -1,8 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -32,11 +34,168 @@ type Cognito struct {
 	ValidScopes []string `mapstructure:"validScopes"`
 }
 
+// Issuer returns the iss claim of the user pool's tokens, or "" when the
+// pool is not configured
+func (c Cognito) Issuer() string {
+	if c.UserPoolID == "" || c.Region == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", c.Region, c.UserPoolID)
+}
+
+// JWKSEndpoint returns the configured JWKS URL, or the Cognito well-known
+// endpoint for the user pool when none is set
+func (c Cognito) JWKSEndpoint() string {
+	if c.JWKSURL != "" {
+		return c.JWKSURL
+	}
+	if iss := c.Issuer(); iss != "" {
+		return iss + "/.well-known/jwks.json"
+	}
+	return ""
+}
+
+type Server struct {
+	Port                int           `mapstructure:"port"`
+	ReadTimeout         time.Duration `mapstructure:"readTimeout"`
+	WriteTimeout        time.Duration `mapstructure:"writeTimeout"`
+	IdleTimeout         time.Duration `mapstructure:"idleTimeout"`
+	MaxHeaderBytes      int           `mapstructure:"maxHeaderBytes"`
+	DrainDelay          time.Duration `mapstructure:"drainDelay"`          // readiness fails for this long before the listener closes
+	ShutdownGracePeriod time.Duration `mapstructure:"shutdownGracePeriod"` // max wait for in-flight requests
+	TrustedProxies      []string      `mapstructure:"trustedProxies"`      // IPs or CIDRs, e.g. the load balancer's subnets, whose X-Forwarded-For is believed
+}
+
+type Health struct {
+	CacheTTL     time.Duration `mapstructure:"cacheTTL"`     // how long a readiness result is reused
+	CheckTimeout time.Duration `mapstructure:"checkTimeout"` // per-probe dependency timeout
+}
+
+type Validation struct {
+	MaxErrors int `mapstructure:"maxErrors"` // field errors returned per 400; 0 means no cap
+}
+
+type Normalize struct {
+	RejectUnknown bool `mapstructure:"rejectUnknown"` // unknown countries/languages fail validation
+}
+
+type Sanitize struct {
+	Allow     []string `mapstructure:"allow"`     // allowlist rules for nextEpisode.html, e.g. span[class]
+	Strict    bool     `mapstructure:"strict"`    // reject items instead of stripping markup
+	PlainText bool     `mapstructure:"plainText"` // also store a plain-text rendering
+}
+
+type Schedule struct {
+	TimeZone string `mapstructure:"timeZone"` // IANA zone for feed dates without an offset
+}
+
+type Channels struct {
+	AutoCreate bool `mapstructure:"autoCreate"` // create unknown channels referenced on ingest instead of rejecting them
+}
+
+type Locales struct {
+	Fallback []string `mapstructure:"fallback"` // BCP 47 tags tried after the client's, e.g. en-AU,en
+}
+
+type Availability struct {
+	CountryHeader string `mapstructure:"countryHeader"` // request header carrying the viewer's country, e.g. CloudFront-Viewer-Country
+	AdminGroup    string `mapstructure:"adminGroup"`    // user group allowed to list hidden shows
+}
+
+type Workflow struct {
+	EditorGroup    string `mapstructure:"editorGroup"`    // user group allowed to submit drafts for review
+	PublisherGroup string `mapstructure:"publisherGroup"` // user group allowed to publish, archive and reject
+}
+
+type Audit struct {
+	Sink  string `mapstructure:"sink"`  // none|stdout|file|dynamodb
+	File  string `mapstructure:"file"`  // JSON-lines file for the file sink
+	Table string `mapstructure:"table"` // table for the dynamodb sink
+}
+
+type Outbox struct {
+	Publisher  string        `mapstructure:"publisher"`  // none|memory|file|sns|sqs|eventbridge
+	File       string        `mapstructure:"file"`       // JSON-lines file for the file publisher
+	TopicARN   string        `mapstructure:"topicArn"`   // topic for the sns publisher
+	QueueURL   string        `mapstructure:"queueUrl"`   // queue for the sqs publisher
+	EventBus   string        `mapstructure:"eventBus"`   // bus name or ARN for the eventbridge publisher
+	Source     string        `mapstructure:"source"`     // EventBridge event source
+	Interval   time.Duration `mapstructure:"interval"`   // time between dispatcher polls
+	BatchSize  int           `mapstructure:"batchSize"`  // events published per poll
+	Lease      time.Duration `mapstructure:"lease"`      // how long a delivery reserves its event
+	MinBackoff time.Duration `mapstructure:"minBackoff"` // first retry delay, doubled per attempt
+	MaxBackoff time.Duration `mapstructure:"maxBackoff"` // longest retry delay
+}
+
+type Webhooks struct {
+	Enabled     bool          `mapstructure:"enabled"`     // fan change events out to webhooks and deliver them
+	Interval    time.Duration `mapstructure:"interval"`    // time between dispatcher polls
+	BatchSize   int           `mapstructure:"batchSize"`   // deliveries sent per poll
+	Lease       time.Duration `mapstructure:"lease"`       // how long a send reserves its delivery; must exceed Timeout
+	Timeout     time.Duration `mapstructure:"timeout"`     // per-request timeout for receivers
+	MinBackoff  time.Duration `mapstructure:"minBackoff"`  // first retry delay, doubled per attempt
+	MaxBackoff  time.Duration `mapstructure:"maxBackoff"`  // longest retry delay
+	MaxAttempts int           `mapstructure:"maxAttempts"` // attempts before a delivery fails
+}
+
+type Stream struct {
+	Replay    int           `mapstructure:"replay"`    // recent changes kept for clients resuming with Last-Event-ID
+	Buffer    int           `mapstructure:"buffer"`    // changes queued per client before it is dropped as too slow
+	Heartbeat time.Duration `mapstructure:"heartbeat"` // time between keep-alive comments on idle streams
+}
+
+type Changefeed struct {
+	Enabled   bool          `mapstructure:"enabled"`   // read the shows table's stream; the table must have one
+	Consumer  string        `mapstructure:"consumer"`  // instances sharing a name split the stream's shards between them
+	StreamARN string        `mapstructure:"streamArn"` // stream to read; empty for the table's latest stream
+	Interval  time.Duration `mapstructure:"interval"`  // time between polls
+	BatchSize int           `mapstructure:"batchSize"` // records read per shard per poll, at most 1000
+	Lease     time.Duration `mapstructure:"lease"`     // how long a consumer holds a shard without renewing
+	Audit     bool          `mapstructure:"audit"`     // record changes to shows, seasons, episodes and channels in the audit sink
+	Indexes   bool          `mapstructure:"indexes"`   // keep this instance's search and duplicate indexes and suggestions in step with the stream
+}
+
+type Suggest struct {
+	MaxShows int           `mapstructure:"maxShows"` // shows held for typeahead, the most popular first; 0 for no cap
+	Refresh  time.Duration `mapstructure:"refresh"`  // time between rebuilds from the table
+}
+
+type Duplicates struct {
+	Threshold float64 `mapstructure:"threshold"` // title similarity, 0 to 1, from which shows are flagged as likely duplicates
+	Strict    bool    `mapstructure:"strict"`    // reject likely duplicates on create instead of flagging them
+}
+
+type Telemetry struct {
+	Exporter    string  `mapstructure:"exporter"`    // none|stdout|otlp
+	Endpoint    string  `mapstructure:"endpoint"`    // OTLP/HTTP collector, e.g. localhost:4318
+	Insecure    bool    `mapstructure:"insecure"`    // plain HTTP to the collector
+	ServiceName string  `mapstructure:"serviceName"` // service.name resource attribute
+	SampleRatio float64 `mapstructure:"sampleRatio"` // 0..1, parent-based
+}
+
 type Config struct {
-	Env      Env      `mapstructure:"env"`
-	Log      Log      `mapstructure:"log"`
-	DynamoDB DynamoDB `mapstructure:"dynamodb"`
-	Cognito  Cognito  `mapstructure:"cognito"`
+	Env          Env          `mapstructure:"env"`
+	Log          Log          `mapstructure:"log"`
+	Server       Server       `mapstructure:"server"`
+	DynamoDB     DynamoDB     `mapstructure:"dynamodb"`
+	Cognito      Cognito      `mapstructure:"cognito"`
+	Telemetry    Telemetry    `mapstructure:"telemetry"`
+	Health       Health       `mapstructure:"health"`
+	Validation   Validation   `mapstructure:"validation"`
+	Normalize    Normalize    `mapstructure:"normalize"`
+	Sanitize     Sanitize     `mapstructure:"sanitize"`
+	Schedule     Schedule     `mapstructure:"schedule"`
+	Channels     Channels     `mapstructure:"channels"`
+	Locales      Locales      `mapstructure:"locales"`
+	Availability Availability `mapstructure:"availability"`
+	Workflow     Workflow     `mapstructure:"workflow"`
+	Audit        Audit        `mapstructure:"audit"`
+	Outbox       Outbox       `mapstructure:"outbox"`
+	Webhooks     Webhooks     `mapstructure:"webhooks"`
+	Stream       Stream       `mapstructure:"stream"`
+	Changefeed   Changefeed   `mapstructure:"changefeed"`
+	Suggest      Suggest      `mapstructure:"suggest"`
+	Duplicates   Duplicates   `mapstructure:"duplicates"`
 }
 
 func Load() (*Config, error) {
@@ -45,13 +204,77 @@ func Load() (*Config, error) {
 	// Set defaults
 	v.SetDefault("env", string(EnvLocal))
 	v.SetDefault("log.level", "info")
+	v.SetDefault("server.port", 8080)
+	v.SetDefault("server.readTimeout", "15s")
+	v.SetDefault("server.writeTimeout", "60s")
+	v.SetDefault("server.idleTimeout", "120s")
+	v.SetDefault("server.maxHeaderBytes", 1<<20)
+	v.SetDefault("server.drainDelay", "5s")
+	v.SetDefault("server.shutdownGracePeriod", "20s")
+	v.SetDefault("server.trustedProxies", []string{})
 	v.SetDefault("dynamodb.region", "ap-southeast-2")
 	v.SetDefault("dynamodb.endpointOverride", "")
 	v.SetDefault("dynamodb.createTableIfMissing", false)
+	v.SetDefault("telemetry.exporter", "none")
+	v.SetDefault("telemetry.endpoint", "")
+	v.SetDefault("telemetry.insecure", false)
+	v.SetDefault("telemetry.serviceName", "show-service")
+	v.SetDefault("telemetry.sampleRatio", 1.0)
+	v.SetDefault("health.cacheTTL", "2s")
+	v.SetDefault("health.checkTimeout", "2s")
+	v.SetDefault("validation.maxErrors", 100)
+	v.SetDefault("normalize.rejectUnknown", false)
+	v.SetDefault("sanitize.allow", []string{"br", "span[class]", "a[href]", "b", "i", "em", "strong"})
+	v.SetDefault("sanitize.strict", false)
+	v.SetDefault("sanitize.plainText", false)
+	v.SetDefault("schedule.timeZone", "Australia/Sydney")
+	v.SetDefault("channels.autoCreate", false)
+	v.SetDefault("locales.fallback", []string{})
+	v.SetDefault("availability.countryHeader", "CloudFront-Viewer-Country")
+	v.SetDefault("availability.adminGroup", "admins")
+	v.SetDefault("workflow.editorGroup", "editors")
+	v.SetDefault("workflow.publisherGroup", "publishers")
+	v.SetDefault("audit.sink", "dynamodb")
+	v.SetDefault("audit.file", "audit.jsonl")
+	v.SetDefault("outbox.publisher", "none")
+	v.SetDefault("outbox.file", "events.jsonl")
+	v.SetDefault("outbox.topicArn", "")
+	v.SetDefault("outbox.queueUrl", "")
+	v.SetDefault("outbox.eventBus", "default")
+	v.SetDefault("outbox.source", "show-service")
+	v.SetDefault("outbox.interval", "1s")
+	v.SetDefault("outbox.batchSize", 25)
+	v.SetDefault("outbox.lease", "30s")
+	v.SetDefault("outbox.minBackoff", "1s")
+	v.SetDefault("outbox.maxBackoff", "5m")
+	v.SetDefault("webhooks.enabled", true)
+	v.SetDefault("webhooks.interval", "1s")
+	v.SetDefault("webhooks.batchSize", 25)
+	v.SetDefault("webhooks.lease", "1m")
+	v.SetDefault("webhooks.timeout", "10s")
+	v.SetDefault("webhooks.minBackoff", "10s")
+	v.SetDefault("webhooks.maxBackoff", "1h")
+	v.SetDefault("webhooks.maxAttempts", 10)
+	v.SetDefault("stream.replay", 1000)
+	v.SetDefault("stream.buffer", 64)
+	v.SetDefault("stream.heartbeat", "15s")
+	v.SetDefault("changefeed.enabled", false)
+	v.SetDefault("changefeed.consumer", "show-service")
+	v.SetDefault("changefeed.streamArn", "")
+	v.SetDefault("changefeed.interval", "1s")
+	v.SetDefault("changefeed.batchSize", 100)
+	v.SetDefault("changefeed.lease", "30s")
+	v.SetDefault("changefeed.audit", true)
+	v.SetDefault("changefeed.indexes", true)
+	v.SetDefault("suggest.maxShows", 100000)
+	v.SetDefault("suggest.refresh", "10m")
+	v.SetDefault("duplicates.threshold", 0.85)
+	v.SetDefault("duplicates.strict", false)
 
 	env := determineEnvironment()
 
 	v.SetDefault("dynamodb.showsTable", "shows-"+env)
+	v.SetDefault("audit.table", "shows-audit-"+env)
 
 	if env == string(EnvLocal) {
 		v.SetConfigFile("configs/config.local.yaml")
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDetermineEnvironment(t *testing.T) {
@@ -134,6 +135,9 @@ func TestLoad(t *testing.T) {
 				if cfg.DynamoDB.ShowsTable != "shows-dev" {
 					t.Errorf("Expected ShowsTable to be 'shows-dev', got %v", cfg.DynamoDB.ShowsTable)
 				}
+				if cfg.Audit.Table != "shows-audit-dev" {
+					t.Errorf("Expected Audit.Table to be 'shows-audit-dev', got %v", cfg.Audit.Table)
+				}
 			},
 		},
 		{
@@ -160,6 +164,153 @@ func TestLoad(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:    "telemetry defaults and overrides",
+			envVars: map[string]string{"APP_TELEMETRY__EXPORTER": "stdout"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Telemetry.Exporter != "stdout" {
+					t.Errorf("Expected Telemetry.Exporter to be 'stdout', got %v", cfg.Telemetry.Exporter)
+				}
+				if cfg.Telemetry.ServiceName != "show-service" {
+					t.Errorf("Expected Telemetry.ServiceName to be 'show-service', got %v", cfg.Telemetry.ServiceName)
+				}
+				if cfg.Telemetry.SampleRatio != 1.0 {
+					t.Errorf("Expected Telemetry.SampleRatio to be 1.0, got %v", cfg.Telemetry.SampleRatio)
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "health durations",
+			envVars: map[string]string{"APP_HEALTH__CACHETTL": "5s"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Health.CacheTTL != 5*time.Second {
+					t.Errorf("Expected Health.CacheTTL to be 5s, got %v", cfg.Health.CacheTTL)
+				}
+				if cfg.Health.CheckTimeout != 2*time.Second {
+					t.Errorf("Expected Health.CheckTimeout to be 2s, got %v", cfg.Health.CheckTimeout)
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "server defaults and overrides",
+			envVars: map[string]string{"APP_SERVER__PORT": "9090", "APP_SERVER__SHUTDOWNGRACEPERIOD": "45s", "APP_SERVER__TRUSTEDPROXIES": "10.0.0.0/24,10.0.1.0/24"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Server.Port != 9090 {
+					t.Errorf("Expected Server.Port to be 9090, got %v", cfg.Server.Port)
+				}
+				if cfg.Server.ShutdownGracePeriod != 45*time.Second {
+					t.Errorf("Expected Server.ShutdownGracePeriod to be 45s, got %v", cfg.Server.ShutdownGracePeriod)
+				}
+				if len(cfg.Server.TrustedProxies) != 2 || cfg.Server.TrustedProxies[1] != "10.0.1.0/24" {
+					t.Errorf("Expected Server.TrustedProxies to be [10.0.0.0/24 10.0.1.0/24], got %v", cfg.Server.TrustedProxies)
+				}
+				if cfg.Server.WriteTimeout != 60*time.Second {
+					t.Errorf("Expected Server.WriteTimeout to be 60s, got %v", cfg.Server.WriteTimeout)
+				}
+				if cfg.Server.MaxHeaderBytes != 1<<20 {
+					t.Errorf("Expected Server.MaxHeaderBytes to be 1MiB, got %v", cfg.Server.MaxHeaderBytes)
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "validation error cap",
+			envVars: map[string]string{"APP_VALIDATION__MAXERRORS": "25"},
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.Validation.MaxErrors != 25 {
+					t.Errorf("Expected Validation.MaxErrors to be 25, got %v", cfg.Validation.MaxErrors)
+				}
+				if cfg.Normalize.RejectUnknown {
+					t.Errorf("Expected Normalize.RejectUnknown to default to false")
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "sanitize allowlist",
+			envVars: map[string]string{"APP_SANITIZE__ALLOW": "br,span[class]", "APP_SANITIZE__STRICT": "true"},
+			validate: func(t *testing.T, cfg *Config) {
+				if len(cfg.Sanitize.Allow) != 2 || cfg.Sanitize.Allow[1] != "span[class]" {
+					t.Errorf("Expected Sanitize.Allow to be [br span[class]], got %v", cfg.Sanitize.Allow)
+				}
+				if !cfg.Sanitize.Strict {
+					t.Errorf("Expected Sanitize.Strict to be true")
+				}
+				if cfg.Sanitize.PlainText {
+					t.Errorf("Expected Sanitize.PlainText to default to false")
+				}
+				if cfg.Channels.AutoCreate {
+					t.Errorf("Expected Channels.AutoCreate to default to false")
+				}
+				if len(cfg.Locales.Fallback) != 0 {
+					t.Errorf("Expected Locales.Fallback to default to empty, got %v", cfg.Locales.Fallback)
+				}
+				if cfg.Availability.CountryHeader != "CloudFront-Viewer-Country" || cfg.Availability.AdminGroup != "admins" {
+					t.Errorf("Expected Availability defaults, got %+v", cfg.Availability)
+				}
+				if cfg.Workflow.EditorGroup != "editors" || cfg.Workflow.PublisherGroup != "publishers" {
+					t.Errorf("Expected Workflow defaults, got %+v", cfg.Workflow)
+				}
+				if cfg.Audit.Sink != "dynamodb" || cfg.Audit.File != "audit.jsonl" || cfg.Audit.Table != "shows-audit-local" {
+					t.Errorf("Expected Audit defaults, got %+v", cfg.Audit)
+				}
+				if cfg.Outbox.Publisher != "none" || cfg.Outbox.File != "events.jsonl" || cfg.Outbox.EventBus != "default" || cfg.Outbox.Source != "show-service" {
+					t.Errorf("Expected Outbox publisher defaults, got %+v", cfg.Outbox)
+				}
+				if cfg.Outbox.Interval != time.Second || cfg.Outbox.BatchSize != 25 || cfg.Outbox.Lease != 30*time.Second ||
+					cfg.Outbox.MinBackoff != time.Second || cfg.Outbox.MaxBackoff != 5*time.Minute {
+					t.Errorf("Expected Outbox dispatcher defaults, got %+v", cfg.Outbox)
+				}
+				if !cfg.Webhooks.Enabled || cfg.Webhooks.Interval != time.Second || cfg.Webhooks.BatchSize != 25 ||
+					cfg.Webhooks.Lease != time.Minute || cfg.Webhooks.Timeout != 10*time.Second {
+					t.Errorf("Expected Webhooks dispatcher defaults, got %+v", cfg.Webhooks)
+				}
+				if cfg.Webhooks.MinBackoff != 10*time.Second || cfg.Webhooks.MaxBackoff != time.Hour || cfg.Webhooks.MaxAttempts != 10 {
+					t.Errorf("Expected Webhooks retry defaults, got %+v", cfg.Webhooks)
+				}
+				if cfg.Stream.Replay != 1000 || cfg.Stream.Buffer != 64 || cfg.Stream.Heartbeat != 15*time.Second {
+					t.Errorf("Expected Stream defaults, got %+v", cfg.Stream)
+				}
+				if cfg.Changefeed.Enabled || cfg.Changefeed.Consumer != "show-service" || cfg.Changefeed.StreamARN != "" || !cfg.Changefeed.Audit || !cfg.Changefeed.Indexes {
+					t.Errorf("Expected Changefeed defaults, got %+v", cfg.Changefeed)
+				}
+				if cfg.Changefeed.Interval != time.Second || cfg.Changefeed.BatchSize != 100 || cfg.Changefeed.Lease != 30*time.Second {
+					t.Errorf("Expected Changefeed polling defaults, got %+v", cfg.Changefeed)
+				}
+				if cfg.Suggest.MaxShows != 100000 || cfg.Suggest.Refresh != 10*time.Minute {
+					t.Errorf("Expected Suggest defaults, got %+v", cfg.Suggest)
+				}
+				if cfg.Duplicates.Threshold != 0.85 || cfg.Duplicates.Strict {
+					t.Errorf("Expected Duplicates defaults, got %+v", cfg.Duplicates)
+				}
+				if cfg.Schedule.TimeZone != "Australia/Sydney" {
+					t.Errorf("Expected Schedule.TimeZone to default to Australia/Sydney, got %v", cfg.Schedule.TimeZone)
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "channel auto-create",
+			envVars: map[string]string{"APP_CHANNELS__AUTOCREATE": "true"},
+			validate: func(t *testing.T, cfg *Config) {
+				if !cfg.Channels.AutoCreate {
+					t.Errorf("Expected Channels.AutoCreate to be true")
+				}
+			},
+			expectError: false,
+		},
+		{
+			name:    "fallback locales",
+			envVars: map[string]string{"APP_LOCALES__FALLBACK": "en-AU,en"},
+			validate: func(t *testing.T, cfg *Config) {
+				if len(cfg.Locales.Fallback) != 2 || cfg.Locales.Fallback[0] != "en-AU" {
+					t.Errorf("Expected Locales.Fallback to be [en-AU en], got %v", cfg.Locales.Fallback)
+				}
+			},
+			expectError: false,
+		},
 		{
 			name:        "invalid APP_ENV",
 			envVars:     map[string]string{"APP_ENV": "prod"},
@@ -182,7 +333,7 @@ func TestLoad(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			envKeys := []string{"APP_ENV", "ECS_CONTAINER_METADATA_URI", "AWS_EXECUTION_ENV", "APP_DYNAMODB__REGION", "APP_LOG__LEVEL"}
+			envKeys := []string{"APP_ENV", "ECS_CONTAINER_METADATA_URI", "AWS_EXECUTION_ENV", "APP_DYNAMODB__REGION", "APP_LOG__LEVEL", "APP_TELEMETRY__EXPORTER", "APP_HEALTH__CACHETTL", "APP_SERVER__PORT", "APP_SERVER__SHUTDOWNGRACEPERIOD", "APP_VALIDATION__MAXERRORS", "APP_SANITIZE__ALLOW", "APP_SANITIZE__STRICT", "APP_CHANNELS__AUTOCREATE", "APP_LOCALES__FALLBACK"}
 			for _, key := range envKeys {
 				os.Unsetenv(key)
 			}
@@ -216,3 +367,44 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestCognito_JWKSEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		cognito  Cognito
+		expected string
+	}{
+		{
+			name:     "explicit URL wins",
+			cognito:  Cognito{JWKSURL: "https://example.com/jwks.json", UserPoolID: "pool", Region: "ap-southeast-2"},
+			expected: "https://example.com/jwks.json",
+		},
+		{
+			name:     "constructed from pool and region",
+			cognito:  Cognito{UserPoolID: "ap-southeast-2_abc", Region: "ap-southeast-2"},
+			expected: "https://cognito-idp.ap-southeast-2.amazonaws.com/ap-southeast-2_abc/.well-known/jwks.json",
+		},
+		{
+			name:     "missing pool",
+			cognito:  Cognito{Region: "ap-southeast-2"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cognito.JWKSEndpoint(); got != tt.expected {
+				t.Errorf("JWKSEndpoint() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCognito_Issuer(t *testing.T) {
+	if got := (Cognito{UserPoolID: "ap-southeast-2_abc", Region: "ap-southeast-2"}).Issuer(); got != "https://cognito-idp.ap-southeast-2.amazonaws.com/ap-southeast-2_abc" {
+		t.Errorf("Issuer() = %v", got)
+	}
+	if got := (Cognito{UserPoolID: "ap-southeast-2_abc"}).Issuer(); got != "" {
+		t.Errorf("Issuer() without a region = %v, want empty", got)
+	}
+}
@@ -12,9 +12,13 @@ import (
 )
 
 type DynamoAPI interface {
+	DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 	PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
 	Query(ctx context.Context, in *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 	Scan(ctx context.Context, in *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 	TableName() string
 }
 
@@ -23,6 +27,18 @@ type RealDynamo struct {
 	Table  string
 }
 
+func (r *RealDynamo) DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return r.Client.DescribeTable(ctx, in, optFns...)
+}
+
+func (r *RealDynamo) GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return r.Client.GetItem(ctx, in, optFns...)
+}
+
+func (r *RealDynamo) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return r.Client.DeleteItem(ctx, in, optFns...)
+}
+
 func (r *RealDynamo) PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
 	return r.Client.PutItem(ctx, in, optFns...)
 }
@@ -35,6 +51,10 @@ func (r *RealDynamo) Scan(ctx context.Context, in *dynamodb.ScanInput, optFns ..
 	return r.Client.Scan(ctx, in, optFns...)
 }
 
+func (r *RealDynamo) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return r.Client.TransactWriteItems(ctx, in, optFns...)
+}
+
 func (r *RealDynamo) TableName() string {
 	return r.Table
 }
@@ -47,29 +67,37 @@ func NewDynamo(ctx context.Context, cfg *config.Config) (DynamoAPI, error) {
 		return nil, fmt.Errorf("config is required")
 	}
 
-	var ac aws.Config
-	var err error
+	ac, err := loadAWSConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dynamodb.NewFromConfig(ac)
+
+	return &RealDynamo{
+		Client: client,
+		Table:  cfg.DynamoDB.ShowsTable,
+	}, nil
+}
 
+// loadAWSConfig returns the AWS config for the DynamoDB APIs, pointed at
+// DynamoDB Local when running locally with an endpoint override
+func loadAWSConfig(ctx context.Context, cfg *config.Config) (aws.Config, error) {
 	if cfg.Env == config.EnvLocal && cfg.DynamoDB.EndpointOverride != "" {
-		ac, err = awscfg.LoadDefaultConfig(ctx,
+		ac, err := awscfg.LoadDefaultConfig(ctx,
 			awscfg.WithRegion(cfg.DynamoDB.Region),
 			awscfg.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("dummy", "dummy", "")),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("aws cfg local: %w", err)
+			return aws.Config{}, fmt.Errorf("aws cfg local: %w", err)
 		}
 		ac.BaseEndpoint = aws.String(cfg.DynamoDB.EndpointOverride)
-	} else {
-		ac, err = awscfg.LoadDefaultConfig(ctx, awscfg.WithRegion(cfg.DynamoDB.Region))
-		if err != nil {
-			return nil, fmt.Errorf("aws cfg prod: %w", err)
-		}
+		return ac, nil
 	}
 
-	client := dynamodb.NewFromConfig(ac)
-
-	return &RealDynamo{
-		Client: client,
-		Table:  cfg.DynamoDB.ShowsTable,
-	}, nil
+	ac, err := awscfg.LoadDefaultConfig(ctx, awscfg.WithRegion(cfg.DynamoDB.Region))
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("aws cfg prod: %w", err)
+	}
+	return ac, nil
 }
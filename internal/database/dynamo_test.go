@@ -312,6 +312,51 @@ func TestRealDynamo_Scan(t *testing.T) {
 	}
 }
 
+func TestRealDynamo_DescribeTable(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *dynamodb.DescribeTableInput
+		mockReturn     *dynamodb.DescribeTableOutput
+		mockError      error
+		expectedStatus types.TableStatus
+		expectedError  error
+	}{
+		{
+			name:  "active table",
+			input: &dynamodb.DescribeTableInput{TableName: aws.String("test-table")},
+			mockReturn: &dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{TableStatus: types.TableStatusActive},
+			},
+			expectedStatus: types.TableStatusActive,
+		},
+		{
+			name:          "table not found",
+			input:         &dynamodb.DescribeTableInput{TableName: aws.String("missing-table")},
+			mockError:     errors.New("ResourceNotFoundException"),
+			expectedError: errors.New("ResourceNotFoundException"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mocks.NewMockDynamoAPI(t)
+			testAPI := &testDynamoAPI{mock: mockClient}
+
+			mockClient.EXPECT().DescribeTable(context.Background(), tt.input).Return(tt.mockReturn, tt.mockError)
+
+			output, err := testAPI.DescribeTable(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				require.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStatus, output.Table.TableStatus)
+		})
+	}
+}
+
 func TestRealDynamo_TableName(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -351,6 +396,27 @@ type testDynamoAPI struct {
 	tableName string
 }
 
+func (t *testDynamoAPI) DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	if t.mock != nil {
+		return t.mock.DescribeTable(ctx, in, optFns...)
+	}
+	return &dynamodb.DescribeTableOutput{}, nil
+}
+
+func (t *testDynamoAPI) GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	if t.mock != nil {
+		return t.mock.GetItem(ctx, in, optFns...)
+	}
+	return &dynamodb.GetItemOutput{}, nil
+}
+
+func (t *testDynamoAPI) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	if t.mock != nil {
+		return t.mock.DeleteItem(ctx, in, optFns...)
+	}
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
 func (t *testDynamoAPI) PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
 	if t.mock != nil {
 		return t.mock.PutItem(ctx, in, optFns...)
@@ -372,6 +438,13 @@ func (t *testDynamoAPI) Scan(ctx context.Context, in *dynamodb.ScanInput, optFns
 	return &dynamodb.ScanOutput{}, nil
 }
 
+func (t *testDynamoAPI) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	if t.mock != nil {
+		return t.mock.TransactWriteItems(ctx, in, optFns...)
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
 func (t *testDynamoAPI) TableName() string {
 	return t.tableName
 }
@@ -38,6 +38,255 @@ func (_m *MockDynamoAPI) EXPECT() *MockDynamoAPI_Expecter {
 	return &MockDynamoAPI_Expecter{mock: &_m.Mock}
 }
 
+// DeleteItem provides a mock function for the type MockDynamoAPI
+func (_mock *MockDynamoAPI) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteItem")
+	}
+
+	var r0 *dynamodb.DeleteItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) *dynamodb.DeleteItemOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.DeleteItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.DeleteItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDynamoAPI_DeleteItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteItem'
+type MockDynamoAPI_DeleteItem_Call struct {
+	*mock.Call
+}
+
+// DeleteItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *dynamodb.DeleteItemInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDynamoAPI_Expecter) DeleteItem(ctx interface{}, in interface{}, optFns ...interface{}) *MockDynamoAPI_DeleteItem_Call {
+	return &MockDynamoAPI_DeleteItem_Call{Call: _e.mock.On("DeleteItem",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockDynamoAPI_DeleteItem_Call) Run(run func(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options))) *MockDynamoAPI_DeleteItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.DeleteItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.DeleteItemInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		var variadicArgs []func(*dynamodb.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*dynamodb.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDynamoAPI_DeleteItem_Call) Return(deleteItemOutput *dynamodb.DeleteItemOutput, err error) *MockDynamoAPI_DeleteItem_Call {
+	_c.Call.Return(deleteItemOutput, err)
+	return _c
+}
+
+func (_c *MockDynamoAPI_DeleteItem_Call) RunAndReturn(run func(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)) *MockDynamoAPI_DeleteItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DescribeTable provides a mock function for the type MockDynamoAPI
+func (_mock *MockDynamoAPI) DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeTable")
+	}
+
+	var r0 *dynamodb.DescribeTableOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) *dynamodb.DescribeTableOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.DescribeTableOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDynamoAPI_DescribeTable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeTable'
+type MockDynamoAPI_DescribeTable_Call struct {
+	*mock.Call
+}
+
+// DescribeTable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *dynamodb.DescribeTableInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDynamoAPI_Expecter) DescribeTable(ctx interface{}, in interface{}, optFns ...interface{}) *MockDynamoAPI_DescribeTable_Call {
+	return &MockDynamoAPI_DescribeTable_Call{Call: _e.mock.On("DescribeTable",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockDynamoAPI_DescribeTable_Call) Run(run func(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options))) *MockDynamoAPI_DescribeTable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.DescribeTableInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.DescribeTableInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		var variadicArgs []func(*dynamodb.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*dynamodb.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDynamoAPI_DescribeTable_Call) Return(describeTableOutput *dynamodb.DescribeTableOutput, err error) *MockDynamoAPI_DescribeTable_Call {
+	_c.Call.Return(describeTableOutput, err)
+	return _c
+}
+
+func (_c *MockDynamoAPI_DescribeTable_Call) RunAndReturn(run func(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)) *MockDynamoAPI_DescribeTable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItem provides a mock function for the type MockDynamoAPI
+func (_mock *MockDynamoAPI) GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *dynamodb.GetItemOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) *dynamodb.GetItemOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.GetItemOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDynamoAPI_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockDynamoAPI_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *dynamodb.GetItemInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDynamoAPI_Expecter) GetItem(ctx interface{}, in interface{}, optFns ...interface{}) *MockDynamoAPI_GetItem_Call {
+	return &MockDynamoAPI_GetItem_Call{Call: _e.mock.On("GetItem",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockDynamoAPI_GetItem_Call) Run(run func(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options))) *MockDynamoAPI_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.GetItemInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.GetItemInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		var variadicArgs []func(*dynamodb.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*dynamodb.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDynamoAPI_GetItem_Call) Return(getItemOutput *dynamodb.GetItemOutput, err error) *MockDynamoAPI_GetItem_Call {
+	_c.Call.Return(getItemOutput, err)
+	return _c
+}
+
+func (_c *MockDynamoAPI_GetItem_Call) RunAndReturn(run func(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)) *MockDynamoAPI_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PutItem provides a mock function for the type MockDynamoAPI
 func (_mock *MockDynamoAPI) PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
 	var tmpRet mock.Arguments
@@ -330,3 +579,86 @@ func (_c *MockDynamoAPI_TableName_Call) RunAndReturn(run func() string) *MockDyn
 	_c.Call.Return(run)
 	return _c
 }
+
+// TransactWriteItems provides a mock function for the type MockDynamoAPI
+func (_mock *MockDynamoAPI) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransactWriteItems")
+	}
+
+	var r0 *dynamodb.TransactWriteItemsOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.TransactWriteItemsInput, ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dynamodb.TransactWriteItemsInput, ...func(*dynamodb.Options)) *dynamodb.TransactWriteItemsOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynamodb.TransactWriteItemsOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dynamodb.TransactWriteItemsInput, ...func(*dynamodb.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDynamoAPI_TransactWriteItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransactWriteItems'
+type MockDynamoAPI_TransactWriteItems_Call struct {
+	*mock.Call
+}
+
+// TransactWriteItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *dynamodb.TransactWriteItemsInput
+//   - optFns ...func(*dynamodb.Options)
+func (_e *MockDynamoAPI_Expecter) TransactWriteItems(ctx interface{}, in interface{}, optFns ...interface{}) *MockDynamoAPI_TransactWriteItems_Call {
+	return &MockDynamoAPI_TransactWriteItems_Call{Call: _e.mock.On("TransactWriteItems",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockDynamoAPI_TransactWriteItems_Call) Run(run func(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options))) *MockDynamoAPI_TransactWriteItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dynamodb.TransactWriteItemsInput
+		if args[1] != nil {
+			arg1 = args[1].(*dynamodb.TransactWriteItemsInput)
+		}
+		var arg2 []func(*dynamodb.Options)
+		var variadicArgs []func(*dynamodb.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*dynamodb.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDynamoAPI_TransactWriteItems_Call) Return(transactWriteItemsOutput *dynamodb.TransactWriteItemsOutput, err error) *MockDynamoAPI_TransactWriteItems_Call {
+	_c.Call.Return(transactWriteItemsOutput, err)
+	return _c
+}
+
+func (_c *MockDynamoAPI_TransactWriteItems_Call) RunAndReturn(run func(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)) *MockDynamoAPI_TransactWriteItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// TracedDynamo wraps a DynamoAPI and records a client span for every call
+type TracedDynamo struct {
+	next DynamoAPI
+}
+
+var _ DynamoAPI = (*TracedDynamo)(nil)
+
+func NewTracedDynamo(next DynamoAPI) DynamoAPI {
+	return &TracedDynamo{next: next}
+}
+
+func (t *TracedDynamo) start(ctx context.Context, op string, table *string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	base := []attribute.KeyValue{
+		semconv.DBSystemNameAWSDynamoDB,
+		semconv.DBOperationName(op),
+		semconv.AWSDynamoDBTableNames(aws.ToString(table)),
+	}
+	return telemetry.Tracer().Start(ctx, "DynamoDB."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(base, attrs...)...),
+	)
+}
+
+func (t *TracedDynamo) DescribeTable(ctx context.Context, in *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	var table *string
+	if in != nil {
+		table = in.TableName
+	}
+	ctx, span := t.start(ctx, "DescribeTable", table)
+	defer span.End()
+
+	out, err := t.next.DescribeTable(ctx, in, optFns...)
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) GetItem(ctx context.Context, in *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	var table *string
+	if in != nil {
+		table = in.TableName
+	}
+	ctx, span := t.start(ctx, "GetItem", table)
+	defer span.End()
+
+	out, err := t.next.GetItem(ctx, in, optFns...)
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	var table *string
+	if in != nil {
+		table = in.TableName
+	}
+	ctx, span := t.start(ctx, "DeleteItem", table)
+	defer span.End()
+
+	out, err := t.next.DeleteItem(ctx, in, optFns...)
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) PutItem(ctx context.Context, in *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	var table *string
+	if in != nil {
+		table = in.TableName
+	}
+	ctx, span := t.start(ctx, "PutItem", table)
+	defer span.End()
+
+	out, err := t.next.PutItem(ctx, in, optFns...)
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) Query(ctx context.Context, in *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	var table *string
+	var attrs []attribute.KeyValue
+	if in != nil {
+		table = in.TableName
+		if in.IndexName != nil {
+			attrs = append(attrs, semconv.AWSDynamoDBIndexName(*in.IndexName))
+		}
+	}
+	ctx, span := t.start(ctx, "Query", table, attrs...)
+	defer span.End()
+
+	out, err := t.next.Query(ctx, in, optFns...)
+	if out != nil {
+		span.SetAttributes(
+			semconv.AWSDynamoDBCount(int(out.Count)),
+			semconv.AWSDynamoDBScannedCount(int(out.ScannedCount)),
+		)
+	}
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) Scan(ctx context.Context, in *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	var table *string
+	var attrs []attribute.KeyValue
+	if in != nil {
+		table = in.TableName
+		if in.IndexName != nil {
+			attrs = append(attrs, semconv.AWSDynamoDBIndexName(*in.IndexName))
+		}
+	}
+	ctx, span := t.start(ctx, "Scan", table, attrs...)
+	defer span.End()
+
+	out, err := t.next.Scan(ctx, in, optFns...)
+	if out != nil {
+		span.SetAttributes(
+			semconv.AWSDynamoDBCount(int(out.Count)),
+			semconv.AWSDynamoDBScannedCount(int(out.ScannedCount)),
+		)
+	}
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) TransactWriteItems(ctx context.Context, in *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	// Every item in this service's transactions targets the same table
+	var table *string
+	if in != nil && len(in.TransactItems) > 0 {
+		table = transactTable(in.TransactItems[0])
+	}
+	ctx, span := t.start(ctx, "TransactWriteItems", table)
+	defer span.End()
+
+	out, err := t.next.TransactWriteItems(ctx, in, optFns...)
+	telemetry.RecordError(span, err)
+	return out, err
+}
+
+func (t *TracedDynamo) TableName() string {
+	return t.next.TableName()
+}
+
+func transactTable(item types.TransactWriteItem) *string {
+	switch {
+	case item.Put != nil:
+		return item.Put.TableName
+	case item.Update != nil:
+		return item.Update.TableName
+	case item.Delete != nil:
+		return item.Delete.TableName
+	case item.ConditionCheck != nil:
+		return item.ConditionCheck.TableName
+	}
+	return nil
+}
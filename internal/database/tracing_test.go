@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	mocks "github.com/marciomarinho/show-service/internal/database/mocks"
+)
+
+func newSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+func spanAttrs(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range s.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracedDynamo_Query(t *testing.T) {
+	sr := newSpanRecorder(t)
+
+	mockDB := mocks.NewMockDynamoAPI(t)
+	mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+		Return(&dynamodb.QueryOutput{Count: 3, ScannedCount: 5}, nil)
+
+	traced := NewTracedDynamo(mockDB)
+	_, err := traced.Query(context.Background(), &dynamodb.QueryInput{
+		TableName: aws.String("shows-test"),
+		IndexName: aws.String("gsi_drm_episode"),
+	})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "DynamoDB.Query", spans[0].Name())
+
+	attrs := spanAttrs(spans[0])
+	require.Equal(t, []string{"shows-test"}, attrs["aws.dynamodb.table_names"].AsStringSlice())
+	require.Equal(t, "gsi_drm_episode", attrs["aws.dynamodb.index_name"].AsString())
+	require.Equal(t, int64(3), attrs["aws.dynamodb.count"].AsInt64())
+	require.Equal(t, int64(5), attrs["aws.dynamodb.scanned_count"].AsInt64())
+}
+
+func TestTracedDynamo_PutItem(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sr := newSpanRecorder(t)
+
+		mockDB := mocks.NewMockDynamoAPI(t)
+		mockDB.On("PutItem", mock.Anything, mock.AnythingOfType("*dynamodb.PutItemInput")).
+			Return(&dynamodb.PutItemOutput{}, nil)
+
+		_, err := NewTracedDynamo(mockDB).PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String("shows-test")})
+		require.NoError(t, err)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, "DynamoDB.PutItem", spans[0].Name())
+		require.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("error marks span", func(t *testing.T) {
+		sr := newSpanRecorder(t)
+
+		mockDB := mocks.NewMockDynamoAPI(t)
+		mockDB.On("PutItem", mock.Anything, mock.AnythingOfType("*dynamodb.PutItemInput")).
+			Return(nil, errors.New("ConditionalCheckFailedException"))
+
+		_, err := NewTracedDynamo(mockDB).PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String("shows-test")})
+		require.Error(t, err)
+
+		spans := sr.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
+func TestTracedDynamo_GetItemAndDeleteItem(t *testing.T) {
+	sr := newSpanRecorder(t)
+
+	mockDB := mocks.NewMockDynamoAPI(t)
+	mockDB.On("GetItem", mock.Anything, mock.AnythingOfType("*dynamodb.GetItemInput")).
+		Return(&dynamodb.GetItemOutput{}, nil)
+	mockDB.On("DeleteItem", mock.Anything, mock.AnythingOfType("*dynamodb.DeleteItemInput")).
+		Return(nil, errors.New("ConditionalCheckFailedException"))
+
+	traced := NewTracedDynamo(mockDB)
+	_, err := traced.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String("shows-test")})
+	require.NoError(t, err)
+	_, err = traced.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{TableName: aws.String("shows-test")})
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "DynamoDB.GetItem", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, "DynamoDB.DeleteItem", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestTracedDynamo_TransactWriteItems(t *testing.T) {
+	sr := newSpanRecorder(t)
+
+	mockDB := mocks.NewMockDynamoAPI(t)
+	mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	_, err := NewTracedDynamo(mockDB).TransactWriteItems(context.Background(), &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{ConditionCheck: &types.ConditionCheck{TableName: aws.String("shows-test")}},
+			{Put: &types.Put{TableName: aws.String("shows-test")}},
+		},
+	})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "DynamoDB.TransactWriteItems", spans[0].Name())
+	require.Equal(t, []string{"shows-test"}, spanAttrs(spans[0])["aws.dynamodb.table_names"].AsStringSlice())
+}
+
+func TestTracedDynamo_Scan(t *testing.T) {
+	sr := newSpanRecorder(t)
+
+	mockDB := mocks.NewMockDynamoAPI(t)
+	mockDB.On("Scan", mock.Anything, mock.AnythingOfType("*dynamodb.ScanInput")).
+		Return(&dynamodb.ScanOutput{Count: 2}, nil)
+	mockDB.On("TableName").Return("shows-test")
+
+	traced := NewTracedDynamo(mockDB)
+	require.Equal(t, "shows-test", traced.TableName())
+
+	_, err := traced.Scan(nil, &dynamodb.ScanInput{TableName: aws.String("shows-test")})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "DynamoDB.Scan", spans[0].Name())
+	require.Equal(t, int64(2), spanAttrs(spans[0])["aws.dynamodb.count"].AsInt64())
+}
@@ -0,0 +1,284 @@
+// Package dedupe finds shows that are likely the same show stored twice,
+// e.g. under show/seapatrol and show/sea-patrol. Titles are folded the way
+// slugs are, with the spaces dropped, and compared by the Dice coefficient
+// of their character trigrams. Shows set in different countries or on
+// different channels are never taken for one another.
+package dedupe
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// DefaultThreshold is the similarity from which titles are taken for the
+// same: "Sea Patrol" and "SeaPatrol" score 1, "Sea Patrol" and "Sea Patrols"
+// 0.86, "Sea Patrol" and "See Patrol" 0.7 and "Sea Patrol" and "Sea Change"
+// 0.3
+const DefaultThreshold = 0.85
+
+// DefaultMaxGramShows bounds the shows a trigram may be shared by and still
+// be used to find candidates, so that lookups stay cheap as the catalogue
+// grows: trigrams such as "$$t" of titles starting "The" are held by too
+// many shows to tell any of them apart
+const DefaultMaxGramShows = 500
+
+// Options tune an Index
+type Options struct {
+	// Threshold is the title similarity, from 0 to 1, from which shows are
+	// flagged; DefaultThreshold when 0
+	Threshold float64
+	// MaxGramShows is the number of shows beyond which a trigram is not
+	// used to find candidates; DefaultMaxGramShows when 0. Candidates are
+	// still scored on all their trigrams, so only shows sharing none but
+	// common ones are missed.
+	MaxGramShows int
+}
+
+// Index is safe for concurrent use
+type Index struct {
+	mu    sync.RWMutex
+	opts  Options
+	docs  map[string]*doc                // by slug
+	grams map[string]map[string]struct{} // trigram, then slug
+}
+
+// doc is the part of a show compared
+type doc struct {
+	slug    string
+	title   string
+	country string
+	channel string
+	grams   []string
+}
+
+func New(opts Options) *Index {
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.MaxGramShows <= 0 {
+		opts.MaxGramShows = DefaultMaxGramShows
+	}
+	return &Index{opts: opts, docs: map[string]*doc{}, grams: map[string]map[string]struct{}{}}
+}
+
+// Put indexes show, replacing what was indexed at its slug. Alias stubs are
+// not indexed.
+func (ix *Index) Put(show domain.Show) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(show.Slug)
+	if show.AliasOf == nil {
+		ix.add(newDoc(show))
+	}
+}
+
+// Delete removes the show at slug, if indexed
+func (ix *Index) Delete(slug string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(slug)
+}
+
+// Rename moves the show indexed at from to slug to
+func (ix *Index) Rename(from, to string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	d, ok := ix.docs[from]
+	if !ok {
+		return
+	}
+	ix.remove(from)
+	ix.remove(to)
+	moved := *d
+	moved.slug = to
+	ix.add(&moved)
+}
+
+// Replace indexes shows in place of everything indexed so far
+func (ix *Index) Replace(shows []domain.Show) {
+	fresh := New(ix.opts)
+	for _, show := range shows {
+		if show.AliasOf == nil {
+			fresh.remove(show.Slug)
+			fresh.add(newDoc(show))
+		}
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.docs, ix.grams = fresh.docs, fresh.grams
+}
+
+// Len returns the number of shows indexed
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Find returns the indexed shows, and those among also, that show likely
+// duplicates, most similar first. The show at show's own slug is not one
+// of them.
+func (ix *Index) Find(show domain.Show, also ...domain.Show) []domain.DuplicateMatch {
+	d := newDoc(show)
+
+	ix.mu.RLock()
+	matches := ix.find(d)
+	ix.mu.RUnlock()
+
+	for _, other := range also {
+		o := newDoc(other)
+		if o.slug == d.slug || slices.ContainsFunc(matches, func(m domain.DuplicateMatch) bool { return m.Slug == o.slug }) {
+			continue
+		}
+		if score := dice(d.grams, o.grams); score >= ix.opts.Threshold && !conflict(d, o) {
+			matches = append(matches, match(o, score))
+		}
+	}
+	sortMatches(matches)
+	return matches
+}
+
+// Report returns up to limit indexed shows that likely duplicate others, by
+// slug, starting after the slug after; a limit of 0 returns all of them.
+// Each pair is reported once, under the show whose slug sorts first. next,
+// set when shows are left to look at, is the after of the following page.
+func (ix *Index) Report(after string, limit int) (report []domain.Duplicate, next string) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	for _, slug := range slices.Sorted(maps.Keys(ix.docs)) {
+		if slug <= after {
+			continue
+		}
+		if limit > 0 && len(report) == limit {
+			return report, report[limit-1].Slug
+		}
+		d := ix.docs[slug]
+		matches := slices.DeleteFunc(ix.find(d), func(m domain.DuplicateMatch) bool { return m.Slug < slug })
+		if len(matches) == 0 {
+			continue
+		}
+		sortMatches(matches)
+		report = append(report, domain.Duplicate{Slug: d.slug, Title: d.title, Matches: matches})
+	}
+	return report, ""
+}
+
+// find scores the indexed shows sharing trigrams with d, other than those
+// held by more than MaxGramShows shows. The caller holds the lock.
+func (ix *Index) find(d *doc) []domain.DuplicateMatch {
+	candidates := map[string]struct{}{}
+	for _, g := range d.grams {
+		if slugs := ix.grams[g]; len(slugs) <= ix.opts.MaxGramShows {
+			for slug := range slugs {
+				candidates[slug] = struct{}{}
+			}
+		}
+	}
+	var matches []domain.DuplicateMatch
+	for slug := range candidates {
+		other := ix.docs[slug]
+		if slug == d.slug || conflict(d, other) {
+			continue
+		}
+		if score := dice(d.grams, other.grams); score >= ix.opts.Threshold {
+			matches = append(matches, match(other, score))
+		}
+	}
+	return matches
+}
+
+// add indexes d, whose slug must not be indexed
+func (ix *Index) add(d *doc) {
+	ix.docs[d.slug] = d
+	for _, g := range d.grams {
+		slugs, ok := ix.grams[g]
+		if !ok {
+			slugs = map[string]struct{}{}
+			ix.grams[g] = slugs
+		}
+		slugs[d.slug] = struct{}{}
+	}
+}
+
+// remove unindexes the show at slug, if any
+func (ix *Index) remove(slug string) {
+	d, ok := ix.docs[slug]
+	if !ok {
+		return
+	}
+	delete(ix.docs, slug)
+	for _, g := range d.grams {
+		delete(ix.grams[g], slug)
+		if len(ix.grams[g]) == 0 {
+			delete(ix.grams, g)
+		}
+	}
+}
+
+func newDoc(show domain.Show) *doc {
+	d := &doc{slug: show.Slug, title: show.Title, grams: trigrams(show.Title)}
+	if show.Country != nil {
+		d.country = strings.ToUpper(strings.TrimSpace(*show.Country))
+	}
+	switch {
+	case show.ChannelID != nil:
+		d.channel = *show.ChannelID
+	case show.TVChannel != nil:
+		d.channel = strings.Join(domain.FoldWords(*show.TVChannel), "-")
+	}
+	return d
+}
+
+// trigrams returns the distinct trigrams of title folded without spaces,
+// padded so that its first letters count as much as the rest
+func trigrams(title string) []string {
+	key := "$$" + strings.Join(domain.FoldWords(title), "") + "$"
+	if len(key) == 3 {
+		return nil
+	}
+	grams := make([]string, 0, len(key)-2)
+	for i := 0; i+3 <= len(key); i++ {
+		if g := key[i : i+3]; !slices.Contains(grams, g) {
+			grams = append(grams, g)
+		}
+	}
+	return grams
+}
+
+// dice returns 2|a∩b| / (|a|+|b|) for sets of trigrams
+func dice(a, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	n := 0
+	for _, g := range a {
+		if slices.Contains(b, g) {
+			n++
+		}
+	}
+	return 2 * float64(n) / float64(len(a)+len(b))
+}
+
+// conflict reports whether a and b are set apart by country or channel.
+// A show without one does not conflict on it.
+func conflict(a, b *doc) bool {
+	return a.country != "" && b.country != "" && a.country != b.country ||
+		a.channel != "" && b.channel != "" && a.channel != b.channel
+}
+
+func match(d *doc, score float64) domain.DuplicateMatch {
+	return domain.DuplicateMatch{Slug: d.slug, Title: d.title, Score: math.Round(score*100) / 100}
+}
+
+func sortMatches(matches []domain.DuplicateMatch) {
+	slices.SortFunc(matches, func(a, b domain.DuplicateMatch) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Slug, b.Slug))
+	})
+}
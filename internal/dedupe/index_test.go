@@ -0,0 +1,195 @@
+package dedupe
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func strPtr(s string) *string { return &s }
+
+func slugs(matches []domain.DuplicateMatch) []string {
+	out := []string{}
+	for _, m := range matches {
+		out = append(out, m.Slug)
+	}
+	return out
+}
+
+func catalogue(opts Options) *Index {
+	ix := New(opts)
+	ix.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol", Country: strPtr("AU"), ChannelID: strPtr("nine")})
+	ix.Put(domain.Show{Slug: "show/sea-change", Title: "Sea Change", Country: strPtr("AU")})
+	ix.Put(domain.Show{Slug: "show/the-taste", Title: "The Taste (Le Goût)", TVChannel: strPtr("SBS One")})
+	ix.Put(domain.Show{Slug: "show/bluey", Title: "Bluey"})
+	return ix
+}
+
+func TestIndex_Find(t *testing.T) {
+	ix := catalogue(Options{})
+
+	tests := []struct {
+		name string
+		show domain.Show
+		want []string
+	}{
+		{name: "spaces", show: domain.Show{Slug: "show/seapatrol", Title: "SeaPatrol"}, want: []string{"show/sea-patrol"}},
+		{name: "case and punctuation", show: domain.Show{Slug: "show/x", Title: "SEA-PATROL!"}, want: []string{"show/sea-patrol"}},
+		{name: "plural", show: domain.Show{Slug: "show/x", Title: "Sea Patrols"}, want: []string{"show/sea-patrol"}},
+		{name: "accents", show: domain.Show{Slug: "show/x", Title: "The Taste (Le Gout)"}, want: []string{"show/the-taste"}},
+		{name: "one letter off", show: domain.Show{Slug: "show/x", Title: "See Patrol"}, want: []string{}},
+		{name: "different show", show: domain.Show{Slug: "show/x", Title: "Sea Shepherd"}, want: []string{}},
+		{name: "own slug", show: domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol"}, want: []string{}},
+		{name: "same country", show: domain.Show{Slug: "show/x", Title: "Sea Patrol", Country: strPtr("au")}, want: []string{"show/sea-patrol"}},
+		{name: "other country", show: domain.Show{Slug: "show/x", Title: "Sea Patrol", Country: strPtr("NZ")}, want: []string{}},
+		{name: "other channel", show: domain.Show{Slug: "show/x", Title: "Sea Patrol", ChannelID: strPtr("seven")}, want: []string{}},
+		{name: "same channel by name", show: domain.Show{Slug: "show/x", Title: "The Taste (Le Gout)", TVChannel: strPtr("sbs  one")}, want: []string{"show/the-taste"}},
+		{name: "other channel by name", show: domain.Show{Slug: "show/x", Title: "The Taste (Le Gout)", TVChannel: strPtr("ABC")}, want: []string{}},
+		{name: "empty title", show: domain.Show{Slug: "show/x", Title: "!!!"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, slugs(ix.Find(tt.show)))
+		})
+	}
+
+	t.Run("scores", func(t *testing.T) {
+		got := ix.Find(domain.Show{Slug: "show/x", Title: "Sea Patrols"})
+		require.Equal(t, []domain.DuplicateMatch{{Slug: "show/sea-patrol", Title: "Sea Patrol", Score: 0.86}}, got)
+	})
+
+	t.Run("also", func(t *testing.T) {
+		also := []domain.Show{
+			{Slug: "show/seapatrol", Title: "SeaPatrol"},
+			{Slug: "show/bluey-2", Title: "Bluey", Country: strPtr("GB")},
+			{Slug: "show/sea-patrol", Title: "Sea Patrol"},
+		}
+		got := ix.Find(domain.Show{Slug: "show/x", Title: "Sea Patrol"}, also...)
+		require.Equal(t, []string{"show/sea-patrol", "show/seapatrol"}, slugs(got))
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		ix := catalogue(Options{Threshold: 0.6})
+		require.Equal(t, []string{"show/sea-patrol"}, slugs(ix.Find(domain.Show{Slug: "show/x", Title: "See Patrol"})))
+	})
+}
+
+func TestIndex_Writes(t *testing.T) {
+	probe := domain.Show{Slug: "show/x", Title: "Sea Patrol"}
+
+	t.Run("put replaces the title", func(t *testing.T) {
+		ix := catalogue(Options{})
+		ix.Put(domain.Show{Slug: "show/sea-patrol", Title: "Patrol Boat"})
+		require.Empty(t, ix.Find(probe))
+		require.Equal(t, 4, ix.Len())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ix := catalogue(Options{})
+		ix.Delete("show/sea-patrol")
+		ix.Delete("show/missing")
+		require.Empty(t, ix.Find(probe))
+		require.Equal(t, 3, ix.Len())
+	})
+
+	t.Run("alias stubs are not indexed", func(t *testing.T) {
+		ix := New(Options{})
+		ix.Put(domain.Show{Slug: "show/old", Title: "Sea Patrol", AliasOf: strPtr("show/sea-patrol")})
+		require.Zero(t, ix.Len())
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		ix := catalogue(Options{})
+		ix.Rename("show/sea-patrol", "show/sea-patrol-2007")
+		ix.Rename("show/missing", "show/elsewhere")
+		require.Equal(t, []string{"show/sea-patrol-2007"}, slugs(ix.Find(probe)))
+		require.Equal(t, 4, ix.Len())
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		ix := catalogue(Options{})
+		ix.Replace([]domain.Show{
+			{Slug: "show/seapatrol", Title: "SeaPatrol"},
+			{Slug: "show/old", Title: "Sea Patrol", AliasOf: strPtr("show/seapatrol")},
+		})
+		require.Equal(t, 1, ix.Len())
+		require.Equal(t, []string{"show/seapatrol"}, slugs(ix.Find(probe)))
+	})
+}
+
+func TestIndex_Report(t *testing.T) {
+	ix := catalogue(Options{})
+	report, next := ix.Report("", 0)
+	require.Empty(t, report)
+	require.Empty(t, next)
+
+	ix.Put(domain.Show{Slug: "show/seapatrol", Title: "SeaPatrol"})
+	ix.Put(domain.Show{Slug: "show/sea-patrols", Title: "Sea Patrols"})
+	ix.Put(domain.Show{Slug: "show/bluey-nz", Title: "Bluey", Country: strPtr("NZ")})
+
+	require.Equal(t, []domain.Duplicate{
+		{Slug: "show/bluey", Title: "Bluey", Matches: []domain.DuplicateMatch{{Slug: "show/bluey-nz", Title: "Bluey", Score: 1}}},
+		{Slug: "show/sea-patrol", Title: "Sea Patrol", Matches: []domain.DuplicateMatch{
+			{Slug: "show/seapatrol", Title: "SeaPatrol", Score: 1},
+			{Slug: "show/sea-patrols", Title: "Sea Patrols", Score: 0.86},
+		}},
+		{Slug: "show/sea-patrols", Title: "Sea Patrols", Matches: []domain.DuplicateMatch{{Slug: "show/seapatrol", Title: "SeaPatrol", Score: 0.86}}},
+	}, first(ix.Report("", 0)))
+
+	t.Run("paged", func(t *testing.T) {
+		report, next := ix.Report("", 2)
+		require.Equal(t, []string{"show/bluey", "show/sea-patrol"}, reported(report))
+		require.Equal(t, "show/sea-patrol", next)
+
+		report, next = ix.Report(next, 2)
+		require.Equal(t, []string{"show/sea-patrols"}, reported(report))
+		require.Empty(t, next)
+	})
+}
+
+func TestIndex_MaxGramShows(t *testing.T) {
+	ix := New(Options{MaxGramShows: 2})
+	ix.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol"})
+	for i := range 3 {
+		ix.Put(domain.Show{Slug: fmt.Sprintf("show/sea-%d", i), Title: fmt.Sprintf("Sea %d", i)})
+	}
+
+	// "$$s", "$se" and "sea" are held by four shows, so only the rarer
+	// trigrams find candidates, which are then scored on all of theirs
+	got := ix.Find(domain.Show{Slug: "show/x", Title: "Sea Patrols"})
+	require.Equal(t, []domain.DuplicateMatch{{Slug: "show/sea-patrol", Title: "Sea Patrol", Score: 0.86}}, got)
+}
+
+func first(report []domain.Duplicate, _ string) []domain.Duplicate {
+	return report
+}
+
+func reported(report []domain.Duplicate) []string {
+	out := []string{}
+	for _, d := range report {
+		out = append(out, d.Slug)
+	}
+	return out
+}
+
+func TestIndex_Concurrent(t *testing.T) {
+	ix := catalogue(Options{})
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			for j := range 100 {
+				slug := fmt.Sprintf("show/%d-%d", i, j)
+				ix.Put(domain.Show{Slug: slug, Title: "Sea Patrol " + slug})
+				ix.Find(domain.Show{Slug: "show/x", Title: "Sea Patrol"})
+				ix.Rename(slug, slug+"-x")
+			}
+		})
+	}
+	wg.Go(func() { ix.Report("", 0) })
+	wg.Go(func() { ix.Replace(nil) })
+	wg.Wait()
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// MatchCountryCode validates ISO 3166 alpha-2 codes as stored, e.g. AU
+var MatchCountryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Reasons a show is hidden from a viewer, reported to admins
+const (
+	HiddenNotYetAvailable   = "not_yet_available"
+	HiddenExpired           = "expired"
+	HiddenCountryBlocked    = "country_blocked"
+	HiddenCountryNotAllowed = "country_not_allowed"
+	HiddenCountryUnknown    = "country_unknown"
+)
+
+// Availability restricts where and when a show is listed. Start is
+// inclusive and End exclusive; either may be open. An empty Countries list
+// allows every country not in BlockedCountries.
+type Availability struct {
+	Start            *string  `json:"start,omitempty" dynamodbav:"start,omitempty"`
+	End              *string  `json:"end,omitempty" dynamodbav:"end,omitempty"`
+	Countries        []string `json:"countries,omitempty" dynamodbav:"countries,omitempty"`
+	BlockedCountries []string `json:"blockedCountries,omitempty" dynamodbav:"blockedCountries,omitempty"`
+}
+
+func (a Availability) Validate() error {
+	errs := validation.Errors{}
+
+	start, startOK := a.bound("start", a.Start, errs)
+	end, endOK := a.bound("end", a.End, errs)
+	if startOK && endOK && !end.After(start) {
+		errs["end"] = validation.NewError("availability_window_invalid", "end must be after start")
+	}
+
+	if err := validateCountries(a.Countries, nil); err != nil {
+		errs["countries"] = err
+	}
+	if err := validateCountries(a.BlockedCountries, a.Countries); err != nil {
+		errs["blockedCountries"] = err
+	}
+
+	return errs.Filter()
+}
+
+// bound parses a window bound, recording an error under field when it is
+// set but unparseable
+func (a Availability) bound(field string, value *string, errs validation.Errors) (time.Time, bool) {
+	if value == nil || *value == "" {
+		return time.Time{}, false
+	}
+	t, err := ParseAirDate(*value, time.UTC)
+	if err != nil {
+		errs[field] = DateInvalid()
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// validateCountries checks each entry is an alpha-2 code and, for the
+// blocked list, that it is not also allowed
+func validateCountries(codes, allowed []string) error {
+	items := validation.Errors{}
+	for i, code := range codes {
+		switch {
+		case !MatchCountryCode.MatchString(code):
+			items[strconv.Itoa(i)] = validation.NewError("country_invalid", "must be an ISO 3166 alpha-2 country code")
+		case slices.Contains(allowed, code):
+			items[strconv.Itoa(i)] = validation.NewError("country_conflict", code+" is also listed in countries")
+		}
+	}
+
+	if len(items) > 0 {
+		return items
+	}
+	return nil
+}
+
+// Check returns why the show is hidden from a viewer in country at time at,
+// or "" when it is available. country is an alpha-2 code, "" when unknown;
+// an unknown country only hides shows limited to an allowlist.
+func (a *Availability) Check(at time.Time, country string) string {
+	if a == nil {
+		return ""
+	}
+	if a.Start != nil && *a.Start != "" {
+		if start, err := ParseAirDate(*a.Start, time.UTC); err == nil && at.Before(start) {
+			return HiddenNotYetAvailable
+		}
+	}
+	if a.End != nil && *a.End != "" {
+		if end, err := ParseAirDate(*a.End, time.UTC); err == nil && !at.Before(end) {
+			return HiddenExpired
+		}
+	}
+	if country != "" && slices.Contains(a.BlockedCountries, country) {
+		return HiddenCountryBlocked
+	}
+	if len(a.Countries) > 0 {
+		if country == "" {
+			return HiddenCountryUnknown
+		}
+		if !slices.Contains(a.Countries, country) {
+			return HiddenCountryNotAllowed
+		}
+	}
+	return ""
+}
+
+// Viewer describes who a read is for
+type Viewer struct {
+	// Locales are the client's locale preferences, most preferred first
+	Locales []string
+	// Country is the viewer's ISO 3166 alpha-2 code, "" when unknown
+	Country string
+	// IncludeHidden lists shows the viewer could not see, marked with the
+	// reason; reserved for admins
+	IncludeHidden bool
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAvailability_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		availability Availability
+		wantCodes    map[string]string
+	}{
+		{
+			name: "valid",
+			availability: Availability{
+				Start:            stringPtr("2024-06-01T00:00:00Z"),
+				End:              stringPtr("2024-07-01T00:00:00Z"),
+				Countries:        []string{"AU", "NZ"},
+				BlockedCountries: []string{"US"},
+			},
+		},
+		{name: "open window", availability: Availability{End: stringPtr("2024-07-01T00:00:00Z")}},
+		{
+			name:         "unparseable bound",
+			availability: Availability{Start: stringPtr("soon")},
+			wantCodes:    map[string]string{"start": "date_invalid"},
+		},
+		{
+			name:         "end before start",
+			availability: Availability{Start: stringPtr("2024-07-01T00:00:00Z"), End: stringPtr("2024-06-01T00:00:00Z")},
+			wantCodes:    map[string]string{"end": "availability_window_invalid"},
+		},
+		{
+			name:         "empty window",
+			availability: Availability{Start: stringPtr("2024-07-01T00:00:00Z"), End: stringPtr("2024-07-01T00:00:00Z")},
+			wantCodes:    map[string]string{"end": "availability_window_invalid"},
+		},
+		{
+			name:         "bad country codes",
+			availability: Availability{Countries: []string{"AU", "Australia"}, BlockedCountries: []string{"us"}},
+			wantCodes:    map[string]string{"countries/1": "country_invalid", "blockedCountries/0": "country_invalid"},
+		},
+		{
+			name:         "allowed and blocked",
+			availability: Availability{Countries: []string{"AU", "NZ"}, BlockedCountries: []string{"NZ"}},
+			wantCodes:    map[string]string{"blockedCountries/0": "country_conflict"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.availability.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Availability.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Availability.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestShow_Validate_Availability(t *testing.T) {
+	show := Show{Slug: "show/a", Title: "A", Availability: &Availability{Countries: []string{"Oz"}}}
+
+	got := map[string]string{}
+	collectCodes(show.Validate(), "", got)
+	if got["availability/countries/0"] != "country_invalid" {
+		t.Errorf("Show.Validate() = %v, want availability/countries/0 country_invalid", got)
+	}
+}
+
+func TestAvailability_Check(t *testing.T) {
+	window := &Availability{Start: stringPtr("2024-06-01T00:00:00Z"), End: stringPtr("2024-07-01T00:00:00Z")}
+	geo := &Availability{Countries: []string{"AU", "NZ"}, BlockedCountries: []string{"US"}}
+	blockedOnly := &Availability{BlockedCountries: []string{"US"}}
+	june := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		availability *Availability
+		at           time.Time
+		country      string
+		want         string
+	}{
+		{name: "no rules", availability: nil, at: june, want: ""},
+		{name: "inside window", availability: window, at: june, want: ""},
+		{name: "start is inclusive", availability: window, at: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), want: ""},
+		{name: "before start", availability: window, at: time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC), want: HiddenNotYetAvailable},
+		{name: "end is exclusive", availability: window, at: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), want: HiddenExpired},
+		{name: "allowed country", availability: geo, at: june, country: "NZ", want: ""},
+		{name: "country not allowed", availability: geo, at: june, country: "GB", want: HiddenCountryNotAllowed},
+		{name: "unknown country with allowlist", availability: geo, at: june, want: HiddenCountryUnknown},
+		{name: "blocked country", availability: blockedOnly, at: june, country: "US", want: HiddenCountryBlocked},
+		{name: "unknown country with blocklist only", availability: blockedOnly, at: june, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.availability.Check(tt.at, tt.country); got != tt.want {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
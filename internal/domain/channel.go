@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// MatchChannelID validates channel ids: lowercase letters, digits and dashes,
+// starting with a letter or digit, e.g. "channel-9"
+var MatchChannelID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// MaxChannelID caps the length of channel ids
+const MaxChannelID = 50
+
+// validChannelID reports whether id could name a channel
+func validChannelID(id string) bool {
+	return len(id) <= MaxChannelID && MatchChannelID.MatchString(id)
+}
+
+// Channel is a broadcaster shows refer to by ID instead of repeating its
+// name and logo
+type Channel struct {
+	ID            string  `json:"id" dynamodbav:"id"`
+	Name          string  `json:"name" dynamodbav:"name"`
+	Logo          *string `json:"logo,omitempty" dynamodbav:"logo"`
+	PrimaryColour *string `json:"primaryColour,omitempty" dynamodbav:"primaryColour"`
+	Country       *string `json:"country,omitempty" dynamodbav:"country"`
+
+	// Keys (not in JSON payloads; set on write)
+	PK string `json:"-" dynamodbav:"slug"`
+	SK string `json:"-" dynamodbav:"sk"`
+}
+
+func (c Channel) Validate() error {
+	errs := validation.Errors{}
+
+	if !validChannelID(c.ID) {
+		errs["id"] = validation.NewError("channel_id_invalid", "id must be lowercase letters, digits and dashes, at most 50 characters")
+	}
+	if len(strings.TrimSpace(c.Name)) == 0 {
+		errs["name"] = validation.NewError("name_required", "name is required")
+	} else if utf8.RuneCountInString(c.Name) > 50 {
+		errs["name"] = validation.NewError("name_too_long", "name must be at most 50 characters")
+	}
+	if c.Logo != nil {
+		if err := ValidateURL(*c.Logo); err != nil {
+			errs["logo"] = err
+		}
+	}
+	if c.PrimaryColour != nil && !MatchHexColor.MatchString(*c.PrimaryColour) {
+		errs["primaryColour"] = validation.NewError("validation_match_invalid", "must be valid hex color")
+	}
+	if err := ValidateStringLength(c.Country, 0, 50); err != nil {
+		errs["country"] = err
+	}
+
+	return errs.Filter()
+}
+
+// validateChannelID checks an optional reference to a channel
+func validateChannelID(value any) error {
+	id, _ := value.(*string)
+	if id == nil || validChannelID(*id) {
+		return nil
+	}
+	return validation.NewError("channel_id_invalid", "must be a channel id")
+}
+
+type ChannelsResponse struct {
+	Response []Channel `json:"response"`
+}
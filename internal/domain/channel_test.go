@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChannel_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   Channel
+		wantCodes map[string]string
+	}{
+		{
+			name: "valid channel",
+			channel: Channel{
+				ID:            "channel-9",
+				Name:          "Channel 9",
+				Logo:          stringPtr("http://example.com/9.png"),
+				PrimaryColour: stringPtr("#0084da"),
+				Country:       stringPtr("AU"),
+			},
+		},
+		{
+			name:      "bad id and missing name",
+			channel:   Channel{ID: "Channel 9"},
+			wantCodes: map[string]string{"id": "channel_id_invalid", "name": "name_required"},
+		},
+		{
+			name:      "id too long",
+			channel:   Channel{ID: strings.Repeat("a", 51), Name: "A"},
+			wantCodes: map[string]string{"id": "channel_id_invalid"},
+		},
+		{
+			name:      "bad logo and colour",
+			channel:   Channel{ID: "nine", Name: "Nine", Logo: stringPtr("logo.png"), PrimaryColour: stringPtr("blue")},
+			wantCodes: map[string]string{"logo": "invalid_url", "primaryColour": "validation_match_invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.channel.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Channel.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Channel.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestShow_Validate_ChannelIDs(t *testing.T) {
+	show := Show{
+		Slug:      "show/a",
+		Title:     "A",
+		ChannelID: stringPtr("Nine!"),
+		NextEpisode: &NextEpisode{
+			ChannelID:   stringPtr(strings.Repeat("a", MaxChannelID+1)),
+			ChannelLogo: "http://example.com/gem.png",
+			HTML:        "soon",
+			URL:         "http://example.com/a",
+		},
+	}
+
+	got := map[string]string{}
+	collectCodes(show.Validate(), "", got)
+	if len(got) != 2 || got["channelId"] != "channel_id_invalid" || got["nextEpisode/channelId"] != "channel_id_invalid" {
+		t.Errorf("Show.Validate() = %v, want channelId and nextEpisode/channelId channel_id_invalid", got)
+	}
+}
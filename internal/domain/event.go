@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Change event types. Writes that store a revision are named after its
+// action.
+const (
+	EventShowCreated      = "show." + RevisionCreated
+	EventShowTransitioned = "show." + RevisionTransitioned
+	EventShowReverted     = "show." + RevisionReverted
+	EventShowRenamed      = "show.renamed"
+)
+
+// ChangeEvent tells downstream systems that a show changed. Consumers fetch
+// the show for its content. Delivery is at least once, so they should
+// deduplicate on ID.
+type ChangeEvent struct {
+	ID             string `json:"id" dynamodbav:"id"`
+	Type           string `json:"type" dynamodbav:"type"`
+	Slug           string `json:"slug" dynamodbav:"slug"`
+	PreviousSlug   string `json:"previousSlug,omitempty" dynamodbav:"previousSlug,omitempty"` // renames
+	Revision       int    `json:"revision,omitempty" dynamodbav:"revision,omitempty"`         // the show's revision after the change
+	Actor          string `json:"actor,omitempty" dynamodbav:"actor,omitempty"`
+	Status         Status `json:"status,omitempty" dynamodbav:"status,omitempty"`                 // the show's effective status after the change
+	PreviousStatus Status `json:"previousStatus,omitempty" dynamodbav:"previousStatus,omitempty"` // before the change, when it moved the show
+	At             string `json:"at" dynamodbav:"at"`                                             // RFC 3339, UTC
+}
+
+// RevisionEvent announces the write that stored rev
+func RevisionEvent(slug string, rev Revision) ChangeEvent {
+	e := ChangeEvent{Type: "show." + rev.Action, Slug: slug, Revision: rev.Number, Actor: rev.Actor, At: rev.At}
+	if rev.Show != nil {
+		e.Status = rev.Show.EffectiveStatus()
+	}
+	return e
+}
+
+// RenameEvent announces show, already renamed, moving from one slug to
+// another
+func RenameEvent(from string, show Show, at time.Time) ChangeEvent {
+	return ChangeEvent{
+		Type:         EventShowRenamed,
+		Slug:         show.Slug,
+		PreviousSlug: from,
+		Revision:     show.Revision,
+		Status:       show.EffectiveStatus(),
+		At:           at.UTC().Format(time.RFC3339),
+	}
+}
+
+// NewID returns a random 32-digit hex ID
+func NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// OutboxEntry is a change event waiting to be published, stored in the same
+// transaction as the write it announces, with its delivery state
+type OutboxEntry struct {
+	Event ChangeEvent `json:"event" dynamodbav:"event"`
+	// Attempts counts deliveries started, including one in progress
+	Attempts int `json:"attempts" dynamodbav:"attempts"`
+	// NextAttemptAt is when the entry is next due, in SortableTime. While a
+	// delivery is in progress it is the end of the dispatcher's lease.
+	NextAttemptAt string `json:"nextAttemptAt" dynamodbav:"nextAttemptAt"`
+	LastError     string `json:"lastError,omitempty" dynamodbav:"lastError,omitempty"`
+
+	// Keys (not in JSON payloads; set on write)
+	PK string `json:"-" dynamodbav:"slug"`
+	SK string `json:"-" dynamodbav:"sk"`
+}
+
+// ShardCheckpoint is how far a stream consumer has read one shard of the
+// table's stream. The consumer holding the shard leases it, so that
+// instances sharing a consumer name read each shard once between them.
+type ShardCheckpoint struct {
+	Consumer string `json:"consumer" dynamodbav:"consumer"`
+	ShardID  string `json:"shardId" dynamodbav:"shardId"`
+	// SequenceNumber is the last record handled; empty before the first
+	SequenceNumber string `json:"sequenceNumber,omitempty" dynamodbav:"sequenceNumber,omitempty"`
+	// Finished is set once a closed shard has been read to its end
+	Finished   bool   `json:"finished,omitempty" dynamodbav:"finished,omitempty"`
+	Owner      string `json:"owner,omitempty" dynamodbav:"owner,omitempty"`
+	LeaseUntil string `json:"leaseUntil,omitempty" dynamodbav:"leaseUntil,omitempty"` // SortableTime
+	UpdatedAt  string `json:"updatedAt,omitempty" dynamodbav:"updatedAt,omitempty"`   // RFC 3339, UTC
+
+	// Keys (not in JSON payloads; set on write)
+	PK string `json:"-" dynamodbav:"slug"`
+	SK string `json:"-" dynamodbav:"sk"`
+}
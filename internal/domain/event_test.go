@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRevisionEvent(t *testing.T) {
+	rev := Revision{Number: 4, Action: RevisionReverted, Actor: "ed", At: "2024-06-15T00:00:00Z"}
+	got := RevisionEvent("show/a", rev)
+	want := ChangeEvent{Type: EventShowReverted, Slug: "show/a", Revision: 4, Actor: "ed", At: "2024-06-15T00:00:00Z"}
+	if got != want {
+		t.Errorf("RevisionEvent() = %+v, want %+v", got, want)
+	}
+
+	// The status comes from the revision's document
+	rev.Show = &Show{Slug: "show/a", Status: StatusArchived}
+	if got := RevisionEvent("show/a", rev); got.Status != StatusArchived {
+		t.Errorf("RevisionEvent().Status = %q, want %q", got.Status, StatusArchived)
+	}
+}
+
+func TestRenameEvent(t *testing.T) {
+	at := time.Date(2024, 6, 15, 10, 0, 0, 0, time.FixedZone("AEST", 10*60*60))
+	got := RenameEvent("show/a", Show{Slug: "show/b"}, at)
+	want := ChangeEvent{Type: EventShowRenamed, Slug: "show/b", PreviousSlug: "show/a", Status: StatusPublished, At: "2024-06-15T00:00:00Z"}
+	if got != want {
+		t.Errorf("RenameEvent() = %+v, want %+v", got, want)
+	}
+
+	// A show stored before revisions has no number to report
+	body, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(body) != `{"id":"","type":"show.renamed","slug":"show/b","previousSlug":"show/a","status":"published","at":"2024-06-15T00:00:00Z"}` {
+		t.Errorf("Marshal() = %s", body)
+	}
+}
+
+func TestNewID(t *testing.T) {
+	a, b := NewID(), NewID()
+	if len(a) != 32 || a == b {
+		t.Errorf("NewID() = %q, %q, want distinct 32-digit IDs", a, b)
+	}
+}
@@ -1,20 +1,29 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/marciomarinho/show-service/internal/genre"
+	"github.com/marciomarinho/show-service/internal/locale"
 )
 
 // MatchShowSlug validates show slug format: show/<handle>
-// handle: letters/digits/dashes, must start with letter or digit
-var MatchShowSlug = regexp.MustCompile(`^show/[a-z0-9][a-z0-9-]*$`)
+// handle: letters/digits/dashes, must start with letter or digit, and must
+// not be one of ReservedHandles
+var MatchShowSlug = regexp.MustCompile(`^show/` + handlePattern(ReservedHandles) + `$`)
 
 // MatchSeasonSlug validates season slug format: show/<handle>/season/<number>=1+
-var MatchSeasonSlug = regexp.MustCompile(`^show/[a-z0-9][a-z0-9-]*/season/[1-9][0-9]*$`)
+var MatchSeasonSlug = regexp.MustCompile(`^show/` + handlePattern(ReservedHandles) + `/season/[1-9][0-9]*$`)
 
 // MatchHexColor validates hex color format (#ffffff)
 var MatchHexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
@@ -56,7 +65,7 @@ func ValidateStringLength(s *string, min, max int) error {
 	if s == nil {
 		return nil
 	}
-	if len(*s) < min || len(*s) > max {
+	if n := utf8.RuneCountInString(*s); n < min || n > max {
 		return validation.NewError("length_error", "string length out of range")
 	}
 	return nil
@@ -72,17 +81,79 @@ func (i Image) Validate() error {
 	)
 }
 
+// SortableTime is the fixed-width UTC layout used for index keys, so that
+// lexical order in DynamoDB is chronological order
+const SortableTime = "2006-01-02T15:04:05Z"
+
+// airDateLayouts are the legacy feed formats accepted besides RFC 3339.
+// Layouts without a zone are read in the feed's time zone.
+var airDateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02/01/2006 15:04",
+	"02/01/2006 3:04pm",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006 3:04pm",
+	"2006-01-02",
+}
+
+// ParseAirDate parses an episode air date in RFC 3339 or one of the legacy
+// feed formats. Values without an offset are interpreted in loc.
+func ParseAirDate(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s = strings.TrimSpace(s)
+	for _, layout := range airDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", s)
+}
+
+// DateInvalid is the validation error for a date ParseAirDate cannot read
+func DateInvalid() validation.Error {
+	return validation.NewError("date_invalid", "must be a date in RFC 3339, RFC 1123 or a legacy feed format")
+}
+
 // NextEpisode represents next episode information
 type NextEpisode struct {
 	Channel     *string `json:"channel,omitempty" dynamodbav:"channel"`
+	ChannelID   *string `json:"channelId,omitempty" dynamodbav:"channelId,omitempty"` // Channel.ID when the episode airs on a catalogued channel
 	ChannelLogo string  `json:"channelLogo" dynamodbav:"channelLogo"`
 	Date        *string `json:"date,omitempty" dynamodbav:"date"`
 	HTML        string  `json:"html" dynamodbav:"html"`
 	URL         string  `json:"url" dynamodbav:"url"`
+
+	// Text is a plain-text rendering of HTML, set on ingest when enabled
+	Text *string `json:"text,omitempty" dynamodbav:"text,omitempty"`
+}
+
+// AirsAt returns Date as a timestamp. Normalization rewrites Date to RFC 3339;
+// un-normalized legacy values without an offset are read as UTC.
+func (n NextEpisode) AirsAt() (time.Time, bool) {
+	if n.Date == nil || *n.Date == "" {
+		return time.Time{}, false
+	}
+	t, err := ParseAirDate(*n.Date, time.UTC)
+	return t, err == nil
 }
 
 func (n NextEpisode) Validate() error {
 	return validation.ValidateStruct(&n,
+		validation.Field(&n.Date, validation.When(n.Date != nil && *n.Date != "", validation.By(func(value any) error {
+			if _, err := ParseAirDate(*value.(*string), time.UTC); err != nil {
+				return DateInvalid()
+			}
+			return nil
+		}))),
+		validation.Field(&n.ChannelID, validation.By(validateChannelID)),
 		validation.Field(&n.ChannelLogo, validation.Required),
 		validation.Field(&n.HTML, validation.Required),
 		validation.Field(&n.URL, validation.Required, URLRule{}),
@@ -110,7 +181,8 @@ type Show struct {
 	Description   *string      `json:"description,omitempty" dynamodbav:"description"`
 	DRM           *bool        `json:"drm,omitempty" dynamodbav:"drm"`
 	EpisodeCount  *int         `json:"episodeCount,omitempty" dynamodbav:"episodeCount"`
-	Genre         *string      `json:"genre,omitempty" dynamodbav:"genre"`
+	Genre         *string      `json:"genre,omitempty" dynamodbav:"genre,omitempty"` // Deprecated: free-text genre, folded into Genres on ingest and read
+	Genres        []string     `json:"genres,omitempty" dynamodbav:"genres,omitempty"`
 	Image         *Image       `json:"image,omitempty" dynamodbav:"image"`
 	Language      *string      `json:"language,omitempty" dynamodbav:"language"`
 	NextEpisode   *NextEpisode `json:"nextEpisode,omitempty" dynamodbav:"nextEpisode"`
@@ -119,51 +191,292 @@ type Show struct {
 	Slug          string       `json:"slug" dynamodbav:"slug"` // PK
 	Title         string       `json:"title" dynamodbav:"title"`
 	TVChannel     *string      `json:"tvChannel,omitempty" dynamodbav:"tvChannel"`
+	ChannelID     *string      `json:"channelId,omitempty" dynamodbav:"channelId,omitempty"` // Channel.ID; TVChannel is the legacy free-text name
+
+	// Per-locale variants of Title, Description and Image keyed by BCP 47
+	// tag. Reads serve the best match for the client and fall back to the
+	// unlocalized fields.
+	Titles       map[string]string `json:"titles,omitempty" dynamodbav:"titles,omitempty"`
+	Descriptions map[string]string `json:"descriptions,omitempty" dynamodbav:"descriptions,omitempty"`
+	Images       map[string]Image  `json:"images,omitempty" dynamodbav:"images,omitempty"`
+
+	// Licensing window and territories; reads hide the show outside them
+	Availability *Availability `json:"availability,omitempty" dynamodbav:"availability,omitempty"`
+
+	// Editorial workflow. New shows start as drafts and only published ones
+	// are listed; shows stored before the workflow have no status and count
+	// as published. Both change through status transitions only.
+	Status      Status       `json:"status,omitempty" dynamodbav:"status,omitempty"`
+	PublishAt   *string      `json:"publishAt,omitempty" dynamodbav:"publishAt,omitempty"` // RFC 3339; hidden until then
+	Transitions []Transition `json:"-" dynamodbav:"transitions,omitempty"`
+
+	// Editorial weight; the more popular of two shows is suggested first
+	// as titles are typed
+	Popularity *int `json:"popularity,omitempty" dynamodbav:"popularity,omitempty"`
+
+	// Former slugs, kept as aliases that redirect here; set by renames only
+	Aliases []string `json:"aliases,omitempty" dynamodbav:"aliases,omitempty"`
+
+	// Number of the latest revision, 0 for shows not written since revisions
+	// were kept; writes are conditional on it
+	Revision int `json:"revision,omitempty" dynamodbav:"revision,omitempty"`
+
+	// Sort key; seasons and episodes share the show's partition
+	SK string `json:"-" dynamodbav:"sk"`
+
+	// AliasOf is set on the stub left at a former slug and names the show's
+	// current slug. The stub holds the slug so no new show can take it.
+	AliasOf *string `json:"-" dynamodbav:"aliasOf,omitempty"`
+	// SlugGenerated marks a slug derived from the title on ingest, which may
+	// be suffixed to avoid collisions
+	SlugGenerated bool `json:"-" dynamodbav:"-"`
+	// Locales lists the variants Localize served, title first
+	Locales []string `json:"-" dynamodbav:"-"`
+	// Hidden is why the workflow or Availability hides the show from the
+	// viewer of a read, set only for viewers listing hidden shows
+	Hidden string `json:"hidden,omitempty" dynamodbav:"-"`
 
 	// Index helpers (not in JSON payloads; set on write for GSI)
-	DRMKey *int `json:"-" dynamodbav:"drmKey,omitempty"`
+	DRMKey        *int    `json:"-" dynamodbav:"drmKey,omitempty"`
+	AiringKey     *int    `json:"-" dynamodbav:"airingKey,omitempty"`     // set only when NextEpisodeAt is, keeping gsi_airing sparse
+	NextEpisodeAt *string `json:"-" dynamodbav:"nextEpisodeAt,omitempty"` // NextEpisode.Date in SortableTime
+}
+
+// FoldLegacyGenre brings a show stored before the genre taxonomy up to
+// date. Such shows hold a free-text Genre; one the taxonomy knows is folded
+// into Genres, and anything else is kept in Genre so that writing the show
+// back does not lose it.
+func (s *Show) FoldLegacyGenre() {
+	if s.Genre == nil {
+		return
+	}
+	code, ok := genre.Lookup(*s.Genre)
+	if !ok {
+		return
+	}
+	if !slices.Contains(s.Genres, code) {
+		s.Genres = append(s.Genres, code)
+	}
+	s.Genre = nil
 }
 
+// Validate reports every violation at once as validation.Errors keyed by JSON
+// field name, so callers can render one JSON pointer per offending value
 func (s Show) Validate() error {
-	if len(strings.TrimSpace(s.Title)) == 0 {
-		return validation.NewError("title_required", "title is required")
+	errs := validation.Errors{}
+
+	if err := validateTitle(s.Title); err != nil {
+		errs["title"] = err
 	}
-	if len(s.Title) > 120 {
-		return validation.NewError("title_too_long", "title must be at most 120 characters")
+	if err := validateVariants(s.Titles, validateTitle); err != nil {
+		errs["titles"] = err
+	}
+	if err := validateVariants(s.Descriptions, func(d string) error { return ValidateStringLength(&d, 0, 500) }); err != nil {
+		errs["descriptions"] = err
+	}
+	if err := validateVariants(s.Images, Image.Validate); err != nil {
+		errs["images"] = err
 	}
 
 	if err := ValidateStringLength(s.Country, 0, 50); err != nil {
-		return fmt.Errorf("country: %w", err)
+		errs["country"] = err
+	}
+	if s.Genre != nil {
+		if _, ok := genre.Lookup(*s.Genre); !ok {
+			errs["genre"] = validation.NewError("genre_unknown", "must be a genre code or a known genre name")
+		}
+	}
+	if err := validateGenres(s.Genres); err != nil {
+		errs["genres"] = err
 	}
-	if err := ValidateStringLength(s.Genre, 0, 50); err != nil {
-		return fmt.Errorf("genre: %w", err)
+	if len(s.Aliases) > 0 {
+		errs["aliases"] = validation.NewError("read_only", "aliases are recorded by renames and cannot be set")
+	}
+	if s.Status != "" && s.Status != StatusDraft {
+		errs["status"] = validation.NewError("read_only", "new shows are drafts; use PUT /v1/shows/{handle}/status")
+	}
+	if s.PublishAt != nil {
+		errs["publishAt"] = validation.NewError("read_only", "publishAt is set when publishing")
 	}
 	if err := ValidateStringLength(s.Language, 0, 50); err != nil {
-		return fmt.Errorf("language: %w", err)
+		errs["language"] = err
 	}
 	if err := ValidateStringLength(s.TVChannel, 0, 50); err != nil {
-		return fmt.Errorf("tvChannel: %w", err)
+		errs["tvChannel"] = err
 	}
 	if err := ValidateStringLength(s.Description, 0, 500); err != nil {
-		return fmt.Errorf("description: %w", err)
+		errs["description"] = err
 	}
 
-	return validation.ValidateStruct(&s,
+	err := validation.ValidateStruct(&s,
 		validation.Field(&s.Slug, validation.Required, validation.Match(MatchShowSlug)),
 		validation.Field(&s.PrimaryColour, validation.When(s.PrimaryColour != nil, validation.Match(MatchHexColor).Error("must be valid hex color"))),
+		validation.Field(&s.ChannelID, validation.By(validateChannelID)),
 		validation.Field(&s.EpisodeCount, validation.When(s.EpisodeCount != nil, validation.Min(0))),
+		validation.Field(&s.Popularity, validation.When(s.Popularity != nil, validation.Min(0))),
 		validation.Field(&s.Image),
 		validation.Field(&s.NextEpisode),
-		validation.Field(&s.Seasons, validation.When(s.Seasons != nil,
-			validation.By(func(value any) error {
-				seasons := value.(*[]Season)
-				if seasons == nil {
-					return nil
-				}
-				return validation.Validate(*seasons, validation.Each())
-			}),
-		)),
+		validation.Field(&s.Availability),
 	)
+	if err != nil {
+		var fieldErrs validation.Errors
+		if !errors.As(err, &fieldErrs) {
+			return err
+		}
+		for field, fieldErr := range fieldErrs {
+			errs[field] = fieldErr
+		}
+	}
+
+	if s.Seasons != nil {
+		if err := s.validateSeasons(); err != nil {
+			errs["seasons"] = err
+		}
+		if _, bad := errs["episodeCount"]; !bad && s.EpisodeCount != nil && *s.EpisodeCount < len(*s.Seasons) {
+			errs["episodeCount"] = validation.NewError("episode_count_too_low", "episodeCount must be at least the number of seasons")
+		}
+	}
+
+	return errs.Filter()
+}
+
+func validateTitle(title string) error {
+	if len(strings.TrimSpace(title)) == 0 {
+		return validation.NewError("title_required", "title is required")
+	}
+	if utf8.RuneCountInString(title) > 120 {
+		return validation.NewError("title_too_long", "title must be at most 120 characters")
+	}
+	return nil
+}
+
+// MaxLocales caps the variants kept per localized field
+const MaxLocales = 50
+
+// validateVariants checks that every key is a canonical BCP 47 tag and
+// every value passes rule. Errors are keyed by tag.
+func validateVariants[V any](variants map[string]V, rule func(V) error) error {
+	if len(variants) > MaxLocales {
+		return validation.NewError("locales_too_many", "at most 50 locales are allowed")
+	}
+
+	items := validation.Errors{}
+	for tag, v := range variants {
+		if !locale.MatchTag.MatchString(tag) {
+			items[tag] = validation.NewError("locale_invalid", "must be a BCP 47 language tag such as en or en-AU")
+		} else if err := rule(v); err != nil {
+			items[tag] = err
+		}
+	}
+
+	if len(items) > 0 {
+		return items
+	}
+	return nil
+}
+
+// Localize returns a copy of the show with Title, Description and Image
+// replaced by the variants best matching locales, in preference order. Each
+// field is matched on its own, so a show with a French title but only an
+// English image serves both; fields without a match keep their defaults.
+func (s Show) Localize(locales []string) Show {
+	s.Locales = nil
+	if tag, ok := locale.Match(locales, s.Titles); ok {
+		s.Title = s.Titles[tag]
+		s.served(tag)
+	}
+	if tag, ok := locale.Match(locales, s.Descriptions); ok {
+		description := s.Descriptions[tag]
+		s.Description = &description
+		s.served(tag)
+	}
+	if tag, ok := locale.Match(locales, s.Images); ok {
+		image := s.Images[tag]
+		s.Image = &image
+		s.served(tag)
+	}
+	return s
+}
+
+func (s *Show) served(tag string) {
+	if !slices.Contains(s.Locales, tag) {
+		s.Locales = append(s.Locales, tag)
+	}
+}
+
+// validateSeasons checks each season's format and, once the show slug itself
+// is valid, that it belongs to this show and follows the previous one. Gaps
+// are allowed because the feed skips seasons it has no episodes for.
+func (s Show) validateSeasons() error {
+	items := validation.Errors{}
+	crossCheck := MatchShowSlug.MatchString(s.Slug)
+	prefix := s.Slug + "/season/"
+	seen := map[int]bool{}
+	last := 0
+
+	for i, season := range *s.Seasons {
+		idx := strconv.Itoa(i)
+		if err := season.Validate(); err != nil {
+			items[idx] = err
+			continue
+		}
+		if !crossCheck {
+			continue
+		}
+
+		number, ok := 0, false
+		if rest, found := strings.CutPrefix(season.Slug, prefix); found {
+			n, err := strconv.Atoi(rest)
+			number, ok = n, err == nil
+		}
+		var err error
+		switch {
+		case !ok:
+			err = validation.NewError("season_show_mismatch", "must be "+prefix+"<number>")
+		case seen[number]:
+			err = validation.NewError("season_duplicate", "duplicates season "+strconv.Itoa(number))
+		case number < last:
+			err = validation.NewError("season_out_of_order", "must come after season "+strconv.Itoa(last))
+		}
+		if err != nil {
+			items[idx] = validation.Errors{"slug": err}
+			continue
+		}
+		seen[number] = true
+		last = number
+	}
+
+	if len(items) > 0 {
+		return items
+	}
+	return nil
+}
+
+// MaxGenres caps the number of genres a show can be tagged with
+const MaxGenres = 10
+
+// validateGenres checks that every entry is a taxonomy code, listed once
+func validateGenres(codes []string) error {
+	if len(codes) > MaxGenres {
+		return validation.NewError("genres_too_many", "at most 10 genres are allowed")
+	}
+
+	items := validation.Errors{}
+	seen := map[string]bool{}
+	for i, code := range codes {
+		switch {
+		case !genre.Known(code):
+			items[strconv.Itoa(i)] = validation.NewError("genre_unknown", "must be a genre code, see /v1/genres")
+		case seen[code]:
+			items[strconv.Itoa(i)] = validation.NewError("genre_duplicate", "duplicates genre "+code)
+		}
+		seen[code] = true
+	}
+
+	if len(items) > 0 {
+		return items
+	}
+	return nil
 }
 
 type Request struct {
@@ -173,34 +486,125 @@ type Request struct {
 	TotalRecords int    `json:"totalRecords"`
 }
 
+// MaxPayloadItems caps the number of shows accepted in one request
+const MaxPayloadItems = 1000
+
+// Validate checks the envelope and every payload item in one pass. Item
+// errors are nested under payload by index, e.g. payload -> 3 -> title.
 func (r Request) Validate() error {
-	if len(r.Payload) < 1 || len(r.Payload) > 1000 {
-		return validation.NewError("payload_size", "payload must contain between 1 and 1000 items")
+	errs := validation.Errors{}
+
+	if len(r.Payload) < 1 || len(r.Payload) > MaxPayloadItems {
+		errs["payload"] = validation.NewError("payload_size", "payload must contain between 1 and 1000 items")
 	}
 	if r.Skip < 0 {
-		return validation.NewError("skip_invalid", "skip must be >= 0")
+		errs["skip"] = validation.NewError("skip_invalid", "skip must be >= 0")
 	}
 	if r.Take < 1 || r.Take > 100 {
-		return validation.NewError("take_invalid", "take must be between 1 and 100")
+		errs["take"] = validation.NewError("take_invalid", "take must be between 1 and 100")
 	}
 	if r.TotalRecords < 0 {
-		return validation.NewError("total_records_invalid", "totalRecords must be >= 0")
+		errs["totalRecords"] = validation.NewError("total_records_invalid", "totalRecords must be >= 0")
 	}
 
-	for i := range r.Payload {
-		if err := r.Payload[i].Validate(); err != nil {
-			return fmt.Errorf("payload[%d]: %w", i, err)
+	// An oversized payload is rejected as a whole rather than item by item
+	if _, rejected := errs["payload"]; !rejected {
+		items := validation.Errors{}
+		for i := range r.Payload {
+			if err := r.Payload[i].Validate(); err != nil {
+				items[strconv.Itoa(i)] = err
+			}
+		}
+		if len(items) > 0 {
+			errs["payload"] = items
 		}
 	}
-	return nil
+
+	return errs.Filter()
 }
 
 type Response struct {
 	Response []ShowResponse `json:"response"`
 }
 
+// Locales lists the variants served across the response, in first-seen order
+func (r *Response) Locales() []string {
+	if r == nil {
+		return nil
+	}
+	var tags []string
+	for _, show := range r.Response {
+		for _, tag := range show.Locales {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
 type ShowResponse struct {
-	Image string `json:"image" dynamodbav:"image"`
-	Slug  string `json:"slug" dynamodbav:"slug"`
-	Title string `json:"title" dynamodbav:"title"`
+	Image   string     `json:"image" dynamodbav:"image"`
+	Slug    string     `json:"slug" dynamodbav:"slug"`
+	Title   string     `json:"title" dynamodbav:"title"`
+	AirsAt  *time.Time `json:"airsAt,omitempty" dynamodbav:"-"`  // set by airing-soon queries
+	Channel *Channel   `json:"channel,omitempty" dynamodbav:"-"` // resolved from Show.ChannelID
+	Locales []string   `json:"-" dynamodbav:"-"`                 // variants served, see Show.Localize
+	Hidden  string     `json:"hidden,omitempty" dynamodbav:"-"`  // set for admins listing hidden shows
+}
+
+// DuplicateMatch is a show another likely duplicates
+type DuplicateMatch struct {
+	Slug  string  `json:"slug"`
+	Title string  `json:"title"`
+	Score float64 `json:"score"` // title similarity, 0 to 1
+}
+
+// Duplicate flags a show, or a payload item, as likely duplicating others
+type Duplicate struct {
+	Path    string           `json:"path,omitempty"` // JSON pointer to the payload item, on ingest
+	Slug    string           `json:"slug"`
+	Title   string           `json:"title"`
+	Matches []DuplicateMatch `json:"matches"`
+}
+
+type DuplicatesResponse struct {
+	Response []Duplicate `json:"response"`
+	Next     string      `json:"next,omitempty"` // the after of the next page, when there may be one
+}
+
+// Created reports the shows an ingest stored, by slug in payload order,
+// and those among them flagged as likely duplicates
+type Created struct {
+	Slugs      []string
+	Duplicates []Duplicate
+}
+
+// Suggestion is a show whose title completes what a viewer is typing
+type Suggestion struct {
+	Slug    string   `json:"slug"`
+	Title   string   `json:"title"`
+	Locales []string `json:"-"`                // variants served, see Show.Localize
+	Hidden  string   `json:"hidden,omitempty"` // set for admins listing hidden shows
+}
+
+type SuggestionsResponse struct {
+	Response []Suggestion `json:"response"`
+}
+
+// Locales lists the variants served across the suggestions, in first-seen
+// order
+func (r *SuggestionsResponse) Locales() []string {
+	if r == nil {
+		return nil
+	}
+	var tags []string
+	for _, s := range r.Response {
+		for _, tag := range s.Locales {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
 }
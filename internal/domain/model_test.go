@@ -1,7 +1,13 @@
 package domain
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 func TestImage_Validate(t *testing.T) {
@@ -292,6 +298,7 @@ func TestShow_Validate(t *testing.T) {
 				DRM:          boolPtr(true),
 				EpisodeCount: intPtr(10),
 				Genre:        stringPtr("Comedy"),
+				Genres:       []string{"comedy/sitcom"},
 				Image: &Image{
 					ShowImage: "http://example.com/image.jpg",
 				},
@@ -384,6 +391,15 @@ func TestShow_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative popularity",
+			show: Show{
+				Slug:       "show/testshow",
+				Title:      "Test Show",
+				Popularity: intPtr(-1),
+			},
+			wantErr: true,
+		},
 		{
 			name: "country too long",
 			show: Show{
@@ -405,7 +421,7 @@ func TestShow_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "genre too long",
+			name: "unknown genre",
 			show: Show{
 				Slug:    "show/testshow",
 				Title:   "Test Show",
@@ -633,6 +649,428 @@ func TestRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestShow_Validate_ReportsEveryViolation(t *testing.T) {
+	show := Show{
+		Slug:          "bad",
+		Title:         "",
+		Country:       stringPtr(strings.Repeat("x", 51)),
+		PrimaryColour: stringPtr("red"),
+		NextEpisode:   &NextEpisode{ChannelLogo: "logo", HTML: "next", URL: "not-a-url"},
+		Seasons:       &[]Season{{Slug: "show/bad/season/1"}, {Slug: "nope"}},
+	}
+
+	err := show.Validate()
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		t.Fatalf("Show.Validate() error = %T, want validation.Errors", err)
+	}
+
+	for _, field := range []string{"title", "slug", "country", "primaryColour", "nextEpisode", "seasons"} {
+		if errs[field] == nil {
+			t.Errorf("Show.Validate() missing error for %q in %v", field, errs)
+		}
+	}
+
+	seasons, ok := errs["seasons"].(validation.Errors)
+	if !ok || seasons["1"] == nil || seasons["0"] != nil {
+		t.Errorf("Show.Validate() seasons errors = %v, want only index 1", errs["seasons"])
+	}
+}
+
+func TestRequest_Validate_ReportsEveryItem(t *testing.T) {
+	request := Request{
+		Payload: []Show{
+			{Slug: "show/ok", Title: "OK"},
+			{Slug: "show/no-title", Title: ""},
+			{Slug: "show/ok-too", Title: "OK"},
+			{Slug: "no-prefix", Title: ""},
+		},
+		Skip:         -1,
+		Take:         10,
+		TotalRecords: 4,
+	}
+
+	err := request.Validate()
+	errs, ok := err.(validation.Errors)
+	if !ok {
+		t.Fatalf("Request.Validate() error = %T, want validation.Errors", err)
+	}
+	if errs["skip"] == nil {
+		t.Errorf("Request.Validate() missing envelope error for skip")
+	}
+
+	items, ok := errs["payload"].(validation.Errors)
+	if !ok {
+		t.Fatalf("Request.Validate() payload error = %T, want validation.Errors", errs["payload"])
+	}
+	if len(items) != 2 || items["1"] == nil || items["3"] == nil {
+		t.Errorf("Request.Validate() payload errors = %v, want items 1 and 3", items)
+	}
+
+	last, _ := items["3"].(validation.Errors)
+	if last["title"] == nil || last["slug"] == nil {
+		t.Errorf("Request.Validate() item 3 errors = %v, want title and slug", last)
+	}
+}
+
+func TestShow_Validate_Seasons(t *testing.T) {
+	seasons := func(slugs ...string) *[]Season {
+		out := make([]Season, len(slugs))
+		for i, slug := range slugs {
+			out[i] = Season{Slug: slug}
+		}
+		return &out
+	}
+
+	tests := []struct {
+		name         string
+		seasons      *[]Season
+		episodeCount *int
+		wantCodes    map[string]string // JSON path below the show -> error code
+	}{
+		{
+			name:    "gaps are allowed",
+			seasons: seasons("show/a/season/1", "show/a/season/3", "show/a/season/8"),
+		},
+		{
+			name:      "season of another show",
+			seasons:   seasons("show/a/season/1", "show/b/season/2"),
+			wantCodes: map[string]string{"seasons/1/slug": "season_show_mismatch"},
+		},
+		{
+			name:      "bare show slug",
+			seasons:   seasons("show/a"),
+			wantCodes: map[string]string{"seasons/0/slug": "season_show_mismatch"},
+		},
+		{
+			name:      "show with a longer handle",
+			seasons:   seasons("show/ab/season/1"),
+			wantCodes: map[string]string{"seasons/0/slug": "season_show_mismatch"},
+		},
+		{
+			name:      "duplicate number",
+			seasons:   seasons("show/a/season/1", "show/a/season/2", "show/a/season/2"),
+			wantCodes: map[string]string{"seasons/2/slug": "season_duplicate"},
+		},
+		{
+			name:      "out of order",
+			seasons:   seasons("show/a/season/2", "show/a/season/1", "show/a/season/3"),
+			wantCodes: map[string]string{"seasons/1/slug": "season_out_of_order"},
+		},
+		{
+			name:      "format errors are reported alongside cross checks",
+			seasons:   seasons("nope", "show/b/season/1"),
+			wantCodes: map[string]string{"seasons/0/slug": "invalid_slug", "seasons/1/slug": "season_show_mismatch"},
+		},
+		{
+			name:         "episode count below season count",
+			seasons:      seasons("show/a/season/1", "show/a/season/2"),
+			episodeCount: intPtr(1),
+			wantCodes:    map[string]string{"episodeCount": "episode_count_too_low"},
+		},
+		{
+			name:         "episode count covers seasons",
+			seasons:      seasons("show/a/season/1", "show/a/season/2"),
+			episodeCount: intPtr(2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := Show{Slug: "show/a", Title: "A", Seasons: tt.seasons, EpisodeCount: tt.episodeCount}
+			got := map[string]string{}
+			collectCodes(show.Validate(), "", got)
+			if len(tt.wantCodes) == 0 && len(got) > 0 {
+				t.Fatalf("Show.Validate() = %v, want no errors", got)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Show.Validate() at %s = %q, want %q (all: %v)", path, got[path], code, got)
+				}
+			}
+			if len(got) != len(tt.wantCodes) {
+				t.Errorf("Show.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestShow_Validate_SeasonsSkipCrossChecksForInvalidSlug(t *testing.T) {
+	show := Show{Slug: "Show A", Title: "A", Seasons: &[]Season{{Slug: "show/a/season/1"}}}
+	got := map[string]string{}
+	collectCodes(show.Validate(), "", got)
+	if _, ok := got["seasons/0/slug"]; ok {
+		t.Errorf("seasons should not be cross-checked against an invalid show slug: %v", got)
+	}
+}
+
+func TestShow_Validate_Genres(t *testing.T) {
+	tests := []struct {
+		name      string
+		genre     *string
+		genres    []string
+		wantCodes map[string]string
+	}{
+		{name: "codes at any level", genres: []string{"reality", "reality/cooking", "kids"}},
+		{name: "legacy alias", genre: stringPtr("Reality")},
+		{name: "legacy unknown", genre: stringPtr("Polka"), wantCodes: map[string]string{"genre": "genre_unknown"}},
+		{
+			name:      "aliases are not codes",
+			genres:    []string{"reality", "Cooking", "reality"},
+			wantCodes: map[string]string{"genres/1": "genre_unknown", "genres/2": "genre_duplicate"},
+		},
+		{
+			name:      "too many",
+			genres:    []string{"action", "animation", "comedy", "crime", "documentary", "drama", "entertainment", "kids", "lifestyle", "news", "reality"},
+			wantCodes: map[string]string{"genres": "genres_too_many"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := Show{Slug: "show/a", Title: "A", Genre: tt.genre, Genres: tt.genres}
+			got := map[string]string{}
+			collectCodes(show.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Show.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Show.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestShow_FoldLegacyGenre(t *testing.T) {
+	tests := []struct {
+		name       string
+		show       Show
+		wantGenre  *string
+		wantGenres []string
+	}{
+		{name: "known genre is folded into genres", show: Show{Genre: stringPtr("Cooking")}, wantGenres: []string{"reality/cooking"}},
+		{name: "folded once", show: Show{Genre: stringPtr("Reality"), Genres: []string{"reality"}}, wantGenres: []string{"reality"}},
+		{name: "unknown genre is kept", show: Show{Genre: stringPtr("Polka")}, wantGenre: stringPtr("Polka")},
+		{name: "taxonomy shows are left alone", show: Show{Genres: []string{"kids", "comedy"}}, wantGenres: []string{"kids", "comedy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := tt.show
+			show.FoldLegacyGenre()
+			if !reflect.DeepEqual(show.Genre, tt.wantGenre) {
+				t.Errorf("Genre = %v, want %v", show.Genre, tt.wantGenre)
+			}
+			if !reflect.DeepEqual(show.Genres, tt.wantGenres) {
+				t.Errorf("Genres = %v, want %v", show.Genres, tt.wantGenres)
+			}
+		})
+	}
+}
+
+func TestShow_Validate_Localized(t *testing.T) {
+	tests := []struct {
+		name         string
+		titles       map[string]string
+		descriptions map[string]string
+		images       map[string]Image
+		wantCodes    map[string]string
+	}{
+		{
+			name:         "valid variants",
+			titles:       map[string]string{"en": "The Taste", "fr-CA": "Le Goût", "zh-Hant": "品味"},
+			descriptions: map[string]string{"es-419": "Cocina"},
+			images:       map[string]Image{"fr-CA": {ShowImage: "https://example.com/fr.jpg"}},
+		},
+		{
+			name:      "limits apply per locale",
+			titles:    map[string]string{"en": strings.Repeat("a", 120), "fr": strings.Repeat("a", 121), "de": " "},
+			wantCodes: map[string]string{"titles/fr": "title_too_long", "titles/de": "title_required"},
+		},
+		{
+			name:         "limits count characters, not bytes",
+			titles:       map[string]string{"ja": strings.Repeat("進", 120), "zh": strings.Repeat("进", 121)},
+			descriptions: map[string]string{"ja": strings.Repeat("撃", 500), "zh": strings.Repeat("击", 501)},
+			wantCodes:    map[string]string{"titles/zh": "title_too_long", "descriptions/zh": "length_error"},
+		},
+		{
+			name:         "description too long",
+			descriptions: map[string]string{"en": strings.Repeat("a", 500), "fr": strings.Repeat("a", 501)},
+			wantCodes:    map[string]string{"descriptions/fr": "length_error"},
+		},
+		{
+			name:      "invalid image",
+			images:    map[string]Image{"en": {ShowImage: "not-a-url"}},
+			wantCodes: map[string]string{"images/en/showImage": "invalid_url"},
+		},
+		{
+			name:      "non-canonical tags",
+			titles:    map[string]string{"EN": "A", "en_AU": "A", "english": "A"},
+			wantCodes: map[string]string{"titles/EN": "locale_invalid", "titles/en_AU": "locale_invalid", "titles/english": "locale_invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := Show{Slug: "show/a", Title: "A", Titles: tt.titles, Descriptions: tt.descriptions, Images: tt.images}
+			got := map[string]string{}
+			collectCodes(show.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Show.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Show.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestShow_Validate_TooManyLocales(t *testing.T) {
+	titles := map[string]string{}
+	for i := 0; i <= MaxLocales; i++ {
+		titles[fmt.Sprintf("en-%03d", i)] = "A"
+	}
+	show := Show{Slug: "show/a", Title: "A", Titles: titles}
+
+	got := map[string]string{}
+	collectCodes(show.Validate(), "", got)
+	if got["titles"] != "locales_too_many" {
+		t.Errorf("Show.Validate() = %v, want titles locales_too_many", got)
+	}
+}
+
+func TestShow_Localize(t *testing.T) {
+	description := "Cooking"
+	show := Show{
+		Slug:         "show/a",
+		Title:        "The Taste",
+		Description:  &description,
+		Image:        &Image{ShowImage: "https://example.com/default.jpg"},
+		Titles:       map[string]string{"fr": "Le Goût", "en-AU": "The Taste Australia"},
+		Descriptions: map[string]string{"en-AU": "Cooking, Australian style"},
+		Images:       map[string]Image{"fr": {ShowImage: "https://example.com/fr.jpg"}},
+	}
+
+	tests := []struct {
+		name            string
+		locales         []string
+		wantTitle       string
+		wantDescription string
+		wantImage       string
+		wantLocales     []string
+	}{
+		{
+			name:            "no preferences keeps defaults",
+			wantTitle:       "The Taste",
+			wantDescription: "Cooking",
+			wantImage:       "https://example.com/default.jpg",
+		},
+		{
+			name:            "regional preference falls back to language",
+			locales:         []string{"fr-CA"},
+			wantTitle:       "Le Goût",
+			wantDescription: "Cooking",
+			wantImage:       "https://example.com/fr.jpg",
+			wantLocales:     []string{"fr"},
+		},
+		{
+			name:            "fields match independently",
+			locales:         []string{"en-AU", "fr"},
+			wantTitle:       "The Taste Australia",
+			wantDescription: "Cooking, Australian style",
+			wantImage:       "https://example.com/fr.jpg",
+			wantLocales:     []string{"en-AU", "fr"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := show.Localize(tt.locales)
+			if got.Title != tt.wantTitle {
+				t.Errorf("Localize() title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if *got.Description != tt.wantDescription {
+				t.Errorf("Localize() description = %q, want %q", *got.Description, tt.wantDescription)
+			}
+			if got.Image.ShowImage != tt.wantImage {
+				t.Errorf("Localize() image = %q, want %q", got.Image.ShowImage, tt.wantImage)
+			}
+			if fmt.Sprint(got.Locales) != fmt.Sprint(tt.wantLocales) {
+				t.Errorf("Localize() locales = %v, want %v", got.Locales, tt.wantLocales)
+			}
+		})
+	}
+
+	if show.Title != "The Taste" || *show.Description != "Cooking" {
+		t.Errorf("Localize() modified the receiver: %q, %q", show.Title, *show.Description)
+	}
+}
+
+// collectCodes flattens nested validation.Errors into path -> code
+func collectCodes(err error, prefix string, out map[string]string) {
+	switch e := err.(type) {
+	case nil:
+	case validation.Errors:
+		for k, v := range e {
+			collectCodes(v, prefix+k+"/", out)
+		}
+	case validation.Error:
+		out[strings.TrimSuffix(prefix, "/")] = e.Code()
+	default:
+		out[strings.TrimSuffix(prefix, "/")] = err.Error()
+	}
+}
+
+func TestParseAirDate(t *testing.T) {
+	sydney := time.FixedZone("AEST", 10*60*60)
+	want := time.Date(2024, 6, 3, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2024-06-03T10:30:00Z", want: want},
+		{in: "2024-06-03T20:30:00+10:00", want: want},
+		{in: "Mon, 03 Jun 2024 20:30:00 +1000", want: want},
+		{in: "2024-06-03 20:30:00", want: want},
+		{in: "2024-06-03T20:30", want: want},
+		{in: "03/06/2024 20:30", want: want},
+		{in: "3 Jun 2024 8:30pm", want: want},
+		{in: "2024-06-03", want: time.Date(2024, 6, 2, 14, 0, 0, 0, time.UTC)},
+		{in: "next Tuesday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAirDate(tt.in, sydney)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAirDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseAirDate() = %v, want %v", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextEpisode_AirsAt(t *testing.T) {
+	if _, ok := (NextEpisode{}).AirsAt(); ok {
+		t.Errorf("AirsAt() without a date should report false")
+	}
+	if _, ok := (NextEpisode{Date: stringPtr("soon")}).AirsAt(); ok {
+		t.Errorf("AirsAt() with an invalid date should report false")
+	}
+	got, ok := NextEpisode{Date: stringPtr("2024-06-03T10:30:00Z")}.AirsAt()
+	if !ok || !got.Equal(time.Date(2024, 6, 3, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("AirsAt() = %v, %v", got, ok)
+	}
+}
+
 // Helper functions for tests
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/jsondiff"
+)
+
+// Actions a revision records
+const (
+	RevisionBaseline     = "baseline" // the show as stored before revisions were kept
+	RevisionCreated      = "created"
+	RevisionTransitioned = "transitioned"
+	RevisionReverted     = "reverted"
+)
+
+// Revision is an immutable snapshot of a show, stored beside it in its
+// partition. Revisions are numbered from 1 and never rewritten.
+type Revision struct {
+	Slug   string `json:"-" dynamodbav:"slug"` // the show's partition
+	SK     string `json:"-" dynamodbav:"sk"`
+	Number int    `json:"number" dynamodbav:"number"`
+	Action string `json:"action" dynamodbav:"action"`
+	Actor  string `json:"actor,omitempty" dynamodbav:"actor,omitempty"`
+	At     string `json:"at" dynamodbav:"at"` // RFC 3339, UTC
+	// RevertedFrom is the revision a revert restored
+	RevertedFrom *int `json:"revertedFrom,omitempty" dynamodbav:"revertedFrom,omitempty"`
+	// Diff is the JSON Patch from the previous revision's document to this
+	// one's
+	Diff []jsondiff.Op `json:"diff" dynamodbav:"diff"`
+	// Show is the full document; listings leave it out
+	Show *Show `json:"show,omitempty" dynamodbav:"show,omitempty"`
+}
+
+// Revise numbers next as the revision after prev, which it replaces, and
+// returns the revisions to store with it. prev is nil for a new show. A show
+// last written before revisions were kept first gets a baseline revision
+// of prev, so every log starts from a complete document.
+func Revise(prev *Show, next *Show, action, actor string, at time.Time) ([]Revision, error) {
+	stamp := at.UTC().Format(time.RFC3339)
+	var revisions []Revision
+	var base any = struct{}{}
+	next.Revision = 1
+	if prev != nil {
+		next.Revision = prev.Revision + 1
+		if prev.Revision == 0 {
+			baseline := *prev
+			baseline.Revision = 1
+			revisions = append(revisions, Revision{Slug: prev.Slug, Number: 1, Action: RevisionBaseline, At: stamp, Diff: []jsondiff.Op{}, Show: &baseline})
+			next.Revision = 2
+		}
+		base = unnumbered(*prev)
+	}
+
+	// Documents are compared without their numbers, which always change
+	diff, err := jsondiff.Diff(base, unnumbered(*next))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *next
+	revisions = append(revisions, Revision{Slug: next.Slug, Number: next.Revision, Action: action, Actor: actor, At: stamp, Diff: diff, Show: &snapshot})
+	return revisions, nil
+}
+
+func unnumbered(s Show) Show {
+	s.Revision = 0
+	return s
+}
+
+// Restore returns the content of an earlier document of the show under its
+// current identity and workflow state: the slug, aliases, status,
+// publication time, transition history and revision number are kept. So are
+// the seasons and episode count, which follow the season and episode items
+// stored beside the show rather than its revisions.
+func (s Show) Restore(earlier Show) Show {
+	earlier.Slug = s.Slug
+	earlier.SK = s.SK
+	earlier.Seasons = s.Seasons
+	earlier.EpisodeCount = s.EpisodeCount
+	earlier.Aliases = s.Aliases
+	earlier.AliasOf = nil
+	earlier.Status = s.Status
+	earlier.PublishAt = s.PublishAt
+	earlier.Transitions = s.Transitions
+	earlier.Revision = s.Revision
+	return earlier
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/jsondiff"
+)
+
+func TestRevise(t *testing.T) {
+	at := time.Date(2024, 6, 15, 10, 0, 0, 0, time.FixedZone("AEST", 10*60*60))
+
+	t.Run("new show", func(t *testing.T) {
+		next := Show{Slug: "show/a", Title: "A"}
+		revisions, err := Revise(nil, &next, RevisionCreated, "ed", at)
+		if err != nil {
+			t.Fatalf("Revise() error = %v", err)
+		}
+		if len(revisions) != 1 || next.Revision != 1 {
+			t.Fatalf("Revise() = %+v, next.Revision = %d, want one revision numbered 1", revisions, next.Revision)
+		}
+		rev := revisions[0]
+		if rev.Number != 1 || rev.Action != RevisionCreated || rev.Actor != "ed" || rev.At != "2024-06-15T00:00:00Z" {
+			t.Errorf("Revise() = %+v", rev)
+		}
+		want := []jsondiff.Op{
+			{Op: jsondiff.OpAdd, Path: "/slug", Value: "show/a"},
+			{Op: jsondiff.OpAdd, Path: "/title", Value: "A"},
+		}
+		if !reflect.DeepEqual(rev.Diff, want) {
+			t.Errorf("Diff = %+v, want %+v", rev.Diff, want)
+		}
+		if rev.Show == nil || rev.Show.Revision != 1 {
+			t.Errorf("Show = %+v, want the numbered document", rev.Show)
+		}
+	})
+
+	t.Run("change leaves the number out of the diff", func(t *testing.T) {
+		prev := Show{Slug: "show/a", Title: "A", Revision: 3}
+		next := prev
+		next.Status = StatusArchived
+		revisions, err := Revise(&prev, &next, RevisionTransitioned, "pub", at)
+		if err != nil {
+			t.Fatalf("Revise() error = %v", err)
+		}
+		want := []jsondiff.Op{{Op: jsondiff.OpAdd, Path: "/status", Value: "archived"}}
+		if len(revisions) != 1 || revisions[0].Number != 4 || !reflect.DeepEqual(revisions[0].Diff, want) {
+			t.Errorf("Revise() = %+v, want revision 4 with %+v", revisions, want)
+		}
+	})
+
+	t.Run("show stored before revisions gets a baseline", func(t *testing.T) {
+		prev := Show{Slug: "show/a", Title: "A"}
+		next := prev
+		next.Title = "B"
+		revisions, err := Revise(&prev, &next, RevisionReverted, "ed", at)
+		if err != nil {
+			t.Fatalf("Revise() error = %v", err)
+		}
+		if len(revisions) != 2 || next.Revision != 2 {
+			t.Fatalf("Revise() = %+v, next.Revision = %d, want baseline and revision 2", revisions, next.Revision)
+		}
+		if base := revisions[0]; base.Number != 1 || base.Action != RevisionBaseline || base.Show.Title != "A" || len(base.Diff) != 0 {
+			t.Errorf("baseline = %+v", base)
+		}
+		want := []jsondiff.Op{{Op: jsondiff.OpReplace, Path: "/title", Value: "B"}}
+		if !reflect.DeepEqual(revisions[1].Diff, want) {
+			t.Errorf("Diff = %+v, want %+v", revisions[1].Diff, want)
+		}
+	})
+}
+
+func TestShow_Restore(t *testing.T) {
+	one, two := 1, 2
+	earlier := Show{
+		Slug:         "show/old",
+		Title:        "Old title",
+		Status:       StatusDraft,
+		Seasons:      &[]Season{{Slug: "show/old/season/1"}},
+		EpisodeCount: &one,
+	}
+	current := Show{
+		Slug:         "show/new",
+		Title:        "New title",
+		Aliases:      []string{"show/old"},
+		Status:       StatusPublished,
+		Seasons:      &[]Season{{Slug: "show/new/season/1"}, {Slug: "show/new/season/2"}},
+		EpisodeCount: &two,
+		Revision:     7,
+	}
+
+	got := current.Restore(earlier)
+
+	if got.Title != "Old title" {
+		t.Errorf("Title = %q, want the earlier content", got.Title)
+	}
+	if got.Slug != "show/new" {
+		t.Errorf("Slug = %q, want the current slug", got.Slug)
+	}
+	if got.Status != StatusPublished || got.Revision != 7 || len(got.Aliases) != 1 {
+		t.Errorf("Restore() = %+v, want the current workflow state and number", got)
+	}
+	if len(*got.Seasons) != 2 || *got.EpisodeCount != 2 {
+		t.Errorf("Restore() seasons = %v, episode count = %d, want the current ones", *got.Seasons, *got.EpisodeCount)
+	}
+}
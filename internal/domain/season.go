@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Season and episode numbers are capped so storage keys stay fixed-width
+const (
+	MaxSeasonNumber  = 9999
+	MaxEpisodeNumber = 9999
+)
+
+// SeasonDetail is a season stored as its own item in the show's partition.
+// Season is the slug-only reference embedded in Show.
+type SeasonDetail struct {
+	Number   int     `json:"number" dynamodbav:"number"`
+	Slug     string  `json:"slug" dynamodbav:"seasonSlug"` // show/<handle>/season/<n>
+	Title    *string `json:"title,omitempty" dynamodbav:"title"`
+	Synopsis *string `json:"synopsis,omitempty" dynamodbav:"synopsis"`
+	AirDate  *string `json:"airDate,omitempty" dynamodbav:"airDate"`
+	Image    *string `json:"image,omitempty" dynamodbav:"image"`
+	DRM      *bool   `json:"drm,omitempty" dynamodbav:"drm"`
+
+	// Keys (not in JSON payloads; set from the route and on write)
+	ShowSlug string `json:"-" dynamodbav:"slug"`
+	SK       string `json:"-" dynamodbav:"sk"`
+}
+
+// SeasonSlug builds show/<handle>/season/<n>
+func SeasonSlug(showSlug string, number int) string {
+	return showSlug + "/season/" + strconv.Itoa(number)
+}
+
+func (s SeasonDetail) Validate() error {
+	errs := validation.Errors{}
+
+	if s.Number < 1 || s.Number > MaxSeasonNumber {
+		errs["number"] = validation.NewError("number_invalid", "number must be between 1 and 9999")
+	} else if s.Slug != "" && MatchShowSlug.MatchString(s.ShowSlug) && s.Slug != SeasonSlug(s.ShowSlug, s.Number) {
+		errs["slug"] = validation.NewError("season_show_mismatch", "must be "+SeasonSlug(s.ShowSlug, s.Number))
+	}
+	validateDetail(errs, s.Title, s.Synopsis, s.AirDate, s.Image)
+
+	return errs.Filter()
+}
+
+// Episode is stored as its own item in the show's partition, keyed by
+// season and episode number
+type Episode struct {
+	Number          int     `json:"number" dynamodbav:"number"`
+	SeasonNumber    int     `json:"seasonNumber" dynamodbav:"seasonNumber"`
+	Slug            string  `json:"slug" dynamodbav:"episodeSlug"` // show/<handle>/season/<n>/episode/<m>
+	Title           *string `json:"title,omitempty" dynamodbav:"title"`
+	Synopsis        *string `json:"synopsis,omitempty" dynamodbav:"synopsis"`
+	AirDate         *string `json:"airDate,omitempty" dynamodbav:"airDate"`
+	DurationSeconds *int    `json:"durationSeconds,omitempty" dynamodbav:"durationSeconds"`
+	Image           *string `json:"image,omitempty" dynamodbav:"image"`
+	DRM             *bool   `json:"drm,omitempty" dynamodbav:"drm"`
+
+	// Keys (not in JSON payloads; set from the route and on write)
+	ShowSlug string `json:"-" dynamodbav:"slug"`
+	SK       string `json:"-" dynamodbav:"sk"`
+}
+
+// EpisodeSlug builds show/<handle>/season/<n>/episode/<m>
+func EpisodeSlug(showSlug string, season, number int) string {
+	return SeasonSlug(showSlug, season) + "/episode/" + strconv.Itoa(number)
+}
+
+func (e Episode) Validate() error {
+	errs := validation.Errors{}
+
+	if e.SeasonNumber < 1 || e.SeasonNumber > MaxSeasonNumber {
+		errs["seasonNumber"] = validation.NewError("number_invalid", "seasonNumber must be between 1 and 9999")
+	}
+	if e.Number < 1 || e.Number > MaxEpisodeNumber {
+		errs["number"] = validation.NewError("number_invalid", "number must be between 1 and 9999")
+	}
+	if len(errs) == 0 && e.Slug != "" && MatchShowSlug.MatchString(e.ShowSlug) {
+		if want := EpisodeSlug(e.ShowSlug, e.SeasonNumber, e.Number); e.Slug != want {
+			errs["slug"] = validation.NewError("episode_season_mismatch", "must be "+want)
+		}
+	}
+	if e.DurationSeconds != nil && *e.DurationSeconds < 0 {
+		errs["durationSeconds"] = validation.NewError("duration_invalid", "durationSeconds must be >= 0")
+	}
+	validateDetail(errs, e.Title, e.Synopsis, e.AirDate, e.Image)
+
+	return errs.Filter()
+}
+
+// validateDetail checks the descriptive fields seasons and episodes share
+func validateDetail(errs validation.Errors, title, synopsis, airDate, image *string) {
+	if title != nil && (len(strings.TrimSpace(*title)) == 0 || len(*title) > 120) {
+		errs["title"] = validation.NewError("title_invalid", "title must be between 1 and 120 characters")
+	}
+	if err := ValidateStringLength(synopsis, 0, 2000); err != nil {
+		errs["synopsis"] = err
+	}
+	if airDate != nil && *airDate != "" {
+		if _, err := ParseAirDate(*airDate, time.UTC); err != nil {
+			errs["airDate"] = DateInvalid()
+		}
+	}
+	if image != nil {
+		if err := ValidateURL(*image); err != nil {
+			errs["image"] = err
+		}
+	}
+}
+
+type SeasonsResponse struct {
+	Response []SeasonDetail `json:"response"`
+}
+
+type EpisodesResponse struct {
+	Response []Episode `json:"response"`
+}
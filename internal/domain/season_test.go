@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSeasonDetail_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		season    SeasonDetail
+		wantCodes map[string]string
+	}{
+		{
+			name: "valid season",
+			season: SeasonDetail{
+				ShowSlug: "show/a",
+				Number:   2,
+				Slug:     "show/a/season/2",
+				Title:    stringPtr("Season 2"),
+				Synopsis: stringPtr("More of it"),
+				AirDate:  stringPtr("2024-06-03T10:30:00Z"),
+				Image:    stringPtr("http://example.com/s2.jpg"),
+				DRM:      boolPtr(true),
+			},
+		},
+		{
+			name:   "slug is optional",
+			season: SeasonDetail{ShowSlug: "show/a", Number: 1},
+		},
+		{
+			name:      "number out of range",
+			season:    SeasonDetail{ShowSlug: "show/a", Number: 0},
+			wantCodes: map[string]string{"number": "number_invalid"},
+		},
+		{
+			name:      "slug for another season",
+			season:    SeasonDetail{ShowSlug: "show/a", Number: 1, Slug: "show/a/season/2"},
+			wantCodes: map[string]string{"slug": "season_show_mismatch"},
+		},
+		{
+			name: "bad details",
+			season: SeasonDetail{
+				ShowSlug: "show/a",
+				Number:   1,
+				Title:    stringPtr(" "),
+				AirDate:  stringPtr("someday"),
+				Image:    stringPtr("ftp://example.com/s1.jpg"),
+			},
+			wantCodes: map[string]string{"title": "title_invalid", "airDate": "date_invalid", "image": "invalid_url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.season.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("SeasonDetail.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("SeasonDetail.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestEpisode_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		episode   Episode
+		wantCodes map[string]string
+	}{
+		{
+			name: "valid episode",
+			episode: Episode{
+				ShowSlug:        "show/a",
+				SeasonNumber:    1,
+				Number:          3,
+				Slug:            "show/a/season/1/episode/3",
+				Title:           stringPtr("Pilot"),
+				DurationSeconds: intPtr(2700),
+			},
+		},
+		{
+			name:      "numbers out of range",
+			episode:   Episode{ShowSlug: "show/a", SeasonNumber: 10000, Number: -1},
+			wantCodes: map[string]string{"seasonNumber": "number_invalid", "number": "number_invalid"},
+		},
+		{
+			name:      "slug for another episode",
+			episode:   Episode{ShowSlug: "show/a", SeasonNumber: 1, Number: 3, Slug: "show/a/season/2/episode/3"},
+			wantCodes: map[string]string{"slug": "episode_season_mismatch"},
+		},
+		{
+			name:      "negative duration",
+			episode:   Episode{ShowSlug: "show/a", SeasonNumber: 1, Number: 1, DurationSeconds: intPtr(-5)},
+			wantCodes: map[string]string{"durationSeconds": "duration_invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.episode.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Episode.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("Episode.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestEpisodeSlug(t *testing.T) {
+	if got := EpisodeSlug("show/a", 2, 7); got != "show/a/season/2/episode/7" {
+		t.Errorf("EpisodeSlug() = %q", got)
+	}
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxGeneratedHandle caps the handle SlugFromTitle derives, leaving room for
+// a collision suffix
+const MaxGeneratedHandle = 60
+
+// ReservedHandles are taken by routes under /v1/shows, so no show may have
+// them: show/search would be unreachable at /v1/shows/search
+var ReservedHandles = []string{"airing-soon", "duplicates", "events", "search", "suggest"}
+
+// IsReservedHandle reports whether handle is one of ReservedHandles
+func IsReservedHandle(handle string) bool {
+	return slices.Contains(ReservedHandles, handle)
+}
+
+// handlePattern matches a show handle other than the reserved ones: a letter
+// or digit, then letters, digits and dashes. RE2 has no lookahead, so it
+// walks the reserved handles as a trie, at each step allowing any character
+// that leaves them, and the end only where no reserved handle ends.
+func handlePattern(reserved []string) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	var walk func(words []string, first bool) string
+	walk = func(words []string, first bool) string {
+		next := map[byte][]string{}
+		ends := false
+		for _, w := range words {
+			if w == "" {
+				ends = true
+				continue
+			}
+			next[w[0]] = append(next[w[0]], w[1:])
+		}
+		var others strings.Builder
+		for _, c := range []byte(chars) {
+			if first && c == '-' {
+				continue
+			}
+			if _, ok := next[c]; !ok {
+				others.WriteByte(c)
+			}
+		}
+		var alts []string
+		if !ends && !first {
+			alts = append(alts, "")
+		}
+		alts = append(alts, "["+others.String()+"][a-z0-9-]*")
+		keys := make([]byte, 0, len(next))
+		for c := range next {
+			keys = append(keys, c)
+		}
+		slices.Sort(keys)
+		for _, c := range keys {
+			alts = append(alts, string(c)+walk(next[c], false))
+		}
+		return "(?:" + strings.Join(alts, "|") + ")"
+	}
+	return walk(reserved, true)
+}
+
+// transliterations covers Latin letters that do not decompose into a base
+// letter and combining marks; apostrophes are dropped so "World's" stays one
+// word
+var transliterations = map[rune]string{
+	'\'': "", '’': "",
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe", 'ø': "o", 'Ø': "o",
+	'đ': "d", 'Đ': "d", 'ł': "l", 'Ł': "l", 'þ': "th", 'Þ': "th", 'ð': "d", 'Ð': "d",
+	'&': " and ",
+}
+
+// SlugFromTitle derives a show slug from a title: accents are stripped,
+// letters lowercased and everything else collapsed into single dashes, so
+// "The Taste (Le Goût)" becomes "show/the-taste-le-gout". A reserved handle
+// is suffixed, so "Search" becomes "show/search-show". It returns "" when the
+// title has no Latin letters or digits to build a handle from.
+func SlugFromTitle(title string) string {
+	handle := strings.Join(FoldWords(title), "-")
+	if len(handle) > MaxGeneratedHandle {
+		handle = handle[:MaxGeneratedHandle]
+		if i := strings.LastIndexByte(handle, '-'); i > 0 {
+			handle = handle[:i]
+		}
+		handle = strings.TrimRight(handle, "-")
+	}
+	if handle == "" {
+		return ""
+	}
+	if IsReservedHandle(handle) {
+		handle += "-show"
+	}
+	return "show/" + handle
+}
+
+// FoldWords splits text into lowercase words of ASCII letters and digits,
+// stripping accents and transliterating as SlugFromTitle does, so that
+// "Le Goût" and "le gout" give the same words
+func FoldWords(text string) []string {
+	var words []string
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			words = append(words, b.String())
+			b.Reset()
+		}
+	}
+	write := func(r rune) {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			return
+		}
+		flush()
+	}
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if t, ok := transliterations[r]; ok {
+			for _, tr := range t {
+				write(tr)
+			}
+			continue
+		}
+		write(r)
+	}
+	flush()
+	return words
+}
+
+// Rename moves the show to slug, carrying its season slugs along
+func (s *Show) Rename(slug string) {
+	if s.Seasons != nil {
+		prefix := s.Slug + "/season/"
+		for i, season := range *s.Seasons {
+			if rest, ok := strings.CutPrefix(season.Slug, prefix); ok {
+				(*s.Seasons)[i].Slug = slug + "/season/" + rest
+			}
+		}
+	}
+	s.Slug = slug
+}
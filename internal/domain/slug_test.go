@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugFromTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "The Taste (Le Goût)", want: "show/the-taste-le-gout"},
+		{title: "16 Kids and Counting", want: "show/16-kids-and-counting"},
+		{title: "  Scooby-Doo!  Mystery   Incorporated ", want: "show/scooby-doo-mystery-incorporated"},
+		{title: "Straße & Smørrebrød", want: "show/strasse-and-smorrebrod"},
+		{title: "Amélie’s Café", want: "show/amelies-cafe"},
+		{title: "World's Most Extreme", want: "show/worlds-most-extreme"},
+		{title: "!!!", want: ""},
+		{title: "進撃の巨人", want: ""},
+		{title: "Search", want: "show/search-show"},
+		{title: "Airing Soon", want: "show/airing-soon-show"},
+		{title: "Search Party", want: "show/search-party"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := SlugFromTitle(tt.title)
+			if got != tt.want {
+				t.Errorf("SlugFromTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+			if got != "" && !MatchShowSlug.MatchString(got) {
+				t.Errorf("SlugFromTitle(%q) = %q does not match MatchShowSlug", tt.title, got)
+			}
+		})
+	}
+}
+
+func TestMatchShowSlug_ReservedHandles(t *testing.T) {
+	for _, handle := range ReservedHandles {
+		if MatchShowSlug.MatchString("show/" + handle) {
+			t.Errorf("MatchShowSlug matches reserved show/%s", handle)
+		}
+		if MatchSeasonSlug.MatchString("show/" + handle + "/season/1") {
+			t.Errorf("MatchSeasonSlug matches reserved show/%s/season/1", handle)
+		}
+	}
+	for _, slug := range []string{"show/a", "show/s", "show/sea", "show/searches", "show/search-2", "show/suggested", "show/event", "show/airing", "show/airing-soonest", "show/1-events"} {
+		if !MatchShowSlug.MatchString(slug) {
+			t.Errorf("MatchShowSlug does not match %s", slug)
+		}
+	}
+	for _, slug := range []string{"show/", "show/-search", "show/Search", "show/a b"} {
+		if MatchShowSlug.MatchString(slug) {
+			t.Errorf("MatchShowSlug matches %s", slug)
+		}
+	}
+}
+
+func TestSlugFromTitle_LongTitle(t *testing.T) {
+	got := SlugFromTitle(strings.Repeat("Extraordinary ", 10))
+	handle := strings.TrimPrefix(got, "show/")
+	if len(handle) > MaxGeneratedHandle {
+		t.Fatalf("SlugFromTitle() handle has %d characters, want at most %d", len(handle), MaxGeneratedHandle)
+	}
+	if strings.HasSuffix(handle, "-") || strings.HasSuffix(handle, "extraordinar") {
+		t.Errorf("SlugFromTitle() = %q, want it cut at a word boundary", got)
+	}
+}
+
+func TestFoldWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "The Taste (Le Goût)", want: "the taste le gout"},
+		{text: "Straße & Smørrebrød", want: "strasse and smorrebrod"},
+		{text: "World's  Most-Extreme", want: "worlds most extreme"},
+		{text: "進撃の巨人", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(FoldWords(tt.text), " "); got != tt.want {
+			t.Errorf("FoldWords(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestShow_Rename(t *testing.T) {
+	show := Show{
+		Slug:    "show/a",
+		Seasons: &[]Season{{Slug: "show/a/season/1"}, {Slug: "show/a"}, {Slug: "show/other/season/2"}},
+	}
+
+	show.Rename("show/b")
+
+	if show.Slug != "show/b" {
+		t.Errorf("Rename() slug = %q, want show/b", show.Slug)
+	}
+	want := []string{"show/b/season/1", "show/a", "show/other/season/2"}
+	for i, season := range *show.Seasons {
+		if season.Slug != want[i] {
+			t.Errorf("Rename() season %d = %q, want %q", i, season.Slug, want[i])
+		}
+	}
+}
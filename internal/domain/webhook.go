@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Webhook events partners subscribe to. Shows are never removed, so
+// archiving one, which takes it out of the catalogue, is reported as
+// deleted.
+const (
+	WebhookShowCreated   = "show.created"
+	WebhookShowUpdated   = "show.updated"
+	WebhookShowPublished = "show.published"
+	WebhookShowDeleted   = "show.deleted"
+)
+
+var WebhookEvents = []string{WebhookShowCreated, WebhookShowUpdated, WebhookShowPublished, WebhookShowDeleted}
+
+// Delivery statuses. A pending delivery is retried until it succeeds or
+// runs out of attempts and fails.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// MinWebhookSecret is the shortest secret a webhook may sign with
+const MinWebhookSecret = 16
+
+// MatchWebhookID validates webhook IDs, as generated by NewID
+var MatchWebhookID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// MatchDeliveryID validates delivery IDs, as generated by DeliveryID
+var MatchDeliveryID = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}Z-[0-9a-f]{16}$`)
+
+// Webhook subscribes a partner URL to show events. The secret signs every
+// delivery and is never returned.
+type Webhook struct {
+	ID        string   `json:"id" dynamodbav:"id"`
+	URL       string   `json:"url" dynamodbav:"url"`
+	Events    []string `json:"events" dynamodbav:"events"`
+	Secret    string   `json:"secret,omitempty" dynamodbav:"secret"`
+	CreatedAt string   `json:"createdAt,omitempty" dynamodbav:"createdAt"` // RFC 3339, UTC
+
+	// Keys (not in JSON payloads; set on write)
+	PK string `json:"-" dynamodbav:"slug"`
+	SK string `json:"-" dynamodbav:"sk"`
+}
+
+func (w Webhook) Validate() error {
+	errs := validation.Errors{}
+
+	if w.URL == "" {
+		errs["url"] = validation.NewError("url_required", "url is required")
+	} else if err := ValidateURL(w.URL); err != nil {
+		errs["url"] = err
+	}
+	if len(w.Events) == 0 {
+		errs["events"] = validation.NewError("events_required", "at least one event is required")
+	}
+	events := validation.Errors{}
+	for i, event := range w.Events {
+		switch {
+		case !slices.Contains(WebhookEvents, event):
+			events[strconv.Itoa(i)] = validation.NewError("event_unknown", "must be one of show.created, show.updated, show.published, show.deleted")
+		case slices.Contains(w.Events[:i], event):
+			events[strconv.Itoa(i)] = validation.NewError("event_duplicate", "duplicates event "+event)
+		}
+	}
+	if len(events) > 0 {
+		errs["events"] = events
+	}
+	if len(w.Secret) < MinWebhookSecret || len(w.Secret) > 256 {
+		errs["secret"] = validation.NewError("secret_invalid", fmt.Sprintf("secret must be %d to 256 characters", MinWebhookSecret))
+	}
+
+	return errs.Filter()
+}
+
+// Wants reports whether the webhook subscribes to event
+func (w Webhook) Wants(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// Redacted returns the webhook without its secret, for responses
+func (w Webhook) Redacted() Webhook {
+	w.Secret = ""
+	return w
+}
+
+type WebhooksResponse struct {
+	Response []Webhook `json:"response"`
+}
+
+// WebhookEventOf names the webhook event announcing e
+func WebhookEventOf(e ChangeEvent) string {
+	switch {
+	case e.Type == EventShowCreated:
+		return WebhookShowCreated
+	case e.Type == EventShowTransitioned && e.Status == StatusPublished:
+		return WebhookShowPublished
+	case e.Type == EventShowTransitioned && e.Status == StatusArchived:
+		return WebhookShowDeleted
+	default:
+		return WebhookShowUpdated
+	}
+}
+
+// WebhookPayload is the JSON body of a delivery. ID is the change event's,
+// the same for every webhook and redelivery, so receivers can deduplicate
+// on it.
+type WebhookPayload struct {
+	ID           string `json:"id"`
+	Event        string `json:"event"`
+	Slug         string `json:"slug"`
+	PreviousSlug string `json:"previousSlug,omitempty"`
+	Revision     int    `json:"revision,omitempty"`
+	Actor        string `json:"actor,omitempty"`
+	At           string `json:"at"`
+	Show         *Show  `json:"show,omitempty"` // the show when the event was dispatched
+}
+
+// WebhookDelivery is one event sent, or to be sent, to one webhook, with
+// the state of its attempts
+type WebhookDelivery struct {
+	ID        string          `json:"id" dynamodbav:"id"`
+	WebhookID string          `json:"webhookId" dynamodbav:"webhookId"`
+	Event     string          `json:"event" dynamodbav:"event"`
+	Payload   json.RawMessage `json:"payload" dynamodbav:"payload"`
+	Status    string          `json:"status" dynamodbav:"status"`
+	// Attempts counts requests started, including one in progress
+	Attempts int `json:"attempts" dynamodbav:"attempts"`
+	// NextAttemptAt is when a pending delivery is next due, in SortableTime.
+	// While a request is in progress it is the end of the dispatcher's lease.
+	NextAttemptAt  string `json:"nextAttemptAt,omitempty" dynamodbav:"nextAttemptAt,omitempty"`
+	ResponseStatus int    `json:"responseStatus,omitempty" dynamodbav:"responseStatus,omitempty"` // of the last attempt
+	LastError      string `json:"lastError,omitempty" dynamodbav:"lastError,omitempty"`
+	CreatedAt      string `json:"createdAt" dynamodbav:"createdAt"` // RFC 3339, UTC
+	CompletedAt    string `json:"completedAt,omitempty" dynamodbav:"completedAt,omitempty"`
+	RedeliveryOf   string `json:"redeliveryOf,omitempty" dynamodbav:"redeliveryOf,omitempty"`
+
+	// Keys (not in JSON payloads; set on write)
+	PK string `json:"-" dynamodbav:"slug"`
+	SK string `json:"-" dynamodbav:"sk"`
+}
+
+// DeliveryID returns the ID of a delivery created at at. IDs sort by
+// creation time. The same seed gives the same ID, so that an event handed
+// over twice is delivered once.
+func DeliveryID(at time.Time, seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return at.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(sum[:8])
+}
+
+type WebhookDeliveriesResponse struct {
+	Response []WebhookDelivery `json:"response"`
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	secret := strings.Repeat("s", MinWebhookSecret)
+
+	tests := []struct {
+		name      string
+		webhook   Webhook
+		wantCodes map[string]string
+	}{
+		{
+			name:    "valid",
+			webhook: Webhook{URL: "https://partner.example.com/hooks", Events: []string{WebhookShowCreated, WebhookShowDeleted}, Secret: secret},
+		},
+		{
+			name:      "missing everything",
+			webhook:   Webhook{},
+			wantCodes: map[string]string{"url": "url_required", "events": "events_required", "secret": "secret_invalid"},
+		},
+		{
+			name:      "bad url and short secret",
+			webhook:   Webhook{URL: "ftp://partner.example.com", Events: []string{WebhookShowUpdated}, Secret: "short"},
+			wantCodes: map[string]string{"url": "invalid_url", "secret": "secret_invalid"},
+		},
+		{
+			name:      "unknown and repeated events",
+			webhook:   Webhook{URL: "https://partner.example.com", Events: []string{WebhookShowUpdated, "show.renamed", WebhookShowUpdated}, Secret: secret},
+			wantCodes: map[string]string{"events/1": "event_unknown", "events/2": "event_duplicate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.webhook.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("Validate() codes = %v, want %v", got, tt.wantCodes)
+			}
+			for field, code := range tt.wantCodes {
+				if got[field] != code {
+					t.Errorf("Validate() %s = %q, want %q", field, got[field], code)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_Redacted(t *testing.T) {
+	w := Webhook{ID: "a", Secret: "secret"}
+	if got := w.Redacted(); got.Secret != "" || got.ID != "a" {
+		t.Errorf("Redacted() = %+v", got)
+	}
+	if w.Secret != "secret" {
+		t.Errorf("Redacted() changed the original")
+	}
+}
+
+func TestWebhookEventOf(t *testing.T) {
+	tests := []struct {
+		event ChangeEvent
+		want  string
+	}{
+		{ChangeEvent{Type: EventShowCreated, Status: StatusDraft}, WebhookShowCreated},
+		{ChangeEvent{Type: EventShowTransitioned, Status: StatusInReview}, WebhookShowUpdated},
+		{ChangeEvent{Type: EventShowTransitioned, Status: StatusPublished}, WebhookShowPublished},
+		{ChangeEvent{Type: EventShowTransitioned, Status: StatusArchived}, WebhookShowDeleted},
+		{ChangeEvent{Type: EventShowReverted, Status: StatusPublished}, WebhookShowUpdated},
+		{ChangeEvent{Type: EventShowRenamed, Status: StatusArchived}, WebhookShowUpdated},
+	}
+	for _, tt := range tests {
+		if got := WebhookEventOf(tt.event); got != tt.want {
+			t.Errorf("WebhookEventOf(%s to %s) = %q, want %q", tt.event.Type, tt.event.Status, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryID(t *testing.T) {
+	at := time.Date(2024, 6, 15, 10, 0, 0, 0, time.FixedZone("AEST", 10*60*60))
+	a := DeliveryID(at, "event#hook")
+	if a != DeliveryID(at, "event#hook") {
+		t.Errorf("DeliveryID() is not deterministic")
+	}
+	if a == DeliveryID(at, "event#other") {
+		t.Errorf("DeliveryID() ignores the seed")
+	}
+	if !strings.HasPrefix(a, "20240615T000000Z-") || !MatchDeliveryID.MatchString(a) {
+		t.Errorf("DeliveryID() = %q", a)
+	}
+	if later := DeliveryID(at.Add(time.Second), "event#hook"); later <= a {
+		t.Errorf("DeliveryID() = %q, does not sort after %q", later, a)
+	}
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Status is a show's place in the editorial workflow
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusInReview  Status = "in_review"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// HiddenScheduled hides a published show until its PublishAt. Shows that are
+// not published are hidden with their status as the reason.
+const HiddenScheduled = "scheduled"
+
+// Role is what a user may do in the workflow
+type Role string
+
+const (
+	RoleEditor    Role = "editor"
+	RolePublisher Role = "publisher"
+)
+
+// workflow lists the transitions allowed from each status and the role each
+// requires
+var workflow = map[Status]map[Status]Role{
+	StatusDraft:     {StatusInReview: RoleEditor},
+	StatusInReview:  {StatusDraft: RolePublisher, StatusPublished: RolePublisher},
+	StatusPublished: {StatusDraft: RolePublisher, StatusArchived: RolePublisher},
+	StatusArchived:  {StatusDraft: RoleEditor},
+}
+
+// RequiredRole returns the role needed to move a show from one status to
+// another, and false when the workflow has no such transition
+func RequiredRole(from, to Status) (Role, bool) {
+	role, ok := workflow[from][to]
+	return role, ok
+}
+
+// Actor is the user behind a workflow transition
+type Actor struct {
+	ID    string
+	Roles []Role
+}
+
+// Can reports whether the actor holds role. Publishers can do anything
+// editors can.
+func (a Actor) Can(role Role) bool {
+	return slices.Contains(a.Roles, role) || (role == RoleEditor && slices.Contains(a.Roles, RolePublisher))
+}
+
+// Transition records one move through the workflow
+type Transition struct {
+	From      Status  `json:"from" dynamodbav:"from"`
+	To        Status  `json:"to" dynamodbav:"to"`
+	Actor     string  `json:"actor" dynamodbav:"actor"`
+	At        string  `json:"at" dynamodbav:"at"`                                   // RFC 3339, UTC
+	PublishAt *string `json:"publishAt,omitempty" dynamodbav:"publishAt,omitempty"` // scheduled publish time, when publishing
+}
+
+// StatusChange asks for a show to move to Status. PublishAt schedules a
+// publication; it is only accepted when publishing.
+type StatusChange struct {
+	Status    Status  `json:"status"`
+	PublishAt *string `json:"publishAt,omitempty"`
+}
+
+func (c StatusChange) Validate() error {
+	errs := validation.Errors{}
+	if _, known := workflow[c.Status]; !known {
+		errs["status"] = validation.NewError("status_invalid", "status must be draft, in_review, published or archived")
+	}
+	if c.PublishAt != nil {
+		if c.Status != StatusPublished {
+			errs["publishAt"] = validation.NewError("publish_at_unexpected", "publishAt is only accepted when publishing")
+		} else if _, err := ParseAirDate(*c.PublishAt, time.UTC); err != nil {
+			errs["publishAt"] = DateInvalid()
+		}
+	}
+	return errs.Filter()
+}
+
+// EffectiveStatus is the show's status, reading shows stored before the
+// workflow existed as published
+func (s Show) EffectiveStatus() Status {
+	if s.Status == "" {
+		return StatusPublished
+	}
+	return s.Status
+}
+
+// HiddenFrom returns why a public viewer in country at time at may not see
+// the show, or "" when they may. Unpublished shows are hidden with their
+// status as the reason, then a scheduled publication and Availability apply.
+func (s Show) HiddenFrom(at time.Time, country string) string {
+	if status := s.EffectiveStatus(); status != StatusPublished {
+		return string(status)
+	}
+	if s.PublishAt != nil {
+		if publishAt, err := ParseAirDate(*s.PublishAt, time.UTC); err == nil && at.Before(publishAt) {
+			return HiddenScheduled
+		}
+	}
+	return s.Availability.Check(at, country)
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     Role
+		wantOK   bool
+	}{
+		{from: StatusDraft, to: StatusInReview, want: RoleEditor, wantOK: true},
+		{from: StatusInReview, to: StatusPublished, want: RolePublisher, wantOK: true},
+		{from: StatusInReview, to: StatusDraft, want: RolePublisher, wantOK: true},
+		{from: StatusPublished, to: StatusArchived, want: RolePublisher, wantOK: true},
+		{from: StatusArchived, to: StatusDraft, want: RoleEditor, wantOK: true},
+		{from: StatusDraft, to: StatusPublished},
+		{from: StatusArchived, to: StatusPublished},
+		{from: StatusDraft, to: StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			got, ok := RequiredRole(tt.from, tt.to)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RequiredRole() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestActor_Can(t *testing.T) {
+	editor := Actor{ID: "ed", Roles: []Role{RoleEditor}}
+	publisher := Actor{ID: "pub", Roles: []Role{RolePublisher}}
+
+	if !editor.Can(RoleEditor) || editor.Can(RolePublisher) {
+		t.Errorf("editor.Can() wrong for %+v", editor)
+	}
+	if !publisher.Can(RoleEditor) || !publisher.Can(RolePublisher) {
+		t.Errorf("publisher.Can() wrong for %+v", publisher)
+	}
+	if (Actor{ID: "nobody"}).Can(RoleEditor) {
+		t.Errorf("an actor without roles can edit")
+	}
+}
+
+func TestStatusChange_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    StatusChange
+		wantCodes map[string]string
+	}{
+		{name: "publish", change: StatusChange{Status: StatusPublished}},
+		{name: "scheduled publish", change: StatusChange{Status: StatusPublished, PublishAt: stringPtr("2024-07-01T09:00:00+10:00")}},
+		{name: "unknown status", change: StatusChange{Status: "live"}, wantCodes: map[string]string{"status": "status_invalid"}},
+		{name: "missing status", change: StatusChange{}, wantCodes: map[string]string{"status": "status_invalid"}},
+		{
+			name:      "publishAt without publishing",
+			change:    StatusChange{Status: StatusArchived, PublishAt: stringPtr("2024-07-01T00:00:00Z")},
+			wantCodes: map[string]string{"publishAt": "publish_at_unexpected"},
+		},
+		{
+			name:      "unparseable publishAt",
+			change:    StatusChange{Status: StatusPublished, PublishAt: stringPtr("tomorrow")},
+			wantCodes: map[string]string{"publishAt": "date_invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			collectCodes(tt.change.Validate(), "", got)
+			if len(got) != len(tt.wantCodes) {
+				t.Fatalf("StatusChange.Validate() = %v, want %v", got, tt.wantCodes)
+			}
+			for path, code := range tt.wantCodes {
+				if got[path] != code {
+					t.Errorf("StatusChange.Validate() at %s = %q, want %q", path, got[path], code)
+				}
+			}
+		})
+	}
+}
+
+func TestShow_Validate_Workflow(t *testing.T) {
+	draft := Show{Slug: "show/a", Title: "A", Status: StatusDraft}
+	if err := draft.Validate(); err != nil {
+		t.Errorf("Show.Validate() on a draft = %v, want nil", err)
+	}
+
+	published := Show{Slug: "show/a", Title: "A", Status: StatusPublished, PublishAt: stringPtr("2024-07-01T00:00:00Z")}
+	got := map[string]string{}
+	collectCodes(published.Validate(), "", got)
+	if got["status"] != "read_only" || got["publishAt"] != "read_only" {
+		t.Errorf("Show.Validate() = %v, want status and publishAt read_only", got)
+	}
+}
+
+func TestShow_HiddenFrom(t *testing.T) {
+	june := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		show Show
+		want string
+	}{
+		{name: "stored before the workflow", show: Show{}, want: ""},
+		{name: "published", show: Show{Status: StatusPublished}, want: ""},
+		{name: "draft", show: Show{Status: StatusDraft}, want: "draft"},
+		{name: "in review", show: Show{Status: StatusInReview}, want: "in_review"},
+		{name: "archived", show: Show{Status: StatusArchived}, want: "archived"},
+		{name: "scheduled", show: Show{Status: StatusPublished, PublishAt: stringPtr("2024-06-15T00:00:01Z")}, want: HiddenScheduled},
+		{name: "publish time reached", show: Show{Status: StatusPublished, PublishAt: stringPtr("2024-06-15T00:00:00Z")}, want: ""},
+		{
+			name: "unavailable once published",
+			show: Show{Status: StatusPublished, Availability: &Availability{End: stringPtr("2024-06-01T00:00:00Z")}},
+			want: HiddenExpired,
+		},
+		{
+			name: "workflow before availability",
+			show: Show{Status: StatusDraft, Availability: &Availability{End: stringPtr("2024-06-01T00:00:00Z")}},
+			want: "draft",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.show.HiddenFrom(june, "AU"); got != tt.want {
+				t.Errorf("HiddenFrom() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
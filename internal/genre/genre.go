@@ -0,0 +1,197 @@
+// Package genre holds the controlled genre taxonomy shows are tagged with.
+// Codes are hierarchical paths such as "reality/cooking"; every code has a
+// display name per supported locale, and the free-text values older feeds
+// send are accepted as aliases.
+package genre
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+//go:embed data/genres.csv
+var genresCSV string
+
+// DefaultLocale names genres when the requested locale is not supported
+const DefaultLocale = "en"
+
+// MatchCode validates genre codes: slash-separated segments of lowercase
+// letters, digits and dashes
+var MatchCode = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*(/[a-z0-9][a-z0-9-]*)*$`)
+
+// The taxonomy is built once at start-up; a malformed embedded file is a
+// build defect, so loading panics rather than returning an error
+var taxonomy = mustLoad(genresCSV)
+
+// Genre is one node of the taxonomy in a single locale
+type Genre struct {
+	Code   string `json:"code"`
+	Parent string `json:"parent,omitempty"`
+	Name   string `json:"name"`
+}
+
+type GenresResponse struct {
+	Locale   string  `json:"locale"`
+	Response []Genre `json:"response"`
+}
+
+type node struct {
+	code  string
+	names map[string]string
+}
+
+type table struct {
+	locales []string
+	nodes   []node // in file order, parents before children
+	byCode  map[string]int
+	byAlias map[string]string
+}
+
+// Known reports whether code is a taxonomy code as stored, without alias
+// or case folding
+func Known(code string) bool {
+	_, ok := taxonomy.byCode[code]
+	return ok
+}
+
+// Lookup maps a code, alias or display name in any supported locale to its
+// code, e.g. "Cooking", "cuisine" and "REALITY/COOKING" -> "reality/cooking"
+func Lookup(s string) (string, bool) {
+	key := fold(s)
+	if _, ok := taxonomy.byCode[key]; ok {
+		return key, true
+	}
+	code, ok := taxonomy.byAlias[key]
+	return code, ok
+}
+
+// Parent returns the code one level up, or "" for a top-level genre
+func Parent(code string) string {
+	if i := strings.LastIndexByte(code, '/'); i >= 0 {
+		return code[:i]
+	}
+	return ""
+}
+
+// Locales lists the supported locales, DefaultLocale first
+func Locales() []string {
+	return append([]string(nil), taxonomy.locales...)
+}
+
+// Locale picks the first supported locale among BCP 47 tags such as
+// "fr-CA", falling back to DefaultLocale
+func Locale(tags ...string) string {
+	for _, tag := range tags {
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		primary, _, _ = strings.Cut(primary, "_")
+		for _, l := range taxonomy.locales {
+			if l == primary {
+				return l
+			}
+		}
+	}
+	return DefaultLocale
+}
+
+// All lists the taxonomy in locale, parents before their children
+func All(locale string) []Genre {
+	locale = Locale(locale)
+	genres := make([]Genre, 0, len(taxonomy.nodes))
+	for _, n := range taxonomy.nodes {
+		genres = append(genres, Genre{Code: n.code, Parent: Parent(n.code), Name: n.name(locale)})
+	}
+	return genres
+}
+
+// Name returns code's display name in locale, or "" when code is unknown
+func Name(code, locale string) string {
+	i, ok := taxonomy.byCode[code]
+	if !ok {
+		return ""
+	}
+	return taxonomy.nodes[i].name(Locale(locale))
+}
+
+func (n node) name(locale string) string {
+	if name := n.names[locale]; name != "" {
+		return name
+	}
+	return n.names[DefaultLocale]
+}
+
+// mustLoad reads a header of code,aliases,locales... followed by rows of
+// code,aliases,names... where aliases is a |-separated list
+func mustLoad(data string) table {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comment = '#'
+
+	header, err := r.Read()
+	if err != nil {
+		panic(fmt.Sprintf("genre: reading embedded taxonomy: %v", err))
+	}
+	if len(header) < 3 || header[0] != "code" || header[1] != "aliases" || header[2] != DefaultLocale {
+		panic(fmt.Sprintf("genre: header must be code,aliases,%s,...: %q", DefaultLocale, header))
+	}
+
+	t := table{locales: header[2:], byCode: map[string]int{}, byAlias: map[string]string{}}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return t
+		}
+		if err != nil {
+			panic(fmt.Sprintf("genre: reading embedded taxonomy: %v", err))
+		}
+
+		code := row[0]
+		if !MatchCode.MatchString(code) {
+			panic(fmt.Sprintf("genre: invalid code %q", code))
+		}
+		if _, dup := t.byCode[code]; dup {
+			panic(fmt.Sprintf("genre: duplicate code %q", code))
+		}
+		if parent := Parent(code); parent != "" {
+			if _, ok := t.byCode[parent]; !ok {
+				panic(fmt.Sprintf("genre: %s listed before its parent %s", code, parent))
+			}
+		}
+
+		n := node{code: code, names: map[string]string{}}
+		for i, locale := range t.locales {
+			n.names[locale] = row[2+i]
+			mustAlias(t.byAlias, row[2+i], code)
+		}
+		if n.names[DefaultLocale] == "" {
+			panic(fmt.Sprintf("genre: %s has no %s name", code, DefaultLocale))
+		}
+		if row[1] != "" {
+			for _, alias := range strings.Split(row[1], "|") {
+				mustAlias(t.byAlias, alias, code)
+			}
+		}
+
+		t.byCode[code] = len(t.nodes)
+		t.nodes = append(t.nodes, n)
+	}
+}
+
+func mustAlias(m map[string]string, alias, code string) {
+	key := fold(alias)
+	if key == "" {
+		return
+	}
+	if prev, ok := m[key]; ok && prev != code {
+		panic(fmt.Sprintf("genre: %q maps to both %s and %s", key, prev, code))
+	}
+	m[key] = code
+}
+
+// fold lowercases and collapses whitespace so " Reality  TV" and
+// "reality tv" share a key
+func fold(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
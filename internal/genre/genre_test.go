@@ -0,0 +1,89 @@
+package genre
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "reality/cooking", want: "reality/cooking", wantOK: true},
+		{in: " REALITY/Cooking ", want: "reality/cooking", wantOK: true},
+		{in: "Reality", want: "reality", wantOK: true},
+		{in: "reality  TV", want: "reality", wantOK: true},
+		{in: "Cooking", want: "reality/cooking", wantOK: true},
+		{in: "cuisine", want: "reality/cooking", wantOK: true},
+		{in: "Kids & Family", want: "kids", wantOK: true},
+		{in: "Téléréalité", want: "reality", wantOK: true},
+		{in: "Polka", wantOK: false},
+		{in: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Lookup(tt.in)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKnown(t *testing.T) {
+	require.True(t, Known("reality/cooking"))
+	require.False(t, Known("Reality"), "aliases are not codes")
+	require.False(t, Known("reality/knitting"))
+}
+
+func TestLocale(t *testing.T) {
+	require.Equal(t, "fr", Locale("fr-CA"))
+	require.Equal(t, "de", Locale("DE_at"))
+	require.Equal(t, DefaultLocale, Locale("ja"))
+	require.Equal(t, DefaultLocale, Locale(""))
+	require.Equal(t, "es", Locale("", "ja", "es-419", "fr"))
+	require.Equal(t, DefaultLocale, Locales()[0])
+}
+
+func TestAll(t *testing.T) {
+	genres := All("es")
+	require.NotEmpty(t, genres)
+
+	seen := map[string]bool{}
+	for _, g := range genres {
+		require.NotEmpty(t, g.Name, g.Code)
+		if g.Parent != "" {
+			require.True(t, seen[g.Parent], "%s listed before its parent", g.Code)
+		}
+		seen[g.Code] = true
+	}
+
+	require.Contains(t, genres, Genre{Code: "reality/cooking", Parent: "reality", Name: "Cocina"})
+}
+
+func TestName(t *testing.T) {
+	require.Equal(t, "Cooking", Name("reality/cooking", "en-AU"))
+	require.Equal(t, "Kochen", Name("reality/cooking", "de"))
+	require.Equal(t, "Cooking", Name("reality/cooking", "ja"))
+	require.Equal(t, "", Name("reality/knitting", "en"))
+}
+
+func TestMustLoad_RejectsBrokenTables(t *testing.T) {
+	tests := map[string]string{
+		"bad header":     "code,en\nreality,Reality\n",
+		"orphan child":   "code,aliases,en\nreality/cooking,,Cooking\n",
+		"duplicate code": "code,aliases,en\nreality,,Reality\nreality,,Reality TV\n",
+		"invalid code":   "code,aliases,en\nReality,,Reality\n",
+		"missing name":   "code,aliases,en\nreality,,\n",
+		"alias clash":    "code,aliases,en\nreality,,Reality\ndrama,reality,Drama\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Panics(t, func() { mustLoad(data) })
+		})
+	}
+}
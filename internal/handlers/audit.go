@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/audit"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// Bounds for audit queries
+const (
+	defaultAuditWindow = 24 * time.Hour
+	maxAuditWindow     = 31 * 24 * time.Hour
+	defaultAuditLimit  = 100
+	maxAuditLimit      = 1000
+)
+
+// auditSlugsKey holds the slugs a request touched, for AuditMiddleware
+const auditSlugsKey = "auditSlugs"
+
+// AuditMiddleware writes a record to sink for every POST, PUT, PATCH and
+// DELETE, whatever its outcome. It must run before ErrorMiddleware, to see
+// the status the client received, and before AuthMiddleware, so requests
+// rejected there are recorded too. A record that cannot be written is
+// logged instead.
+func AuditMiddleware(sink audit.Sink) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		at := time.Now().UTC()
+		c.Next()
+
+		r := audit.Record{
+			At:        at,
+			RequestID: requestIDOf(c),
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Slugs:     c.GetStringSlice(auditSlugsKey),
+			Status:    c.Writer.Status(),
+			Outcome:   audit.OutcomeSuccess,
+			SourceIP:  c.ClientIP(),
+		}
+		if r.Status >= http.StatusBadRequest {
+			r.Outcome = audit.OutcomeFailure
+		}
+		if user, err := GetUserFromContext(c); err == nil {
+			r.Sub = user.UserID
+		}
+		r.Actor = r.Sub
+		if r.Actor == "" {
+			r.Actor = viewerOf(c).actor.ID
+		}
+
+		// The client may already be gone; the record is still owed
+		if err := sink.Write(context.WithoutCancel(c.Request.Context()), r); err != nil {
+			log.Printf("audit: %v: %+v", err, r)
+		}
+	}
+}
+
+// auditSlugs names show slugs, channel IDs or webhook IDs the request affects
+func auditSlugs(c *gin.Context, slugs ...string) {
+	c.Set(auditSlugsKey, append(c.GetStringSlice(auditSlugsKey), slugs...))
+}
+
+type AuditHandler interface {
+	GetAudit(c *gin.Context)
+}
+
+type AuditHTTPHandler struct {
+	reader audit.Reader
+	now    func() time.Time
+}
+
+// NewAuditHandler serves queries from reader, which is nil when the
+// configured sink cannot be read back
+func NewAuditHandler(reader audit.Reader) AuditHandler {
+	return &AuditHTTPHandler{reader: reader, now: time.Now}
+}
+
+// GetAudit lists audit records oldest first, filtered by ?actor= and the
+// range ?from= (inclusive) to ?to= (exclusive), at most ?limit= of them. It
+// is for the admin group only.
+func (h *AuditHTTPHandler) GetAudit(c *gin.Context) {
+	if !viewerOf(c).admin {
+		_ = c.Error(apperror.Forbidden("the audit log requires the admin group"))
+		return
+	}
+	if h.reader == nil {
+		_ = c.Error(apperror.New(http.StatusNotImplemented, apperror.CodeNotImplemented, "the configured audit sink cannot be queried", nil))
+		return
+	}
+
+	q, ok := auditQuery(c, h.now().UTC())
+	if !ok {
+		return
+	}
+
+	// One more than asked for tells whether the list was cut short
+	limit := q.Limit
+	q.Limit++
+	records, err := h.reader.Query(c.Request.Context(), q)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	truncated := len(records) > limit
+	if truncated {
+		records = records[:limit]
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"from":      q.From,
+		"to":        q.To,
+		"records":   records,
+		"truncated": truncated,
+	})
+}
+
+// auditQuery reads the query parameters of GetAudit. Without ?to= the range
+// ends now; without ?from= it spans the day before ?to=.
+func auditQuery(c *gin.Context, now time.Time) (audit.Query, bool) {
+	errs := validation.Errors{}
+	q := audit.Query{Actor: c.Query("actor"), To: now, Limit: defaultAuditLimit}
+
+	parse := func(name string, into *time.Time) bool {
+		raw := c.Query(name)
+		if raw == "" {
+			return false
+		}
+		t, err := domain.ParseAirDate(raw, time.UTC)
+		if err != nil {
+			errs[name] = domain.DateInvalid()
+			return false
+		}
+		*into = t.UTC()
+		return true
+	}
+	parse("to", &q.To)
+	if !parse("from", &q.From) {
+		q.From = q.To.Add(-defaultAuditWindow)
+	}
+
+	if len(errs) == 0 {
+		switch {
+		case !q.From.Before(q.To):
+			errs["from"] = validation.NewError("range_invalid", "from must be before to")
+		case q.To.Sub(q.From) > maxAuditWindow:
+			errs["from"] = validation.NewError("range_too_long", "the range may span at most 31 days")
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxAuditLimit {
+			errs["limit"] = validation.NewError("limit_invalid", fmt.Sprintf("limit must be an integer between 1 and %d", maxAuditLimit))
+		}
+		q.Limit = n
+	}
+
+	if len(errs) > 0 {
+		_ = c.Error(apperror.Validation(errs))
+		return audit.Query{}, false
+	}
+	return q, true
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/audit"
+	auditMocks "github.com/marciomarinho/show-service/internal/audit/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		user    *UserContext
+		handler gin.HandlerFunc
+		want    *audit.Record // nil when nothing is audited
+	}{
+		{
+			name:   "successful write by a user",
+			method: http.MethodPut,
+			path:   "/v1/shows/worlds/status",
+			user:   &UserContext{UserID: "sub-1", Username: "ed"},
+			handler: func(c *gin.Context) {
+				if _, ok := showSlugParam(c); ok {
+					c.Status(http.StatusOK)
+				}
+			},
+			want: &audit.Record{
+				RequestID: "req-1", Actor: "sub-1", Sub: "sub-1",
+				Method: http.MethodPut, Route: "/v1/shows/:handle/status", Path: "/v1/shows/worlds/status",
+				Slugs: []string{"show/worlds"}, Status: http.StatusOK, Outcome: audit.OutcomeSuccess, SourceIP: "192.0.2.1",
+			},
+		},
+		{
+			name:   "failed write",
+			method: http.MethodPut,
+			path:   "/v1/shows/worlds/status",
+			user:   &UserContext{UserID: "sub-2"},
+			handler: func(c *gin.Context) {
+				if _, ok := showSlugParam(c); ok {
+					_ = c.Error(apperror.ErrConflict)
+				}
+			},
+			want: &audit.Record{
+				RequestID: "req-1", Actor: "sub-2", Sub: "sub-2",
+				Method: http.MethodPut, Route: "/v1/shows/:handle/status", Path: "/v1/shows/worlds/status",
+				Slugs: []string{"show/worlds"}, Status: http.StatusConflict, Outcome: audit.OutcomeFailure, SourceIP: "192.0.2.1",
+			},
+		},
+		{
+			name:    "without a user the viewer's actor",
+			method:  http.MethodPut,
+			path:    "/v1/shows/worlds/status",
+			handler: func(c *gin.Context) { c.Status(http.StatusOK) },
+			want: &audit.Record{
+				RequestID: "req-1", Actor: "local",
+				Method: http.MethodPut, Route: "/v1/shows/:handle/status", Path: "/v1/shows/worlds/status",
+				Status: http.StatusOK, Outcome: audit.OutcomeSuccess, SourceIP: "192.0.2.1",
+			},
+		},
+		{
+			name:    "reads are not audited",
+			method:  http.MethodGet,
+			path:    "/v1/shows/worlds/status",
+			handler: func(c *gin.Context) { c.Status(http.StatusOK) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := auditMocks.NewMockSink(t)
+			before := time.Now().UTC()
+			if tt.want != nil {
+				sink.EXPECT().Write(mock.Anything, mock.MatchedBy(func(r audit.Record) bool {
+					if r.At.Before(before) || r.At.Location() != time.UTC {
+						return false
+					}
+					r.At = time.Time{}
+					require.Equal(t, *tt.want, r)
+					return true
+				})).Return(nil)
+			}
+
+			r := gin.New()
+			r.Use(RequestIDMiddleware())
+			r.Use(AuditMiddleware(sink))
+			r.Use(ErrorMiddleware(0))
+			r.Use(func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+				c.Set(viewerKey, viewerContext{actor: domain.Actor{ID: "local"}})
+			})
+			r.Handle(tt.method, "/v1/shows/:handle/status", tt.handler)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(RequestIDHeader, "req-1")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
+
+func TestAuditMiddleware_SourceIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		trusted []string
+		want    string
+	}{
+		{name: "no trusted proxies", want: "192.0.2.1"},
+		{name: "an untrusted peer", trusted: []string{"10.0.0.0/16"}, want: "192.0.2.1"},
+		{name: "a trusted peer", trusted: []string{"192.0.2.0/24"}, want: "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := auditMocks.NewMockSink(t)
+			sink.EXPECT().Write(mock.Anything, mock.MatchedBy(func(r audit.Record) bool {
+				return r.SourceIP == tt.want
+			})).Return(nil)
+
+			r := gin.New()
+			require.NoError(t, r.SetTrustedProxies(tt.trusted))
+			r.Use(AuditMiddleware(sink))
+			r.DELETE("/v1/channels/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+			req := httptest.NewRequest(http.MethodDelete, "/v1/channels/nine", nil)
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
+
+func TestAuditMiddleware_SinkFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sink := auditMocks.NewMockSink(t)
+	sink.EXPECT().Write(mock.Anything, mock.Anything).Return(errors.New("table missing"))
+
+	r := gin.New()
+	r.Use(AuditMiddleware(sink))
+	r.DELETE("/v1/channels/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/channels/nine", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestAuditHTTPHandler_GetAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	record := audit.Record{At: now.Add(-time.Hour), RequestID: "r1", Actor: "u1"}
+
+	tests := []struct {
+		name           string
+		query          string
+		admin          bool
+		noReader       bool
+		mockSetup      func(*auditMocks.MockReader)
+		expectedStatus int
+		expectedCode   string
+		wantRecords    int
+		wantTruncated  bool
+	}{
+		{
+			name:  "defaults to the last day",
+			admin: true,
+			mockSetup: func(m *auditMocks.MockReader) {
+				m.EXPECT().Query(mock.Anything, audit.Query{From: now.Add(-24 * time.Hour), To: now, Limit: 101}).
+					Return([]audit.Record{record}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantRecords:    1,
+		},
+		{
+			name:  "filtered and truncated",
+			query: "?actor=u1&from=2024-06-01T00:00:00%2B10:00&to=2024-06-02T00:00:00Z&limit=1",
+			admin: true,
+			mockSetup: func(m *auditMocks.MockReader) {
+				m.EXPECT().Query(mock.Anything, audit.Query{
+					Actor: "u1",
+					From:  time.Date(2024, 5, 31, 14, 0, 0, 0, time.UTC),
+					To:    time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+					Limit: 2,
+				}).Return([]audit.Record{record, record}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			wantRecords:    1,
+			wantTruncated:  true,
+		},
+		{
+			name:  "dates without an offset are UTC",
+			query: "?from=2024-06-14&to=2024-06-14%2012:00",
+			admin: true,
+			mockSetup: func(m *auditMocks.MockReader) {
+				m.EXPECT().Query(mock.Anything, audit.Query{
+					From:  time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC),
+					To:    time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC),
+					Limit: 101,
+				}).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not an admin",
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name:           "sink cannot be queried",
+			admin:          true,
+			noReader:       true,
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusNotImplemented,
+			expectedCode:   "not_implemented",
+		},
+		{
+			name:           "malformed time",
+			query:          "?from=yesterday",
+			admin:          true,
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "range reversed",
+			query:          "?from=2024-06-16T00:00:00Z",
+			admin:          true,
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "range too long",
+			query:          "?from=2024-01-01T00:00:00Z",
+			admin:          true,
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "limit out of range",
+			query:          "?limit=5000",
+			admin:          true,
+			mockSetup:      func(m *auditMocks.MockReader) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:  "reader error",
+			admin: true,
+			mockSetup: func(m *auditMocks.MockReader) {
+				m.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := auditMocks.NewMockReader(t)
+			tt.mockSetup(reader)
+			h := &AuditHTTPHandler{reader: reader, now: func() time.Time { return now }}
+			if tt.noReader {
+				h.reader = nil
+			}
+
+			r := gin.New()
+			r.Use(ErrorMiddleware(0))
+			r.Use(func(c *gin.Context) { c.Set(viewerKey, viewerContext{admin: tt.admin}) })
+			r.GET("/v1/audit", h.GetAudit)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/audit"+tt.query, nil))
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var body struct {
+				Records   []audit.Record `json:"records"`
+				Truncated bool           `json:"truncated"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Len(t, body.Records, tt.wantRecords)
+			require.Equal(t, tt.wantTruncated, body.Truncated)
+		})
+	}
+}
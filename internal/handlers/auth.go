@@ -1,11 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/apperror"
 	"github.com/marciomarinho/show-service/internal/config"
 )
 
@@ -21,19 +22,20 @@ type CognitoClaims struct {
 	Exp             int64    `json:"exp"`
 	Iat             int64    `json:"iat"`
 	ClientID        string   `json:"client_id"`
+	Aud             string   `json:"aud"`
 	Username        string   `json:"username"`
 }
 
 // UserContext represents authenticated user information
 type UserContext struct {
-	UserID   string
+	UserID   string // sub claim
 	Username string
 	Groups   []string
 }
 
 func getRequiredScope(path, method string, validScopes []string) string {
 	switch {
-	case path == "/shows" && method == "GET":
+	case (path == "/shows" || path == "/v1/shows/events") && method == "GET":
 		for _, scope := range validScopes {
 			if strings.Contains(scope, "shows.read") {
 				return scope
@@ -83,8 +85,9 @@ func hasValidScopeFromConfig(tokenScopes, requiredScope string, validScopes []st
 	return hasValidScope(tokenScopes, requiredScope)
 }
 
-// AuthMiddleware validates JWT tokens for non-local environments
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+// AuthMiddleware validates JWT tokens for non-local environments with
+// verifier, and puts the user they name, with their groups, in the context
+func AuthMiddleware(cfg *config.Config, verifier TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if cfg.Env == config.EnvLocal {
 			c.Next()
@@ -93,13 +96,13 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			_ = c.Error(apperror.Unauthorized("Authorization header required"))
 			c.Abort()
 			return
 		}
 
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			_ = c.Error(apperror.Unauthorized("Invalid authorization header format"))
 			c.Abort()
 			return
 		}
@@ -108,46 +111,44 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 
 		// For now, we'll do basic token format validation
 		if len(tokenString) < 10 {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			_ = c.Error(apperror.Unauthorized("Invalid token format"))
 			c.Abort()
 			return
 		}
 
-		requiredScope := getRequiredScope(c.Request.URL.Path, c.Request.Method, cfg.Cognito.ValidScopes)
-
-		if requiredScope == "" {
-			userCtx := &UserContext{
-				UserID:   "placeholder-user-id",
-				Username: "placeholder-user",
-				Groups:   []string{"users"},
-			}
-			c.Set("user", userCtx)
-			c.Next()
+		claims, err := verifier.Verify(c.Request.Context(), tokenString)
+		switch {
+		case errors.Is(err, errTokenExpired):
+			_ = c.Error(apperror.Unauthorized("Token expired"))
+			c.Abort()
+			return
+		case errors.Is(err, errTokenMalformed), errors.Is(err, errTokenSignature), errors.Is(err, errTokenClaims):
+			_ = c.Error(apperror.Unauthorized("Invalid token"))
+			c.Abort()
+			return
+		case err != nil:
+			_ = c.Error(err)
+			c.Abort()
 			return
 		}
 
-		// For now, we'll use a placeholder scope for development
-		// In production, this would come from the validated JWT token claims
-		tokenScopes := strings.Join(cfg.Cognito.ValidScopes, " ")
-
-		if !hasValidScopeFromConfig(tokenScopes, requiredScope, cfg.Cognito.ValidScopes) {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":          "Insufficient scope",
-				"required_scope": requiredScope,
-				"token_scopes":   tokenScopes,
-				"valid_scopes":   cfg.Cognito.ValidScopes,
-			})
+		// Scopes are deliberately not echoed back to the caller
+		requiredScope := getRequiredScope(c.Request.URL.Path, c.Request.Method, cfg.Cognito.ValidScopes)
+		if !hasValidScopeFromConfig(claims.Scope, requiredScope, cfg.Cognito.ValidScopes) {
+			_ = c.Error(apperror.InsufficientScope("Insufficient scope"))
 			c.Abort()
 			return
 		}
 
-		userCtx := &UserContext{
-			UserID:   "placeholder-user-id",
-			Username: "placeholder-user",
-			Groups:   []string{"users"},
+		username := claims.Username
+		if username == "" {
+			username = claims.CognitoUsername
 		}
-		c.Set("user", userCtx)
-
+		c.Set("user", &UserContext{
+			UserID:   claims.Sub,
+			Username: username,
+			Groups:   claims.CognitoGroups,
+		})
 		c.Next()
 	}
 }
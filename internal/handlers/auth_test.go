@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -55,6 +56,13 @@ func TestAuth_getRequiredScope(t *testing.T) {
 			validScopes: []string{"https://show-service-dev.api/shows.read"},
 			expected:    "",
 		},
+		{
+			name:        "event stream needs the read scope",
+			path:        "/v1/shows/events",
+			method:      "GET",
+			validScopes: []string{"https://show-service-dev.api/shows.read", "https://show-service-dev.api/shows.write"},
+			expected:    "https://show-service-dev.api/shows.read",
+		},
 		{
 			name:        "unknown endpoint",
 			path:        "/unknown",
@@ -174,6 +182,12 @@ func TestAuth_hasValidScopeFromConfig(t *testing.T) {
 
 func TestAuth_AuthMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	signer := newTestSigner(t)
+	readScope := "https://show-service-dev.api/shows.read"
+	writeScope := "https://show-service-dev.api/shows.write"
+	token := func(scope string) string {
+		return "Bearer " + signer.sign(t, testClaims(func(c *CognitoClaims) { c.Scope = scope }))
+	}
 
 	tests := []struct {
 		name           string
@@ -184,6 +198,7 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 		validScopes    []string
 		expectedStatus int
 		expectedBody   map[string]interface{}
+		expectedUser   *UserContext
 	}{
 		{
 			name:           "local environment - no auth required",
@@ -191,7 +206,7 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 			authHeader:     "",
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.read"},
+			validScopes:    []string{readScope},
 			expectedStatus: http.StatusOK,
 			expectedBody:   nil,
 		},
@@ -201,10 +216,11 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 			authHeader:     "",
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.read"},
+			validScopes:    []string{readScope},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header required",
+				"code":   "unauthorized",
+				"detail": "Authorization header required",
 			},
 		},
 		{
@@ -213,10 +229,11 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 			authHeader:     "InvalidFormat token",
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.read"},
+			validScopes:    []string{readScope},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Invalid authorization header format",
+				"code":   "unauthorized",
+				"detail": "Invalid authorization header format",
 			},
 		},
 		{
@@ -225,51 +242,94 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 			authHeader:     "Bearer short",
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.read"},
+			validScopes:    []string{readScope},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Invalid token format",
+				"code":   "unauthorized",
+				"detail": "Invalid token format",
 			},
 		},
 		{
-			name:           "insufficient scope - no read scope for GET",
+			name:           "unsigned token",
 			env:            config.EnvDev,
 			authHeader:     "Bearer valid.token.here",
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.write"},
+			validScopes:    []string{readScope},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":   "unauthorized",
+				"detail": "Invalid token",
+			},
+		},
+		{
+			name:           "expired token",
+			env:            config.EnvDev,
+			authHeader:     "Bearer " + signer.sign(t, testClaims(func(c *CognitoClaims) { c.Exp = testTokenNow.Add(-time.Minute).Unix() })),
+			requestPath:    "/shows",
+			requestMethod:  "GET",
+			validScopes:    []string{readScope},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":   "unauthorized",
+				"detail": "Token expired",
+			},
+		},
+		{
+			name:           "insufficient scope - no read scope for GET",
+			env:            config.EnvDev,
+			authHeader:     token(""),
+			requestPath:    "/shows",
+			requestMethod:  "GET",
+			validScopes:    []string{writeScope},
 			expectedStatus: http.StatusOK,
 			expectedBody:   nil,
 		},
 		{
 			name:           "insufficient scope - no write scope for POST",
 			env:            config.EnvDev,
-			authHeader:     "Bearer valid.token.here",
+			authHeader:     token(""),
 			requestPath:    "/shows",
 			requestMethod:  "POST",
-			validScopes:    []string{"https://show-service-dev.api/shows.read"},
+			validScopes:    []string{readScope},
 			expectedStatus: http.StatusOK,
 			expectedBody:   nil,
 		},
 		{
 			name:           "insufficient scope - token missing read scope for GET",
 			env:            config.EnvDev,
-			authHeader:     "Bearer valid.token.here",
+			authHeader:     token(writeScope),
 			requestPath:    "/shows",
 			requestMethod:  "GET",
-			validScopes:    []string{"https://show-service-dev.api/shows.read", "https://show-service-dev.api/shows.write"},
-			expectedStatus: http.StatusOK,
-			expectedBody:   nil,
+			validScopes:    []string{readScope, writeScope},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]interface{}{
+				"code":   "insufficient_scope",
+				"detail": "Insufficient scope",
+			},
 		},
 		{
 			name:           "insufficient scope - token missing write scope for POST",
 			env:            config.EnvDev,
-			authHeader:     "Bearer valid.token.here",
+			authHeader:     token(readScope),
 			requestPath:    "/shows",
 			requestMethod:  "POST",
-			validScopes:    []string{"https://show-service-dev.api/shows.read", "https://show-service-dev.api/shows.write"},
+			validScopes:    []string{readScope, writeScope},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]interface{}{
+				"code":   "insufficient_scope",
+				"detail": "Insufficient scope",
+			},
+		},
+		{
+			name:           "token with the scope names its user and groups",
+			env:            config.EnvDev,
+			authHeader:     token(readScope + " " + writeScope),
+			requestPath:    "/shows",
+			requestMethod:  "POST",
+			validScopes:    []string{readScope, writeScope},
 			expectedStatus: http.StatusOK,
-			expectedBody:   nil,
+			expectedUser:   &UserContext{UserID: "sub-1", Username: "ed", Groups: []string{"editors", "publishers"}},
 		},
 	}
 
@@ -282,7 +342,13 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 				},
 			}
 
-			handler := AuthMiddleware(cfg)
+			var user *UserContext
+			r := gin.New()
+			r.Use(ErrorMiddleware(0), AuthMiddleware(cfg, signer.verifier()))
+			r.Handle(tt.requestMethod, tt.requestPath, func(c *gin.Context) {
+				user, _ = GetUserFromContext(c)
+				c.Status(http.StatusOK)
+			})
 
 			req, _ := http.NewRequest(tt.requestMethod, tt.requestPath, nil)
 			if tt.authHeader != "" {
@@ -290,19 +356,22 @@ func TestAuth_AuthMiddleware(t *testing.T) {
 			}
 
 			w := httptest.NewRecorder()
-
-			c, _ := gin.CreateTestContext(w)
-			c.Request = req
-
-			handler(c)
+			r.ServeHTTP(w, req)
 
 			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUser != nil {
+				require.Equal(t, tt.expectedUser, user)
+			}
 
 			if tt.expectedBody != nil {
+				require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
 				var responseBody map[string]interface{}
 				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 				require.NoError(t, err)
-				require.Equal(t, tt.expectedBody, responseBody)
+				for key, expectedValue := range tt.expectedBody {
+					require.Equal(t, expectedValue, responseBody[key])
+				}
 			}
 		})
 	}
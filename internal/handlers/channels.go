@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	"github.com/marciomarinho/show-service/internal/service"
+)
+
+type ChannelHandler interface {
+	PostChannel(c *gin.Context)
+	GetChannels(c *gin.Context)
+	GetChannel(c *gin.Context)
+	PutChannel(c *gin.Context)
+	DeleteChannel(c *gin.Context)
+}
+
+type ChannelHTTPHandler struct {
+	svc        service.ChannelService
+	normalizer *normalize.Normalizer
+}
+
+func NewChannelHandler(s service.ChannelService, n *normalize.Normalizer) ChannelHandler {
+	return &ChannelHTTPHandler{svc: s, normalizer: n}
+}
+
+func (h *ChannelHTTPHandler) PostChannel(c *gin.Context) {
+	var channel domain.Channel
+	if err := c.ShouldBindJSON(&channel); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+
+	normalized := h.normalizer.Channel(&channel)
+	if err := channel.Validate(); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+	auditSlugs(c, channel.ID)
+
+	if err := h.svc.Create(c.Request.Context(), channel); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := gin.H{"message": "Channel created successfully", "id": channel.ID}
+	if len(normalized.Changes) > 0 {
+		body["normalized"] = normalized.Changes
+	}
+	c.JSON(http.StatusCreated, body)
+}
+
+func (h *ChannelHTTPHandler) GetChannels(c *gin.Context) {
+	response, err := h.svc.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ChannelHTTPHandler) GetChannel(c *gin.Context) {
+	id, ok := channelIDParam(c)
+	if !ok {
+		return
+	}
+
+	channel, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// PutChannel replaces an existing channel. The ID comes from the route; a
+// conflicting body value is reported rather than silently replaced.
+func (h *ChannelHTTPHandler) PutChannel(c *gin.Context) {
+	id, ok := channelIDParam(c)
+	if !ok {
+		return
+	}
+
+	var channel domain.Channel
+	if err := c.ShouldBindJSON(&channel); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+
+	normalized := h.normalizer.Channel(&channel)
+	var mismatch error
+	if channel.ID != "" && channel.ID != id {
+		mismatch = validation.Errors{
+			"id": validation.NewError("channel_id_mismatch", "id must match the channel in the URL"),
+		}
+	}
+	channel.ID = id
+	if err := errors.Join(mismatch, channel.Validate()); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := h.svc.Update(c.Request.Context(), channel); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := gin.H{"message": "Channel updated successfully", "id": channel.ID}
+	if len(normalized.Changes) > 0 {
+		body["normalized"] = normalized.Changes
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+func (h *ChannelHTTPHandler) DeleteChannel(c *gin.Context) {
+	id, ok := channelIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// channelIDParam reads the :id route parameter. An ID no channel could have
+// is reported as not found.
+func channelIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if !domain.MatchChannelID.MatchString(id) {
+		_ = c.Error(apperror.NotFound("channel " + id + " not found"))
+		return "", false
+	}
+	auditSlugs(c, id)
+	return id, true
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+)
+
+func TestChannelHTTPHandler_PostChannel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*serviceMocks.MockChannelService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "created and normalized",
+			body: `{"id": " Nine ", "name": "Channel  9"}`,
+			mockSetup: func(m *serviceMocks.MockChannelService) {
+				m.EXPECT().Create(mock.Anything, domain.Channel{ID: "nine", Name: "Channel 9"}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"id": 9}`,
+			mockSetup:      func(m *serviceMocks.MockChannelService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_json",
+		},
+		{
+			name:           "validation error",
+			body:           `{"id": "nine"}`,
+			mockSetup:      func(m *serviceMocks.MockChannelService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name: "duplicate",
+			body: `{"id": "nine", "name": "Nine"}`,
+			mockSetup: func(m *serviceMocks.MockChannelService) {
+				m.EXPECT().Create(mock.Anything, mock.Anything).Return(apperror.ErrDuplicateSlug)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "duplicate_slug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockChannelService(t)
+			tt.mockSetup(mockSvc)
+			h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/channels", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := serveWithErrors(h.PostChannel, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+		})
+	}
+}
+
+func TestChannelHTTPHandler_GetChannel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("found", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockChannelService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "nine").Return(&domain.Channel{ID: "nine", Name: "Nine"}, nil)
+		h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/channels/nine", nil)
+		w := serveRoute(http.MethodGet, "/v1/channels/:id", h.GetChannel, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"id":"nine","name":"Nine"}`, w.Body.String())
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockChannelService(t)
+		h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/channels/Nine!", nil)
+		w := serveRoute(http.MethodGet, "/v1/channels/:id", h.GetChannel, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestChannelHTTPHandler_GetChannels(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockChannelService(t)
+	mockSvc.EXPECT().List(mock.Anything).Return(nil, errors.New("boom"))
+	h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/channels", nil)
+	w := serveWithErrors(h.GetChannels, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestChannelHTTPHandler_PutChannel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("id taken from the route", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockChannelService(t)
+		mockSvc.EXPECT().Update(mock.Anything, domain.Channel{ID: "nine", Name: "Nine"}).Return(nil)
+		h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/channels/nine", strings.NewReader(`{"name": "Nine"}`))
+		w := serveRoute(http.MethodPut, "/v1/channels/:id", h.PutChannel, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("conflicting id in body", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockChannelService(t)
+		h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/channels/nine", strings.NewReader(`{"id": "gem", "name": "GEM"}`))
+		w := serveRoute(http.MethodPut, "/v1/channels/:id", h.PutChannel, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		var problem Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		require.Equal(t, "/id", problem.Errors[0].Path)
+		require.Equal(t, "channel_id_mismatch", problem.Errors[0].Code)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockChannelService(t)
+		mockSvc.EXPECT().Update(mock.Anything, mock.Anything).Return(apperror.ErrNotFound)
+		h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/channels/nine", strings.NewReader(`{"name": "Nine"}`))
+		w := serveRoute(http.MethodPut, "/v1/channels/:id", h.PutChannel, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestChannelHTTPHandler_DeleteChannel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockChannelService(t)
+	mockSvc.EXPECT().Delete(mock.Anything, "nine").Return(nil)
+	h := NewChannelHandler(mockSvc, normalize.New(normalize.Options{}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/channels/nine", nil)
+	w := serveRoute(http.MethodDelete, "/v1/channels/:id", h.DeleteChannel, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/stream"
+)
+
+// sseRetry is how long browsers wait before reconnecting a dropped stream
+const sseRetry = 3 * time.Second
+
+// removedShow is the event data sent to viewers who may no longer see a
+// show: its slug, and nothing of the change
+type removedShow struct {
+	Slug string `json:"slug"`
+}
+
+type EventsHandler interface {
+	GetShowEvents(c *gin.Context)
+}
+
+type EventsHTTPHandler struct {
+	broker    *stream.Broker
+	heartbeat time.Duration
+}
+
+// NewEventsHandler streams the changes published to broker, with a comment
+// line every heartbeat to keep idle connections open through proxies
+func NewEventsHandler(broker *stream.Broker, heartbeat time.Duration) EventsHandler {
+	return &EventsHTTPHandler{broker: broker, heartbeat: heartbeat}
+}
+
+// GetShowEvents streams show changes as Server-Sent Events. Each is named
+// as for webhooks, e.g. show.updated, and carries the change event as its
+// data. A client that reconnects with Last-Event-ID is first sent what it
+// missed; if the replay buffer no longer covers that, a reset event tells it
+// to reload instead. Changes to shows that are not published after the
+// change are only sent to admins and editorial staff, except that everyone
+// hears when a published show is archived or unpublished: by its slug alone,
+// so clients can drop it.
+func (h *EventsHTTPHandler) GetShowEvents(c *gin.Context) {
+	v := viewerOf(c)
+	staff := v.admin || v.actor.Can(domain.RoleEditor)
+
+	sub, replay, complete := h.broker.Subscribe(c.GetHeader("Last-Event-ID"))
+	defer sub.Close()
+
+	// The server's write timeout is meant for ordinary responses and would
+	// cut the stream short. Writers that cannot lift it, as in tests, are
+	// left alone.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(format string, args ...any) bool {
+		if _, err := fmt.Fprintf(c.Writer, format, args...); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+	write := func(e stream.Event) bool {
+		var payload any = e.Change
+		if !staff && e.Change.Status != domain.StatusPublished {
+			if e.Change.PreviousStatus != domain.StatusPublished {
+				return true
+			}
+			payload = removedShow{Slug: e.Change.Slug}
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return false
+		}
+		return send("id: %s\nevent: %s\ndata: %s\n\n", e.ID, domain.WebhookEventOf(e.Change), data)
+	}
+
+	if !send("retry: %d\n\n", sseRetry.Milliseconds()) {
+		return
+	}
+	if !complete && !send("event: reset\ndata: {}\n\n") {
+		return
+	}
+	for _, e := range replay {
+		if !write(e) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.Events():
+			// A closed subscription fell behind or the server is stopping;
+			// either way the client reconnects and resumes
+			if !ok || !write(e) {
+				return
+			}
+		case <-ticker.C:
+			if !send(": heartbeat\n\n") {
+				return
+			}
+		}
+	}
+}
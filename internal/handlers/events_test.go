@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/stream"
+)
+
+// openStream serves the event stream for viewer and connects to it,
+// returning a reader positioned after the retry line. The handler has
+// subscribed by then, so whatever is published next reaches it.
+func openStream(t *testing.T, broker *stream.Broker, viewer viewerContext, lastEventID string) *bufio.Reader {
+	t.Helper()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(viewerKey, viewer) })
+	r.GET("/v1/shows/events", NewEventsHandler(broker, 50*time.Millisecond).GetShowEvents)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v1/shows/events", nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	body := bufio.NewReader(resp.Body)
+	require.Equal(t, "retry: 3000\n", readLine(t, body))
+	require.Equal(t, "\n", readLine(t, body))
+	return body
+}
+
+func readLine(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	return line
+}
+
+// readEvent reads the next event, skipping heartbeats
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var event strings.Builder
+	for {
+		line := readLine(t, r)
+		switch {
+		case line == "\n" && event.Len() > 0:
+			return event.String()
+		case line == "\n" || strings.HasPrefix(line, ":"):
+		default:
+			event.WriteString(line)
+		}
+	}
+}
+
+func TestEventsHTTPHandler_GetShowEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := domain.ChangeEvent{ID: "e1", Type: domain.EventShowCreated, Slug: "show/a", Revision: 1, Status: domain.StatusDraft, At: "2024-06-15T00:00:00Z"}
+	published := domain.ChangeEvent{ID: "e2", Type: domain.EventShowTransitioned, Slug: "show/a", Revision: 2, Status: domain.StatusPublished, At: "2024-06-15T00:01:00Z"}
+	archived := domain.ChangeEvent{ID: "e3", Type: domain.EventShowTransitioned, Slug: "show/a", Revision: 3, Status: domain.StatusArchived, At: "2024-06-15T00:02:00Z"}
+
+	t.Run("staff see every change", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		body := openStream(t, broker, viewerContext{admin: true}, "")
+
+		broker.Publish(created)
+		broker.Publish(archived)
+		first := readEvent(t, body)
+		require.Regexp(t, `^id: \S+-1\nevent: show.created\ndata: \{"id":"e1","type":"show.created","slug":"show/a","revision":1,"status":"draft","at":"2024-06-15T00:00:00Z"\}\n$`, first)
+		require.Contains(t, readEvent(t, body), "event: show.deleted\n")
+	})
+
+	t.Run("others only see published shows", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		body := openStream(t, broker, viewerContext{}, "")
+
+		broker.Publish(created)
+		broker.Publish(published)
+		require.Contains(t, readEvent(t, body), "event: show.published\n")
+	})
+
+	t.Run("others hear of removals by slug alone", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		body := openStream(t, broker, viewerContext{}, "")
+
+		unpublished := domain.ChangeEvent{ID: "e4", Type: domain.EventShowTransitioned, Slug: "show/b", Revision: 4, Actor: "ed", Status: domain.StatusDraft, PreviousStatus: domain.StatusPublished, At: "2024-06-15T00:03:00Z"}
+		archivedDraft := domain.ChangeEvent{ID: "e5", Type: domain.EventShowTransitioned, Slug: "show/c", Revision: 2, Status: domain.StatusArchived, PreviousStatus: domain.StatusDraft, At: "2024-06-15T00:04:00Z"}
+		withdrawn := archived
+		withdrawn.PreviousStatus = domain.StatusPublished
+		broker.Publish(archivedDraft)
+		broker.Publish(withdrawn)
+		broker.Publish(unpublished)
+		require.Regexp(t, `^id: \S+-2\nevent: show.deleted\ndata: \{"slug":"show/a"\}\n$`, readEvent(t, body))
+		require.Regexp(t, `^id: \S+-3\nevent: show.updated\ndata: \{"slug":"show/b"\}\n$`, readEvent(t, body))
+	})
+
+	t.Run("renames of published shows reach everyone", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		body := openStream(t, broker, viewerContext{}, "")
+
+		broker.Publish(domain.ChangeEvent{ID: "e6", Type: domain.EventShowRenamed, Slug: "show/z", PreviousSlug: "show/draft", Status: domain.StatusDraft, At: "2024-06-15T00:05:00Z"})
+		broker.Publish(domain.ChangeEvent{ID: "e7", Type: domain.EventShowRenamed, Slug: "show/b", PreviousSlug: "show/a", Revision: 2, Status: domain.StatusPublished, At: "2024-06-15T00:06:00Z"})
+		event := readEvent(t, body)
+		require.Contains(t, event, "event: show.updated\n")
+		require.Contains(t, event, `"previousSlug":"show/a"`)
+	})
+
+	t.Run("resumes after Last-Event-ID", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		sub, _, _ := broker.Subscribe("")
+		broker.Publish(created)
+		broker.Publish(published)
+		first := <-sub.Events()
+		sub.Close()
+
+		body := openStream(t, broker, viewerContext{admin: true}, first.ID)
+		require.Contains(t, readEvent(t, body), `"id":"e2"`)
+	})
+
+	t.Run("reset when the replay buffer falls short", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+
+		body := openStream(t, broker, viewerContext{admin: true}, "stale-7")
+		require.Equal(t, "event: reset\ndata: {}\n", readEvent(t, body))
+	})
+
+	t.Run("heartbeats keep the connection open", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+
+		body := openStream(t, broker, viewerContext{admin: true}, "")
+		require.Equal(t, ": heartbeat\n", readLine(t, body))
+	})
+
+	t.Run("closing the broker ends the stream", func(t *testing.T) {
+		broker := stream.NewBroker(10, 10)
+		body := openStream(t, broker, viewerContext{admin: true}, "")
+
+		broker.Close()
+		for {
+			if _, err := body.ReadString('\n'); err != nil {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/genre"
+	"github.com/marciomarinho/show-service/internal/locale"
+)
+
+// GetGenres lists the genre taxonomy. Display names follow the lang query
+// parameter, then Accept-Language, then English.
+func GetGenres(c *gin.Context) {
+	tags := append([]string{c.Query("lang")}, locale.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+
+	l := genre.Locale(tags...)
+	setContentLanguage(c, l)
+	c.JSON(http.StatusOK, genre.GenresResponse{Locale: l, Response: genre.All(l)})
+}
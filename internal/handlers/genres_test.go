@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/genre"
+)
+
+func TestGetGenres(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		wantLocale     string
+		wantCooking    string
+	}{
+		{name: "default", target: "/v1/genres", wantLocale: "en", wantCooking: "Cooking"},
+		{name: "lang parameter", target: "/v1/genres?lang=fr", acceptLanguage: "de", wantLocale: "fr", wantCooking: "Cuisine"},
+		{name: "accept language", target: "/v1/genres", acceptLanguage: "ja, de-AT;q=0.8, en;q=0.5", wantLocale: "de", wantCooking: "Kochen"},
+		{name: "unsupported", target: "/v1/genres?lang=ja", wantLocale: "en", wantCooking: "Cooking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := serveWithErrors(GetGenres, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tt.wantLocale, w.Header().Get("Content-Language"))
+
+			var body genre.GenresResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Equal(t, tt.wantLocale, body.Locale)
+			require.Contains(t, body.Response, genre.Genre{Code: "reality/cooking", Parent: "reality", Name: tt.wantCooking})
+		})
+	}
+}
@@ -4,10 +4,42 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/health"
 )
 
+// HealthCheck is the legacy /v1/health endpoint, kept for existing clients
 func HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ok",
 	})
 }
+
+type HealthHandler interface {
+	Live(c *gin.Context)
+	Ready(c *gin.Context)
+}
+
+type HealthHTTPHandler struct {
+	prober *health.Prober
+}
+
+func NewHealthHandler(p *health.Prober) HealthHandler {
+	return &HealthHTTPHandler{prober: p}
+}
+
+// Live reports that the process is up; it never touches dependencies
+func (h *HealthHTTPHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Ready reports every dependency with its status and latency, and answers
+// 503 when any of them is down
+func (h *HealthHTTPHandler) Ready(c *gin.Context) {
+	report := h.prober.Ready(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/marciomarinho/show-service/internal/health"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -33,3 +38,67 @@ func TestHealthCheck(t *testing.T) {
 
 	assert.Equal(t, "ok", response["message"])
 }
+
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (s stubChecker) Name() string                    { return s.name }
+func (s stubChecker) Check(ctx context.Context) error { return s.err }
+
+func TestHealthHTTPHandler_Live(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/v1/health/live", NewHealthHandler(health.NewProber(time.Second, time.Second)).Live)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/health/live", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+}
+
+func TestHealthHTTPHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		checkers       []health.Checker
+		expectedStatus int
+		expectedReport health.Status
+	}{
+		{
+			name:           "dependencies up",
+			checkers:       []health.Checker{stubChecker{name: "dynamodb"}, stubChecker{name: "jwks"}},
+			expectedStatus: http.StatusOK,
+			expectedReport: health.StatusUp,
+		},
+		{
+			name:           "dynamodb down",
+			checkers:       []health.Checker{stubChecker{name: "dynamodb", err: errors.New("table missing")}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: health.StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/v1/health/ready", NewHealthHandler(health.NewProber(time.Second, time.Second, tt.checkers...)).Ready)
+
+			req, _ := http.NewRequest(http.MethodGet, "/v1/health/ready", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var report health.Report
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedReport, report.Status)
+			assert.Len(t, report.Components, len(tt.checkers))
+		})
+	}
+}
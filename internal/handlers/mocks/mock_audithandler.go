@@ -0,0 +1,77 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAuditHandler creates a new instance of MockAuditHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditHandler {
+	mock := &MockAuditHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditHandler is an autogenerated mock type for the AuditHandler type
+type MockAuditHandler struct {
+	mock.Mock
+}
+
+type MockAuditHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditHandler) EXPECT() *MockAuditHandler_Expecter {
+	return &MockAuditHandler_Expecter{mock: &_m.Mock}
+}
+
+// GetAudit provides a mock function for the type MockAuditHandler
+func (_mock *MockAuditHandler) GetAudit(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockAuditHandler_GetAudit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAudit'
+type MockAuditHandler_GetAudit_Call struct {
+	*mock.Call
+}
+
+// GetAudit is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockAuditHandler_Expecter) GetAudit(c interface{}) *MockAuditHandler_GetAudit_Call {
+	return &MockAuditHandler_GetAudit_Call{Call: _e.mock.On("GetAudit", c)}
+}
+
+func (_c *MockAuditHandler_GetAudit_Call) Run(run func(c *gin.Context)) *MockAuditHandler_GetAudit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditHandler_GetAudit_Call) Return() *MockAuditHandler_GetAudit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuditHandler_GetAudit_Call) RunAndReturn(run func(c *gin.Context)) *MockAuditHandler_GetAudit_Call {
+	_c.Run(run)
+	return _c
+}
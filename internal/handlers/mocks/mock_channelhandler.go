@@ -0,0 +1,237 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockChannelHandler creates a new instance of MockChannelHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChannelHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChannelHandler {
+	mock := &MockChannelHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChannelHandler is an autogenerated mock type for the ChannelHandler type
+type MockChannelHandler struct {
+	mock.Mock
+}
+
+type MockChannelHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChannelHandler) EXPECT() *MockChannelHandler_Expecter {
+	return &MockChannelHandler_Expecter{mock: &_m.Mock}
+}
+
+// DeleteChannel provides a mock function for the type MockChannelHandler
+func (_mock *MockChannelHandler) DeleteChannel(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockChannelHandler_DeleteChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChannel'
+type MockChannelHandler_DeleteChannel_Call struct {
+	*mock.Call
+}
+
+// DeleteChannel is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockChannelHandler_Expecter) DeleteChannel(c interface{}) *MockChannelHandler_DeleteChannel_Call {
+	return &MockChannelHandler_DeleteChannel_Call{Call: _e.mock.On("DeleteChannel", c)}
+}
+
+func (_c *MockChannelHandler_DeleteChannel_Call) Run(run func(c *gin.Context)) *MockChannelHandler_DeleteChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelHandler_DeleteChannel_Call) Return() *MockChannelHandler_DeleteChannel_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChannelHandler_DeleteChannel_Call) RunAndReturn(run func(c *gin.Context)) *MockChannelHandler_DeleteChannel_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetChannel provides a mock function for the type MockChannelHandler
+func (_mock *MockChannelHandler) GetChannel(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockChannelHandler_GetChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannel'
+type MockChannelHandler_GetChannel_Call struct {
+	*mock.Call
+}
+
+// GetChannel is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockChannelHandler_Expecter) GetChannel(c interface{}) *MockChannelHandler_GetChannel_Call {
+	return &MockChannelHandler_GetChannel_Call{Call: _e.mock.On("GetChannel", c)}
+}
+
+func (_c *MockChannelHandler_GetChannel_Call) Run(run func(c *gin.Context)) *MockChannelHandler_GetChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelHandler_GetChannel_Call) Return() *MockChannelHandler_GetChannel_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChannelHandler_GetChannel_Call) RunAndReturn(run func(c *gin.Context)) *MockChannelHandler_GetChannel_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetChannels provides a mock function for the type MockChannelHandler
+func (_mock *MockChannelHandler) GetChannels(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockChannelHandler_GetChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChannels'
+type MockChannelHandler_GetChannels_Call struct {
+	*mock.Call
+}
+
+// GetChannels is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockChannelHandler_Expecter) GetChannels(c interface{}) *MockChannelHandler_GetChannels_Call {
+	return &MockChannelHandler_GetChannels_Call{Call: _e.mock.On("GetChannels", c)}
+}
+
+func (_c *MockChannelHandler_GetChannels_Call) Run(run func(c *gin.Context)) *MockChannelHandler_GetChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelHandler_GetChannels_Call) Return() *MockChannelHandler_GetChannels_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChannelHandler_GetChannels_Call) RunAndReturn(run func(c *gin.Context)) *MockChannelHandler_GetChannels_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostChannel provides a mock function for the type MockChannelHandler
+func (_mock *MockChannelHandler) PostChannel(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockChannelHandler_PostChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostChannel'
+type MockChannelHandler_PostChannel_Call struct {
+	*mock.Call
+}
+
+// PostChannel is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockChannelHandler_Expecter) PostChannel(c interface{}) *MockChannelHandler_PostChannel_Call {
+	return &MockChannelHandler_PostChannel_Call{Call: _e.mock.On("PostChannel", c)}
+}
+
+func (_c *MockChannelHandler_PostChannel_Call) Run(run func(c *gin.Context)) *MockChannelHandler_PostChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelHandler_PostChannel_Call) Return() *MockChannelHandler_PostChannel_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChannelHandler_PostChannel_Call) RunAndReturn(run func(c *gin.Context)) *MockChannelHandler_PostChannel_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutChannel provides a mock function for the type MockChannelHandler
+func (_mock *MockChannelHandler) PutChannel(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockChannelHandler_PutChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutChannel'
+type MockChannelHandler_PutChannel_Call struct {
+	*mock.Call
+}
+
+// PutChannel is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockChannelHandler_Expecter) PutChannel(c interface{}) *MockChannelHandler_PutChannel_Call {
+	return &MockChannelHandler_PutChannel_Call{Call: _e.mock.On("PutChannel", c)}
+}
+
+func (_c *MockChannelHandler_PutChannel_Call) Run(run func(c *gin.Context)) *MockChannelHandler_PutChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelHandler_PutChannel_Call) Return() *MockChannelHandler_PutChannel_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockChannelHandler_PutChannel_Call) RunAndReturn(run func(c *gin.Context)) *MockChannelHandler_PutChannel_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockEventsHandler creates a new instance of MockEventsHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEventsHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEventsHandler {
+	mock := &MockEventsHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEventsHandler is an autogenerated mock type for the EventsHandler type
+type MockEventsHandler struct {
+	mock.Mock
+}
+
+type MockEventsHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEventsHandler) EXPECT() *MockEventsHandler_Expecter {
+	return &MockEventsHandler_Expecter{mock: &_m.Mock}
+}
+
+// GetShowEvents provides a mock function for the type MockEventsHandler
+func (_mock *MockEventsHandler) GetShowEvents(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockEventsHandler_GetShowEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowEvents'
+type MockEventsHandler_GetShowEvents_Call struct {
+	*mock.Call
+}
+
+// GetShowEvents is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockEventsHandler_Expecter) GetShowEvents(c interface{}) *MockEventsHandler_GetShowEvents_Call {
+	return &MockEventsHandler_GetShowEvents_Call{Call: _e.mock.On("GetShowEvents", c)}
+}
+
+func (_c *MockEventsHandler_GetShowEvents_Call) Run(run func(c *gin.Context)) *MockEventsHandler_GetShowEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventsHandler_GetShowEvents_Call) Return() *MockEventsHandler_GetShowEvents_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockEventsHandler_GetShowEvents_Call) RunAndReturn(run func(c *gin.Context)) *MockEventsHandler_GetShowEvents_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHealthHandler creates a new instance of MockHealthHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthHandler {
+	mock := &MockHealthHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHealthHandler is an autogenerated mock type for the HealthHandler type
+type MockHealthHandler struct {
+	mock.Mock
+}
+
+type MockHealthHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthHandler) EXPECT() *MockHealthHandler_Expecter {
+	return &MockHealthHandler_Expecter{mock: &_m.Mock}
+}
+
+// Live provides a mock function for the type MockHealthHandler
+func (_mock *MockHealthHandler) Live(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockHealthHandler_Live_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Live'
+type MockHealthHandler_Live_Call struct {
+	*mock.Call
+}
+
+// Live is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockHealthHandler_Expecter) Live(c interface{}) *MockHealthHandler_Live_Call {
+	return &MockHealthHandler_Live_Call{Call: _e.mock.On("Live", c)}
+}
+
+func (_c *MockHealthHandler_Live_Call) Run(run func(c *gin.Context)) *MockHealthHandler_Live_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthHandler_Live_Call) Return() *MockHealthHandler_Live_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthHandler_Live_Call) RunAndReturn(run func(c *gin.Context)) *MockHealthHandler_Live_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Ready provides a mock function for the type MockHealthHandler
+func (_mock *MockHealthHandler) Ready(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockHealthHandler_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type MockHealthHandler_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockHealthHandler_Expecter) Ready(c interface{}) *MockHealthHandler_Ready_Call {
+	return &MockHealthHandler_Ready_Call{Call: _e.mock.On("Ready", c)}
+}
+
+func (_c *MockHealthHandler_Ready_Call) Run(run func(c *gin.Context)) *MockHealthHandler_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthHandler_Ready_Call) Return() *MockHealthHandler_Ready_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthHandler_Ready_Call) RunAndReturn(run func(c *gin.Context)) *MockHealthHandler_Ready_Call {
+	_c.Run(run)
+	return _c
+}
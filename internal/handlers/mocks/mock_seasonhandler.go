@@ -0,0 +1,197 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSeasonHandler creates a new instance of MockSeasonHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSeasonHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSeasonHandler {
+	mock := &MockSeasonHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSeasonHandler is an autogenerated mock type for the SeasonHandler type
+type MockSeasonHandler struct {
+	mock.Mock
+}
+
+type MockSeasonHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSeasonHandler) EXPECT() *MockSeasonHandler_Expecter {
+	return &MockSeasonHandler_Expecter{mock: &_m.Mock}
+}
+
+// GetEpisodes provides a mock function for the type MockSeasonHandler
+func (_mock *MockSeasonHandler) GetEpisodes(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockSeasonHandler_GetEpisodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEpisodes'
+type MockSeasonHandler_GetEpisodes_Call struct {
+	*mock.Call
+}
+
+// GetEpisodes is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockSeasonHandler_Expecter) GetEpisodes(c interface{}) *MockSeasonHandler_GetEpisodes_Call {
+	return &MockSeasonHandler_GetEpisodes_Call{Call: _e.mock.On("GetEpisodes", c)}
+}
+
+func (_c *MockSeasonHandler_GetEpisodes_Call) Run(run func(c *gin.Context)) *MockSeasonHandler_GetEpisodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonHandler_GetEpisodes_Call) Return() *MockSeasonHandler_GetEpisodes_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSeasonHandler_GetEpisodes_Call) RunAndReturn(run func(c *gin.Context)) *MockSeasonHandler_GetEpisodes_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetSeasons provides a mock function for the type MockSeasonHandler
+func (_mock *MockSeasonHandler) GetSeasons(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockSeasonHandler_GetSeasons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSeasons'
+type MockSeasonHandler_GetSeasons_Call struct {
+	*mock.Call
+}
+
+// GetSeasons is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockSeasonHandler_Expecter) GetSeasons(c interface{}) *MockSeasonHandler_GetSeasons_Call {
+	return &MockSeasonHandler_GetSeasons_Call{Call: _e.mock.On("GetSeasons", c)}
+}
+
+func (_c *MockSeasonHandler_GetSeasons_Call) Run(run func(c *gin.Context)) *MockSeasonHandler_GetSeasons_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonHandler_GetSeasons_Call) Return() *MockSeasonHandler_GetSeasons_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSeasonHandler_GetSeasons_Call) RunAndReturn(run func(c *gin.Context)) *MockSeasonHandler_GetSeasons_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostEpisode provides a mock function for the type MockSeasonHandler
+func (_mock *MockSeasonHandler) PostEpisode(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockSeasonHandler_PostEpisode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostEpisode'
+type MockSeasonHandler_PostEpisode_Call struct {
+	*mock.Call
+}
+
+// PostEpisode is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockSeasonHandler_Expecter) PostEpisode(c interface{}) *MockSeasonHandler_PostEpisode_Call {
+	return &MockSeasonHandler_PostEpisode_Call{Call: _e.mock.On("PostEpisode", c)}
+}
+
+func (_c *MockSeasonHandler_PostEpisode_Call) Run(run func(c *gin.Context)) *MockSeasonHandler_PostEpisode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonHandler_PostEpisode_Call) Return() *MockSeasonHandler_PostEpisode_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSeasonHandler_PostEpisode_Call) RunAndReturn(run func(c *gin.Context)) *MockSeasonHandler_PostEpisode_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostSeason provides a mock function for the type MockSeasonHandler
+func (_mock *MockSeasonHandler) PostSeason(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockSeasonHandler_PostSeason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostSeason'
+type MockSeasonHandler_PostSeason_Call struct {
+	*mock.Call
+}
+
+// PostSeason is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockSeasonHandler_Expecter) PostSeason(c interface{}) *MockSeasonHandler_PostSeason_Call {
+	return &MockSeasonHandler_PostSeason_Call{Call: _e.mock.On("PostSeason", c)}
+}
+
+func (_c *MockSeasonHandler_PostSeason_Call) Run(run func(c *gin.Context)) *MockSeasonHandler_PostSeason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonHandler_PostSeason_Call) Return() *MockSeasonHandler_PostSeason_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSeasonHandler_PostSeason_Call) RunAndReturn(run func(c *gin.Context)) *MockSeasonHandler_PostSeason_Call {
+	_c.Run(run)
+	return _c
+}
@@ -36,6 +36,326 @@ func (_m *MockShowHandler) EXPECT() *MockShowHandler_Expecter {
 	return &MockShowHandler_Expecter{mock: &_m.Mock}
 }
 
+// GetAiringSoon provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetAiringSoon(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetAiringSoon_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAiringSoon'
+type MockShowHandler_GetAiringSoon_Call struct {
+	*mock.Call
+}
+
+// GetAiringSoon is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetAiringSoon(c interface{}) *MockShowHandler_GetAiringSoon_Call {
+	return &MockShowHandler_GetAiringSoon_Call{Call: _e.mock.On("GetAiringSoon", c)}
+}
+
+func (_c *MockShowHandler_GetAiringSoon_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetAiringSoon_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetAiringSoon_Call) Return() *MockShowHandler_GetAiringSoon_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetAiringSoon_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetAiringSoon_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShow provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShow(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShow'
+type MockShowHandler_GetShow_Call struct {
+	*mock.Call
+}
+
+// GetShow is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShow(c interface{}) *MockShowHandler_GetShow_Call {
+	return &MockShowHandler_GetShow_Call{Call: _e.mock.On("GetShow", c)}
+}
+
+func (_c *MockShowHandler_GetShow_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShow_Call) Return() *MockShowHandler_GetShow_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShow_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShow_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowDuplicates provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowDuplicates(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowDuplicates'
+type MockShowHandler_GetShowDuplicates_Call struct {
+	*mock.Call
+}
+
+// GetShowDuplicates is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowDuplicates(c interface{}) *MockShowHandler_GetShowDuplicates_Call {
+	return &MockShowHandler_GetShowDuplicates_Call{Call: _e.mock.On("GetShowDuplicates", c)}
+}
+
+func (_c *MockShowHandler_GetShowDuplicates_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowDuplicates_Call) Return() *MockShowHandler_GetShowDuplicates_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowDuplicates_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowDuplicates_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowRevision provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowRevision(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowRevision'
+type MockShowHandler_GetShowRevision_Call struct {
+	*mock.Call
+}
+
+// GetShowRevision is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowRevision(c interface{}) *MockShowHandler_GetShowRevision_Call {
+	return &MockShowHandler_GetShowRevision_Call{Call: _e.mock.On("GetShowRevision", c)}
+}
+
+func (_c *MockShowHandler_GetShowRevision_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowRevision_Call) Return() *MockShowHandler_GetShowRevision_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowRevision_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowRevision_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowRevisions provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowRevisions(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowRevisions'
+type MockShowHandler_GetShowRevisions_Call struct {
+	*mock.Call
+}
+
+// GetShowRevisions is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowRevisions(c interface{}) *MockShowHandler_GetShowRevisions_Call {
+	return &MockShowHandler_GetShowRevisions_Call{Call: _e.mock.On("GetShowRevisions", c)}
+}
+
+func (_c *MockShowHandler_GetShowRevisions_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowRevisions_Call) Return() *MockShowHandler_GetShowRevisions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowRevisions_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowRevisions_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowSearch provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowSearch(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowSearch'
+type MockShowHandler_GetShowSearch_Call struct {
+	*mock.Call
+}
+
+// GetShowSearch is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowSearch(c interface{}) *MockShowHandler_GetShowSearch_Call {
+	return &MockShowHandler_GetShowSearch_Call{Call: _e.mock.On("GetShowSearch", c)}
+}
+
+func (_c *MockShowHandler_GetShowSearch_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowSearch_Call) Return() *MockShowHandler_GetShowSearch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowSearch_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowSearch_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowSuggest provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowSuggest(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowSuggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowSuggest'
+type MockShowHandler_GetShowSuggest_Call struct {
+	*mock.Call
+}
+
+// GetShowSuggest is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowSuggest(c interface{}) *MockShowHandler_GetShowSuggest_Call {
+	return &MockShowHandler_GetShowSuggest_Call{Call: _e.mock.On("GetShowSuggest", c)}
+}
+
+func (_c *MockShowHandler_GetShowSuggest_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowSuggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowSuggest_Call) Return() *MockShowHandler_GetShowSuggest_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowSuggest_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowSuggest_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetShowTransitions provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) GetShowTransitions(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_GetShowTransitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShowTransitions'
+type MockShowHandler_GetShowTransitions_Call struct {
+	*mock.Call
+}
+
+// GetShowTransitions is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) GetShowTransitions(c interface{}) *MockShowHandler_GetShowTransitions_Call {
+	return &MockShowHandler_GetShowTransitions_Call{Call: _e.mock.On("GetShowTransitions", c)}
+}
+
+func (_c *MockShowHandler_GetShowTransitions_Call) Run(run func(c *gin.Context)) *MockShowHandler_GetShowTransitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowTransitions_Call) Return() *MockShowHandler_GetShowTransitions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_GetShowTransitions_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_GetShowTransitions_Call {
+	_c.Run(run)
+	return _c
+}
+
 // GetShows provides a mock function for the type MockShowHandler
 func (_mock *MockShowHandler) GetShows(c *gin.Context) {
 	_mock.Called(c)
@@ -76,6 +396,86 @@ func (_c *MockShowHandler_GetShows_Call) RunAndReturn(run func(c *gin.Context))
 	return _c
 }
 
+// PostShowReindex provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) PostShowReindex(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_PostShowReindex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostShowReindex'
+type MockShowHandler_PostShowReindex_Call struct {
+	*mock.Call
+}
+
+// PostShowReindex is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) PostShowReindex(c interface{}) *MockShowHandler_PostShowReindex_Call {
+	return &MockShowHandler_PostShowReindex_Call{Call: _e.mock.On("PostShowReindex", c)}
+}
+
+func (_c *MockShowHandler_PostShowReindex_Call) Run(run func(c *gin.Context)) *MockShowHandler_PostShowReindex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_PostShowReindex_Call) Return() *MockShowHandler_PostShowReindex_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_PostShowReindex_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_PostShowReindex_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostShowRevert provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) PostShowRevert(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_PostShowRevert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostShowRevert'
+type MockShowHandler_PostShowRevert_Call struct {
+	*mock.Call
+}
+
+// PostShowRevert is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) PostShowRevert(c interface{}) *MockShowHandler_PostShowRevert_Call {
+	return &MockShowHandler_PostShowRevert_Call{Call: _e.mock.On("PostShowRevert", c)}
+}
+
+func (_c *MockShowHandler_PostShowRevert_Call) Run(run func(c *gin.Context)) *MockShowHandler_PostShowRevert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_PostShowRevert_Call) Return() *MockShowHandler_PostShowRevert_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_PostShowRevert_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_PostShowRevert_Call {
+	_c.Run(run)
+	return _c
+}
+
 // PostShows provides a mock function for the type MockShowHandler
 func (_mock *MockShowHandler) PostShows(c *gin.Context) {
 	_mock.Called(c)
@@ -115,3 +515,83 @@ func (_c *MockShowHandler_PostShows_Call) RunAndReturn(run func(c *gin.Context))
 	_c.Run(run)
 	return _c
 }
+
+// PutShowSlug provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) PutShowSlug(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_PutShowSlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutShowSlug'
+type MockShowHandler_PutShowSlug_Call struct {
+	*mock.Call
+}
+
+// PutShowSlug is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) PutShowSlug(c interface{}) *MockShowHandler_PutShowSlug_Call {
+	return &MockShowHandler_PutShowSlug_Call{Call: _e.mock.On("PutShowSlug", c)}
+}
+
+func (_c *MockShowHandler_PutShowSlug_Call) Run(run func(c *gin.Context)) *MockShowHandler_PutShowSlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_PutShowSlug_Call) Return() *MockShowHandler_PutShowSlug_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_PutShowSlug_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_PutShowSlug_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutShowStatus provides a mock function for the type MockShowHandler
+func (_mock *MockShowHandler) PutShowStatus(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockShowHandler_PutShowStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutShowStatus'
+type MockShowHandler_PutShowStatus_Call struct {
+	*mock.Call
+}
+
+// PutShowStatus is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockShowHandler_Expecter) PutShowStatus(c interface{}) *MockShowHandler_PutShowStatus_Call {
+	return &MockShowHandler_PutShowStatus_Call{Call: _e.mock.On("PutShowStatus", c)}
+}
+
+func (_c *MockShowHandler_PutShowStatus_Call) Run(run func(c *gin.Context)) *MockShowHandler_PutShowStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowHandler_PutShowStatus_Call) Return() *MockShowHandler_PutShowStatus_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShowHandler_PutShowStatus_Call) RunAndReturn(run func(c *gin.Context)) *MockShowHandler_PutShowStatus_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,317 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookHandler creates a new instance of MockWebhookHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookHandler {
+	mock := &MockWebhookHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookHandler is an autogenerated mock type for the WebhookHandler type
+type MockWebhookHandler struct {
+	mock.Mock
+}
+
+type MockWebhookHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookHandler) EXPECT() *MockWebhookHandler_Expecter {
+	return &MockWebhookHandler_Expecter{mock: &_m.Mock}
+}
+
+// DeleteWebhook provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) DeleteWebhook(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type MockWebhookHandler_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) DeleteWebhook(c interface{}) *MockWebhookHandler_DeleteWebhook_Call {
+	return &MockWebhookHandler_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", c)}
+}
+
+func (_c *MockWebhookHandler_DeleteWebhook_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_DeleteWebhook_Call) Return() *MockWebhookHandler_DeleteWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_DeleteWebhook_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_DeleteWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetWebhook provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) GetWebhook(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type MockWebhookHandler_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) GetWebhook(c interface{}) *MockWebhookHandler_GetWebhook_Call {
+	return &MockWebhookHandler_GetWebhook_Call{Call: _e.mock.On("GetWebhook", c)}
+}
+
+func (_c *MockWebhookHandler_GetWebhook_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhook_Call) Return() *MockWebhookHandler_GetWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhook_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_GetWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetWebhookDeliveries provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type MockWebhookHandler_GetWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveries is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) GetWebhookDeliveries(c interface{}) *MockWebhookHandler_GetWebhookDeliveries_Call {
+	return &MockWebhookHandler_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", c)}
+}
+
+func (_c *MockWebhookHandler_GetWebhookDeliveries_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_GetWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhookDeliveries_Call) Return() *MockWebhookHandler_GetWebhookDeliveries_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhookDeliveries_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_GetWebhookDeliveries_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetWebhooks provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) GetWebhooks(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_GetWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhooks'
+type MockWebhookHandler_GetWebhooks_Call struct {
+	*mock.Call
+}
+
+// GetWebhooks is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) GetWebhooks(c interface{}) *MockWebhookHandler_GetWebhooks_Call {
+	return &MockWebhookHandler_GetWebhooks_Call{Call: _e.mock.On("GetWebhooks", c)}
+}
+
+func (_c *MockWebhookHandler_GetWebhooks_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_GetWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhooks_Call) Return() *MockWebhookHandler_GetWebhooks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_GetWebhooks_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_GetWebhooks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostWebhook provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) PostWebhook(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_PostWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostWebhook'
+type MockWebhookHandler_PostWebhook_Call struct {
+	*mock.Call
+}
+
+// PostWebhook is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) PostWebhook(c interface{}) *MockWebhookHandler_PostWebhook_Call {
+	return &MockWebhookHandler_PostWebhook_Call{Call: _e.mock.On("PostWebhook", c)}
+}
+
+func (_c *MockWebhookHandler_PostWebhook_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_PostWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_PostWebhook_Call) Return() *MockWebhookHandler_PostWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_PostWebhook_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_PostWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PostWebhookRedeliver provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) PostWebhookRedeliver(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_PostWebhookRedeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostWebhookRedeliver'
+type MockWebhookHandler_PostWebhookRedeliver_Call struct {
+	*mock.Call
+}
+
+// PostWebhookRedeliver is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) PostWebhookRedeliver(c interface{}) *MockWebhookHandler_PostWebhookRedeliver_Call {
+	return &MockWebhookHandler_PostWebhookRedeliver_Call{Call: _e.mock.On("PostWebhookRedeliver", c)}
+}
+
+func (_c *MockWebhookHandler_PostWebhookRedeliver_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_PostWebhookRedeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_PostWebhookRedeliver_Call) Return() *MockWebhookHandler_PostWebhookRedeliver_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_PostWebhookRedeliver_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_PostWebhookRedeliver_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PutWebhook provides a mock function for the type MockWebhookHandler
+func (_mock *MockWebhookHandler) PutWebhook(c *gin.Context) {
+	_mock.Called(c)
+	return
+}
+
+// MockWebhookHandler_PutWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutWebhook'
+type MockWebhookHandler_PutWebhook_Call struct {
+	*mock.Call
+}
+
+// PutWebhook is a helper method to define mock.On call
+//   - c *gin.Context
+func (_e *MockWebhookHandler_Expecter) PutWebhook(c interface{}) *MockWebhookHandler_PutWebhook_Call {
+	return &MockWebhookHandler_PutWebhook_Call{Call: _e.mock.On("PutWebhook", c)}
+}
+
+func (_c *MockWebhookHandler_PutWebhook_Call) Run(run func(c *gin.Context)) *MockWebhookHandler_PutWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gin.Context
+		if args[0] != nil {
+			arg0 = args[0].(*gin.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookHandler_PutWebhook_Call) Return() *MockWebhookHandler_PutWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWebhookHandler_PutWebhook_Call) RunAndReturn(run func(c *gin.Context)) *MockWebhookHandler_PutWebhook_Call {
+	_c.Run(run)
+	return _c
+}
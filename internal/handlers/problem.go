@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/apperror"
+)
+
+// ProblemContentType is the RFC 7807 media type for error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document with a stable machine-readable code
+type Problem struct {
+	Type     string                `json:"type"`
+	Title    string                `json:"title"`
+	Status   int                   `json:"status"`
+	Detail   string                `json:"detail,omitempty"`
+	Instance string                `json:"instance,omitempty"`
+	Code     string                `json:"code"`
+	Errors   []apperror.FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem builds the problem document for err as seen on request c
+func NewProblem(c *gin.Context, err error) Problem {
+	appErr := apperror.From(err)
+	return Problem{
+		Type:     "/problems/" + appErr.Code,
+		Title:    appErr.Title,
+		Status:   appErr.Status,
+		Detail:   appErr.Detail,
+		Instance: c.Request.URL.Path,
+		Code:     appErr.Code,
+		Errors:   appErr.Fields,
+	}
+}
+
+// ErrorMiddleware renders the last error recorded with c.Error as
+// application/problem+json, unless a handler already wrote a response.
+// At most maxFieldErrors field errors are listed; 0 lists them all.
+func ErrorMiddleware(maxFieldErrors int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+
+		problem := NewProblem(c, apperror.From(last.Err).LimitFields(maxFieldErrors))
+		if problem.Status >= http.StatusInternalServerError {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, last.Err)
+		}
+
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+// NoRoute answers unknown routes with a problem document
+func NoRoute(c *gin.Context) {
+	_ = c.Error(apperror.NotFound("no route matches " + c.Request.Method + " " + c.Request.URL.Path))
+}
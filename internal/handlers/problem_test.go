@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+)
+
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		handler         gin.HandlerFunc
+		expectedStatus  int
+		expectedProblem *Problem
+	}{
+		{
+			name: "renders recorded error",
+			handler: func(c *gin.Context) {
+				_ = c.Error(apperror.NotFound("show show/a not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedProblem: &Problem{
+				Type:     "/problems/not_found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "show show/a not found",
+				Instance: "/shows",
+				Code:     "not_found",
+			},
+		},
+		{
+			name: "unknown error becomes internal error",
+			handler: func(c *gin.Context) {
+				_ = c.Error(errors.New("boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedProblem: &Problem{
+				Type:     "/problems/internal_error",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "an unexpected error occurred",
+				Instance: "/shows",
+				Code:     "internal_error",
+			},
+		},
+		{
+			name: "response already written",
+			handler: func(c *gin.Context) {
+				_ = c.Error(errors.New("ignored"))
+				c.JSON(http.StatusAccepted, gin.H{"ok": true})
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "no error",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/shows", nil)
+			w := serveWithErrors(tt.handler, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedProblem == nil {
+				require.NotEqual(t, ProblemContentType, w.Header().Get("Content-Type"))
+				return
+			}
+
+			require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			var got Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			require.Equal(t, *tt.expectedProblem, got)
+		})
+	}
+}
+
+func TestErrorMiddleware_LimitsFieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ErrorMiddleware(2))
+	r.POST("/shows", func(c *gin.Context) {
+		_ = c.Error(apperror.Validation(validation.Errors{
+			"skip":         validation.NewError("skip_invalid", "skip must be >= 0"),
+			"take":         validation.NewError("take_invalid", "take must be between 1 and 100"),
+			"totalRecords": validation.NewError("total_records_invalid", "totalRecords must be >= 0"),
+		}))
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/shows", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var got Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Len(t, got.Errors, 2)
+	require.Equal(t, "/skip", got.Errors[0].Path)
+	require.Equal(t, "request has 3 validation errors; showing the first 2", got.Detail)
+}
+
+func TestNoRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.NoRoute(NoRoute)
+
+	req, _ := http.NewRequest(http.MethodGet, "/nope", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var got Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	require.Equal(t, "not_found", got.Code)
+	require.Equal(t, "/nope", got.Instance)
+}
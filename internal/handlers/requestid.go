@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey holds the ID chosen by RequestIDMiddleware
+const requestIDKey = "requestID"
+
+// matchRequestID accepts the IDs load balancers and gateways generate
+var matchRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware keeps the caller's X-Request-Id when it looks like one,
+// or generates a random one, and echoes it on the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !matchRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// requestIDOf returns the request's ID, or "" without RequestIDMiddleware
+func requestIDOf(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
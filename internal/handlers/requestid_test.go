@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "caller's ID kept", incoming: "3f2c1a9e-7b4d-4e2a-9c1f-0a8b6d5e4f3c", keep: true},
+		{name: "generated when missing"},
+		{name: "generated for junk", incoming: "<script>"},
+		{name: "generated when too long", incoming: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			r := gin.New()
+			r.Use(RequestIDMiddleware())
+			r.GET("/", func(c *gin.Context) { seen = requestIDOf(c) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, seen, w.Header().Get(RequestIDHeader))
+			if tt.keep {
+				require.Equal(t, tt.incoming, seen)
+			} else {
+				require.Len(t, seen, 32)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// maxRevision bounds the :revision route parameter to the six digits the
+// sort key holds
+const maxRevision = 999999
+
+// GetShowRevisions lists a show's revisions, oldest first, with the diff
+// each made but not the documents. It is for editorial staff only.
+func (h *ShowHTTPHandler) GetShowRevisions(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok || !requireEditor(c, "revisions") {
+		return
+	}
+
+	revisions, err := h.svc.Revisions(c.Request.Context(), slug)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"slug": slug, "revisions": revisions})
+}
+
+// GetShowRevision returns one revision with the full document
+func (h *ShowHTTPHandler) GetShowRevision(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok || !requireEditor(c, "revisions") {
+		return
+	}
+	n, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+
+	rev, err := h.svc.Revision(c.Request.Context(), slug, n)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rev)
+}
+
+// PostShowRevert restores the content of a revision as a new revision
+func (h *ShowHTTPHandler) PostShowRevert(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+	n, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+
+	show, err := h.svc.Revert(c.Request.Context(), slug, n, viewerOf(c).actor)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Location", showPath(slug)+"/revisions/"+strconv.Itoa(show.Revision))
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Show reverted successfully",
+		"slug":         slug,
+		"revision":     show.Revision,
+		"revertedFrom": n,
+	})
+}
+
+// requireEditor rejects callers without the editor or publisher role from
+// reading what, e.g. a show's revisions
+func requireEditor(c *gin.Context, what string) bool {
+	if viewerOf(c).actor.Can(domain.RoleEditor) {
+		return true
+	}
+	_ = c.Error(apperror.Forbidden("the " + what + " require the editor or publisher role"))
+	return false
+}
+
+// revisionParam parses the :revision route parameter
+func revisionParam(c *gin.Context) (int, bool) {
+	n, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || n < 1 || n > maxRevision {
+		_ = c.Error(apperror.Validation(validation.Errors{
+			"revision": validation.NewError("revision_invalid", "revision must be an integer between 1 and 999999"),
+		}))
+		return 0, false
+	}
+	return n, true
+}
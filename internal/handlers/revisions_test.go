@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+)
+
+func TestShowHTTPHandler_Revisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+
+	t.Run("list", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Revisions(mock.Anything, "show/a").Return([]domain.Revision{{Number: 1, Action: domain.RevisionCreated}}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/revisions", nil)
+		w := serveAsActor(editor, http.MethodGet, "/v1/shows/:handle/revisions", h.GetShowRevisions, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), `"action":"created"`)
+	})
+
+	t.Run("list needs an editorial role", func(t *testing.T) {
+		h := NewShowHandler(serviceMocks.NewMockShowService(t), normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/revisions", nil)
+		w := serveAsActor(domain.Actor{ID: "viewer"}, http.MethodGet, "/v1/shows/:handle/revisions", h.GetShowRevisions, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+		assertProblemCode(t, w, "forbidden")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Revision(mock.Anything, "show/a", 2).Return(&domain.Revision{Number: 2, Show: &domain.Show{Slug: "show/a", Title: "A"}}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/revisions/2", nil)
+		w := serveAsActor(editor, http.MethodGet, "/v1/shows/:handle/revisions/:revision", h.GetShowRevision, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), `"title":"A"`)
+	})
+
+	t.Run("malformed revision", func(t *testing.T) {
+		h := NewShowHandler(serviceMocks.NewMockShowService(t), normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/revisions/latest", nil)
+		w := serveAsActor(editor, http.MethodGet, "/v1/shows/:handle/revisions/:revision", h.GetShowRevision, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assertProblemCode(t, w, "validation_failed")
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Revert(mock.Anything, "show/a", 2, editor).Return(&domain.Show{Slug: "show/a", Revision: 5}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/shows/a/revisions/2/revert", nil)
+		w := serveAsActor(editor, http.MethodPost, "/v1/shows/:handle/revisions/:revision/revert", h.PostShowRevert, req)
+
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.Equal(t, "/v1/shows/a/revisions/5", w.Header().Get("Location"))
+	})
+
+	t.Run("revert conflict", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Revert(mock.Anything, "show/a", 2, editor).Return(nil, fmt.Errorf("show show/a: %w", apperror.ErrConflict))
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/shows/a/revisions/2/revert", nil)
+		w := serveAsActor(editor, http.MethodPost, "/v1/shows/:handle/revisions/:revision/revert", h.PostShowRevert, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+		assertProblemCode(t, w, "conflict")
+	})
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+)
+
+// Bounds for show searches, suggestions and duplicate reports
+const (
+	maxSearchQuery      = 200
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+	maxSuggestPrefix    = 100
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 20
+	defaultReportLimit  = 50
+	maxReportLimit      = 200
+)
+
+// GetShowSearch lists the shows matching ?q=, best first, up to ?limit=
+// (default 20)
+func (h *ShowHTTPHandler) GetShowSearch(c *gin.Context) {
+	errs := validation.Errors{}
+	q := strings.TrimSpace(c.Query("q"))
+	switch {
+	case q == "":
+		errs["q"] = validation.NewError("q_required", "q is required")
+	case len(q) > maxSearchQuery:
+		errs["q"] = validation.NewError("q_too_long", fmt.Sprintf("q must be at most %d bytes", maxSearchQuery))
+	}
+	limit := defaultSearchLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			errs["limit"] = validation.NewError("limit_invalid", fmt.Sprintf("limit must be an integer between 1 and %d", maxSearchLimit))
+		}
+		limit = n
+	}
+	if len(errs) > 0 {
+		_ = c.Error(apperror.Validation(errs))
+		return
+	}
+
+	viewer, ok := requestViewer(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.Search(c.Request.Context(), q, limit, viewer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setContentLanguage(c, response.Locales()...)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetShowSuggest completes ?prefix= with up to ?limit= (default 10) show
+// titles, most popular first. A trailing space in prefix ends its last word.
+func (h *ShowHTTPHandler) GetShowSuggest(c *gin.Context) {
+	errs := validation.Errors{}
+	prefix := strings.TrimLeft(c.Query("prefix"), " ")
+	switch {
+	case strings.TrimSpace(prefix) == "":
+		errs["prefix"] = validation.NewError("prefix_required", "prefix is required")
+	case len(prefix) > maxSuggestPrefix:
+		errs["prefix"] = validation.NewError("prefix_too_long", fmt.Sprintf("prefix must be at most %d bytes", maxSuggestPrefix))
+	}
+	limit := defaultSuggestLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxSuggestLimit {
+			errs["limit"] = validation.NewError("limit_invalid", fmt.Sprintf("limit must be an integer between 1 and %d", maxSuggestLimit))
+		}
+		limit = n
+	}
+	if len(errs) > 0 {
+		_ = c.Error(apperror.Validation(errs))
+		return
+	}
+
+	viewer, ok := requestViewer(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.Suggest(c.Request.Context(), prefix, limit, viewer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setContentLanguage(c, response.Locales()...)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetShowDuplicates reports up to ?limit= (default 50) shows that likely
+// duplicate others, by slug after ?after=, each pair once. It is for editors
+// and publishers.
+func (h *ShowHTTPHandler) GetShowDuplicates(c *gin.Context) {
+	if !requireEditor(c, "duplicate reports") {
+		return
+	}
+	limit := defaultReportLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxReportLimit {
+			_ = c.Error(apperror.Validation(validation.Errors{
+				"limit": validation.NewError("limit_invalid", fmt.Sprintf("limit must be an integer between 1 and %d", maxReportLimit)),
+			}))
+			return
+		}
+		limit = n
+	}
+
+	response, err := h.svc.Duplicates(c.Request.Context(), c.Query("after"), limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// PostShowReindex rebuilds the search index from the table. It is for the
+// admin group only.
+func (h *ShowHTTPHandler) PostShowReindex(c *gin.Context) {
+	if !viewerOf(c).admin {
+		_ = c.Error(apperror.Forbidden("rebuilding the search index requires the admin group"))
+		return
+	}
+
+	n, err := h.svc.Reindex(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Search index rebuilt", "indexed": n})
+}
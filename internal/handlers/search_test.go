@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowHTTPHandler_GetShowSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "defaults to 20 results",
+			query: "?q=taste",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Search(mock.Anything, "taste", 20, mock.Anything).Return(&domain.Response{Response: []domain.ShowResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "custom limit",
+			query: "?q=+le+go%C3%BBt+&limit=5",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Search(mock.Anything, "le goût", 5, mock.Anything).Return(&domain.Response{Response: []domain.ShowResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing query",
+			query:          "?q=+",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "query too long",
+			query:          "?q=" + strings.Repeat("a", maxSearchQuery+1),
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "limit too large",
+			query:          "?q=taste&limit=101",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:  "service error",
+			query: "?q=taste",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Search(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/shows/search"+tt.query, nil)
+			w := serveWithErrors(handler.GetShowSearch, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+		})
+	}
+}
+
+func TestShowHTTPHandler_GetShowSuggest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		query           string
+		mockSetup       func(*serviceMocks.MockShowService)
+		expectedStatus  int
+		expectedCode    string
+		expectedBody    string
+		contentLanguage string
+	}{
+		{
+			name:  "defaults to 10 suggestions",
+			query: "?prefix=tas",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Suggest(mock.Anything, "tas", 10, mock.Anything).Return(&domain.SuggestionsResponse{Response: []domain.Suggestion{
+					{Slug: "show/the-taste", Title: "Le Goût", Locales: []string{"fr"}},
+					{Slug: "show/tasty", Title: "Tasty"},
+				}}, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"response":[{"slug":"show/the-taste","title":"Le Goût"},{"slug":"show/tasty","title":"Tasty"}]}`,
+			contentLanguage: "fr",
+		},
+		{
+			name:  "trailing space kept",
+			query: "?prefix=+the+&limit=5",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Suggest(mock.Anything, "the ", 5, mock.Anything).Return(&domain.SuggestionsResponse{Response: []domain.Suggestion{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"response":[]}`,
+		},
+		{
+			name:           "missing prefix",
+			query:          "",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "prefix too long",
+			query:          "?prefix=" + strings.Repeat("a", maxSuggestPrefix+1),
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "limit too large",
+			query:          "?prefix=tas&limit=21",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:  "service error",
+			query: "?prefix=tas",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Suggest(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/shows/suggest"+tt.query, nil)
+			w := serveWithErrors(handler.GetShowSuggest, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, w.Body.String())
+				require.Equal(t, tt.contentLanguage, w.Header().Get("Content-Language"))
+			}
+		})
+	}
+}
+
+func TestShowHTTPHandler_PostShowReindex(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		admin          bool
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "rebuilds",
+			admin: true,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Reindex(mock.Anything).Return(3, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not an admin",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name:  "service error",
+			admin: true,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Reindex(mock.Anything).Return(0, errors.New("failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			r := gin.New()
+			r.Use(ErrorMiddleware(0))
+			r.Use(func(c *gin.Context) { c.Set(viewerKey, viewerContext{admin: tt.admin}) })
+			r.POST("/v1/shows/search/reindex", handler.PostShowReindex)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/shows/search/reindex", nil))
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if tt.expectedStatus == http.StatusOK {
+				require.JSONEq(t, `{"message":"Search index rebuilt","indexed":3}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestShowHTTPHandler_GetShowDuplicates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		roles          []domain.Role
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+		expectedBody   string
+	}{
+		{
+			name:  "reports",
+			roles: []domain.Role{domain.RoleEditor},
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Duplicates(mock.Anything, "", 50).Return(&domain.DuplicatesResponse{Response: []domain.Duplicate{{Slug: "show/sea-patrol", Title: "Sea Patrol", Matches: []domain.DuplicateMatch{
+					{Slug: "show/seapatrol", Title: "SeaPatrol", Score: 1},
+				}}}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"response":[{"slug":"show/sea-patrol","title":"Sea Patrol","matches":[{"slug":"show/seapatrol","title":"SeaPatrol","score":1}]}]}`,
+		},
+		{
+			name:  "paged",
+			query: "?after=show/bluey&limit=1",
+			roles: []domain.Role{domain.RoleEditor},
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Duplicates(mock.Anything, "show/bluey", 1).Return(&domain.DuplicatesResponse{
+					Response: []domain.Duplicate{{Slug: "show/sea-patrol", Title: "Sea Patrol", Matches: []domain.DuplicateMatch{}}},
+					Next:     "show/sea-patrol",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"response":[{"slug":"show/sea-patrol","title":"Sea Patrol","matches":[]}],"next":"show/sea-patrol"}`,
+		},
+		{
+			name:           "limit out of range",
+			query:          "?limit=201",
+			roles:          []domain.Role{domain.RoleEditor},
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "not an editor",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name:  "service error",
+			roles: []domain.Role{domain.RolePublisher},
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Duplicates(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			r := gin.New()
+			r.Use(ErrorMiddleware(0))
+			r.Use(func(c *gin.Context) { c.Set(viewerKey, viewerContext{actor: domain.Actor{Roles: tt.roles}}) })
+			r.GET("/v1/shows/duplicates", handler.GetShowDuplicates)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/shows/duplicates"+tt.query, nil))
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
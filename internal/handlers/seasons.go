@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	"github.com/marciomarinho/show-service/internal/service"
+)
+
+// SeasonHandler serves the nested /v1/shows/:handle/seasons routes. The
+// handle is the show slug without its "show/" prefix.
+type SeasonHandler interface {
+	PostSeason(c *gin.Context)
+	GetSeasons(c *gin.Context)
+	PostEpisode(c *gin.Context)
+	GetEpisodes(c *gin.Context)
+}
+
+type SeasonHTTPHandler struct {
+	svc        service.SeasonService
+	normalizer *normalize.Normalizer
+}
+
+func NewSeasonHandler(s service.SeasonService, n *normalize.Normalizer) SeasonHandler {
+	return &SeasonHTTPHandler{svc: s, normalizer: n}
+}
+
+func (h *SeasonHTTPHandler) PostSeason(c *gin.Context) {
+	showSlug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+
+	var season domain.SeasonDetail
+	if err := c.ShouldBindJSON(&season); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+	season.ShowSlug = showSlug
+
+	normalized := h.normalizer.Season(&season)
+	if err := season.Validate(); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := h.svc.CreateSeason(c.Request.Context(), season); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := gin.H{"message": "Season created successfully", "slug": domain.SeasonSlug(showSlug, season.Number)}
+	if len(normalized.Changes) > 0 {
+		body["normalized"] = normalized.Changes
+	}
+	c.JSON(http.StatusCreated, body)
+}
+
+func (h *SeasonHTTPHandler) GetSeasons(c *gin.Context) {
+	showSlug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.ListSeasons(c.Request.Context(), showSlug)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *SeasonHTTPHandler) PostEpisode(c *gin.Context) {
+	showSlug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+	season, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	var episode domain.Episode
+	if err := c.ShouldBindJSON(&episode); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+
+	// The season comes from the route; a conflicting body value is reported
+	// rather than silently replaced
+	var mismatch error
+	if episode.SeasonNumber != 0 && episode.SeasonNumber != season {
+		mismatch = validation.Errors{
+			"seasonNumber": validation.NewError("season_mismatch", "seasonNumber must match the season in the URL"),
+		}
+	}
+	episode.ShowSlug = showSlug
+	episode.SeasonNumber = season
+
+	normalized := h.normalizer.Episode(&episode)
+	if err := errors.Join(mismatch, episode.Validate()); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := h.svc.CreateEpisode(c.Request.Context(), episode); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := gin.H{"message": "Episode created successfully", "slug": domain.EpisodeSlug(showSlug, season, episode.Number)}
+	if len(normalized.Changes) > 0 {
+		body["normalized"] = normalized.Changes
+	}
+	c.JSON(http.StatusCreated, body)
+}
+
+func (h *SeasonHTTPHandler) GetEpisodes(c *gin.Context) {
+	showSlug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+	season, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.ListEpisodes(c.Request.Context(), showSlug, season)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// showSlugParam turns the :handle route parameter into a show slug. A handle
+// no show could have is reported as not found.
+func showSlugParam(c *gin.Context) (string, bool) {
+	slug := "show/" + c.Param("handle")
+	if !domain.MatchShowSlug.MatchString(slug) {
+		_ = c.Error(apperror.NotFound("show " + slug + " not found"))
+		return "", false
+	}
+	auditSlugs(c, slug)
+	return slug, true
+}
+
+// seasonParam parses the :season route parameter
+func seasonParam(c *gin.Context) (int, bool) {
+	n, err := strconv.Atoi(c.Param("season"))
+	if err != nil || n < 1 || n > domain.MaxSeasonNumber {
+		_ = c.Error(apperror.Validation(validation.Errors{
+			"season": validation.NewError("season_invalid", "season must be an integer between 1 and 9999"),
+		}))
+		return 0, false
+	}
+	return n, true
+}
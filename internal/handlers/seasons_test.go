@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+)
+
+func TestSeasonHTTPHandler_PostSeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mockSetup      func(*serviceMocks.MockSeasonService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "created",
+			path: "/v1/shows/worlds/seasons",
+			body: `{"number": 2, "title": " Season  2 "}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateSeason(mock.Anything, mock.MatchedBy(func(s domain.SeasonDetail) bool {
+					return s.ShowSlug == "show/worlds" && s.Number == 2 && *s.Title == "Season 2"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid handle",
+			path:           "/v1/shows/Worlds!/seasons",
+			body:           `{"number": 1}`,
+			mockSetup:      func(m *serviceMocks.MockSeasonService) {},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name:           "invalid JSON",
+			path:           "/v1/shows/worlds/seasons",
+			body:           `{"number": "one"}`,
+			mockSetup:      func(m *serviceMocks.MockSeasonService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_json",
+		},
+		{
+			name:           "validation error",
+			path:           "/v1/shows/worlds/seasons",
+			body:           `{"number": 0}`,
+			mockSetup:      func(m *serviceMocks.MockSeasonService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name: "show missing",
+			path: "/v1/shows/worlds/seasons",
+			body: `{"number": 1}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateSeason(mock.Anything, mock.Anything).Return(fmt.Errorf("show show/worlds: %w", apperror.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name: "season exists",
+			path: "/v1/shows/worlds/seasons",
+			body: `{"number": 1}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateSeason(mock.Anything, mock.Anything).Return(apperror.ErrDuplicateSlug)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "duplicate_slug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockSeasonService(t)
+			tt.mockSetup(mockSvc)
+			h := NewSeasonHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := serveRoute(http.MethodPost, "/v1/shows/:handle/seasons", h.PostSeason, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if w.Code == http.StatusCreated {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, "show/worlds/season/2", body["slug"])
+				require.NotEmpty(t, body["normalized"])
+			}
+		})
+	}
+}
+
+func TestSeasonHTTPHandler_GetSeasons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ok", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockSeasonService(t)
+		mockSvc.EXPECT().ListSeasons(mock.Anything, "show/worlds").
+			Return(&domain.SeasonsResponse{Response: []domain.SeasonDetail{{Number: 1, Slug: "show/worlds/season/1"}}}, nil)
+		h := NewSeasonHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/worlds/seasons", nil)
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle/seasons", h.GetSeasons, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"response":[{"number":1,"slug":"show/worlds/season/1"}]}`, w.Body.String())
+	})
+
+	t.Run("show missing", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockSeasonService(t)
+		mockSvc.EXPECT().ListSeasons(mock.Anything, "show/worlds").Return(nil, apperror.ErrNotFound)
+		h := NewSeasonHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/worlds/seasons", nil)
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle/seasons", h.GetSeasons, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSeasonHTTPHandler_PostEpisode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mockSetup      func(*serviceMocks.MockSeasonService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "season taken from the route",
+			path: "/v1/shows/worlds/seasons/2/episodes",
+			body: `{"number": 5, "durationSeconds": 2700}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateEpisode(mock.Anything, mock.MatchedBy(func(e domain.Episode) bool {
+					return e.ShowSlug == "show/worlds" && e.SeasonNumber == 2 && e.Number == 5
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "conflicting season in body",
+			path:           "/v1/shows/worlds/seasons/2/episodes",
+			body:           `{"number": 5, "seasonNumber": 3}`,
+			mockSetup:      func(m *serviceMocks.MockSeasonService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "invalid season in route",
+			path:           "/v1/shows/worlds/seasons/two/episodes",
+			body:           `{"number": 5}`,
+			mockSetup:      func(m *serviceMocks.MockSeasonService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name: "season missing",
+			path: "/v1/shows/worlds/seasons/2/episodes",
+			body: `{"number": 5}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateEpisode(mock.Anything, mock.Anything).Return(apperror.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not_found",
+		},
+		{
+			name: "service error",
+			path: "/v1/shows/worlds/seasons/2/episodes",
+			body: `{"number": 5}`,
+			mockSetup: func(m *serviceMocks.MockSeasonService) {
+				m.EXPECT().CreateEpisode(mock.Anything, mock.Anything).Return(errors.New("boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockSeasonService(t)
+			tt.mockSetup(mockSvc)
+			h := NewSeasonHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := serveRoute(http.MethodPost, "/v1/shows/:handle/seasons/:season/episodes", h.PostEpisode, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+		})
+	}
+}
+
+func TestSeasonHTTPHandler_GetEpisodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockSeasonService(t)
+	mockSvc.EXPECT().ListEpisodes(mock.Anything, "show/worlds", 2).
+		Return(&domain.EpisodesResponse{Response: []domain.Episode{}}, nil)
+	h := NewSeasonHandler(mockSvc, normalize.New(normalize.Options{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/shows/worlds/seasons/2/episodes", nil)
+	w := serveRoute(http.MethodGet, "/v1/shows/:handle/seasons/:season/episodes", h.GetEpisodes, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"response":[]}`, w.Body.String())
+}
+
+// serveRoute is serveWithErrors for handlers that read path parameters
+func serveRoute(method, pattern string, h gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.Handle(method, pattern, h)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func assertProblemCode(t *testing.T, w *httptest.ResponseRecorder, code string) {
+	t.Helper()
+	if code == "" {
+		return
+	}
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	require.Equal(t, code, problem.Code)
+}
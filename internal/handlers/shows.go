@@ -1,59 +1,281 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
 	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/locale"
+	"github.com/marciomarinho/show-service/internal/normalize"
 	"github.com/marciomarinho/show-service/internal/service"
 )
 
+// Bounds for the airing-soon window, in hours
+const (
+	defaultAiringHours = 24
+	maxAiringHours     = 7 * 24
+)
+
 type ShowHandler interface {
 	PostShows(c *gin.Context)
 	GetShows(c *gin.Context)
+	GetShow(c *gin.Context)
+	PutShowSlug(c *gin.Context)
+	PutShowStatus(c *gin.Context)
+	GetShowTransitions(c *gin.Context)
+	GetShowRevisions(c *gin.Context)
+	GetShowRevision(c *gin.Context)
+	PostShowRevert(c *gin.Context)
+	GetAiringSoon(c *gin.Context)
+	GetShowSearch(c *gin.Context)
+	GetShowSuggest(c *gin.Context)
+	PostShowReindex(c *gin.Context)
+	GetShowDuplicates(c *gin.Context)
 }
 
 type ShowHTTPHandler struct {
-	svc service.ShowService
+	svc        service.ShowService
+	normalizer *normalize.Normalizer
 }
 
-func NewShowHandler(s service.ShowService) ShowHandler {
-	return &ShowHTTPHandler{svc: s}
+func NewShowHandler(s service.ShowService, n *normalize.Normalizer) ShowHandler {
+	return &ShowHTTPHandler{svc: s, normalizer: n}
 }
 
 func (h *ShowHTTPHandler) PostShows(c *gin.Context) {
 	var req domain.Request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not decode request: " + err.Error()})
+		_ = c.Error(apperror.InvalidJSON(err))
 		return
 	}
 
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not decode request: " + err.Error()})
+	// Normalize first so validation sees canonical values; rejected values
+	// are reported alongside the validation errors
+	normalized, normErr := h.normalizer.Request(&req)
+	if err := errors.Join(normErr, req.Validate()); err != nil {
+		_ = c.Error(apperror.Validation(err))
 		return
 	}
 
-	for _, show := range req.Payload {
-		if err := show.Validate(); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Could not decode request: " + err.Error()})
-			return
+	created, err := h.svc.Create(c.Request.Context(), req, viewerOf(c).actor)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	auditSlugs(c, created.Slugs...)
+
+	body := gin.H{"message": "Shows created successfully", "slugs": created.Slugs}
+	if len(normalized.Changes) > 0 {
+		body["normalized"] = normalized.Changes
+	}
+	if len(normalized.Warnings) > 0 {
+		body["warnings"] = normalized.Warnings
+	}
+	if len(created.Duplicates) > 0 {
+		body["duplicates"] = created.Duplicates
+	}
+	c.JSON(http.StatusCreated, body)
+}
+
+func (h *ShowHTTPHandler) GetShows(c *gin.Context) {
+	viewer, ok := requestViewer(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.List(c.Request.Context(), viewer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setContentLanguage(c, response.Locales()...)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetShow returns a single show. A former slug answers 301 with the show's
+// current location.
+func (h *ShowHTTPHandler) GetShow(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+
+	viewer, ok := requestViewer(c)
+	if !ok {
+		return
+	}
+
+	show, err := h.svc.Get(c.Request.Context(), slug, viewer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if show.AliasOf != nil {
+		location := showPath(*show.AliasOf)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
 		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+	setContentLanguage(c, show.Locales...)
+	c.JSON(http.StatusOK, show)
+}
+
+// PutShowSlug renames a show. The old slug becomes an alias that redirects
+// to the new one.
+func (h *ShowHTTPHandler) PutShowSlug(c *gin.Context) {
+	from, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Slug string `json:"slug"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+	to := strings.TrimSpace(body.Slug)
+
+	err := validation.Validate(to, validation.Required, validation.Match(domain.MatchShowSlug))
+	if err == nil && to == from {
+		err = validation.NewError("slug_unchanged", "slug must differ from the current one")
+	}
+	if err != nil {
+		_ = c.Error(apperror.Validation(validation.Errors{"slug": err}))
+		return
 	}
 
-	if err := h.svc.Create(req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	auditSlugs(c, to)
+	if err := h.svc.Rename(c.Request.Context(), from, to); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Shows created successfully"})
+	c.Header("Location", showPath(to))
+	c.JSON(http.StatusOK, gin.H{"message": "Show renamed successfully", "slug": to})
 }
 
-func (h *ShowHTTPHandler) GetShows(c *gin.Context) {
-	response, err := h.svc.List()
+// PutShowStatus moves a show through the editorial workflow. The caller's
+// workflow roles decide which transitions they may make.
+func (h *ShowHTTPHandler) PutShowStatus(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok {
+		return
+	}
+
+	var change domain.StatusChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+	change.Status = domain.Status(strings.TrimSpace(string(change.Status)))
+	if change.PublishAt != nil {
+		publishAt := strings.TrimSpace(*change.PublishAt)
+		change.PublishAt = &publishAt
+	}
+	if err := change.Validate(); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	show, err := h.svc.Transition(c.Request.Context(), slug, change, viewerOf(c).actor)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := gin.H{"message": "Show status updated successfully", "slug": slug, "status": show.Status}
+	if show.PublishAt != nil {
+		body["publishAt"] = *show.PublishAt
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// GetShowTransitions lists a show's workflow history, oldest first. It is
+// for editorial staff only.
+func (h *ShowHTTPHandler) GetShowTransitions(c *gin.Context) {
+	slug, ok := showSlugParam(c)
+	if !ok || !requireEditor(c, "workflow history") {
+		return
+	}
+
+	show, err := h.svc.Get(c.Request.Context(), slug, domain.Viewer{IncludeHidden: true})
+	if err == nil && show.AliasOf != nil {
+		err = apperror.NotFound("show " + slug + " has been renamed to " + *show.AliasOf)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	transitions := show.Transitions
+	if transitions == nil {
+		transitions = []domain.Transition{}
+	}
+	c.JSON(http.StatusOK, gin.H{"slug": slug, "status": show.EffectiveStatus(), "transitions": transitions})
+}
+
+// showPath is the URL path of the show at slug
+func showPath(slug string) string {
+	return "/v1/shows/" + strings.TrimPrefix(slug, "show/")
+}
+
+// GetAiringSoon lists shows whose next episode airs within ?hours= (default 24)
+func (h *ShowHTTPHandler) GetAiringSoon(c *gin.Context) {
+	hours := defaultAiringHours
+	if raw := c.Query("hours"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxAiringHours {
+			_ = c.Error(apperror.Validation(validation.Errors{
+				"hours": validation.NewError("hours_invalid", fmt.Sprintf("hours must be an integer between 1 and %d", maxAiringHours)),
+			}))
+			return
+		}
+		hours = n
+	}
+
+	viewer, ok := requestViewer(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.svc.AiringSoon(c.Request.Context(), time.Duration(hours)*time.Hour, viewer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setContentLanguage(c, response.Locales()...)
 	c.JSON(http.StatusOK, response)
 }
+
+// requestLocales lists the client's locale preferences: ?locale= first, then
+// Accept-Language by weight
+func requestLocales(c *gin.Context) []string {
+	var locales []string
+	if l := strings.TrimSpace(c.Query("locale")); l != "" {
+		locales = append(locales, l)
+	}
+	return append(locales, locale.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+}
+
+// setContentLanguage names the locales of the variants served. Responses
+// vary with Accept-Language even when no variant matched.
+func setContentLanguage(c *gin.Context, tags ...string) {
+	c.Header("Vary", "Accept-Language")
+	if len(tags) > 0 {
+		c.Header("Content-Language", strings.Join(tags, ", "))
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/marciomarinho/show-service/internal/apperror"
 	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
 	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
 )
 
@@ -42,11 +47,12 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 				"totalRecords": 1
 			}`,
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().Create(mock.AnythingOfType("domain.Request")).Return(nil)
+				m.EXPECT().Create(mock.Anything, mock.AnythingOfType("domain.Request"), mock.Anything).Return(&domain.Created{Slugs: []string{"show/testshow"}}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
 				"message": "Shows created successfully",
+				"slugs":   []interface{}{"show/testshow"},
 			},
 		},
 		{
@@ -60,7 +66,8 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: invalid character ':' after array element",
+				"code":  "invalid_json",
+				"title": "Bad Request",
 			},
 		},
 		{
@@ -75,7 +82,11 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: payload must contain between 1 and 1000 items",
+				"code":   "validation_failed",
+				"detail": "payload must contain between 1 and 1000 items",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/payload", "code": "payload_size", "message": "payload must contain between 1 and 1000 items"},
+				},
 			},
 		},
 		{
@@ -95,7 +106,11 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: take must be between 1 and 100",
+				"code":   "validation_failed",
+				"detail": "take must be between 1 and 100",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/take", "code": "take_invalid", "message": "take must be between 1 and 100"},
+				},
 			},
 		},
 		{
@@ -115,7 +130,10 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: payload[0]: title is required",
+				"code": "validation_failed",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/payload/0/title", "code": "title_required", "message": "title is required"},
+				},
 			},
 		},
 		{
@@ -135,7 +153,10 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: payload[0]: slug: must be in a valid format.",
+				"code": "validation_failed",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/payload/0/slug", "code": "validation_match_invalid", "message": "must be in a valid format"},
+				},
 			},
 		},
 		{
@@ -152,11 +173,35 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 				"totalRecords": 1
 			}`,
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().Create(mock.AnythingOfType("domain.Request")).Return(errors.New("failed to create show"))
+				m.EXPECT().Create(mock.Anything, mock.AnythingOfType("domain.Request"), mock.Anything).Return(nil, errors.New("failed to create show"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
-				"error": "failed to create show",
+				"code":   "internal_error",
+				"detail": "an unexpected error occurred",
+			},
+		},
+		{
+			name: "duplicate slug",
+			requestBody: `{
+				"payload": [
+					{
+						"slug": "show/testshow",
+						"title": "Test Show"
+					}
+				],
+				"skip": 0,
+				"take": 10,
+				"totalRecords": 1
+			}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Create(mock.Anything, mock.AnythingOfType("domain.Request"), mock.Anything).
+					Return(nil, fmt.Errorf("failed to create show show/testshow: %w", apperror.ErrDuplicateSlug))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]interface{}{
+				"code": "duplicate_slug",
+				"type": "/problems/duplicate_slug",
 			},
 		},
 		{
@@ -180,14 +225,102 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: payload[1]: title is required",
+				"code": "validation_failed",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/payload/1/title", "code": "title_required", "message": "title is required"},
+				},
+			},
+		},
+		{
+			name: "every violation reported at once",
+			requestBody: `{
+				"payload": [
+					{
+						"slug": "show/first",
+						"title": ""
+					},
+					{
+						"slug": "show/second",
+						"title": "Second",
+						"nextEpisode": {"channelLogo": "logo", "html": "next", "url": "not-a-url"}
+					}
+				],
+				"skip": -1,
+				"take": 10,
+				"totalRecords": 2
+			}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"code":   "validation_failed",
+				"detail": "request has 3 validation errors",
+				"errors": []interface{}{
+					map[string]interface{}{"path": "/payload/0/title", "code": "title_required", "message": "title is required"},
+					map[string]interface{}{"path": "/payload/1/nextEpisode/url", "code": "invalid_url", "message": "must start with http:// or https://"},
+					map[string]interface{}{"path": "/skip", "code": "skip_invalid", "message": "skip must be >= 0"},
+				},
+			},
+		},
+		{
+			name: "normalized values reported",
+			requestBody: `{
+				"payload": [
+					{
+						"slug": "show/testshow",
+						"title": "Test  Show",
+						"country": " USA",
+						"primaryColour": "#DF0000"
+					}
+				],
+				"skip": 0,
+				"take": 10,
+				"totalRecords": 1
+			}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Create(mock.Anything, mock.MatchedBy(func(r domain.Request) bool {
+					return *r.Payload[0].Country == "US" && r.Payload[0].Title == "Test Show"
+				}), mock.Anything).Return(&domain.Created{Slugs: []string{"show/testshow"}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"message": "Shows created successfully",
+				"normalized": []interface{}{
+					map[string]interface{}{"path": "/payload/0/title", "from": "Test  Show", "to": "Test Show"},
+					map[string]interface{}{"path": "/payload/0/primaryColour", "from": "#DF0000", "to": "#df0000"},
+					map[string]interface{}{"path": "/payload/0/country", "from": " USA", "to": "US"},
+				},
+			},
+		},
+		{
+			name:        "likely duplicates flagged",
+			requestBody: `{"payload": [{"slug": "show/seapatrol", "title": "SeaPatrol"}], "skip": 0, "take": 10, "totalRecords": 1}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Create(mock.Anything, mock.AnythingOfType("domain.Request"), mock.Anything).Return(&domain.Created{
+					Slugs: []string{"show/seapatrol"},
+					Duplicates: []domain.Duplicate{{Path: "/payload/0", Slug: "show/seapatrol", Title: "SeaPatrol", Matches: []domain.DuplicateMatch{
+						{Slug: "show/sea-patrol", Title: "Sea Patrol", Score: 1},
+					}}},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"slugs": []interface{}{"show/seapatrol"},
+				"duplicates": []interface{}{map[string]interface{}{
+					"path":  "/payload/0",
+					"slug":  "show/seapatrol",
+					"title": "SeaPatrol",
+					"matches": []interface{}{
+						map[string]interface{}{"slug": "show/sea-patrol", "title": "Sea Patrol", "score": 1.0},
+					},
+				}},
 			},
 		},
 		{
 			name:        "large payload - edge case",
 			requestBody: createLargePayload(1000),
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().Create(mock.AnythingOfType("domain.Request")).Return(nil)
+				m.EXPECT().Create(mock.Anything, mock.AnythingOfType("domain.Request"), mock.Anything).Return(&domain.Created{Slugs: make([]string, 1000)}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
@@ -201,7 +334,8 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{
-				"error": "Could not decode request: payload must contain between 1 and 1000 items",
+				"code":   "validation_failed",
+				"detail": "payload must contain between 1 and 1000 items",
 			},
 		},
 	}
@@ -211,19 +345,17 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 			mockSvc := serviceMocks.NewMockShowService(t)
 			tt.mockSetup(mockSvc)
 
-			handler := NewShowHandler(mockSvc)
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
 
 			req, _ := http.NewRequest(http.MethodPost, "/shows", bytes.NewBufferString(tt.requestBody))
 			req.Header.Set("Content-Type", "application/json")
 
-			w := httptest.NewRecorder()
-
-			c, _ := gin.CreateTestContext(w)
-			c.Request = req
-
-			handler.PostShows(c)
+			w := serveWithErrors(handler.PostShows, req)
 
 			require.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code >= http.StatusBadRequest {
+				require.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			}
 
 			var responseBody map[string]interface{}
 			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
@@ -238,6 +370,28 @@ func TestShowHTTPHandler_PostShows(t *testing.T) {
 	}
 }
 
+func TestShowHTTPHandler_PostShows_RejectUnknown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockShowService(t)
+	handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{RejectUnknown: true}))
+
+	body := `{"payload": [{"slug": "show/a", "title": "", "country": "Atlantis"}], "skip": 0, "take": 10, "totalRecords": 1}`
+	req, _ := http.NewRequest(http.MethodPost, "/shows", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := serveWithErrors(handler.PostShows, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	require.ElementsMatch(t, []apperror.FieldError{
+		{Path: "/payload/0/country", Code: "country_unknown", Message: "must be an ISO 3166 country code or name"},
+		{Path: "/payload/0/title", Code: "title_required", Message: "title is required"},
+	}, problem.Errors)
+}
+
 func TestShowHTTPHandler_GetShows(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -250,7 +404,7 @@ func TestShowHTTPHandler_GetShows(t *testing.T) {
 		{
 			name: "successful shows retrieval",
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().List().Return(&domain.Response{
+				m.EXPECT().List(mock.Anything, mock.Anything).Return(&domain.Response{
 					Response: []domain.ShowResponse{
 						{
 							Slug:  "show/testshow1",
@@ -284,7 +438,7 @@ func TestShowHTTPHandler_GetShows(t *testing.T) {
 		{
 			name: "empty shows list",
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().List().Return(&domain.Response{
+				m.EXPECT().List(mock.Anything, mock.Anything).Return(&domain.Response{
 					Response: []domain.ShowResponse{},
 				}, nil)
 			},
@@ -296,17 +450,22 @@ func TestShowHTTPHandler_GetShows(t *testing.T) {
 		{
 			name: "service error",
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().List().Return((*domain.Response)(nil), errors.New("failed to retrieve shows"))
+				m.EXPECT().List(mock.Anything, mock.Anything).Return((*domain.Response)(nil), errors.New("failed to retrieve shows"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
-				"error": "failed to retrieve shows",
+				"type":     "/problems/internal_error",
+				"title":    "Internal Server Error",
+				"status":   float64(http.StatusInternalServerError),
+				"detail":   "an unexpected error occurred",
+				"instance": "/shows",
+				"code":     "internal_error",
 			},
 		},
 		{
 			name: "service returns nil response",
 			mockSetup: func(m *serviceMocks.MockShowService) {
-				m.EXPECT().List().Return((*domain.Response)(nil), nil)
+				m.EXPECT().List(mock.Anything, mock.Anything).Return((*domain.Response)(nil), nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   nil,
@@ -318,16 +477,11 @@ func TestShowHTTPHandler_GetShows(t *testing.T) {
 			mockSvc := serviceMocks.NewMockShowService(t)
 			tt.mockSetup(mockSvc)
 
-			handler := NewShowHandler(mockSvc)
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
 
 			req, _ := http.NewRequest(http.MethodGet, "/shows", nil)
 
-			w := httptest.NewRecorder()
-
-			c, _ := gin.CreateTestContext(w)
-			c.Request = req
-
-			handler.GetShows(c)
+			w := serveWithErrors(handler.GetShows, req)
 
 			require.Equal(t, tt.expectedStatus, w.Code)
 
@@ -343,6 +497,111 @@ func TestShowHTTPHandler_GetShows(t *testing.T) {
 	}
 }
 
+func TestShowHTTPHandler_GetShows_Localized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockShowService(t)
+	mockSvc.EXPECT().List(mock.Anything, domain.Viewer{Locales: []string{"de", "fr"}}).Return(&domain.Response{
+		Response: []domain.ShowResponse{
+			{Slug: "show/a", Title: "A (de)", Locales: []string{"de"}},
+			{Slug: "show/b", Title: "B (fr)", Locales: []string{"fr"}},
+			{Slug: "show/c", Title: "C (de)", Locales: []string{"de"}},
+		},
+	}, nil)
+	handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/shows", nil)
+	req.Header.Set("Accept-Language", "fr;q=0.8, de, *;q=0.1")
+	w := serveWithErrors(handler.GetShows, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "de, fr", w.Header().Get("Content-Language"))
+	require.NotContains(t, w.Body.String(), "locales")
+}
+
+func TestShowHTTPHandler_GetAiringSoon(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "defaults to 24 hours",
+			query: "",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().AiringSoon(mock.Anything, 24*time.Hour, mock.Anything).Return(&domain.Response{Response: []domain.ShowResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "custom window",
+			query: "?hours=6",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().AiringSoon(mock.Anything, 6*time.Hour, mock.Anything).Return(&domain.Response{Response: []domain.ShowResponse{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "window too large",
+			query:          "?hours=169",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "window not a number",
+			query:          "?hours=soon",
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:  "service error",
+			query: "",
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().AiringSoon(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+
+			handler := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/shows/airing-soon"+tt.query, nil)
+			w := serveWithErrors(handler.GetAiringSoon, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var problem Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				require.Equal(t, tt.expectedCode, problem.Code)
+			}
+		})
+	}
+}
+
+// serveWithErrors runs h behind ErrorMiddleware so recorded errors are
+// rendered as problem documents, as they are in the real router
+func serveWithErrors(h gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.Handle(req.Method, req.URL.Path, h)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 // Helper function to create large payloads for testing
 func createLargePayload(size int) string {
 	shows := make([]map[string]interface{}, size)
@@ -363,3 +622,260 @@ func createLargePayload(size int) string {
 	jsonBytes, _ := json.Marshal(payload)
 	return string(jsonBytes)
 }
+
+func TestShowHTTPHandler_GetShow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("found", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/worlds", mock.Anything).Return(&domain.Show{Slug: "show/worlds", Title: "Worlds"}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/worlds", nil)
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle", h.GetShow, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"slug":"show/worlds","title":"Worlds"}`, w.Body.String())
+	})
+
+	t.Run("former slug redirects", func(t *testing.T) {
+		canonical := "show/new"
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/old", mock.Anything).Return(&domain.Show{Slug: "show/old", AliasOf: &canonical}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/old?locale=fr", nil)
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle", h.GetShow, req)
+
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, "/v1/shows/new?locale=fr", w.Header().Get("Location"))
+	})
+
+	t.Run("localized", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/worlds", domain.Viewer{Locales: []string{"fr-CA", "fr", "en"}}).
+			Return(&domain.Show{Slug: "show/worlds", Title: "Mondes", Locales: []string{"fr"}}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/worlds?locale=fr-CA", nil)
+		req.Header.Set("Accept-Language", "en;q=0.5, fr")
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle", h.GetShow, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "fr", w.Header().Get("Content-Language"))
+		require.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/gone", mock.Anything).Return(nil, apperror.ErrNotFound)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/gone", nil)
+		w := serveRoute(http.MethodGet, "/v1/shows/:handle", h.GetShow, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestShowHTTPHandler_PutShowSlug(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "renamed",
+			body: `{"slug": " show/new "}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Rename(mock.Anything, "show/old", "show/new").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid slug",
+			body:           `{"slug": "New Show"}`,
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "reserved handle",
+			body:           `{"slug": "show/search"}`,
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "unchanged",
+			body:           `{"slug": "show/old"}`,
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name: "taken",
+			body: `{"slug": "show/new"}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Rename(mock.Anything, "show/old", "show/new").Return(apperror.ErrDuplicateSlug)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "duplicate_slug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+			h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodPut, "/v1/shows/old/slug", strings.NewReader(tt.body))
+			w := serveRoute(http.MethodPut, "/v1/shows/:handle/slug", h.PutShowSlug, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if w.Code == http.StatusOK {
+				require.Equal(t, "/v1/shows/new", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+// serveAsActor runs h on pattern as if ViewerMiddleware had resolved actor
+func serveAsActor(actor domain.Actor, method, pattern string, h gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.Use(func(c *gin.Context) { c.Set(viewerKey, viewerContext{actor: actor}) })
+	r.Handle(method, pattern, h)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestShowHTTPHandler_PutShowStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	publisher := domain.Actor{ID: "pub", Roles: []domain.Role{domain.RolePublisher}}
+	publishAt := "2024-07-01T00:00:00Z"
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*serviceMocks.MockShowService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "published",
+			body: `{"status": " published ", "publishAt": "2024-07-01T00:00:00Z"}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Transition(mock.Anything, "show/a", domain.StatusChange{Status: domain.StatusPublished, PublishAt: &publishAt}, publisher).
+					Return(&domain.Show{Slug: "show/a", Status: domain.StatusPublished, PublishAt: &publishAt}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown status",
+			body:           `{"status": "live"}`,
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "malformed body",
+			body:           `{"status": `,
+			mockSetup:      func(m *serviceMocks.MockShowService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_json",
+		},
+		{
+			name: "missing role",
+			body: `{"status": "archived"}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Transition(mock.Anything, "show/a", mock.Anything, publisher).
+					Return(nil, apperror.Forbidden("moving a show from draft to archived requires the publisher role"))
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name: "changed concurrently",
+			body: `{"status": "in_review"}`,
+			mockSetup: func(m *serviceMocks.MockShowService) {
+				m.EXPECT().Transition(mock.Anything, "show/a", mock.Anything, publisher).
+					Return(nil, fmt.Errorf("show show/a: %w", apperror.ErrConflict))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			tt.mockSetup(mockSvc)
+			h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodPut, "/v1/shows/a/status", strings.NewReader(tt.body))
+			w := serveAsActor(publisher, http.MethodPut, "/v1/shows/:handle/status", h.PutShowStatus, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+		})
+	}
+}
+
+func TestShowHTTPHandler_GetShowTransitions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+	history := []domain.Transition{{From: domain.StatusDraft, To: domain.StatusInReview, Actor: "ed", At: "2024-06-15T00:00:00Z"}}
+
+	t.Run("editors see the history", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/a", domain.Viewer{IncludeHidden: true}).
+			Return(&domain.Show{Slug: "show/a", Status: domain.StatusInReview, Transitions: history}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/transitions", nil)
+		w := serveAsActor(editor, http.MethodGet, "/v1/shows/:handle/transitions", h.GetShowTransitions, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Status      domain.Status       `json:"status"`
+			Transitions []domain.Transition `json:"transitions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Equal(t, domain.StatusInReview, body.Status)
+		require.Equal(t, history, body.Transitions)
+	})
+
+	t.Run("others are forbidden", func(t *testing.T) {
+		h := NewShowHandler(serviceMocks.NewMockShowService(t), normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/a/transitions", nil)
+		w := serveAsActor(domain.Actor{ID: "viewer"}, http.MethodGet, "/v1/shows/:handle/transitions", h.GetShowTransitions, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+		assertProblemCode(t, w, "forbidden")
+	})
+
+	t.Run("former slug", func(t *testing.T) {
+		canonical := "show/new"
+		mockSvc := serviceMocks.NewMockShowService(t)
+		mockSvc.EXPECT().Get(mock.Anything, "show/old", mock.Anything).
+			Return(&domain.Show{Slug: "show/old", AliasOf: &canonical}, nil)
+		h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/shows/old/transitions", nil)
+		w := serveAsActor(editor, http.MethodGet, "/v1/shows/:handle/transitions", h.GetShowTransitions, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+// Token errors, reported to the caller as 401s
+var (
+	errTokenMalformed = errors.New("malformed token")
+	errTokenSignature = errors.New("invalid token signature")
+	errTokenExpired   = errors.New("token expired")
+	errTokenClaims    = errors.New("token not issued for this service")
+)
+
+// TokenVerifier checks a bearer token and returns its claims
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*CognitoClaims, error)
+}
+
+// CognitoVerifier verifies Cognito tokens: RS256 signatures against the user
+// pool's signing keys, expiry, issuer, and for ID tokens the audience and for
+// access tokens the client. Keys are fetched on first use and again when a
+// token names one not seen yet, at most once per refresh interval.
+type CognitoVerifier struct {
+	jwksURL  string
+	issuer   string
+	clientID string
+	client   *http.Client
+	now      func() time.Time
+	refresh  time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey // by key ID
+	fetchedAt time.Time
+}
+
+var _ TokenVerifier = (*CognitoVerifier)(nil)
+
+func NewCognitoVerifier(cfg config.Cognito, client *http.Client) *CognitoVerifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &CognitoVerifier{
+		jwksURL:  cfg.JWKSEndpoint(),
+		issuer:   cfg.Issuer(),
+		clientID: cfg.ClientID,
+		client:   client,
+		now:      time.Now,
+		refresh:  time.Minute,
+	}
+}
+
+func (v *CognitoVerifier) Verify(ctx context.Context, token string) (*CognitoClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errTokenMalformed
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errTokenMalformed
+	}
+	if header.Alg != "RS256" {
+		return nil, errTokenSignature
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errTokenSignature
+	}
+
+	var claims CognitoClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errTokenMalformed
+	}
+	if claims.Exp == 0 || !v.now().Before(time.Unix(claims.Exp, 0)) {
+		return nil, errTokenExpired
+	}
+	if v.issuer != "" && claims.Iss != v.issuer {
+		return nil, errTokenClaims
+	}
+	switch claims.TokenUse {
+	case "access":
+		if v.clientID != "" && claims.ClientID != v.clientID {
+			return nil, errTokenClaims
+		}
+	case "id":
+		if v.clientID != "" && claims.Aud != v.clientID {
+			return nil, errTokenClaims
+		}
+	default:
+		return nil, errTokenClaims
+	}
+	if claims.Sub == "" {
+		return nil, errTokenClaims
+	}
+	return &claims, nil
+}
+
+// key returns the signing key kid, fetching the key set if it is unknown
+func (v *CognitoVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if v.keys != nil && v.now().Sub(v.fetchedAt) < v.refresh {
+		return nil, errTokenSignature
+	}
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+	v.keys, v.fetchedAt = keys, v.now()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errTokenSignature
+}
+
+func (v *CognitoVerifier) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	if v.jwksURL == "" {
+		return nil, fmt.Errorf("jwks url is not configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks returned status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("jwks decode: %w", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+var testTokenNow = time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+var testCognito = config.Cognito{UserPoolID: "ap-southeast-2_pool", Region: "ap-southeast-2", ClientID: "app-1"}
+
+// testSigner signs tokens with a key served from a JWKS endpoint
+type testSigner struct {
+	key     *rsa.PrivateKey
+	kid     string
+	jwks    *httptest.Server
+	fetches atomic.Int32
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	s := &testSigner{key: key, kid: "key-1"}
+	s.jwks = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": s.kid,
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(s.jwks.Close)
+	return s
+}
+
+func (s *testSigner) verifier() *CognitoVerifier {
+	cfg := testCognito
+	cfg.JWKSURL = s.jwks.URL
+	v := NewCognitoVerifier(cfg, s.jwks.Client())
+	v.now = func() time.Time { return testTokenNow }
+	return v
+}
+
+func (s *testSigner) sign(t *testing.T, claims CognitoClaims) string {
+	return s.signWith(t, s.key, s.kid, claims)
+}
+
+func (s *testSigner) signWith(t *testing.T, key *rsa.PrivateKey, kid string, claims CognitoClaims) string {
+	t.Helper()
+	segment := func(v any) string {
+		raw, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	signed := segment(map[string]string{"alg": "RS256", "kid": kid}) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// testClaims are those of a valid access token, changed by each of edits
+func testClaims(edits ...func(*CognitoClaims)) CognitoClaims {
+	c := CognitoClaims{
+		Sub:           "sub-1",
+		Username:      "ed",
+		CognitoGroups: []string{"editors", "publishers"},
+		TokenUse:      "access",
+		Iss:           testCognito.Issuer(),
+		ClientID:      testCognito.ClientID,
+		Exp:           testTokenNow.Add(time.Hour).Unix(),
+	}
+	for _, edit := range edits {
+		edit(&c)
+	}
+	return c
+}
+
+func TestCognitoVerifier_Verify(t *testing.T) {
+	signer := newTestSigner(t)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "access token", token: signer.sign(t, testClaims())},
+		{name: "id token", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.TokenUse, c.ClientID, c.Aud = "id", "", "app-1" }))},
+		{name: "expired", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.Exp = testTokenNow.Unix() })), wantErr: errTokenExpired},
+		{name: "other issuer", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.Iss = "https://example.com" })), wantErr: errTokenClaims},
+		{name: "other client", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.ClientID = "app-2" })), wantErr: errTokenClaims},
+		{name: "id token for another client", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.TokenUse, c.Aud = "id", "app-2" })), wantErr: errTokenClaims},
+		{name: "unknown use", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.TokenUse = "refresh" })), wantErr: errTokenClaims},
+		{name: "no subject", token: signer.sign(t, testClaims(func(c *CognitoClaims) { c.Sub = "" })), wantErr: errTokenClaims},
+		{name: "signed by another key", token: signer.signWith(t, other, signer.kid, testClaims()), wantErr: errTokenSignature},
+		{name: "unknown key", token: signer.signWith(t, other, "key-2", testClaims()), wantErr: errTokenSignature},
+		{name: "not a JWT", token: "valid.token.here", wantErr: errTokenMalformed},
+		{name: "two segments", token: "a.b", wantErr: errTokenMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := signer.verifier().Verify(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "sub-1", claims.Sub)
+			require.Equal(t, []string{"editors", "publishers"}, claims.CognitoGroups)
+		})
+	}
+
+	t.Run("tampered claims", func(t *testing.T) {
+		parts := strings.Split(signer.sign(t, testClaims()), ".")
+		forged := signer.signWith(t, signer.key, signer.kid, testClaims(func(c *CognitoClaims) { c.CognitoGroups = []string{"admins"} }))
+		parts[1] = strings.Split(forged, ".")[1]
+		_, err := signer.verifier().Verify(context.Background(), strings.Join(parts, "."))
+		require.ErrorIs(t, err, errTokenSignature)
+	})
+
+	t.Run("keys are fetched once, and again for an unknown key only after the refresh interval", func(t *testing.T) {
+		v := signer.verifier()
+		before := signer.fetches.Load()
+		for range 3 {
+			_, err := v.Verify(context.Background(), signer.sign(t, testClaims()))
+			require.NoError(t, err)
+		}
+		unknown := signer.signWith(t, other, "key-2", testClaims())
+		_, err := v.Verify(context.Background(), unknown)
+		require.ErrorIs(t, err, errTokenSignature)
+		require.Equal(t, before+1, signer.fetches.Load())
+
+		v.now = func() time.Time { return testTokenNow.Add(2 * time.Minute) }
+		_, err = v.Verify(context.Background(), unknown)
+		require.ErrorIs(t, err, errTokenSignature)
+		require.Equal(t, before+2, signer.fetches.Load())
+	})
+
+	t.Run("keys cannot be fetched", func(t *testing.T) {
+		v := NewCognitoVerifier(config.Cognito{}, nil)
+		_, err := v.Verify(context.Background(), signer.sign(t, testClaims()))
+		require.EqualError(t, err, "jwks: jwks url is not configured")
+	})
+}
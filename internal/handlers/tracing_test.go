@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	sr := tracetest.NewSpanRecorder()
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	router := gin.New()
+	router.Use(TracingMiddleware())
+	router.GET("/v1/shows", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/v1/shows", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	t.Run("continues incoming trace", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/shows", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		spans := sr.Ended()
+		require.NotEmpty(t, spans)
+		span := spans[len(spans)-1]
+		require.Equal(t, "GET /v1/shows", span.Name())
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	})
+
+	t.Run("server errors mark span", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/v1/shows", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		spans := sr.Ended()
+		span := spans[len(spans)-1]
+		require.Equal(t, "POST /v1/shows", span.Name())
+		require.Equal(t, codes.Error, span.Status().Code)
+	})
+}
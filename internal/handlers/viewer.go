@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/config"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+)
+
+// viewerKey holds the viewer resolved by ViewerMiddleware
+const viewerKey = "viewer"
+
+// localActor stands in for the user when authentication is off
+const localActor = "local"
+
+type viewerContext struct {
+	country string
+	admin   bool // in the admin group
+	actor   domain.Actor
+}
+
+// ViewerMiddleware resolves who a request is for: the country named by the
+// configured header, e.g. CloudFront-Viewer-Country, the user's workflow
+// roles, and whether they are in the admin group. It must run after
+// AuthMiddleware, which takes the groups from the token's cognito:groups.
+// Locally, where authentication is off, every caller is an admin and a
+// publisher.
+func ViewerMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var v viewerContext
+		if cfg.Availability.CountryHeader != "" {
+			if code, ok := normalize.Country(c.GetHeader(cfg.Availability.CountryHeader)); ok {
+				v.country = code
+			}
+		}
+		if cfg.Env == config.EnvLocal {
+			v.admin = true
+			v.actor = domain.Actor{ID: localActor, Roles: []domain.Role{domain.RoleEditor, domain.RolePublisher}}
+		} else if user, err := GetUserFromContext(c); err == nil {
+			v.actor.ID = user.Username
+			if v.actor.ID == "" {
+				v.actor.ID = user.UserID
+			}
+			if slices.Contains(user.Groups, cfg.Workflow.EditorGroup) {
+				v.actor.Roles = append(v.actor.Roles, domain.RoleEditor)
+			}
+			if slices.Contains(user.Groups, cfg.Workflow.PublisherGroup) {
+				v.actor.Roles = append(v.actor.Roles, domain.RolePublisher)
+			}
+			v.admin = slices.Contains(user.Groups, cfg.Availability.AdminGroup)
+		}
+		c.Set(viewerKey, v)
+		c.Next()
+	}
+}
+
+// viewerOf returns what ViewerMiddleware resolved, or the zero value
+// without it: no country, no roles
+func viewerOf(c *gin.Context) viewerContext {
+	value, _ := c.Get(viewerKey)
+	v, _ := value.(viewerContext)
+	return v
+}
+
+// requestViewer builds the viewer for a read from the middleware's findings,
+// the client's locales and ?hidden=. Asking for hidden shows without being
+// an admin or editorial staff is forbidden.
+func requestViewer(c *gin.Context) (domain.Viewer, bool) {
+	v := viewerOf(c)
+	out := domain.Viewer{Locales: requestLocales(c), Country: v.country}
+
+	if raw := c.Query("hidden"); raw != "" {
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			_ = c.Error(apperror.Validation(validation.Errors{
+				"hidden": validation.NewError("hidden_invalid", "hidden must be true or false"),
+			}))
+			return domain.Viewer{}, false
+		}
+		if include && !v.admin && !v.actor.Can(domain.RoleEditor) {
+			_ = c.Error(apperror.Forbidden("listing hidden shows requires the admin or an editorial group"))
+			return domain.Viewer{}, false
+		}
+		out.IncludeHidden = include
+	}
+	return out, true
+}
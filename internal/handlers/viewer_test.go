@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/config"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/normalize"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+)
+
+// serveAsViewer runs GetShows behind ViewerMiddleware, with user standing in
+// for what AuthMiddleware would have set
+func serveAsViewer(cfg *config.Config, user *UserContext, h ShowHandler, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.Use(func(c *gin.Context) {
+		if user != nil {
+			c.Set("user", user)
+		}
+	})
+	r.Use(ViewerMiddleware(cfg))
+	r.GET("/v1/shows", h.GetShows)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestViewerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dev := &config.Config{
+		Env:          config.EnvDev,
+		Availability: config.Availability{CountryHeader: "CloudFront-Viewer-Country", AdminGroup: "admins"},
+		Workflow:     config.Workflow{EditorGroup: "editors", PublisherGroup: "publishers"},
+	}
+	admin := &UserContext{UserID: "u1", Groups: []string{"users", "admins"}}
+	editor := &UserContext{UserID: "u3", Username: "ed", Groups: []string{"editors"}}
+	user := &UserContext{UserID: "u2", Groups: []string{"users"}}
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		user       *UserContext
+		query      string
+		country    string
+		wantViewer *domain.Viewer
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "country from header",
+			cfg:        dev,
+			user:       user,
+			country:    "au",
+			wantViewer: &domain.Viewer{Country: "AU"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unrecognised country is unknown",
+			cfg:        dev,
+			user:       user,
+			country:    "XX",
+			wantViewer: &domain.Viewer{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "admin includes hidden shows",
+			cfg:        dev,
+			user:       admin,
+			query:      "?hidden=true",
+			wantViewer: &domain.Viewer{IncludeHidden: true},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "editors include hidden shows",
+			cfg:        dev,
+			user:       editor,
+			query:      "?hidden=true",
+			wantViewer: &domain.Viewer{IncludeHidden: true},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "non-admin asking for hidden shows",
+			cfg:        dev,
+			user:       user,
+			query:      "?hidden=true",
+			wantStatus: http.StatusForbidden,
+			wantCode:   "forbidden",
+		},
+		{
+			name:       "non-admin opting out",
+			cfg:        dev,
+			user:       user,
+			query:      "?hidden=false",
+			wantViewer: &domain.Viewer{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "malformed hidden",
+			cfg:        dev,
+			user:       admin,
+			query:      "?hidden=maybe",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "validation_failed",
+		},
+		{
+			name:       "local callers are admins",
+			cfg:        &config.Config{Env: config.EnvLocal, Availability: dev.Availability, Workflow: dev.Workflow},
+			query:      "?hidden=1",
+			wantViewer: &domain.Viewer{IncludeHidden: true},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockShowService(t)
+			if tt.wantViewer != nil {
+				mockSvc.EXPECT().List(mock.Anything, *tt.wantViewer).Return(&domain.Response{Response: []domain.ShowResponse{}}, nil)
+			}
+			h := NewShowHandler(mockSvc, normalize.New(normalize.Options{}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/shows"+tt.query, nil)
+			if tt.country != "" {
+				req.Header.Set("CloudFront-Viewer-Country", tt.country)
+			}
+			w := serveAsViewer(tt.cfg, tt.user, h, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.wantCode)
+		})
+	}
+}
+
+func TestViewerMiddleware_Actor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dev := &config.Config{Env: config.EnvDev, Workflow: config.Workflow{EditorGroup: "editors", PublisherGroup: "publishers"}}
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		user *UserContext
+		want domain.Actor
+	}{
+		{
+			name: "editor",
+			cfg:  dev,
+			user: &UserContext{UserID: "u1", Username: "ed", Groups: []string{"editors"}},
+			want: domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}},
+		},
+		{
+			name: "publisher without a username",
+			cfg:  dev,
+			user: &UserContext{UserID: "u2", Groups: []string{"editors", "publishers"}},
+			want: domain.Actor{ID: "u2", Roles: []domain.Role{domain.RoleEditor, domain.RolePublisher}},
+		},
+		{
+			name: "no workflow groups",
+			cfg:  dev,
+			user: &UserContext{UserID: "u3", Username: "viewer", Groups: []string{"users"}},
+			want: domain.Actor{ID: "viewer"},
+		},
+		{
+			name: "local",
+			cfg:  &config.Config{Env: config.EnvLocal},
+			want: domain.Actor{ID: "local", Roles: []domain.Role{domain.RoleEditor, domain.RolePublisher}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got domain.Actor
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+			})
+			r.Use(ViewerMiddleware(tt.cfg))
+			r.GET("/", func(c *gin.Context) { got = viewerOf(c).actor })
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/service"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 100
+)
+
+// WebhookHandler manages webhooks and their deliveries. Secrets are
+// written, never read back, and every endpoint is for the admin group only.
+type WebhookHandler interface {
+	PostWebhook(c *gin.Context)
+	GetWebhooks(c *gin.Context)
+	GetWebhook(c *gin.Context)
+	PutWebhook(c *gin.Context)
+	DeleteWebhook(c *gin.Context)
+	GetWebhookDeliveries(c *gin.Context)
+	PostWebhookRedeliver(c *gin.Context)
+}
+
+type WebhookHTTPHandler struct {
+	svc service.WebhookService
+}
+
+func NewWebhookHandler(s service.WebhookService) WebhookHandler {
+	return &WebhookHTTPHandler{svc: s}
+}
+
+func (h *WebhookHTTPHandler) PostWebhook(c *gin.Context) {
+	if !webhookAdmin(c) {
+		return
+	}
+
+	var webhook domain.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+	// The ID is assigned on creation
+	webhook.ID = ""
+	if err := webhook.Validate(); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	created, err := h.svc.Create(c.Request.Context(), webhook)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	auditSlugs(c, created.ID)
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Webhook created successfully", "id": created.ID})
+}
+
+func (h *WebhookHTTPHandler) GetWebhooks(c *gin.Context) {
+	if !webhookAdmin(c) {
+		return
+	}
+
+	response, err := h.svc.List(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhookHTTPHandler) GetWebhook(c *gin.Context) {
+	id, ok := webhookIDParam(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.svc.Get(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// PutWebhook replaces an existing webhook, secret included. The ID comes
+// from the route; a conflicting body value is reported rather than silently
+// replaced.
+func (h *WebhookHTTPHandler) PutWebhook(c *gin.Context) {
+	id, ok := webhookIDParam(c)
+	if !ok {
+		return
+	}
+
+	var webhook domain.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		_ = c.Error(apperror.InvalidJSON(err))
+		return
+	}
+
+	var mismatch error
+	if webhook.ID != "" && webhook.ID != id {
+		mismatch = validation.Errors{
+			"id": validation.NewError("webhook_id_mismatch", "id must match the webhook in the URL"),
+		}
+	}
+	webhook.ID = id
+	if err := errors.Join(mismatch, webhook.Validate()); err != nil {
+		_ = c.Error(apperror.Validation(err))
+		return
+	}
+
+	if err := h.svc.Update(c.Request.Context(), webhook); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated successfully", "id": webhook.ID})
+}
+
+func (h *WebhookHTTPHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := webhookIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries lists a webhook's deliveries newest first, at most
+// ?limit= of them
+func (h *WebhookHTTPHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, ok := webhookIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultDeliveriesLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxDeliveriesLimit {
+			_ = c.Error(apperror.Validation(validation.Errors{
+				"limit": validation.NewError("limit_invalid", fmt.Sprintf("limit must be an integer between 1 and %d", maxDeliveriesLimit)),
+			}))
+			return
+		}
+		limit = n
+	}
+
+	response, err := h.svc.Deliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PostWebhookRedeliver queues a new delivery of a logged delivery's payload.
+// It is sent like any other, so the response only acknowledges it.
+func (h *WebhookHTTPHandler) PostWebhookRedeliver(c *gin.Context) {
+	id, ok := webhookIDParam(c)
+	if !ok {
+		return
+	}
+	deliveryID := c.Param("delivery")
+	if !domain.MatchDeliveryID.MatchString(deliveryID) {
+		_ = c.Error(apperror.NotFound("delivery " + deliveryID + " not found"))
+		return
+	}
+
+	delivery, err := h.svc.Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// webhookAdmin reports whether the viewer is in the admin group, failing
+// the request if not
+func webhookAdmin(c *gin.Context) bool {
+	if !viewerOf(c).admin {
+		_ = c.Error(apperror.Forbidden("managing webhooks requires the admin group"))
+		return false
+	}
+	return true
+}
+
+// webhookIDParam checks the viewer is an admin and reads the :id route
+// parameter. An ID no webhook could have is reported as not found.
+func webhookIDParam(c *gin.Context) (string, bool) {
+	if !webhookAdmin(c) {
+		return "", false
+	}
+	id := c.Param("id")
+	if !domain.MatchWebhookID.MatchString(id) {
+		_ = c.Error(apperror.NotFound("webhook " + id + " not found"))
+		return "", false
+	}
+	auditSlugs(c, id)
+	return id, true
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	serviceMocks "github.com/marciomarinho/show-service/internal/service/mocks"
+)
+
+const (
+	webhookID  = "0123456789abcdef0123456789abcdef"
+	deliveryID = "20240615T010000Z-0123456789abcdef"
+)
+
+// serveWebhook serves req through h with the viewer an admin or not
+func serveWebhook(admin bool, pattern string, h gin.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(ErrorMiddleware(0))
+	r.Use(func(c *gin.Context) { c.Set(viewerKey, viewerContext{admin: admin}) })
+	r.Handle(req.Method, pattern, h)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookHTTPHandler_PostWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	valid := `{"url": "https://partner.example.com/hooks", "events": ["show.published"], "secret": "0123456789abcdef"}`
+	tests := []struct {
+		name           string
+		body           string
+		admin          bool
+		mockSetup      func(*serviceMocks.MockWebhookService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "created",
+			body:  valid,
+			admin: true,
+			mockSetup: func(m *serviceMocks.MockWebhookService) {
+				m.EXPECT().Create(mock.Anything, domain.Webhook{
+					URL:    "https://partner.example.com/hooks",
+					Events: []string{domain.WebhookShowPublished},
+					Secret: "0123456789abcdef",
+				}).Return(&domain.Webhook{ID: webhookID}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "not an admin",
+			body:           valid,
+			mockSetup:      func(m *serviceMocks.MockWebhookService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "forbidden",
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"url": 1}`,
+			admin:          true,
+			mockSetup:      func(m *serviceMocks.MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_json",
+		},
+		{
+			name:           "validation error",
+			body:           `{"url": "https://partner.example.com/hooks", "events": ["show.renamed"], "secret": "short"}`,
+			admin:          true,
+			mockSetup:      func(m *serviceMocks.MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockWebhookService(t)
+			tt.mockSetup(mockSvc)
+			h := NewWebhookHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := serveWebhook(tt.admin, "/v1/webhooks", h.PostWebhook, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+			if tt.expectedStatus == http.StatusCreated {
+				require.JSONEq(t, `{"message":"Webhook created successfully","id":"`+webhookID+`"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookHTTPHandler_GetWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("found", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockWebhookService(t)
+		mockSvc.EXPECT().Get(mock.Anything, webhookID).Return(&domain.Webhook{ID: webhookID, URL: "https://partner.example.com/hooks"}, nil)
+		h := NewWebhookHandler(mockSvc)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/"+webhookID, nil)
+		w := serveWebhook(true, "/v1/webhooks/:id", h.GetWebhook, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		h := NewWebhookHandler(serviceMocks.NewMockWebhookService(t))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/nope", nil)
+		w := serveWebhook(true, "/v1/webhooks/:id", h.GetWebhook, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		h := NewWebhookHandler(serviceMocks.NewMockWebhookService(t))
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/"+webhookID, nil)
+		w := serveWebhook(false, "/v1/webhooks/:id", h.GetWebhook, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestWebhookHTTPHandler_GetWebhooks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockWebhookService(t)
+	mockSvc.EXPECT().List(mock.Anything).Return(&domain.WebhooksResponse{Response: []domain.Webhook{{ID: webhookID}}}, nil)
+	h := NewWebhookHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	w := serveWebhook(true, "/v1/webhooks", h.GetWebhooks, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body domain.WebhooksResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Response, 1)
+}
+
+func TestWebhookHTTPHandler_PutWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("id taken from the route", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockWebhookService(t)
+		mockSvc.EXPECT().Update(mock.Anything, domain.Webhook{
+			ID:     webhookID,
+			URL:    "https://partner.example.com/v2",
+			Events: []string{domain.WebhookShowCreated, domain.WebhookShowDeleted},
+			Secret: "0123456789abcdef",
+		}).Return(nil)
+		h := NewWebhookHandler(mockSvc)
+
+		body := `{"url": "https://partner.example.com/v2", "events": ["show.created", "show.deleted"], "secret": "0123456789abcdef"}`
+		req := httptest.NewRequest(http.MethodPut, "/v1/webhooks/"+webhookID, strings.NewReader(body))
+		w := serveWebhook(true, "/v1/webhooks/:id", h.PutWebhook, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("id mismatch", func(t *testing.T) {
+		h := NewWebhookHandler(serviceMocks.NewMockWebhookService(t))
+
+		body := `{"id": "fedcba9876543210fedcba9876543210", "url": "https://partner.example.com/v2", "events": ["show.created"], "secret": "0123456789abcdef"}`
+		req := httptest.NewRequest(http.MethodPut, "/v1/webhooks/"+webhookID, strings.NewReader(body))
+		w := serveWebhook(true, "/v1/webhooks/:id", h.PutWebhook, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "webhook_id_mismatch")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockWebhookService(t)
+		mockSvc.EXPECT().Update(mock.Anything, mock.Anything).Return(apperror.ErrNotFound)
+		h := NewWebhookHandler(mockSvc)
+
+		body := `{"url": "https://partner.example.com/v2", "events": ["show.created"], "secret": "0123456789abcdef"}`
+		req := httptest.NewRequest(http.MethodPut, "/v1/webhooks/"+webhookID, strings.NewReader(body))
+		w := serveWebhook(true, "/v1/webhooks/:id", h.PutWebhook, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestWebhookHTTPHandler_DeleteWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSvc := serviceMocks.NewMockWebhookService(t)
+	mockSvc.EXPECT().Delete(mock.Anything, webhookID).Return(nil)
+	h := NewWebhookHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/webhooks/"+webhookID, nil)
+	w := serveWebhook(true, "/v1/webhooks/:id", h.DeleteWebhook, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestWebhookHTTPHandler_GetWebhookDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*serviceMocks.MockWebhookService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "default limit",
+			mockSetup: func(m *serviceMocks.MockWebhookService) {
+				m.EXPECT().Deliveries(mock.Anything, webhookID, 50).Return(&domain.WebhookDeliveriesResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "given limit",
+			query: "?limit=5",
+			mockSetup: func(m *serviceMocks.MockWebhookService) {
+				m.EXPECT().Deliveries(mock.Anything, webhookID, 5).Return(&domain.WebhookDeliveriesResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "limit out of range",
+			query:          "?limit=500",
+			mockSetup:      func(m *serviceMocks.MockWebhookService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name: "missing webhook",
+			mockSetup: func(m *serviceMocks.MockWebhookService) {
+				m.EXPECT().Deliveries(mock.Anything, webhookID, 50).Return(nil, apperror.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := serviceMocks.NewMockWebhookService(t)
+			tt.mockSetup(mockSvc)
+			h := NewWebhookHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/webhooks/"+webhookID+"/deliveries"+tt.query, nil)
+			w := serveWebhook(true, "/v1/webhooks/:id/deliveries", h.GetWebhookDeliveries, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assertProblemCode(t, w, tt.expectedCode)
+		})
+	}
+}
+
+func TestWebhookHTTPHandler_PostWebhookRedeliver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("queued", func(t *testing.T) {
+		mockSvc := serviceMocks.NewMockWebhookService(t)
+		mockSvc.EXPECT().Redeliver(mock.Anything, webhookID, deliveryID).
+			Return(&domain.WebhookDelivery{ID: "20240615T020000Z-fedcba9876543210", WebhookID: webhookID, RedeliveryOf: deliveryID}, nil)
+		h := NewWebhookHandler(mockSvc)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/"+webhookID+"/deliveries/"+deliveryID+"/redeliver", nil)
+		w := serveWebhook(true, "/v1/webhooks/:id/deliveries/:delivery/redeliver", h.PostWebhookRedeliver, req)
+
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var got domain.WebhookDelivery
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		require.Equal(t, deliveryID, got.RedeliveryOf)
+	})
+
+	t.Run("invalid delivery id", func(t *testing.T) {
+		h := NewWebhookHandler(serviceMocks.NewMockWebhookService(t))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/"+webhookID+"/deliveries/latest/redeliver", nil)
+		w := serveWebhook(true, "/v1/webhooks/:id/deliveries/:delivery/redeliver", h.PostWebhookRedeliver, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/database"
+)
+
+// DynamoChecker verifies the shows table and its GSIs are ACTIVE
+type DynamoChecker struct {
+	db      database.DynamoAPI
+	indexes []string
+}
+
+var _ Checker = (*DynamoChecker)(nil)
+
+func NewDynamoChecker(db database.DynamoAPI, indexes ...string) *DynamoChecker {
+	return &DynamoChecker{db: db, indexes: indexes}
+}
+
+func (d *DynamoChecker) Name() string {
+	return "dynamodb"
+}
+
+func (d *DynamoChecker) Check(ctx context.Context) error {
+	out, err := d.db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(d.db.TableName()),
+	})
+	if err != nil {
+		return err
+	}
+	if out == nil || out.Table == nil {
+		return fmt.Errorf("table %s: empty description", d.db.TableName())
+	}
+	if out.Table.TableStatus != types.TableStatusActive {
+		return fmt.Errorf("table %s is %s", d.db.TableName(), out.Table.TableStatus)
+	}
+
+	for _, want := range d.indexes {
+		var found *types.GlobalSecondaryIndexDescription
+		for i := range out.Table.GlobalSecondaryIndexes {
+			if aws.ToString(out.Table.GlobalSecondaryIndexes[i].IndexName) == want {
+				found = &out.Table.GlobalSecondaryIndexes[i]
+				break
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("index %s is missing", want)
+		}
+		if found.IndexStatus != types.IndexStatusActive {
+			return fmt.Errorf("index %s is %s", want, found.IndexStatus)
+		}
+	}
+	return nil
+}
+
+// JWKSChecker verifies the token signing keys can be fetched and parsed
+type JWKSChecker struct {
+	url    string
+	client *http.Client
+}
+
+var _ Checker = (*JWKSChecker)(nil)
+
+func NewJWKSChecker(url string, client *http.Client) *JWKSChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &JWKSChecker{url: url, client: client}
+}
+
+func (j *JWKSChecker) Name() string {
+	return "jwks"
+}
+
+func (j *JWKSChecker) Check(ctx context.Context) error {
+	if j.url == "" {
+		return fmt.Errorf("jwks url is not configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks returned status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("jwks decode: %w", err)
+	}
+	if len(set.Keys) == 0 {
+		return fmt.Errorf("jwks has no keys")
+	}
+	return nil
+}
+
+// IndexChecker reports whether the in-memory indexes were built from the
+// table. Until they are, each check tries to build them again, so an
+// instance whose startup build failed stays out of rotation rather than
+// answering searches from empty indexes.
+type IndexChecker struct {
+	build func(ctx context.Context) (int, error)
+	built atomic.Bool
+}
+
+var _ Checker = (*IndexChecker)(nil)
+
+// NewIndexChecker takes the function that builds the indexes and whether it
+// has already succeeded
+func NewIndexChecker(build func(ctx context.Context) (int, error), built bool) *IndexChecker {
+	c := &IndexChecker{build: build}
+	c.built.Store(built)
+	return c
+}
+
+func (i *IndexChecker) Name() string {
+	return "indexes"
+}
+
+func (i *IndexChecker) Check(ctx context.Context) error {
+	if i.built.Load() {
+		return nil
+	}
+	if _, err := i.build(ctx); err != nil {
+		return err
+	}
+	i.built.Store(true)
+	return nil
+}
@@ -0,0 +1,165 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+)
+
+func TestDynamoChecker_Check(t *testing.T) {
+	activeIndex := types.GlobalSecondaryIndexDescription{
+		IndexName:   aws.String("gsi_drm_episode"),
+		IndexStatus: types.IndexStatusActive,
+	}
+
+	tests := []struct {
+		name        string
+		output      *dynamodb.DescribeTableOutput
+		err         error
+		errContains string
+	}{
+		{
+			name: "table and index active",
+			output: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+				TableStatus:            types.TableStatusActive,
+				GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{activeIndex},
+			}},
+		},
+		{
+			name:        "describe fails",
+			err:         errors.New("ResourceNotFoundException"),
+			errContains: "ResourceNotFoundException",
+		},
+		{
+			name: "table still creating",
+			output: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+				TableStatus: types.TableStatusCreating,
+			}},
+			errContains: "is CREATING",
+		},
+		{
+			name: "index missing",
+			output: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+				TableStatus: types.TableStatusActive,
+			}},
+			errContains: "index gsi_drm_episode is missing",
+		},
+		{
+			name: "index backfilling",
+			output: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{
+				TableStatus: types.TableStatusActive,
+				GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{
+					IndexName:   aws.String("gsi_drm_episode"),
+					IndexStatus: types.IndexStatusCreating,
+				}},
+			}},
+			errContains: "index gsi_drm_episode is CREATING",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := dynamoMocks.NewMockDynamoAPI(t)
+			mockDB.On("TableName").Return("shows-test").Maybe()
+			mockDB.On("DescribeTable", mock.Anything, mock.AnythingOfType("*dynamodb.DescribeTableInput")).
+				Return(tt.output, tt.err)
+
+			err := NewDynamoChecker(mockDB, "gsi_drm_episode").Check(context.Background())
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJWKSChecker_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		errContains string
+	}{
+		{
+			name:   "keys available",
+			status: http.StatusOK,
+			body:   `{"keys":[{"kid":"1","kty":"RSA"}]}`,
+		},
+		{
+			name:        "empty key set",
+			status:      http.StatusOK,
+			body:        `{"keys":[]}`,
+			errContains: "no keys",
+		},
+		{
+			name:        "upstream error",
+			status:      http.StatusInternalServerError,
+			body:        ``,
+			errContains: "status 500",
+		},
+		{
+			name:        "malformed body",
+			status:      http.StatusOK,
+			body:        `not json`,
+			errContains: "jwks decode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := NewJWKSChecker(srv.URL, srv.Client()).Check(context.Background())
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("missing url", func(t *testing.T) {
+		err := NewJWKSChecker("", nil).Check(context.Background())
+		require.ErrorContains(t, err, "not configured")
+	})
+}
+
+func TestIndexChecker_Check(t *testing.T) {
+	t.Run("built on start", func(t *testing.T) {
+		c := NewIndexChecker(func(context.Context) (int, error) {
+			t.Fatal("indexes rebuilt")
+			return 0, nil
+		}, true)
+		require.NoError(t, c.Check(context.Background()))
+	})
+
+	t.Run("failed start is retried until a build succeeds", func(t *testing.T) {
+		builds := 0
+		c := NewIndexChecker(func(context.Context) (int, error) {
+			builds++
+			if builds == 1 {
+				return 0, errors.New("failed to rebuild search index: throttled")
+			}
+			return 3, nil
+		}, false)
+		require.EqualError(t, c.Check(context.Background()), "failed to rebuild search index: throttled")
+		require.NoError(t, c.Check(context.Background()))
+		require.NoError(t, c.Check(context.Background()))
+		require.Equal(t, 2, builds)
+	})
+}
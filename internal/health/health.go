@@ -0,0 +1,139 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker probes a single dependency. Check returns nil when it is usable.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// ComponentResult is the outcome of one Checker
+type ComponentResult struct {
+	Name      string `json:"name"`
+	Status    Status `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report aggregates every component; Status is down if any component is down
+type Report struct {
+	Status     Status            `json:"status"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+	Components []ComponentResult `json:"components"`
+}
+
+// Prober runs the readiness checks and caches the report for ttl so probes
+// from load balancers and orchestrators can't hammer the dependencies
+type Prober struct {
+	checkers []Checker
+	ttl      time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	cached   *Report
+	cachedAt time.Time
+
+	draining atomic.Bool
+}
+
+func NewProber(ttl, timeout time.Duration, checkers ...Checker) *Prober {
+	return &Prober{
+		checkers: checkers,
+		ttl:      ttl,
+		timeout:  timeout,
+		now:      time.Now,
+	}
+}
+
+// SetDraining makes Ready report down without probing dependencies, so load
+// balancers stop routing new traffic while in-flight requests finish
+func (p *Prober) SetDraining(draining bool) {
+	p.draining.Store(draining)
+}
+
+// Ready returns the cached report when fresh, otherwise runs every checker
+// concurrently. Concurrent callers wait for a single in-flight run.
+func (p *Prober) Ready(ctx context.Context) Report {
+	if p.draining.Load() {
+		return Report{
+			Status:    StatusDown,
+			CheckedAt: p.now().UTC(),
+			Components: []ComponentResult{
+				{Name: "server", Status: StatusDown, Error: "shutting down"},
+			},
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached != nil && p.now().Sub(p.cachedAt) < p.ttl {
+		return *p.cached
+	}
+
+	report := p.run(ctx)
+	p.cached = &report
+	p.cachedAt = p.now()
+	return report
+}
+
+// run probes with a context detached from the caller's, because the report
+// is cached for every caller: one that gives up must not fail it for ttl.
+// The timeout still bounds the checks.
+func (p *Prober) run(ctx context.Context) Report {
+	ctx = context.WithoutCancel(ctx)
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	results := make([]ComponentResult, len(p.checkers))
+	var wg sync.WaitGroup
+	for i, c := range p.checkers {
+		wg.Add(1)
+		go func(i int, c Checker) {
+			defer wg.Done()
+			start := p.now()
+			err := c.Check(ctx)
+			res := ComponentResult{
+				Name:      c.Name(),
+				Status:    StatusUp,
+				LatencyMs: p.now().Sub(start).Milliseconds(),
+			}
+			if err != nil {
+				res.Status = StatusDown
+				res.Error = err.Error()
+			}
+			results[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:     StatusUp,
+		CheckedAt:  p.now().UTC(),
+		Components: results,
+	}
+	for _, r := range results {
+		if r.Status == StatusDown {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChecker struct {
+	name  string
+	err   error
+	hang  bool // wait for ctx to be done
+	calls atomic.Int32
+}
+
+func (f *fakeChecker) Name() string { return f.name }
+
+func (f *fakeChecker) Check(ctx context.Context) error {
+	f.calls.Add(1)
+	if f.hang {
+		<-ctx.Done()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.err
+}
+
+func TestProber_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		checkers       []*fakeChecker
+		expectedStatus Status
+	}{
+		{
+			name:           "all components up",
+			checkers:       []*fakeChecker{{name: "dynamodb"}, {name: "jwks"}},
+			expectedStatus: StatusUp,
+		},
+		{
+			name:           "one component down",
+			checkers:       []*fakeChecker{{name: "dynamodb", err: errors.New("table missing")}, {name: "jwks"}},
+			expectedStatus: StatusDown,
+		},
+		{
+			name:           "no components",
+			checkers:       nil,
+			expectedStatus: StatusUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checkers []Checker
+			for _, c := range tt.checkers {
+				checkers = append(checkers, c)
+			}
+
+			report := NewProber(time.Second, time.Second, checkers...).Ready(context.Background())
+
+			require.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Components, len(tt.checkers))
+			for i, c := range tt.checkers {
+				require.Equal(t, c.name, report.Components[i].Name)
+				if c.err != nil {
+					require.Equal(t, StatusDown, report.Components[i].Status)
+					require.Equal(t, c.err.Error(), report.Components[i].Error)
+				} else {
+					require.Equal(t, StatusUp, report.Components[i].Status)
+				}
+			}
+		})
+	}
+}
+
+func TestProber_Cache(t *testing.T) {
+	checker := &fakeChecker{name: "dynamodb"}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p := NewProber(2*time.Second, time.Second, checker)
+	p.now = func() time.Time { return now }
+
+	p.Ready(context.Background())
+	p.Ready(context.Background())
+	require.Equal(t, int32(1), checker.calls.Load(), "second call within ttl should be cached")
+
+	now = now.Add(3 * time.Second)
+	p.Ready(context.Background())
+	require.Equal(t, int32(2), checker.calls.Load(), "expired cache should re-run checks")
+}
+
+func TestProber_CallerContext(t *testing.T) {
+	t.Run("a caller giving up does not fail the cached report", func(t *testing.T) {
+		checker := &fakeChecker{name: "dynamodb"}
+		p := NewProber(time.Minute, time.Second, checker)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.Equal(t, StatusUp, p.Ready(ctx).Status)
+		require.Equal(t, StatusUp, p.Ready(context.Background()).Status)
+		require.Equal(t, int32(1), checker.calls.Load())
+	})
+
+	t.Run("checks are bounded by the timeout", func(t *testing.T) {
+		p := NewProber(time.Minute, 10*time.Millisecond, &fakeChecker{name: "dynamodb", hang: true})
+
+		report := p.Ready(context.Background())
+		require.Equal(t, StatusDown, report.Status)
+		require.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].Error)
+	})
+}
+
+func TestProber_Draining(t *testing.T) {
+	checker := &fakeChecker{name: "dynamodb"}
+	p := NewProber(time.Minute, time.Second, checker)
+
+	require.Equal(t, StatusUp, p.Ready(context.Background()).Status)
+
+	p.SetDraining(true)
+	report := p.Ready(context.Background())
+	require.Equal(t, StatusDown, report.Status)
+	require.Equal(t, "server", report.Components[0].Name)
+	require.Equal(t, int32(1), checker.calls.Load(), "draining must not probe dependencies")
+}
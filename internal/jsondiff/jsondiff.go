@@ -0,0 +1,95 @@
+// Package jsondiff computes the difference between two JSON documents as an
+// RFC 6902 JSON Patch.
+package jsondiff
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Operations produced by Diff
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Op is one JSON Patch operation. Value is unset for removals.
+type Op struct {
+	Op    string `json:"op" dynamodbav:"op"`
+	Path  string `json:"path" dynamodbav:"path"`
+	Value any    `json:"value,omitempty" dynamodbav:"value"`
+}
+
+// Diff returns the operations that turn from into to, after marshaling both
+// with encoding/json. Object members are compared one by one, in key order;
+// arrays of equal length element by element, and otherwise replaced whole.
+func Diff(from, to any) ([]Op, error) {
+	a, err := document(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := document(to)
+	if err != nil {
+		return nil, err
+	}
+	ops := []Op{}
+	return diff("", a, b, ops), nil
+}
+
+// document round-trips v through JSON so that structs, maps and slices
+// compare as plain JSON values
+func document(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	err = json.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+func diff(path string, a, b any, ops []Op) []Op {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, key := range slices.Sorted(maps.Keys(a)) {
+			if _, kept := b[key]; !kept {
+				ops = append(ops, Op{Op: OpRemove, Path: path + "/" + escape(key)})
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(b)) {
+			if old, existed := a[key]; existed {
+				ops = diff(path+"/"+escape(key), old, b[key], ops)
+			} else {
+				ops = append(ops, Op{Op: OpAdd, Path: path + "/" + escape(key), Value: b[key]})
+			}
+		}
+		return ops
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			break
+		}
+		for i := range a {
+			ops = diff(path+"/"+strconv.Itoa(i), a[i], b[i], ops)
+		}
+		return ops
+	}
+	if !reflect.DeepEqual(a, b) {
+		ops = append(ops, Op{Op: OpReplace, Path: path, Value: b})
+	}
+	return ops
+}
+
+// escape encodes a member name as an RFC 6901 reference token
+func escape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
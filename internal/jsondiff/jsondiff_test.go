@@ -0,0 +1,87 @@
+package jsondiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to any
+		want     []Op
+	}{
+		{
+			name: "identical",
+			from: map[string]any{"title": "A", "drm": true},
+			to:   map[string]any{"drm": true, "title": "A"},
+			want: []Op{},
+		},
+		{
+			name: "members added, removed and replaced in key order",
+			from: map[string]any{"title": "A", "country": "AU", "drm": true},
+			to:   map[string]any{"title": "B", "drm": true, "language": "en"},
+			want: []Op{
+				{Op: OpRemove, Path: "/country"},
+				{Op: OpAdd, Path: "/language", Value: "en"},
+				{Op: OpReplace, Path: "/title", Value: "B"},
+			},
+		},
+		{
+			name: "nested objects",
+			from: map[string]any{"image": map[string]any{"showImage": "a.jpg"}},
+			to:   map[string]any{"image": map[string]any{"showImage": "b.jpg"}},
+			want: []Op{{Op: OpReplace, Path: "/image/showImage", Value: "b.jpg"}},
+		},
+		{
+			name: "arrays of equal length element by element",
+			from: map[string]any{"genres": []string{"drama", "comedy"}},
+			to:   map[string]any{"genres": []string{"drama", "reality"}},
+			want: []Op{{Op: OpReplace, Path: "/genres/1", Value: "reality"}},
+		},
+		{
+			name: "arrays of different length whole",
+			from: map[string]any{"genres": []string{"drama"}},
+			to:   map[string]any{"genres": []string{"drama", "reality"}},
+			want: []Op{{Op: OpReplace, Path: "/genres", Value: []any{"drama", "reality"}}},
+		},
+		{
+			name: "change of type",
+			from: map[string]any{"seasons": nil},
+			to:   map[string]any{"seasons": []string{}},
+			want: []Op{{Op: OpReplace, Path: "/seasons", Value: []any{}}},
+		},
+		{
+			name: "member names are escaped",
+			from: map[string]any{},
+			to:   map[string]any{"a/b~c": 1},
+			want: []Op{{Op: OpAdd, Path: "/a~1b~0c", Value: float64(1)}},
+		},
+		{
+			name: "structs through their JSON encoding",
+			from: struct {
+				Title string `json:"title"`
+				Drop  string `json:"-"`
+			}{Title: "A", Drop: "x"},
+			to: struct {
+				Title string `json:"title"`
+				Drop  string `json:"-"`
+			}{Title: "A", Drop: "y"},
+			want: []Op{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.from, tt.to)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiff_Unmarshalable(t *testing.T) {
+	_, err := Diff(map[string]any{"f": func() {}}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// Package locale picks between per-locale variants of a value. Variants are
+// keyed by canonical BCP 47 tags such as "en", "en-AU" or "zh-Hant"; clients
+// state their preferences with Accept-Language or an explicit tag.
+package locale
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MatchTag validates the canonical tags variants are stored under: a
+// lowercase language, then optionally a title-case script and an uppercase
+// or UN M.49 region
+var MatchTag = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+// ParseAcceptLanguage returns the tags in an Accept-Language header, most
+// preferred first. Entries with q=0, malformed weights and the "*" wildcard
+// are dropped; equal weights keep header order.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var entries []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		entries = append(entries, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	tags := make([]string, len(entries))
+	for i, e := range entries {
+		tags[i] = e.tag
+	}
+	return tags
+}
+
+// Match picks the variant for the first preference that has one, following
+// RFC 4647 lookup: "zh-Hant-TW" tries zh-Hant-TW, then zh-Hant, then zh.
+// Preferences are compared case-insensitively and may use '_' separators.
+func Match[V any](prefs []string, variants map[string]V) (string, bool) {
+	if len(variants) == 0 {
+		return "", false
+	}
+	folded := make(map[string]string, len(variants))
+	for tag := range variants {
+		folded[fold(tag)] = tag
+	}
+
+	for _, pref := range prefs {
+		for p := fold(pref); p != ""; p = truncate(p) {
+			if tag, ok := folded[p]; ok {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+func fold(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// truncate drops the last subtag, along with a single-letter extension
+// marker left dangling in front of it
+func truncate(tag string) string {
+	i := strings.LastIndexByte(tag, '-')
+	if i < 0 {
+		return ""
+	}
+	tag = tag[:i]
+	if j := strings.LastIndexByte(tag, '-'); j >= 0 && len(tag)-j == 2 {
+		tag = tag[:j]
+	}
+	return tag
+}
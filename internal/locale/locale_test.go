@@ -0,0 +1,63 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "fr-CA", want: []string{"fr-CA"}},
+		{header: "en;q=0.5, fr-CA, fr;q=0.9", want: []string{"fr-CA", "fr", "en"}},
+		{header: "de;q=0.8, es;q=0.8", want: []string{"de", "es"}},
+		{header: "*, en;q=0, ja;q=abc, it;q=0.1", want: []string{"it"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			require.Equal(t, tt.want, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	variants := map[string]string{"en": "The Taste", "fr-CA": "Le Goût", "zh-Hant": "品味"}
+
+	tests := []struct {
+		name   string
+		prefs  []string
+		want   string
+		wantOK bool
+	}{
+		{name: "exact", prefs: []string{"fr-CA"}, want: "fr-CA", wantOK: true},
+		{name: "case and separator", prefs: []string{"FR_ca"}, want: "fr-CA", wantOK: true},
+		{name: "truncated", prefs: []string{"en-AU"}, want: "en", wantOK: true},
+		{name: "script", prefs: []string{"zh-Hant-TW"}, want: "zh-Hant", wantOK: true},
+		{name: "extension", prefs: []string{"en-u-ca-gregory"}, want: "en", wantOK: true},
+		{name: "no broadening", prefs: []string{"fr"}, wantOK: false},
+		{name: "first preference wins", prefs: []string{"de", "zh-Hant", "en"}, want: "zh-Hant", wantOK: true},
+		{name: "none", prefs: nil, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Match(tt.prefs, variants)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchTag(t *testing.T) {
+	for _, tag := range []string{"en", "en-AU", "zh-Hant", "zh-Hant-TW", "es-419", "haw"} {
+		require.True(t, MatchTag.MatchString(tag), tag)
+	}
+	for _, tag := range []string{"EN", "en_AU", "en-au", "english", "zh-hant", ""} {
+		require.False(t, MatchTag.MatchString(tag), tag)
+	}
+}
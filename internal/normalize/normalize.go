@@ -0,0 +1,489 @@
+package normalize
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/genre"
+	"github.com/marciomarinho/show-service/internal/sanitize"
+)
+
+// Options tunes the normalizer
+type Options struct {
+	// RejectUnknown fails countries and languages missing from the embedded
+	// tables instead of passing them through trimmed
+	RejectUnknown bool
+
+	// HTML, when set, strips NextEpisode.HTML down to the allowlist
+	HTML *sanitize.Policy
+	// StrictHTML rejects items whose markup had to be stripped
+	StrictHTML bool
+	// PlainText stores a text rendering of NextEpisode.HTML in NextEpisode.Text
+	PlainText bool
+
+	// Location is the feed's time zone, applied to air dates without an
+	// offset. Nil means UTC.
+	Location *time.Location
+}
+
+// Change records one rewritten value so it can be reported to the client
+type Change struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Warning flags an accepted value the client probably did not intend
+type Warning struct {
+	Path    string `json:"path"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Result reports what normalization did
+type Result struct {
+	Changes  []Change
+	Warnings []Warning
+}
+
+// Normalizer cleans incoming shows before validation: it trims strings,
+// derives missing slugs from titles, collapses whitespace in display names,
+// canonicalizes colours, maps countries to ISO 3166 alpha-2 codes, languages
+// and locale keys to BCP 47 tags and genres to taxonomy codes, and sanitizes
+// episode HTML
+type Normalizer struct {
+	opts Options
+	now  func() time.Time
+}
+
+func New(opts Options) *Normalizer {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return &Normalizer{opts: opts, now: time.Now}
+}
+
+// Request normalizes every payload item in place. The error, when not nil,
+// is validation.Errors keyed like Request.Validate so both can be reported
+// together.
+func (n *Normalizer) Request(r *domain.Request) (Result, error) {
+	var res Result
+	rejected := validation.Errors{}
+	for i := range r.Payload {
+		idx := strconv.Itoa(i)
+		rec := &recorder{path: "/payload/" + idx}
+		if err := n.show(&r.Payload[i], rec); err != nil {
+			rejected[idx] = err
+		}
+		res.Changes = append(res.Changes, rec.changes...)
+		res.Warnings = append(res.Warnings, rec.warnings...)
+	}
+	if len(rejected) > 0 {
+		return res, validation.Errors{"payload": rejected}
+	}
+	return res, nil
+}
+
+// Show normalizes a single show in place
+func (n *Normalizer) Show(s *domain.Show) (Result, error) {
+	rec := &recorder{}
+	err := n.show(s, rec)
+	return Result{Changes: rec.changes, Warnings: rec.warnings}, err
+}
+
+func (n *Normalizer) show(s *domain.Show, rec *recorder) error {
+	rejected := validation.Errors{}
+
+	rec.set("/slug", &s.Slug, strings.TrimSpace(s.Slug))
+	rec.set("/title", &s.Title, collapse(s.Title))
+	if s.Slug == "" {
+		if slug := domain.SlugFromTitle(s.Title); slug != "" {
+			rec.set("/slug", &s.Slug, slug)
+			s.SlugGenerated = true
+		}
+	}
+	rec.setPtr("/description", s.Description, strings.TrimSpace)
+	n.localized(s, rec, rejected)
+	n.genres(s, rec)
+	rec.setPtr("/tvChannel", s.TVChannel, collapse)
+	rec.setPtr("/channelId", s.ChannelID, channelID)
+	rec.setPtr("/primaryColour", s.PrimaryColour, func(v string) string {
+		if c, ok := Colour(v); ok {
+			return c
+		}
+		return strings.TrimSpace(v)
+	})
+
+	if s.Country != nil {
+		if code, ok := Country(*s.Country); ok {
+			rec.set("/country", s.Country, code)
+		} else {
+			rec.set("/country", s.Country, collapse(*s.Country))
+			if n.opts.RejectUnknown && *s.Country != "" {
+				rejected["country"] = validation.NewError("country_unknown", "must be an ISO 3166 country code or name")
+			}
+		}
+	}
+	if s.Language != nil {
+		if tag, ok := Language(*s.Language); ok {
+			rec.set("/language", s.Language, tag)
+		} else {
+			rec.set("/language", s.Language, collapse(*s.Language))
+			if n.opts.RejectUnknown && *s.Language != "" {
+				rejected["language"] = validation.NewError("language_unknown", "must be a BCP 47 language tag or language name")
+			}
+		}
+	}
+
+	if s.Image != nil {
+		rec.set("/image/showImage", &s.Image.ShowImage, strings.TrimSpace(s.Image.ShowImage))
+	}
+	if ne := s.NextEpisode; ne != nil {
+		rec.setPtr("/nextEpisode/channel", ne.Channel, collapse)
+		rec.setPtr("/nextEpisode/channelId", ne.ChannelID, channelID)
+		rec.set("/nextEpisode/channelLogo", &ne.ChannelLogo, strings.TrimSpace(ne.ChannelLogo))
+		if ne.Date != nil {
+			if t, ok := n.airDate("/nextEpisode/date", ne.Date, rec); ok && t.Before(n.now()) {
+				rec.warn("/nextEpisode/date", "date_in_past", "next episode date is in the past")
+			}
+		}
+		rec.set("/nextEpisode/url", &ne.URL, strings.TrimSpace(ne.URL))
+
+		html := strings.TrimSpace(ne.HTML)
+		if n.opts.HTML != nil {
+			res := n.opts.HTML.Sanitize(html)
+			if res.Removed && n.opts.StrictHTML {
+				rejected["nextEpisode"] = validation.Errors{
+					"html": validation.NewError("html_disallowed", "contains markup outside the allowlist"),
+				}
+			}
+			html = res.HTML
+		}
+		rec.set("/nextEpisode/html", &ne.HTML, html)
+
+		if n.opts.PlainText {
+			text := sanitize.PlainText(ne.HTML)
+			ne.Text = &text
+		}
+	}
+	if a := s.Availability; a != nil {
+		if a.Start != nil {
+			n.airDate("/availability/start", a.Start, rec)
+		}
+		if a.End != nil {
+			n.airDate("/availability/end", a.End, rec)
+		}
+		countryCodes("/availability/countries", a.Countries, rec)
+		countryCodes("/availability/blockedCountries", a.BlockedCountries, rec)
+	}
+	if s.Seasons != nil {
+		for i := range *s.Seasons {
+			season := &(*s.Seasons)[i]
+			rec.set("/seasons/"+strconv.Itoa(i)+"/slug", &season.Slug, strings.TrimSpace(season.Slug))
+		}
+	}
+
+	if len(rejected) > 0 {
+		return rejected
+	}
+	return nil
+}
+
+// Channel normalizes a channel in place. Unknown countries pass through
+// trimmed; RejectUnknown only applies to shows.
+func (n *Normalizer) Channel(c *domain.Channel) Result {
+	rec := &recorder{}
+	rec.set("/id", &c.ID, channelID(c.ID))
+	rec.set("/name", &c.Name, collapse(c.Name))
+	rec.setPtr("/logo", c.Logo, strings.TrimSpace)
+	rec.setPtr("/primaryColour", c.PrimaryColour, func(v string) string {
+		if col, ok := Colour(v); ok {
+			return col
+		}
+		return strings.TrimSpace(v)
+	})
+	rec.setPtr("/country", c.Country, func(v string) string {
+		if code, ok := Country(v); ok {
+			return code
+		}
+		return collapse(v)
+	})
+	return Result{Changes: rec.changes, Warnings: rec.warnings}
+}
+
+// localized rekeys the per-locale variants by canonical BCP 47 tag and
+// cleans their values like the unlocalized fields. Tags that are not
+// recognised are left trimmed for validation to reject.
+func (n *Normalizer) localized(s *domain.Show, rec *recorder, rejected validation.Errors) {
+	var err error
+	if s.Titles, err = rekey("/titles", s.Titles, rec); err != nil {
+		rejected["titles"] = err
+	}
+	for _, tag := range slices.Sorted(maps.Keys(s.Titles)) {
+		title := s.Titles[tag]
+		rec.set("/titles/"+tag, &title, collapse(title))
+		s.Titles[tag] = title
+	}
+
+	if s.Descriptions, err = rekey("/descriptions", s.Descriptions, rec); err != nil {
+		rejected["descriptions"] = err
+	}
+	for _, tag := range slices.Sorted(maps.Keys(s.Descriptions)) {
+		description := s.Descriptions[tag]
+		rec.set("/descriptions/"+tag, &description, strings.TrimSpace(description))
+		s.Descriptions[tag] = description
+	}
+
+	if s.Images, err = rekey("/images", s.Images, rec); err != nil {
+		rejected["images"] = err
+	}
+	for _, tag := range slices.Sorted(maps.Keys(s.Images)) {
+		image := s.Images[tag]
+		rec.set("/images/"+tag+"/showImage", &image.ShowImage, strings.TrimSpace(image.ShowImage))
+		s.Images[tag] = image
+	}
+}
+
+// rekey maps variant keys such as "EN_au" or "english" to canonical tags.
+// Keys that collapse onto a tag already taken are rejected rather than one
+// of the values being dropped silently.
+func rekey[V any](field string, variants map[string]V, rec *recorder) (map[string]V, error) {
+	if len(variants) == 0 {
+		return variants, nil
+	}
+
+	tags := make(map[string]string, len(variants))
+	for key := range variants {
+		if tag, ok := Language(key); ok {
+			tags[key] = tag
+		} else {
+			tags[key] = strings.TrimSpace(key)
+		}
+	}
+	// Keys already in canonical form go first so they win over variants
+	var keys, rest []string
+	for _, key := range slices.Sorted(maps.Keys(variants)) {
+		if tags[key] == key {
+			keys = append(keys, key)
+		} else {
+			rest = append(rest, key)
+		}
+	}
+	keys = append(keys, rest...)
+
+	out := make(map[string]V, len(variants))
+	dups := validation.Errors{}
+	for _, key := range keys {
+		tag := tags[key]
+		if _, taken := out[tag]; taken {
+			dups[key] = validation.NewError("locale_duplicate", "duplicates locale "+tag)
+			continue
+		}
+		from := key
+		rec.set(field+"/"+key, &from, tag)
+		out[tag] = variants[key]
+	}
+
+	if len(dups) > 0 {
+		return out, dups
+	}
+	return out, nil
+}
+
+// genres maps each genres entry to its taxonomy code and moves the legacy
+// free-text genre into genres. Values the taxonomy does not know are left
+// for validation to reject.
+func (n *Normalizer) genres(s *domain.Show, rec *recorder) {
+	for i := range s.Genres {
+		field := "/genres/" + strconv.Itoa(i)
+		if code, ok := genre.Lookup(s.Genres[i]); ok {
+			rec.set(field, &s.Genres[i], code)
+		} else {
+			rec.set(field, &s.Genres[i], collapse(s.Genres[i]))
+		}
+	}
+
+	if s.Genre == nil {
+		return
+	}
+	code, ok := genre.Lookup(*s.Genre)
+	if !ok {
+		rec.setPtr("/genre", s.Genre, collapse)
+		return
+	}
+	rec.set("/genre", s.Genre, code)
+	if !slices.Contains(s.Genres, code) {
+		s.Genres = append(s.Genres, code)
+	}
+	s.Genre = nil
+}
+
+// Season normalizes a season in place
+func (n *Normalizer) Season(s *domain.SeasonDetail) Result {
+	rec := &recorder{}
+	n.detail(rec, s.Title, s.Synopsis, s.AirDate, s.Image)
+	return Result{Changes: rec.changes, Warnings: rec.warnings}
+}
+
+// Episode normalizes an episode in place
+func (n *Normalizer) Episode(e *domain.Episode) Result {
+	rec := &recorder{}
+	n.detail(rec, e.Title, e.Synopsis, e.AirDate, e.Image)
+	return Result{Changes: rec.changes, Warnings: rec.warnings}
+}
+
+// detail normalizes the descriptive fields seasons and episodes share. Past
+// air dates are expected here, so they are not flagged.
+func (n *Normalizer) detail(rec *recorder, title, synopsis, airDate, image *string) {
+	rec.setPtr("/title", title, collapse)
+	rec.setPtr("/synopsis", synopsis, strings.TrimSpace)
+	rec.setPtr("/image", image, strings.TrimSpace)
+	if airDate != nil {
+		n.airDate("/airDate", airDate, rec)
+	}
+}
+
+// airDate rewrites a parseable date to RFC 3339 in UTC and returns it.
+// Unparseable dates are left for validation to reject.
+func (n *Normalizer) airDate(field string, date *string, rec *recorder) (time.Time, bool) {
+	trimmed := strings.TrimSpace(*date)
+	if trimmed == "" {
+		rec.set(field, date, trimmed)
+		return time.Time{}, false
+	}
+	t, err := domain.ParseAirDate(trimmed, n.opts.Location)
+	if err != nil {
+		rec.set(field, date, trimmed)
+		return time.Time{}, false
+	}
+	rec.set(field, date, t.UTC().Format(time.RFC3339))
+	return t, true
+}
+
+// countryCodes maps each entry to its alpha-2 code. Unknown countries are
+// left trimmed for validation to reject.
+func countryCodes(field string, codes []string, rec *recorder) {
+	for i := range codes {
+		code, ok := Country(codes[i])
+		if !ok {
+			code = strings.TrimSpace(codes[i])
+		}
+		rec.set(field+"/"+strconv.Itoa(i), &codes[i], code)
+	}
+}
+
+// Country maps an ISO 3166 alpha-2 or alpha-3 code or a country name to its
+// alpha-2 code
+func Country(s string) (string, bool) {
+	return countries.lookup(s)
+}
+
+// Language maps a language name, an ISO 639 code or a BCP 47 tag in any case
+// to its canonical BCP 47 form, e.g. "english" -> "en", "EN_au" -> "en-AU"
+func Language(s string) (string, bool) {
+	if tag, ok := languages.lookup(s); ok {
+		return tag, true
+	}
+
+	parts := strings.FieldsFunc(strings.TrimSpace(s), func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) < 2 {
+		return "", false
+	}
+	primary, ok := languages.byCode[fold(parts[0])]
+	if !ok {
+		return "", false
+	}
+
+	out := []string{primary}
+	for _, p := range parts[1:] {
+		switch {
+		case len(p) == 4 && isLetters(p):
+			out = append(out, strings.ToUpper(p[:1])+strings.ToLower(p[1:])) // script, e.g. Hant
+		case len(p) == 2 && isLetters(p) && countries.hasCode(p):
+			out = append(out, strings.ToUpper(p)) // region, e.g. AU
+		case len(p) == 3 && isDigits(p):
+			out = append(out, p) // UN M.49 region, e.g. 419
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(out, "-"), true
+}
+
+// Colour canonicalizes a hex colour to lowercase #rrggbb, accepting a
+// missing '#' and the #rgb shorthand
+func Colour(s string) (string, bool) {
+	h := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if len(h) != 6 {
+		return "", false
+	}
+	for _, r := range h {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", false
+		}
+	}
+	return "#" + h, true
+}
+
+// channelID trims and lowercases a channel reference
+func channelID(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// collapse trims and folds internal runs of whitespace into single spaces
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+type recorder struct {
+	path     string
+	changes  []Change
+	warnings []Warning
+}
+
+func (r *recorder) warn(field, code, message string) {
+	r.warnings = append(r.warnings, Warning{Path: r.path + field, Code: code, Message: message})
+}
+
+func (r *recorder) set(field string, dst *string, to string) {
+	if *dst == to {
+		return
+	}
+	r.changes = append(r.changes, Change{Path: r.path + field, From: *dst, To: to})
+	*dst = to
+}
+
+func (r *recorder) setPtr(field string, dst *string, fn func(string) string) {
+	if dst == nil {
+		return
+	}
+	r.set(field, dst, fn(*dst))
+}
@@ -0,0 +1,437 @@
+package normalize
+
+import (
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/sanitize"
+)
+
+func TestCountry(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "AU", want: "AU", wantOK: true},
+		{in: "AUS", want: "AU", wantOK: true},
+		{in: " USA", want: "US", wantOK: true},
+		{in: "U.S.A.", want: "US", wantOK: true},
+		{in: "UK", want: "GB", wantOK: true},
+		{in: "united kingdom", want: "GB", wantOK: true},
+		{in: "New  Zealand", want: "NZ", wantOK: true},
+		{in: "Atlantis", wantOK: false},
+		{in: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Country(tt.in)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "English", want: "en", wantOK: true},
+		{in: "english", want: "en", wantOK: true},
+		{in: "eng", want: "en", wantOK: true},
+		{in: "Castilian", want: "es", wantOK: true},
+		{in: "ga", want: "ga", wantOK: true},
+		{in: "Ga", want: "ga", wantOK: true},
+		{in: "gaa", want: "gaa", wantOK: true},
+		{in: "EN_au", want: "en-AU", wantOK: true},
+		{in: "zh-hant-tw", want: "zh-Hant-TW", wantOK: true},
+		{in: "es-419", want: "es-419", wantOK: true},
+		{in: "en-ZZ", wantOK: false},
+		{in: "xx-AU", wantOK: false},
+		{in: "Elvish", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Language(tt.in)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestColour(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{in: "#DF0000", want: "#df0000", wantOK: true},
+		{in: "df0000", want: "#df0000", wantOK: true},
+		{in: " #F70 ", want: "#ff7700", wantOK: true},
+		{in: "#12345", wantOK: false},
+		{in: "#gggggg", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Colour(tt.in)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizer_Show(t *testing.T) {
+	show := domain.Show{
+		Slug:          " show/worlds ",
+		Title:         "  World's\tMost   Extreme ",
+		Country:       stringPtr(" USA"),
+		Language:      stringPtr("English"),
+		PrimaryColour: stringPtr("#0084DA"),
+		TVChannel:     stringPtr("Channel  9"),
+		Image:         &domain.Image{ShowImage: " http://example.com/a.jpg"},
+		Seasons:       &[]domain.Season{{Slug: "show/worlds/season/1 "}},
+	}
+
+	res, err := New(Options{}).Show(&show)
+	require.NoError(t, err)
+
+	require.Equal(t, "show/worlds", show.Slug)
+	require.Equal(t, "World's Most Extreme", show.Title)
+	require.Equal(t, "US", *show.Country)
+	require.Equal(t, "en", *show.Language)
+	require.Equal(t, "#0084da", *show.PrimaryColour)
+	require.Equal(t, "Channel 9", *show.TVChannel)
+	require.Equal(t, "http://example.com/a.jpg", show.Image.ShowImage)
+	require.Equal(t, "show/worlds/season/1", (*show.Seasons)[0].Slug)
+
+	require.Contains(t, res.Changes, Change{Path: "/country", From: " USA", To: "US"})
+	require.Contains(t, res.Changes, Change{Path: "/seasons/0/slug", From: "show/worlds/season/1 ", To: "show/worlds/season/1"})
+	require.Len(t, res.Changes, 8)
+}
+
+func TestNormalizer_Show_Unchanged(t *testing.T) {
+	show := domain.Show{Slug: "show/a", Title: "A", Country: stringPtr("AU")}
+
+	res, err := New(Options{}).Show(&show)
+	require.NoError(t, err)
+	require.Empty(t, res.Changes)
+}
+
+func TestNormalizer_Request_UnknownValues(t *testing.T) {
+	newRequest := func() domain.Request {
+		return domain.Request{Payload: []domain.Show{
+			{Slug: "show/a", Title: "A", Country: stringPtr("AUS")},
+			{Slug: "show/b", Title: "B", Country: stringPtr(" Atlantis "), Language: stringPtr("Elvish")},
+		}}
+	}
+
+	t.Run("passed through by default", func(t *testing.T) {
+		req := newRequest()
+		res, err := New(Options{}).Request(&req)
+		require.NoError(t, err)
+		require.Equal(t, "Atlantis", *req.Payload[1].Country)
+		require.Equal(t, []Change{
+			{Path: "/payload/0/country", From: "AUS", To: "AU"},
+			{Path: "/payload/1/country", From: " Atlantis ", To: "Atlantis"},
+		}, res.Changes)
+	})
+
+	t.Run("rejected when configured", func(t *testing.T) {
+		req := newRequest()
+		_, err := New(Options{RejectUnknown: true}).Request(&req)
+		require.Error(t, err)
+
+		errs := err.(validation.Errors)["payload"].(validation.Errors)
+		require.Len(t, errs, 1)
+		item := errs["1"].(validation.Errors)
+		require.EqualError(t, item["country"], "must be an ISO 3166 country code or name")
+		require.EqualError(t, item["language"], "must be a BCP 47 language tag or language name")
+	})
+}
+
+func TestNormalizer_Show_HTML(t *testing.T) {
+	policy, err := sanitize.NewPolicy([]string{"br", "span[class]"})
+	require.NoError(t, err)
+
+	newShow := func() domain.Show {
+		return domain.Show{
+			Slug:  "show/a",
+			Title: "A",
+			NextEpisode: &domain.NextEpisode{
+				ChannelLogo: "http://example.com/logo.gif",
+				HTML:        `Monday<br><span class="visit" onclick="x()">GO!</span><script>alert(1)</script>`,
+				URL:         "http://example.com/a",
+			},
+		}
+	}
+
+	t.Run("stripped and reported", func(t *testing.T) {
+		show := newShow()
+		res, err := New(Options{HTML: policy, PlainText: true}).Show(&show)
+		require.NoError(t, err)
+		require.Equal(t, `Monday<br><span class="visit">GO!</span>`, show.NextEpisode.HTML)
+		require.Equal(t, "Monday\nGO!", *show.NextEpisode.Text)
+		require.Len(t, res.Changes, 1)
+		require.Equal(t, "/nextEpisode/html", res.Changes[0].Path)
+	})
+
+	t.Run("rejected in strict mode", func(t *testing.T) {
+		show := newShow()
+		_, err := New(Options{HTML: policy, StrictHTML: true}).Show(&show)
+		require.Error(t, err)
+		require.EqualError(t, err.(validation.Errors)["nextEpisode"].(validation.Errors)["html"], "contains markup outside the allowlist")
+		require.Nil(t, show.NextEpisode.Text)
+	})
+
+	t.Run("clean markup passes strict mode", func(t *testing.T) {
+		show := newShow()
+		show.NextEpisode.HTML = `Monday<br><span class="visit">GO!</span>`
+		res, err := New(Options{HTML: policy, StrictHTML: true}).Show(&show)
+		require.NoError(t, err)
+		require.Empty(t, res.Changes)
+	})
+}
+
+func TestNormalizer_Show_AirDate(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	n := New(Options{Location: sydney})
+	n.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name        string
+		date        string
+		want        string
+		wantWarning bool
+	}{
+		{name: "RFC 3339 with offset", date: "2024-06-03T20:30:00+10:00", want: "2024-06-03T10:30:00Z"},
+		{name: "legacy local time uses feed zone", date: "2024-06-03 20:30", want: "2024-06-03T10:30:00Z"},
+		{name: "day-first legacy format", date: "03/06/2024 8:30pm", want: "2024-06-03T10:30:00Z"},
+		{name: "past date flagged", date: "2024-05-01T00:00:00Z", want: "2024-05-01T00:00:00Z", wantWarning: true},
+		{name: "unparseable left for validation", date: " next Tuesday ", want: "next Tuesday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			show := domain.Show{Slug: "show/a", Title: "A", NextEpisode: &domain.NextEpisode{Date: stringPtr(tt.date)}}
+
+			res, err := n.Show(&show)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, *show.NextEpisode.Date)
+			if tt.wantWarning {
+				require.Equal(t, []Warning{{Path: "/nextEpisode/date", Code: "date_in_past", Message: "next episode date is in the past"}}, res.Warnings)
+			} else {
+				require.Empty(t, res.Warnings)
+			}
+		})
+	}
+}
+
+func TestNormalizer_Channel(t *testing.T) {
+	channel := domain.Channel{
+		ID:            " Channel-9 ",
+		Name:          "Channel  9",
+		Logo:          stringPtr(" http://example.com/9.png"),
+		PrimaryColour: stringPtr("#F70"),
+		Country:       stringPtr("Australia"),
+	}
+
+	res := New(Options{}).Channel(&channel)
+	require.Equal(t, "channel-9", channel.ID)
+	require.Equal(t, "Channel 9", channel.Name)
+	require.Equal(t, "http://example.com/9.png", *channel.Logo)
+	require.Equal(t, "#ff7700", *channel.PrimaryColour)
+	require.Equal(t, "AU", *channel.Country)
+	require.Len(t, res.Changes, 5)
+}
+
+func TestNormalizer_Show_ChannelIDs(t *testing.T) {
+	show := domain.Show{
+		Slug:        "show/a",
+		Title:       "A",
+		ChannelID:   stringPtr(" NINE "),
+		NextEpisode: &domain.NextEpisode{ChannelID: stringPtr("Gem")},
+	}
+
+	_, err := New(Options{}).Show(&show)
+	require.NoError(t, err)
+	require.Equal(t, "nine", *show.ChannelID)
+	require.Equal(t, "gem", *show.NextEpisode.ChannelID)
+}
+
+func TestNormalizer_Show_GeneratesSlug(t *testing.T) {
+	show := domain.Show{Slug: "  ", Title: " The Taste  (Le Goût)"}
+
+	res, err := New(Options{}).Show(&show)
+	require.NoError(t, err)
+	require.Equal(t, "show/the-taste-le-gout", show.Slug)
+	require.True(t, show.SlugGenerated)
+	require.Contains(t, res.Changes, Change{Path: "/slug", From: "", To: "show/the-taste-le-gout"})
+
+	given := domain.Show{Slug: "show/taste", Title: "The Taste"}
+	_, err = New(Options{}).Show(&given)
+	require.NoError(t, err)
+	require.Equal(t, "show/taste", given.Slug)
+	require.False(t, given.SlugGenerated)
+}
+
+func TestNormalizer_Show_Genres(t *testing.T) {
+	t.Run("entries and legacy genre map to codes", func(t *testing.T) {
+		show := domain.Show{
+			Slug:   "show/a",
+			Title:  "A",
+			Genre:  stringPtr(" Reality TV"),
+			Genres: []string{"Cooking", "kids", " Polka  Music "},
+		}
+
+		res, err := New(Options{}).Show(&show)
+		require.NoError(t, err)
+		require.Nil(t, show.Genre)
+		require.Equal(t, []string{"reality/cooking", "kids", "Polka Music", "reality"}, show.Genres)
+		require.Equal(t, []Change{
+			{Path: "/genres/0", From: "Cooking", To: "reality/cooking"},
+			{Path: "/genres/2", From: " Polka  Music ", To: "Polka Music"},
+			{Path: "/genre", From: " Reality TV", To: "reality"},
+		}, res.Changes)
+	})
+
+	t.Run("legacy genre already listed", func(t *testing.T) {
+		show := domain.Show{Slug: "show/a", Title: "A", Genre: stringPtr("reality"), Genres: []string{"reality"}}
+
+		_, err := New(Options{}).Show(&show)
+		require.NoError(t, err)
+		require.Nil(t, show.Genre)
+		require.Equal(t, []string{"reality"}, show.Genres)
+	})
+
+	t.Run("unknown legacy genre is kept for validation", func(t *testing.T) {
+		show := domain.Show{Slug: "show/a", Title: "A", Genre: stringPtr(" Polka ")}
+
+		_, err := New(Options{}).Show(&show)
+		require.NoError(t, err)
+		require.Equal(t, "Polka", *show.Genre)
+		require.Empty(t, show.Genres)
+	})
+}
+
+func TestNormalizer_Show_Localized(t *testing.T) {
+	t.Run("tags are canonicalized and values cleaned", func(t *testing.T) {
+		show := domain.Show{
+			Slug:         "show/a",
+			Title:        "A",
+			Titles:       map[string]string{"EN_au": "  The   Taste ", "french": "Le Goût"},
+			Descriptions: map[string]string{"en": " Cooking "},
+			Images:       map[string]domain.Image{"fr-ca": {ShowImage: " https://example.com/fr.jpg"}},
+		}
+
+		res, err := New(Options{}).Show(&show)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"en-AU": "The Taste", "fr": "Le Goût"}, show.Titles)
+		require.Equal(t, map[string]string{"en": "Cooking"}, show.Descriptions)
+		require.Equal(t, map[string]domain.Image{"fr-CA": {ShowImage: "https://example.com/fr.jpg"}}, show.Images)
+		require.Equal(t, []Change{
+			{Path: "/titles/EN_au", From: "EN_au", To: "en-AU"},
+			{Path: "/titles/french", From: "french", To: "fr"},
+			{Path: "/titles/en-AU", From: "  The   Taste ", To: "The Taste"},
+			{Path: "/descriptions/en", From: " Cooking ", To: "Cooking"},
+			{Path: "/images/fr-ca", From: "fr-ca", To: "fr-CA"},
+			{Path: "/images/fr-CA/showImage", From: " https://example.com/fr.jpg", To: "https://example.com/fr.jpg"},
+		}, res.Changes)
+	})
+
+	t.Run("colliding tags are rejected", func(t *testing.T) {
+		show := domain.Show{Slug: "show/a", Title: "A", Titles: map[string]string{"EN": "Upper", "en": "Lower", "english": "Name"}}
+
+		_, err := New(Options{}).Show(&show)
+		var errs validation.Errors
+		require.ErrorAs(t, err, &errs)
+		titles, ok := errs["titles"].(validation.Errors)
+		require.True(t, ok)
+		require.Len(t, titles, 2)
+		require.Contains(t, titles, "EN")
+		require.Contains(t, titles, "english")
+		require.Equal(t, map[string]string{"en": "Lower"}, show.Titles, "the canonical key wins")
+	})
+
+	t.Run("unknown tags are left for validation", func(t *testing.T) {
+		show := domain.Show{Slug: "show/a", Title: "A", Titles: map[string]string{" Elvish ": "A"}}
+
+		_, err := New(Options{}).Show(&show)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Elvish": "A"}, show.Titles)
+	})
+}
+
+func TestNormalizer_Show_Availability(t *testing.T) {
+	show := domain.Show{
+		Slug:  "show/a",
+		Title: "A",
+		Availability: &domain.Availability{
+			Start:            stringPtr("2024-06-01 00:00"),
+			End:              stringPtr(" 2024-07-01T00:00:00Z "),
+			Countries:        []string{"Australia", " nz"},
+			BlockedCountries: []string{"Atlantis "},
+		},
+	}
+
+	res, err := New(Options{}).Show(&show)
+	require.NoError(t, err)
+	require.Equal(t, "2024-06-01T00:00:00Z", *show.Availability.Start)
+	require.Equal(t, "2024-07-01T00:00:00Z", *show.Availability.End)
+	require.Equal(t, []string{"AU", "NZ"}, show.Availability.Countries)
+	require.Equal(t, []string{"Atlantis"}, show.Availability.BlockedCountries)
+	require.Len(t, res.Changes, 5)
+	require.Empty(t, res.Warnings, "past windows are not flagged")
+}
+
+func TestNormalizer_Season(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	season := domain.SeasonDetail{
+		Number:   1,
+		Title:    stringPtr(" Season\t 1 "),
+		Synopsis: stringPtr(" The first one. "),
+		AirDate:  stringPtr("2001-03-05 19:30"),
+		Image:    stringPtr("http://example.com/s1.jpg"),
+	}
+
+	res := New(Options{Location: sydney}).Season(&season)
+	require.Equal(t, "Season 1", *season.Title)
+	require.Equal(t, "The first one.", *season.Synopsis)
+	require.Equal(t, "2001-03-05T08:30:00Z", *season.AirDate)
+	require.Len(t, res.Changes, 3)
+	require.Empty(t, res.Warnings, "past air dates are normal for seasons")
+}
+
+func TestNormalizer_Episode(t *testing.T) {
+	episode := domain.Episode{Number: 1, SeasonNumber: 1, Title: stringPtr("Pilot "), AirDate: stringPtr(" soon ")}
+
+	res := New(Options{}).Episode(&episode)
+	require.Equal(t, "Pilot", *episode.Title)
+	require.Equal(t, "soon", *episode.AirDate, "unparseable dates are left for validation")
+	require.Equal(t, []Change{
+		{Path: "/title", From: "Pilot ", To: "Pilot"},
+		{Path: "/airDate", From: " soon ", To: "soon"},
+	}, res.Changes)
+}
+
+func TestTables_Loaded(t *testing.T) {
+	require.Len(t, countries.byCode, 249*2)
+	require.True(t, languages.hasCode("en"))
+	require.True(t, languages.hasCode("gre"), "bibliographic codes are accepted")
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,87 @@
+package normalize
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed data/countries.csv
+var countriesCSV string
+
+//go:embed data/languages.csv
+var languagesCSV string
+
+// Lookup tables are built once at start-up; a malformed embedded file is a
+// build defect, so loading panics rather than returning an error
+var (
+	countries = mustLoadTable(countriesCSV)
+	languages = mustLoadTable(languagesCSV)
+)
+
+// table maps folded codes and names onto a canonical code. Codes win over
+// names, so "ga" is Irish even though another language is named "Ga".
+type table struct {
+	byCode map[string]string
+	byName map[string]string
+}
+
+func (t table) lookup(s string) (string, bool) {
+	key := fold(s)
+	if code, ok := t.byCode[key]; ok {
+		return code, true
+	}
+	code, ok := t.byName[key]
+	return code, ok
+}
+
+func (t table) hasCode(code string) bool {
+	_, ok := t.byCode[fold(code)]
+	return ok
+}
+
+// mustLoadTable reads rows of canonical,codes,names... where codes is a
+// space-separated list of alternative codes
+func mustLoadTable(data string) table {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+
+	t := table{byCode: map[string]string{}, byName: map[string]string{}}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return t
+		}
+		if err != nil {
+			panic(fmt.Sprintf("normalize: reading embedded table: %v", err))
+		}
+		if len(row) < 3 {
+			panic(fmt.Sprintf("normalize: short row %q", row))
+		}
+
+		canonical := row[0]
+		for _, code := range append([]string{canonical}, strings.Fields(row[1])...) {
+			mustAdd(t.byCode, code, canonical)
+		}
+		for _, name := range row[2:] {
+			mustAdd(t.byName, name, canonical)
+		}
+	}
+}
+
+func mustAdd(m map[string]string, key, canonical string) {
+	key = fold(key)
+	if prev, ok := m[key]; ok && prev != canonical {
+		panic(fmt.Sprintf("normalize: %q maps to both %s and %s", key, prev, canonical))
+	}
+	m[key] = canonical
+}
+
+// fold lowercases, drops dots and collapses whitespace so "U.S.A." and
+// " usa " share a key
+func fold(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(s, ".", ""))), " ")
+}
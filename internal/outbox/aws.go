@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// The AWS publishers send the event as JSON and its type as the "type"
+// message attribute, or detail-type on EventBridge, for subscription
+// filters. FIFO topics and queues get the slug as message group, so events
+// for one show stay in order, and the event ID for deduplication.
+
+// SNSAPI is the part of the SNS client SNSPublisher uses
+type SNSAPI interface {
+	Publish(ctx context.Context, in *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+type SNSPublisher struct {
+	client   SNSAPI
+	topicARN string
+}
+
+var _ EventPublisher = (*SNSPublisher)(nil)
+
+func NewSNSPublisher(client SNSAPI, topicARN string) *SNSPublisher {
+	return &SNSPublisher{client: client, topicARN: topicARN}
+}
+
+func (p *SNSPublisher) Publish(ctx context.Context, e domain.ChangeEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	in := &sns.PublishInput{
+		TopicArn: aws.String(p.topicARN),
+		Message:  aws.String(string(body)),
+		MessageAttributes: map[string]snstypes.MessageAttributeValue{
+			"type": {DataType: aws.String("String"), StringValue: aws.String(e.Type)},
+		},
+	}
+	if strings.HasSuffix(p.topicARN, ".fifo") {
+		in.MessageGroupId = aws.String(e.Slug)
+		in.MessageDeduplicationId = aws.String(e.ID)
+	}
+	_, err = p.client.Publish(ctx, in)
+	return err
+}
+
+// SQSAPI is the part of the SQS client SQSPublisher uses
+type SQSAPI interface {
+	SendMessage(ctx context.Context, in *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+type SQSPublisher struct {
+	client   SQSAPI
+	queueURL string
+}
+
+var _ EventPublisher = (*SQSPublisher)(nil)
+
+func NewSQSPublisher(client SQSAPI, queueURL string) *SQSPublisher {
+	return &SQSPublisher{client: client, queueURL: queueURL}
+}
+
+func (p *SQSPublisher) Publish(ctx context.Context, e domain.ChangeEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	in := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(p.queueURL),
+		MessageBody: aws.String(string(body)),
+		MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+			"type": {DataType: aws.String("String"), StringValue: aws.String(e.Type)},
+		},
+	}
+	if strings.HasSuffix(p.queueURL, ".fifo") {
+		in.MessageGroupId = aws.String(e.Slug)
+		in.MessageDeduplicationId = aws.String(e.ID)
+	}
+	_, err = p.client.SendMessage(ctx, in)
+	return err
+}
+
+// EventBridgeAPI is the part of the EventBridge client EventBridgePublisher
+// uses
+type EventBridgeAPI interface {
+	PutEvents(ctx context.Context, in *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)
+}
+
+type EventBridgePublisher struct {
+	client EventBridgeAPI
+	bus    string
+	source string
+}
+
+var _ EventPublisher = (*EventBridgePublisher)(nil)
+
+// NewEventBridgePublisher puts events on bus, a name or ARN, with source as
+// their source
+func NewEventBridgePublisher(client EventBridgeAPI, bus, source string) *EventBridgePublisher {
+	return &EventBridgePublisher{client: client, bus: bus, source: source}
+}
+
+func (p *EventBridgePublisher) Publish(ctx context.Context, e domain.ChangeEvent) error {
+	detail, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	out, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []ebtypes.PutEventsRequestEntry{{
+			EventBusName: aws.String(p.bus),
+			Source:       aws.String(p.source),
+			DetailType:   aws.String(e.Type),
+			Detail:       aws.String(string(detail)),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	// PutEvents succeeds as a call even when entries are rejected
+	if out.FailedEntryCount > 0 && len(out.Entries) > 0 {
+		return fmt.Errorf("eventbridge: %s: %s", aws.ToString(out.Entries[0].ErrorCode), aws.ToString(out.Entries[0].ErrorMessage))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	outboxMocks "github.com/marciomarinho/show-service/internal/outbox/mocks"
+)
+
+var testEvent = domain.ChangeEvent{ID: "e1", Type: domain.EventShowCreated, Slug: "show/a", Revision: 1, Actor: "ed", At: "2024-06-15T00:00:00Z"}
+
+func requireEventJSON(t *testing.T, body *string) {
+	t.Helper()
+	var got domain.ChangeEvent
+	require.NoError(t, json.Unmarshal([]byte(aws.ToString(body)), &got))
+	require.Equal(t, testEvent, got)
+}
+
+func TestSNSPublisher(t *testing.T) {
+	t.Run("standard topic", func(t *testing.T) {
+		client := outboxMocks.NewMockSNSAPI(t)
+		client.EXPECT().Publish(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, in *sns.PublishInput, _ ...func(*sns.Options)) {
+				require.Equal(t, "arn:aws:sns:ap-southeast-2:123456789012:shows", aws.ToString(in.TopicArn))
+				requireEventJSON(t, in.Message)
+				require.Equal(t, "show.created", aws.ToString(in.MessageAttributes["type"].StringValue))
+				require.Nil(t, in.MessageGroupId)
+				require.Nil(t, in.MessageDeduplicationId)
+			}).
+			Return(&sns.PublishOutput{}, nil)
+
+		p := NewSNSPublisher(client, "arn:aws:sns:ap-southeast-2:123456789012:shows")
+		require.NoError(t, p.Publish(context.Background(), testEvent))
+	})
+
+	t.Run("fifo topic", func(t *testing.T) {
+		client := outboxMocks.NewMockSNSAPI(t)
+		client.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(in *sns.PublishInput) bool {
+			return aws.ToString(in.MessageGroupId) == "show/a" && aws.ToString(in.MessageDeduplicationId) == "e1"
+		})).Return(&sns.PublishOutput{}, nil)
+
+		p := NewSNSPublisher(client, "arn:aws:sns:ap-southeast-2:123456789012:shows.fifo")
+		require.NoError(t, p.Publish(context.Background(), testEvent))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		client := outboxMocks.NewMockSNSAPI(t)
+		client.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+
+		p := NewSNSPublisher(client, "arn:aws:sns:ap-southeast-2:123456789012:shows")
+		require.EqualError(t, p.Publish(context.Background(), testEvent), "throttled")
+	})
+}
+
+func TestSQSPublisher(t *testing.T) {
+	t.Run("standard queue", func(t *testing.T) {
+		client := outboxMocks.NewMockSQSAPI(t)
+		client.EXPECT().SendMessage(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, in *sqs.SendMessageInput, _ ...func(*sqs.Options)) {
+				require.Equal(t, "https://sqs.ap-southeast-2.amazonaws.com/123456789012/shows", aws.ToString(in.QueueUrl))
+				requireEventJSON(t, in.MessageBody)
+				require.Equal(t, "show.created", aws.ToString(in.MessageAttributes["type"].StringValue))
+				require.Nil(t, in.MessageGroupId)
+			}).
+			Return(&sqs.SendMessageOutput{}, nil)
+
+		p := NewSQSPublisher(client, "https://sqs.ap-southeast-2.amazonaws.com/123456789012/shows")
+		require.NoError(t, p.Publish(context.Background(), testEvent))
+	})
+
+	t.Run("fifo queue", func(t *testing.T) {
+		client := outboxMocks.NewMockSQSAPI(t)
+		client.EXPECT().SendMessage(mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageInput) bool {
+			return aws.ToString(in.MessageGroupId) == "show/a" && aws.ToString(in.MessageDeduplicationId) == "e1"
+		})).Return(&sqs.SendMessageOutput{}, nil)
+
+		p := NewSQSPublisher(client, "https://sqs.ap-southeast-2.amazonaws.com/123456789012/shows.fifo")
+		require.NoError(t, p.Publish(context.Background(), testEvent))
+	})
+}
+
+func TestEventBridgePublisher(t *testing.T) {
+	t.Run("puts the event", func(t *testing.T) {
+		client := outboxMocks.NewMockEventBridgeAPI(t)
+		client.EXPECT().PutEvents(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, in *eventbridge.PutEventsInput, _ ...func(*eventbridge.Options)) {
+				require.Len(t, in.Entries, 1)
+				entry := in.Entries[0]
+				require.Equal(t, "shows", aws.ToString(entry.EventBusName))
+				require.Equal(t, "show-service", aws.ToString(entry.Source))
+				require.Equal(t, "show.created", aws.ToString(entry.DetailType))
+				requireEventJSON(t, entry.Detail)
+			}).
+			Return(&eventbridge.PutEventsOutput{Entries: []ebtypes.PutEventsResultEntry{{EventId: aws.String("x")}}}, nil)
+
+		p := NewEventBridgePublisher(client, "shows", "show-service")
+		require.NoError(t, p.Publish(context.Background(), testEvent))
+	})
+
+	t.Run("rejected entry", func(t *testing.T) {
+		client := outboxMocks.NewMockEventBridgeAPI(t)
+		client.EXPECT().PutEvents(mock.Anything, mock.Anything).Return(&eventbridge.PutEventsOutput{
+			FailedEntryCount: 1,
+			Entries:          []ebtypes.PutEventsResultEntry{{ErrorCode: aws.String("InternalFailure"), ErrorMessage: aws.String("try again")}},
+		}, nil)
+
+		p := NewEventBridgePublisher(client, "shows", "show-service")
+		require.EqualError(t, p.Publish(context.Background(), testEvent), "eventbridge: InternalFailure: try again")
+	})
+}
@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+type DispatcherOptions struct {
+	Interval   time.Duration // time between polls
+	BatchSize  int           // entries published per poll
+	Lease      time.Duration // how long a claimed entry is reserved for its delivery
+	MinBackoff time.Duration // delay before the first retry, doubled per attempt
+	MaxBackoff time.Duration // longest delay between retries
+}
+
+// Dispatcher delivers outbox entries at least once. It claims each due
+// entry, publishes it and deletes it. A failed delivery is retried with
+// exponential backoff, and a dispatcher that dies mid-delivery leaves its
+// claim to expire, so the entry is delivered again. Events for one show may
+// be delivered out of order when one of them is retried.
+type Dispatcher struct {
+	repo      repository.OutboxRepository
+	publisher EventPublisher
+	opts      DispatcherOptions
+	now       func() time.Time
+}
+
+func NewDispatcher(repo repository.OutboxRepository, publisher EventPublisher, opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{repo: repo, publisher: publisher, opts: opts, now: time.Now}
+}
+
+// Run dispatches every Interval until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("outbox: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch publishes up to BatchSize entries due now and returns how many
+// were delivered. Entries another dispatcher claimed first are skipped.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	now := d.now()
+	due, err := d.repo.Due(ctx, now, d.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Settle deliveries even when shutdown cancels ctx mid-batch
+	settle := context.WithoutCancel(ctx)
+	delivered := 0
+	var errs []error
+	for _, entry := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		claimed, err := d.repo.Claim(ctx, entry, now.Add(d.opts.Lease))
+		if errors.Is(err, apperror.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := d.publisher.Publish(ctx, claimed.Event); err != nil {
+			errs = append(errs, fmt.Errorf("event %s attempt %d: %w", claimed.Event.ID, claimed.Attempts, err))
+			if err := d.repo.Release(settle, *claimed, now.Add(d.backoff(claimed.Attempts)), err); err != nil && !errors.Is(err, apperror.ErrConflict) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// An entry that cannot be deleted is delivered again once its claim
+		// expires
+		if err := d.repo.Delete(settle, *claimed); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered++
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// backoff is the delay after a failed delivery attempt, numbered from 1
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.opts.MinBackoff
+	for i := 1; i < attempt && delay < d.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.opts.MaxBackoff)
+}
@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	outboxMocks "github.com/marciomarinho/show-service/internal/outbox/mocks"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+var dispatchNow = time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+func testDispatcher(repo *repoMocks.MockOutboxRepository, pub EventPublisher) *Dispatcher {
+	d := NewDispatcher(repo, pub, DispatcherOptions{
+		Interval:   time.Second,
+		BatchSize:  10,
+		Lease:      30 * time.Second,
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+	})
+	d.now = func() time.Time { return dispatchNow }
+	return d
+}
+
+func entry(id string, attempts int) domain.OutboxEntry {
+	return domain.OutboxEntry{Event: domain.ChangeEvent{ID: id, Type: domain.EventShowCreated, Slug: "show/" + id}, Attempts: attempts}
+}
+
+func claimed(e domain.OutboxEntry) *domain.OutboxEntry {
+	e.Attempts++
+	return &e
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	lease := dispatchNow.Add(30 * time.Second)
+
+	t.Run("publishes and deletes", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepository(t)
+		pub := &MemoryPublisher{}
+		repo.EXPECT().Due(mock.Anything, dispatchNow, 10).Return([]domain.OutboxEntry{entry("a", 0), entry("b", 0)}, nil)
+		for _, id := range []string{"a", "b"} {
+			repo.EXPECT().Claim(mock.Anything, entry(id, 0), lease).Return(claimed(entry(id, 0)), nil)
+			repo.EXPECT().Delete(mock.Anything, *claimed(entry(id, 0))).Return(nil)
+		}
+
+		delivered, err := testDispatcher(repo, pub).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, delivered)
+		require.Equal(t, []domain.ChangeEvent{entry("a", 0).Event, entry("b", 0).Event}, pub.Events())
+	})
+
+	t.Run("skips entries claimed elsewhere", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepository(t)
+		pub := outboxMocks.NewMockEventPublisher(t)
+		repo.EXPECT().Due(mock.Anything, dispatchNow, 10).Return([]domain.OutboxEntry{entry("a", 0)}, nil)
+		repo.EXPECT().Claim(mock.Anything, entry("a", 0), lease).Return(nil, fmt.Errorf("outbox event a: %w", apperror.ErrConflict))
+
+		delivered, err := testDispatcher(repo, pub).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+	})
+
+	t.Run("releases failed deliveries with backoff", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepository(t)
+		pub := outboxMocks.NewMockEventPublisher(t)
+		repo.EXPECT().Due(mock.Anything, dispatchNow, 10).Return([]domain.OutboxEntry{entry("a", 2), entry("b", 0)}, nil)
+		repo.EXPECT().Claim(mock.Anything, entry("a", 2), lease).Return(claimed(entry("a", 2)), nil)
+		repo.EXPECT().Claim(mock.Anything, entry("b", 0), lease).Return(claimed(entry("b", 0)), nil)
+		cause := errors.New("topic unavailable")
+		pub.EXPECT().Publish(mock.Anything, entry("a", 2).Event).Return(cause)
+		pub.EXPECT().Publish(mock.Anything, entry("b", 0).Event).Return(nil)
+		// The third attempt failed, so the retry waits 1s doubled twice
+		repo.EXPECT().Release(mock.Anything, *claimed(entry("a", 2)), dispatchNow.Add(4*time.Second), cause).Return(nil)
+		repo.EXPECT().Delete(mock.Anything, *claimed(entry("b", 0))).Return(nil)
+
+		delivered, err := testDispatcher(repo, pub).Dispatch(context.Background())
+		require.ErrorIs(t, err, cause)
+		require.ErrorContains(t, err, "event a attempt 3")
+		require.Equal(t, 1, delivered)
+	})
+
+	t.Run("an undeleted entry is not counted", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepository(t)
+		repo.EXPECT().Due(mock.Anything, dispatchNow, 10).Return([]domain.OutboxEntry{entry("a", 0)}, nil)
+		repo.EXPECT().Claim(mock.Anything, entry("a", 0), lease).Return(claimed(entry("a", 0)), nil)
+		repo.EXPECT().Delete(mock.Anything, *claimed(entry("a", 0))).Return(apperror.ErrThrottled)
+
+		delivered, err := testDispatcher(repo, &MemoryPublisher{}).Dispatch(context.Background())
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+		require.Zero(t, delivered)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepository(t)
+		repo.EXPECT().Due(mock.Anything, dispatchNow, 10).Return(nil, apperror.ErrThrottled)
+
+		_, err := testDispatcher(repo, &MemoryPublisher{}).Dispatch(context.Background())
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+	})
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := testDispatcher(nil, nil)
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{6, 32 * time.Second},
+		{7, time.Minute},
+		{1000, time.Minute},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, d.backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	repo := repoMocks.NewMockOutboxRepository(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	repo.EXPECT().Due(mock.Anything, dispatchNow, 10).
+		Run(func(context.Context, time.Time, int) { cancel() }).
+		Return(nil, nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		testDispatcher(repo, &MemoryPublisher{}).Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop when its context was cancelled")
+	}
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package outbox
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockEventBridgeAPI creates a new instance of MockEventBridgeAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEventBridgeAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEventBridgeAPI {
+	mock := &MockEventBridgeAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEventBridgeAPI is an autogenerated mock type for the EventBridgeAPI type
+type MockEventBridgeAPI struct {
+	mock.Mock
+}
+
+type MockEventBridgeAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEventBridgeAPI) EXPECT() *MockEventBridgeAPI_Expecter {
+	return &MockEventBridgeAPI_Expecter{mock: &_m.Mock}
+}
+
+// PutEvents provides a mock function for the type MockEventBridgeAPI
+func (_mock *MockEventBridgeAPI) PutEvents(ctx context.Context, in *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutEvents")
+	}
+
+	var r0 *eventbridge.PutEventsOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *eventbridge.PutEventsInput, ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *eventbridge.PutEventsInput, ...func(*eventbridge.Options)) *eventbridge.PutEventsOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*eventbridge.PutEventsOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *eventbridge.PutEventsInput, ...func(*eventbridge.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventBridgeAPI_PutEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutEvents'
+type MockEventBridgeAPI_PutEvents_Call struct {
+	*mock.Call
+}
+
+// PutEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *eventbridge.PutEventsInput
+//   - optFns ...func(*eventbridge.Options)
+func (_e *MockEventBridgeAPI_Expecter) PutEvents(ctx interface{}, in interface{}, optFns ...interface{}) *MockEventBridgeAPI_PutEvents_Call {
+	return &MockEventBridgeAPI_PutEvents_Call{Call: _e.mock.On("PutEvents",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockEventBridgeAPI_PutEvents_Call) Run(run func(ctx context.Context, in *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options))) *MockEventBridgeAPI_PutEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *eventbridge.PutEventsInput
+		if args[1] != nil {
+			arg1 = args[1].(*eventbridge.PutEventsInput)
+		}
+		var arg2 []func(*eventbridge.Options)
+		var variadicArgs []func(*eventbridge.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*eventbridge.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventBridgeAPI_PutEvents_Call) Return(putEventsOutput *eventbridge.PutEventsOutput, err error) *MockEventBridgeAPI_PutEvents_Call {
+	_c.Call.Return(putEventsOutput, err)
+	return _c
+}
+
+func (_c *MockEventBridgeAPI_PutEvents_Call) RunAndReturn(run func(ctx context.Context, in *eventbridge.PutEventsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutEventsOutput, error)) *MockEventBridgeAPI_PutEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package outbox
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSNSAPI creates a new instance of MockSNSAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSNSAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSNSAPI {
+	mock := &MockSNSAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSNSAPI is an autogenerated mock type for the SNSAPI type
+type MockSNSAPI struct {
+	mock.Mock
+}
+
+type MockSNSAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSNSAPI) EXPECT() *MockSNSAPI_Expecter {
+	return &MockSNSAPI_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type MockSNSAPI
+func (_mock *MockSNSAPI) Publish(ctx context.Context, in *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *sns.PublishOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sns.PublishInput, ...func(*sns.Options)) (*sns.PublishOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sns.PublishInput, ...func(*sns.Options)) *sns.PublishOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sns.PublishOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *sns.PublishInput, ...func(*sns.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSNSAPI_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockSNSAPI_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *sns.PublishInput
+//   - optFns ...func(*sns.Options)
+func (_e *MockSNSAPI_Expecter) Publish(ctx interface{}, in interface{}, optFns ...interface{}) *MockSNSAPI_Publish_Call {
+	return &MockSNSAPI_Publish_Call{Call: _e.mock.On("Publish",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockSNSAPI_Publish_Call) Run(run func(ctx context.Context, in *sns.PublishInput, optFns ...func(*sns.Options))) *MockSNSAPI_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sns.PublishInput
+		if args[1] != nil {
+			arg1 = args[1].(*sns.PublishInput)
+		}
+		var arg2 []func(*sns.Options)
+		var variadicArgs []func(*sns.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*sns.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSNSAPI_Publish_Call) Return(publishOutput *sns.PublishOutput, err error) *MockSNSAPI_Publish_Call {
+	_c.Call.Return(publishOutput, err)
+	return _c
+}
+
+func (_c *MockSNSAPI_Publish_Call) RunAndReturn(run func(ctx context.Context, in *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)) *MockSNSAPI_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
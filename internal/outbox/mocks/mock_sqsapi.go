@@ -0,0 +1,122 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package outbox
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSQSAPI creates a new instance of MockSQSAPI. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSQSAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSQSAPI {
+	mock := &MockSQSAPI{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSQSAPI is an autogenerated mock type for the SQSAPI type
+type MockSQSAPI struct {
+	mock.Mock
+}
+
+type MockSQSAPI_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSQSAPI) EXPECT() *MockSQSAPI_Expecter {
+	return &MockSQSAPI_Expecter{mock: &_m.Mock}
+}
+
+// SendMessage provides a mock function for the type MockSQSAPI
+func (_mock *MockSQSAPI) SendMessage(ctx context.Context, in *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	var tmpRet mock.Arguments
+	if len(optFns) > 0 {
+		tmpRet = _mock.Called(ctx, in, optFns)
+	} else {
+		tmpRet = _mock.Called(ctx, in)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 *sqs.SendMessageOutput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqs.SendMessageInput, ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)); ok {
+		return returnFunc(ctx, in, optFns...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *sqs.SendMessageInput, ...func(*sqs.Options)) *sqs.SendMessageOutput); ok {
+		r0 = returnFunc(ctx, in, optFns...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.SendMessageOutput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *sqs.SendMessageInput, ...func(*sqs.Options)) error); ok {
+		r1 = returnFunc(ctx, in, optFns...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSQSAPI_SendMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMessage'
+type MockSQSAPI_SendMessage_Call struct {
+	*mock.Call
+}
+
+// SendMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *sqs.SendMessageInput
+//   - optFns ...func(*sqs.Options)
+func (_e *MockSQSAPI_Expecter) SendMessage(ctx interface{}, in interface{}, optFns ...interface{}) *MockSQSAPI_SendMessage_Call {
+	return &MockSQSAPI_SendMessage_Call{Call: _e.mock.On("SendMessage",
+		append([]interface{}{ctx, in}, optFns...)...)}
+}
+
+func (_c *MockSQSAPI_SendMessage_Call) Run(run func(ctx context.Context, in *sqs.SendMessageInput, optFns ...func(*sqs.Options))) *MockSQSAPI_SendMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *sqs.SendMessageInput
+		if args[1] != nil {
+			arg1 = args[1].(*sqs.SendMessageInput)
+		}
+		var arg2 []func(*sqs.Options)
+		var variadicArgs []func(*sqs.Options)
+		if len(args) > 2 {
+			variadicArgs = args[2].([]func(*sqs.Options))
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSQSAPI_SendMessage_Call) Return(sendMessageOutput *sqs.SendMessageOutput, err error) *MockSQSAPI_SendMessage_Call {
+	_c.Call.Return(sendMessageOutput, err)
+	return _c
+}
+
+func (_c *MockSQSAPI_SendMessage_Call) RunAndReturn(run func(ctx context.Context, in *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)) *MockSQSAPI_SendMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,166 @@
+// Package outbox publishes the change events ShowRepo writes to its outbox
+// partition. A Dispatcher polls for due entries and hands each to an
+// EventPublisher, retrying with backoff until delivery succeeds.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	awscfg "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+
+	"github.com/marciomarinho/show-service/internal/config"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+const (
+	PublisherNone        = "none"
+	PublisherMemory      = "memory"
+	PublisherFile        = "file"
+	PublisherSNS         = "sns"
+	PublisherSQS         = "sqs"
+	PublisherEventBridge = "eventbridge"
+)
+
+// EventPublisher delivers one change event downstream. An error leaves the
+// event in the outbox to be retried.
+type EventPublisher interface {
+	Publish(ctx context.Context, e domain.ChangeEvent) error
+}
+
+// Open returns the publisher named by cfg.Outbox, or nil for "none", in
+// which case events stay in the outbox until one is configured
+func Open(ctx context.Context, cfg *config.Config) (EventPublisher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	switch cfg.Outbox.Publisher {
+	case "", PublisherNone:
+		return nil, nil
+	case PublisherMemory:
+		return &MemoryPublisher{}, nil
+	case PublisherFile:
+		if cfg.Outbox.File == "" {
+			return nil, fmt.Errorf("the file publisher requires a file")
+		}
+		return OpenFile(cfg.Outbox.File)
+	case PublisherSNS, PublisherSQS, PublisherEventBridge:
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", cfg.Outbox.Publisher)
+	}
+
+	ac, err := awscfg.LoadDefaultConfig(ctx, awscfg.WithRegion(cfg.DynamoDB.Region))
+	if err != nil {
+		return nil, fmt.Errorf("aws cfg: %w", err)
+	}
+	switch cfg.Outbox.Publisher {
+	case PublisherSNS:
+		if cfg.Outbox.TopicARN == "" {
+			return nil, fmt.Errorf("the sns publisher requires a topic ARN")
+		}
+		return NewSNSPublisher(sns.NewFromConfig(ac), cfg.Outbox.TopicARN), nil
+	case PublisherSQS:
+		if cfg.Outbox.QueueURL == "" {
+			return nil, fmt.Errorf("the sqs publisher requires a queue URL")
+		}
+		return NewSQSPublisher(sqs.NewFromConfig(ac), cfg.Outbox.QueueURL), nil
+	default:
+		return NewEventBridgePublisher(eventbridge.NewFromConfig(ac), cfg.Outbox.EventBus, cfg.Outbox.Source), nil
+	}
+}
+
+// Multi returns a publisher that publishes to each of publishers, ignoring
+// nil ones, or nil if there are none. An event that fails anywhere is
+// retried everywhere, which at-least-once consumers already tolerate.
+func Multi(publishers ...EventPublisher) EventPublisher {
+	var all multiPublisher
+	for _, p := range publishers {
+		if p != nil {
+			all = append(all, p)
+		}
+	}
+	switch len(all) {
+	case 0:
+		return nil
+	case 1:
+		return all[0]
+	}
+	return all
+}
+
+type multiPublisher []EventPublisher
+
+func (m multiPublisher) Publish(ctx context.Context, e domain.ChangeEvent) error {
+	var errs []error
+	for _, p := range m {
+		errs = append(errs, p.Publish(ctx, e))
+	}
+	return errors.Join(errs...)
+}
+
+// MemoryPublisher keeps events in memory, for tests and local runs
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.ChangeEvent
+}
+
+var _ EventPublisher = (*MemoryPublisher)(nil)
+
+func (p *MemoryPublisher) Publish(_ context.Context, e domain.ChangeEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+// Events returns the events published so far, in order
+func (p *MemoryPublisher) Events() []domain.ChangeEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.events)
+}
+
+// FilePublisher appends each event to a file as one line of JSON
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var _ EventPublisher = (*FilePublisher)(nil)
+
+// OpenFile opens path for appending, creating it if needed
+func OpenFile(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("outbox file: %w", err)
+	}
+	return &FilePublisher{file: f}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, e domain.ChangeEvent) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.file.Write(line)
+	return err
+}
+
+func (p *FilePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return errors.Join(p.file.Sync(), p.file.Close())
+}
@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/config"
+	"github.com/marciomarinho/show-service/internal/domain"
+	outboxMocks "github.com/marciomarinho/show-service/internal/outbox/mocks"
+)
+
+func TestOpen(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.jsonl")
+	aws := func(o config.Outbox) *config.Config {
+		return &config.Config{DynamoDB: config.DynamoDB{Region: "ap-southeast-2"}, Outbox: o}
+	}
+
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		want        any
+		expectError bool
+	}{
+		{name: "nil config", expectError: true},
+		{name: "none", cfg: &config.Config{Outbox: config.Outbox{Publisher: PublisherNone}}},
+		{name: "empty publisher defaults to none", cfg: &config.Config{}},
+		{name: "memory", cfg: &config.Config{Outbox: config.Outbox{Publisher: PublisherMemory}}, want: &MemoryPublisher{}},
+		{name: "file", cfg: &config.Config{Outbox: config.Outbox{Publisher: PublisherFile, File: file}}, want: &FilePublisher{}},
+		{name: "file without a path", cfg: &config.Config{Outbox: config.Outbox{Publisher: PublisherFile}}, expectError: true},
+		{name: "sns", cfg: aws(config.Outbox{Publisher: PublisherSNS, TopicARN: "arn:aws:sns:ap-southeast-2:123456789012:shows"}), want: &SNSPublisher{}},
+		{name: "sns without a topic", cfg: aws(config.Outbox{Publisher: PublisherSNS}), expectError: true},
+		{name: "sqs", cfg: aws(config.Outbox{Publisher: PublisherSQS, QueueURL: "https://sqs.ap-southeast-2.amazonaws.com/123456789012/shows"}), want: &SQSPublisher{}},
+		{name: "sqs without a queue", cfg: aws(config.Outbox{Publisher: PublisherSQS}), expectError: true},
+		{name: "eventbridge", cfg: aws(config.Outbox{Publisher: PublisherEventBridge, EventBus: "default", Source: "show-service"}), want: &EventBridgePublisher{}},
+		{name: "unknown publisher", cfg: &config.Config{Outbox: config.Outbox{Publisher: "kafka"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub, err := Open(context.Background(), tt.cfg)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				require.Nil(t, pub)
+				return
+			}
+			require.IsType(t, tt.want, pub)
+			if f, ok := pub.(*FilePublisher); ok {
+				require.NoError(t, f.Close())
+			}
+		})
+	}
+}
+
+func TestMulti(t *testing.T) {
+	require.Nil(t, Multi())
+	require.Nil(t, Multi(nil, nil))
+
+	only := &MemoryPublisher{}
+	require.Same(t, only, Multi(nil, only))
+
+	failing := outboxMocks.NewMockEventPublisher(t)
+	event := domain.ChangeEvent{ID: "e1", Type: domain.EventShowCreated, Slug: "show/a"}
+	failing.EXPECT().Publish(mock.Anything, event).Return(errors.New("unavailable"))
+	other := &MemoryPublisher{}
+
+	err := Multi(failing, nil, other).Publish(context.Background(), event)
+	require.EqualError(t, err, "unavailable")
+	require.Equal(t, []domain.ChangeEvent{event}, other.Events())
+}
+
+func TestMemoryPublisher(t *testing.T) {
+	p := &MemoryPublisher{}
+	require.Empty(t, p.Events())
+
+	first := domain.ChangeEvent{ID: "e1", Type: domain.EventShowCreated, Slug: "show/a"}
+	second := domain.ChangeEvent{ID: "e2", Type: domain.EventShowRenamed, Slug: "show/b", PreviousSlug: "show/a"}
+	require.NoError(t, p.Publish(context.Background(), first))
+	require.NoError(t, p.Publish(context.Background(), second))
+
+	events := p.Events()
+	require.Equal(t, []domain.ChangeEvent{first, second}, events)
+	events[0].ID = "changed"
+	require.Equal(t, "e1", p.Events()[0].ID)
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	events := []domain.ChangeEvent{
+		{ID: "e1", Type: domain.EventShowCreated, Slug: "show/a", Revision: 1, At: "2024-06-15T00:00:00Z"},
+		{ID: "e2", Type: domain.EventShowTransitioned, Slug: "show/a", Revision: 2, At: "2024-06-15T00:01:00Z"},
+	}
+
+	// Events are appended across reopens
+	for _, e := range events {
+		p, err := OpenFile(path)
+		require.NoError(t, err)
+		require.NoError(t, p.Publish(context.Background(), e))
+		require.NoError(t, p.Close())
+	}
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var got []domain.ChangeEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e domain.ChangeEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		got = append(got, e)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, events, got)
+}
+
+func TestOpenFile_Error(t *testing.T) {
+	_, err := OpenFile(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+	require.Error(t, err)
+}
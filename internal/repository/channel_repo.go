@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+type ChannelRepository interface {
+	Create(ctx context.Context, c domain.Channel) error
+	Update(ctx context.Context, c domain.Channel) error
+	Get(ctx context.Context, id string) (*domain.Channel, error)
+	List(ctx context.Context) ([]domain.Channel, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ChannelRepo struct {
+	db database.DynamoAPI
+}
+
+var _ ChannelRepository = (*ChannelRepo)(nil)
+
+func NewChannelRepository(db database.DynamoAPI) ChannelRepository {
+	return &ChannelRepo{db: db}
+}
+
+// Create stores a new channel, failing with ErrDuplicateSlug if the ID is
+// taken
+func (r *ChannelRepo) Create(ctx context.Context, c domain.Channel) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelRepository.Create")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	return r.put(ctx, c, "attribute_not_exists(slug)", apperror.ErrDuplicateSlug)
+}
+
+// Update replaces an existing channel, failing with ErrNotFound if there is
+// none
+func (r *ChannelRepo) Update(ctx context.Context, c domain.Channel) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelRepository.Update")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	return r.put(ctx, c, "attribute_exists(slug)", apperror.ErrNotFound)
+}
+
+func (r *ChannelRepo) put(ctx context.Context, c domain.Channel, condition string, onFailed error) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	c.PK = channelsPK
+	c.SK = channelSK(c.ID)
+
+	item, err := attributevalue.MarshalMap(c)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           awsString(r.db.TableName()),
+		Item:                stamped(item),
+		ConditionExpression: awsString(condition),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("channel %s: %w", c.ID, onFailed)
+	}
+	return translateError(err, "")
+}
+
+func (r *ChannelRepo) Get(ctx context.Context, id string) (_ *domain.Channel, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelRepository.Get")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(channelsPK, channelSK(id)),
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("channel %s: %w", id, apperror.ErrNotFound)
+	}
+	var c domain.Channel
+	if err := attributevalue.UnmarshalMap(out.Item, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List returns every channel in ID order
+func (r *ChannelRepo) List(ctx context.Context) (_ []domain.Channel, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelRepository.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug":   &types.AttributeValueMemberS{Value: channelsPK},
+			":prefix": &types.AttributeValueMemberS{Value: channelSKPrefix},
+		},
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	channels := []domain.Channel{}
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &channels); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(channels)))
+	return channels, nil
+}
+
+// Delete removes a channel, failing with ErrNotFound if there is none. Shows
+// still referencing it are left alone and simply stop resolving it.
+func (r *ChannelRepo) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelRepository.Delete")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	// Marked first, so that the stream can tell the delete is the service's
+	key := itemKey(channelsPK, channelSK(id))
+	mark := markDeleting(r.db.TableName(), key)
+	mark.Update.ConditionExpression = awsString("attribute_exists(slug)")
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{mark},
+	})
+	if err := translateTransactError(err, fmt.Errorf("channel %s: %w", id, apperror.ErrNotFound)); err != nil {
+		return err
+	}
+
+	_, err = r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           awsString(r.db.TableName()),
+		Key:                 key,
+		ConditionExpression: awsString("attribute_exists(slug)"),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("channel %s: %w", id, apperror.ErrNotFound)
+	}
+	return translateError(err, "")
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func TestChannelRepo_CreateAndUpdate(t *testing.T) {
+	channel := domain.Channel{ID: "nine", Name: "Nine"}
+
+	t.Run("create writes into the channels partition", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.AnythingOfType("*dynamodb.PutItemInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.PutItemInput)
+				require.Equal(t, &types.AttributeValueMemberS{Value: "channels"}, in.Item["slug"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "CHANNEL#nine"}, in.Item["sk"])
+				require.Equal(t, "attribute_not_exists(slug)", *in.ConditionExpression)
+			}).
+			Return(&dynamodb.PutItemOutput{}, nil)
+
+		require.NoError(t, NewChannelRepository(mockDB).Create(context.Background(), channel))
+	})
+
+	t.Run("create duplicate", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		err := NewChannelRepository(mockDB).Create(context.Background(), channel)
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+
+	t.Run("update missing", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attribute_exists(slug)"
+		})).Return(nil, &types.ConditionalCheckFailedException{})
+
+		err := NewChannelRepository(mockDB).Update(context.Background(), channel)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("invalid channel", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		err := NewChannelRepository(mockDB).Create(context.Background(), domain.Channel{ID: "nine"})
+		require.Error(t, err)
+	})
+}
+
+func TestChannelRepo_Get(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.AnythingOfType("*dynamodb.GetItemInput")).
+			Run(func(args mock.Arguments) {
+				require.Equal(t, itemKey("channels", "CHANNEL#nine"), args.Get(1).(*dynamodb.GetItemInput).Key)
+			}).
+			Return(&dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+				"id":   &types.AttributeValueMemberS{Value: "nine"},
+				"name": &types.AttributeValueMemberS{Value: "Nine"},
+			}}, nil)
+
+		got, err := NewChannelRepository(mockDB).Get(context.Background(), "nine")
+		require.NoError(t, err)
+		require.Equal(t, "Nine", got.Name)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		_, err := NewChannelRepository(mockDB).Get(context.Background(), "nine")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestChannelRepo_List(t *testing.T) {
+	t.Run("queries the channels partition", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.QueryInput)
+				require.Equal(t, &types.AttributeValueMemberS{Value: "channels"}, in.ExpressionAttributeValues[":slug"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "CHANNEL#"}, in.ExpressionAttributeValues[":prefix"])
+			}).
+			Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+				{"id": &types.AttributeValueMemberS{Value: "gem"}, "name": &types.AttributeValueMemberS{Value: "GEM"}},
+			}}, nil)
+
+		got, err := NewChannelRepository(mockDB).List(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err := NewChannelRepository(mockDB).List(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestChannelRepo_Delete(t *testing.T) {
+	t.Run("deleted", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.MatchedBy(func(in *dynamodb.TransactWriteItemsInput) bool {
+			mark := in.TransactItems[0].Update
+			return mark != nil && mark.ExpressionAttributeValues[":deleting"] != nil
+		})).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+		mockDB.On("DeleteItem", mock.Anything, mock.AnythingOfType("*dynamodb.DeleteItemInput")).
+			Return(&dynamodb.DeleteItemOutput{}, nil)
+
+		require.NoError(t, NewChannelRepository(mockDB).Delete(context.Background(), "nine"))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{{Code: awsString("ConditionalCheckFailed")}},
+		})
+
+		err := NewChannelRepository(mockDB).Delete(context.Background(), "nine")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// CheckpointRepository stores how far stream consumers have read each shard.
+// A consumer claims a shard before reading it, and each later save is
+// conditional on still holding the claim.
+type CheckpointRepository interface {
+	Get(ctx context.Context, consumer, shardID string) (*domain.ShardCheckpoint, error)
+	Claim(ctx context.Context, cp domain.ShardCheckpoint, owner string, now, until time.Time) (*domain.ShardCheckpoint, error)
+	Save(ctx context.Context, cp domain.ShardCheckpoint) error
+}
+
+type CheckpointRepo struct {
+	db database.DynamoAPI
+}
+
+var _ CheckpointRepository = (*CheckpointRepo)(nil)
+
+func NewCheckpointRepository(db database.DynamoAPI) CheckpointRepository {
+	return &CheckpointRepo{db: db}
+}
+
+// Get returns the checkpoint of one shard, failing with ErrNotFound if the
+// consumer has not claimed it yet
+func (r *CheckpointRepo) Get(ctx context.Context, consumer, shardID string) (_ *domain.ShardCheckpoint, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "CheckpointRepository.Get")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      awsString(r.db.TableName()),
+		Key:            itemKey(checkpointPK(consumer), shardSK(shardID)),
+		ConsistentRead: awsBool(true),
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("shard %s: %w", shardID, apperror.ErrNotFound)
+	}
+	var cp domain.ShardCheckpoint
+	if err := attributevalue.UnmarshalMap(out.Item, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// Claim leases cp's shard to owner until then. It fails with ErrConflict if
+// another owner holds an unexpired lease.
+func (r *CheckpointRepo) Claim(ctx context.Context, cp domain.ShardCheckpoint, owner string, now, until time.Time) (_ *domain.ShardCheckpoint, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "CheckpointRepository.Claim")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	cp.Owner = owner
+	cp.LeaseUntil = until.UTC().Format(domain.SortableTime)
+	cp.UpdatedAt = now.UTC().Format(time.RFC3339)
+	err = r.put(ctx, cp, "attribute_not_exists(slug) OR #owner = :owner OR leaseUntil < :now", map[string]types.AttributeValue{
+		":owner": &types.AttributeValueMemberS{Value: owner},
+		":now":   &types.AttributeValueMemberS{Value: now.UTC().Format(domain.SortableTime)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// Save stores cp's progress. It fails with ErrConflict if cp.Owner lost the
+// shard to another consumer.
+func (r *CheckpointRepo) Save(ctx context.Context, cp domain.ShardCheckpoint) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "CheckpointRepository.Save")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	return r.put(ctx, cp, "#owner = :owner", map[string]types.AttributeValue{
+		":owner": &types.AttributeValueMemberS{Value: cp.Owner},
+	})
+}
+
+func (r *CheckpointRepo) put(ctx context.Context, cp domain.ShardCheckpoint, condition string, values map[string]types.AttributeValue) error {
+	cp.PK = checkpointPK(cp.Consumer)
+	cp.SK = shardSK(cp.ShardID)
+	item, err := attributevalue.MarshalMap(cp)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 awsString(r.db.TableName()),
+		Item:                      item,
+		ConditionExpression:       awsString(condition),
+		ExpressionAttributeNames:  map[string]string{"#owner": "owner"},
+		ExpressionAttributeValues: values,
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("shard %s: %w", cp.ShardID, apperror.ErrConflict)
+	}
+	return translateError(err, "")
+}
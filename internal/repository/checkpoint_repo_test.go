@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func TestCheckpointRepo_Get(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+		cp := domain.ShardCheckpoint{Consumer: "show-service", ShardID: "shard-1", SequenceNumber: "100", PK: "changefeed/show-service", SK: "SHARD#shard-1"}
+		item, err := attributevalue.MarshalMap(cp)
+		require.NoError(t, err)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+			return *in.ConsistentRead
+		})).Run(func(args mock.Arguments) {
+			require.Equal(t, itemKey("changefeed/show-service", "SHARD#shard-1"), args.Get(1).(*dynamodb.GetItemInput).Key)
+		}).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+
+		got, err := NewCheckpointRepository(mockDB).Get(context.Background(), "show-service", "shard-1")
+		require.NoError(t, err)
+		require.Equal(t, cp, *got)
+	})
+
+	t.Run("not claimed yet", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		_, err := NewCheckpointRepository(mockDB).Get(context.Background(), "show-service", "shard-1")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestCheckpointRepo_Claim(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	cp := domain.ShardCheckpoint{Consumer: "show-service", ShardID: "shard-1", SequenceNumber: "100", Owner: "other", LeaseUntil: "2024-06-14T23:59:00Z"}
+
+	t.Run("leases the shard", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var stored domain.ShardCheckpoint
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attribute_not_exists(slug) OR #owner = :owner OR leaseUntil < :now" &&
+				in.ExpressionAttributeValues[":owner"].(*types.AttributeValueMemberS).Value == "me" &&
+				in.ExpressionAttributeValues[":now"].(*types.AttributeValueMemberS).Value == "2024-06-15T00:00:00Z"
+		})).Run(func(args mock.Arguments) {
+			require.NoError(t, attributevalue.UnmarshalMap(args.Get(1).(*dynamodb.PutItemInput).Item, &stored))
+		}).Return(&dynamodb.PutItemOutput{}, nil)
+
+		claimed, err := NewCheckpointRepository(mockDB).Claim(context.Background(), cp, "me", now, now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.Equal(t, "me", claimed.Owner)
+		require.Equal(t, "2024-06-15T00:00:30Z", claimed.LeaseUntil)
+		require.Equal(t, "100", claimed.SequenceNumber)
+		require.Equal(t, "changefeed/show-service", stored.PK)
+		require.Equal(t, "SHARD#shard-1", stored.SK)
+		require.Equal(t, "me", stored.Owner)
+	})
+
+	t.Run("leased elsewhere", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		_, err := NewCheckpointRepository(mockDB).Claim(context.Background(), cp, "me", now, now.Add(30*time.Second))
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestCheckpointRepo_Save(t *testing.T) {
+	t.Run("while holding the lease", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "#owner = :owner" &&
+				in.ExpressionAttributeValues[":owner"].(*types.AttributeValueMemberS).Value == "me"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		err := NewCheckpointRepository(mockDB).Save(context.Background(), domain.ShardCheckpoint{Consumer: "show-service", ShardID: "shard-1", SequenceNumber: "200", Owner: "me"})
+		require.NoError(t, err)
+	})
+
+	t.Run("lease lost", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		err := NewCheckpointRepository(mockDB).Save(context.Background(), domain.ShardCheckpoint{Consumer: "show-service", ShardID: "shard-1", Owner: "me"})
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		pk, sk  string
+		aliasOf bool
+		want    string
+	}{
+		{"show/a", "SHOW", false, KindShow},
+		{"show/old", "SHOW", true, KindAlias},
+		{"show/a", "SEASON#0001", false, KindSeason},
+		{"show/a", "EPISODE#0001#0002", false, KindEpisode},
+		{"show/a", "REV#000003", false, KindRevision},
+		{"channels", "CHANNEL#abc", false, KindChannel},
+		{"outbox", "EVENT#2024-06-15T00:00:00Z#e1", false, KindOutbox},
+		{"webhooks", "WEBHOOK#w1", false, KindWebhook},
+		{"webhook/w1", "DELIVERY#d1", false, KindDelivery},
+		{"webhook-due", "DELIVERY#w1#d1", false, KindDelivery},
+		{"changefeed/show-service", "SHARD#s1", false, KindCheckpoint},
+		{"show/a", "OTHER", false, KindUnknown},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.pk, tt.sk, tt.aliasOf); got != tt.want {
+			t.Errorf("KindOf(%q, %q, %v) = %q, want %q", tt.pk, tt.sk, tt.aliasOf, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// Shows, seasons, episodes and show revisions share a partition keyed by the
+// show slug. The sort key tells them apart; numbers are zero-padded so that
+// sort order is numeric order, and "EPISODE#" < "REV#" < "SEASON#" < "SHOW"
+// keeps each kind contiguous.
+const (
+	showSK           = "SHOW"
+	seasonSKPrefix   = "SEASON#"
+	episodeSKPrefix  = "EPISODE#"
+	revisionSKPrefix = "REV#"
+)
+
+// Channels are few, so they share one partition and List is a single query
+const (
+	channelsPK      = "channels"
+	channelSKPrefix = "CHANNEL#"
+)
+
+// Change events wait in one outbox partition, keyed by when they are next
+// due, until the dispatcher publishes them
+const (
+	outboxPK       = "outbox"
+	outboxSKPrefix = "EVENT#"
+)
+
+// Webhooks share one partition too. Each webhook logs its deliveries in a
+// partition of its own, where IDs sort oldest first; deliveries still to be
+// made are also kept in one due partition, for the dispatcher.
+const (
+	webhooksPK         = "webhooks"
+	webhookSKPrefix    = "WEBHOOK#"
+	deliverySKPrefix   = "DELIVERY#"
+	deliveriesPKPrefix = "webhook/"
+	webhookDuePK       = "webhook-due"
+)
+
+// Stream consumers checkpoint each shard in a partition per consumer name
+const (
+	checkpointPKPrefix = "changefeed/"
+	shardSKPrefix      = "SHARD#"
+)
+
+// Kinds of item stored in the shows table, as told apart by KindOf
+const (
+	KindShow       = "show"
+	KindAlias      = "alias" // the stub left at a renamed show's former slug
+	KindSeason     = "season"
+	KindEpisode    = "episode"
+	KindRevision   = "revision"
+	KindChannel    = "channel"
+	KindOutbox     = "outbox"
+	KindWebhook    = "webhook"
+	KindDelivery   = "delivery"
+	KindCheckpoint = "checkpoint"
+	KindUnknown    = "unknown"
+)
+
+// KindOf tells what kind of item is stored under the key pk, sk. Show
+// items are told from aliases by whether the item has aliasOf.
+func KindOf(pk, sk string, aliasOf bool) string {
+	switch {
+	case pk == channelsPK:
+		return KindChannel
+	case pk == outboxPK:
+		return KindOutbox
+	case pk == webhooksPK:
+		return KindWebhook
+	case pk == webhookDuePK || strings.HasPrefix(pk, deliveriesPKPrefix):
+		return KindDelivery
+	case strings.HasPrefix(pk, checkpointPKPrefix):
+		return KindCheckpoint
+	case sk == showSK && aliasOf:
+		return KindAlias
+	case sk == showSK:
+		return KindShow
+	case strings.HasPrefix(sk, seasonSKPrefix):
+		return KindSeason
+	case strings.HasPrefix(sk, episodeSKPrefix):
+		return KindEpisode
+	case strings.HasPrefix(sk, revisionSKPrefix):
+		return KindRevision
+	}
+	return KindUnknown
+}
+
+// Show, alias, season, episode and channel items written by the service carry
+// a write ID, new on every write, so that stream consumers can tell its writes
+// from others: a change that sets a new write ID is the service's. Items the
+// service deletes are first marked as deleting, for the same reason.
+const (
+	WriteIDAttr  = "writeId"
+	DeletingAttr = "deleting"
+)
+
+func checkpointPK(consumer string) string {
+	return checkpointPKPrefix + consumer
+}
+
+func shardSK(shardID string) string {
+	return shardSKPrefix + shardID
+}
+
+func webhookSK(id string) string {
+	return webhookSKPrefix + id
+}
+
+func deliveriesPK(webhookID string) string {
+	return deliveriesPKPrefix + webhookID
+}
+
+func deliverySK(id string) string {
+	return deliverySKPrefix + id
+}
+
+func dueDeliverySK(webhookID, id string) string {
+	return deliverySKPrefix + webhookID + "#" + id
+}
+
+func outboxSK(at time.Time, id string) string {
+	return outboxSKPrefix + at.UTC().Format(domain.SortableTime) + "#" + id
+}
+
+// outboxDueSK sorts after the key of every event due at or before at: "$"
+// sorts just after the "#" ending the time
+func outboxDueSK(at time.Time) string {
+	return outboxSKPrefix + at.UTC().Format(domain.SortableTime) + "$"
+}
+
+func channelSK(id string) string {
+	return channelSKPrefix + id
+}
+
+func seasonSK(number int) string {
+	return fmt.Sprintf("%s%04d", seasonSKPrefix, number)
+}
+
+func episodeSK(season, number int) string {
+	return fmt.Sprintf("%s%04d#%04d", episodeSKPrefix, season, number)
+}
+
+func revisionSK(number int) string {
+	return fmt.Sprintf("%s%06d", revisionSKPrefix, number)
+}
+
+// episodeSKSeasonPrefix matches every episode of one season
+func episodeSKSeasonPrefix(season int) string {
+	return fmt.Sprintf("%s%04d#", episodeSKPrefix, season)
+}
@@ -0,0 +1,257 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCheckpointRepository creates a new instance of MockCheckpointRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCheckpointRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCheckpointRepository {
+	mock := &MockCheckpointRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCheckpointRepository is an autogenerated mock type for the CheckpointRepository type
+type MockCheckpointRepository struct {
+	mock.Mock
+}
+
+type MockCheckpointRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCheckpointRepository) EXPECT() *MockCheckpointRepository_Expecter {
+	return &MockCheckpointRepository_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function for the type MockCheckpointRepository
+func (_mock *MockCheckpointRepository) Claim(ctx context.Context, cp domain.ShardCheckpoint, owner string, now time.Time, until time.Time) (*domain.ShardCheckpoint, error) {
+	ret := _mock.Called(ctx, cp, owner, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 *domain.ShardCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ShardCheckpoint, string, time.Time, time.Time) (*domain.ShardCheckpoint, error)); ok {
+		return returnFunc(ctx, cp, owner, now, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ShardCheckpoint, string, time.Time, time.Time) *domain.ShardCheckpoint); ok {
+		r0 = returnFunc(ctx, cp, owner, now, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ShardCheckpoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ShardCheckpoint, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, cp, owner, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCheckpointRepository_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockCheckpointRepository_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cp domain.ShardCheckpoint
+//   - owner string
+//   - now time.Time
+//   - until time.Time
+func (_e *MockCheckpointRepository_Expecter) Claim(ctx interface{}, cp interface{}, owner interface{}, now interface{}, until interface{}) *MockCheckpointRepository_Claim_Call {
+	return &MockCheckpointRepository_Claim_Call{Call: _e.mock.On("Claim", ctx, cp, owner, now, until)}
+}
+
+func (_c *MockCheckpointRepository_Claim_Call) Run(run func(ctx context.Context, cp domain.ShardCheckpoint, owner string, now time.Time, until time.Time)) *MockCheckpointRepository_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ShardCheckpoint
+		if args[1] != nil {
+			arg1 = args[1].(domain.ShardCheckpoint)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Claim_Call) Return(shardCheckpoint *domain.ShardCheckpoint, err error) *MockCheckpointRepository_Claim_Call {
+	_c.Call.Return(shardCheckpoint, err)
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Claim_Call) RunAndReturn(run func(ctx context.Context, cp domain.ShardCheckpoint, owner string, now time.Time, until time.Time) (*domain.ShardCheckpoint, error)) *MockCheckpointRepository_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockCheckpointRepository
+func (_mock *MockCheckpointRepository) Get(ctx context.Context, consumer string, shardID string) (*domain.ShardCheckpoint, error) {
+	ret := _mock.Called(ctx, consumer, shardID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.ShardCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ShardCheckpoint, error)); ok {
+		return returnFunc(ctx, consumer, shardID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.ShardCheckpoint); ok {
+		r0 = returnFunc(ctx, consumer, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ShardCheckpoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, consumer, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCheckpointRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCheckpointRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - consumer string
+//   - shardID string
+func (_e *MockCheckpointRepository_Expecter) Get(ctx interface{}, consumer interface{}, shardID interface{}) *MockCheckpointRepository_Get_Call {
+	return &MockCheckpointRepository_Get_Call{Call: _e.mock.On("Get", ctx, consumer, shardID)}
+}
+
+func (_c *MockCheckpointRepository_Get_Call) Run(run func(ctx context.Context, consumer string, shardID string)) *MockCheckpointRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Get_Call) Return(shardCheckpoint *domain.ShardCheckpoint, err error) *MockCheckpointRepository_Get_Call {
+	_c.Call.Return(shardCheckpoint, err)
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Get_Call) RunAndReturn(run func(ctx context.Context, consumer string, shardID string) (*domain.ShardCheckpoint, error)) *MockCheckpointRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockCheckpointRepository
+func (_mock *MockCheckpointRepository) Save(ctx context.Context, cp domain.ShardCheckpoint) error {
+	ret := _mock.Called(ctx, cp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ShardCheckpoint) error); ok {
+		r0 = returnFunc(ctx, cp)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCheckpointRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockCheckpointRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cp domain.ShardCheckpoint
+func (_e *MockCheckpointRepository_Expecter) Save(ctx interface{}, cp interface{}) *MockCheckpointRepository_Save_Call {
+	return &MockCheckpointRepository_Save_Call{Call: _e.mock.On("Save", ctx, cp)}
+}
+
+func (_c *MockCheckpointRepository_Save_Call) Run(run func(ctx context.Context, cp domain.ShardCheckpoint)) *MockCheckpointRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ShardCheckpoint
+		if args[1] != nil {
+			arg1 = args[1].(domain.ShardCheckpoint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Save_Call) Return(err error) *MockCheckpointRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCheckpointRepository_Save_Call) RunAndReturn(run func(ctx context.Context, cp domain.ShardCheckpoint) error) *MockCheckpointRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
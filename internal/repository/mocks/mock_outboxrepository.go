@@ -0,0 +1,314 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+type MockOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepository) EXPECT() *MockOutboxRepository_Expecter {
+	return &MockOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) Claim(ctx context.Context, e domain.OutboxEntry, until time.Time) (*domain.OutboxEntry, error) {
+	ret := _mock.Called(ctx, e, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 *domain.OutboxEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEntry, time.Time) (*domain.OutboxEntry, error)); ok {
+		return returnFunc(ctx, e, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEntry, time.Time) *domain.OutboxEntry); ok {
+		r0 = returnFunc(ctx, e, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OutboxEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.OutboxEntry, time.Time) error); ok {
+		r1 = returnFunc(ctx, e, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockOutboxRepository_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.OutboxEntry
+//   - until time.Time
+func (_e *MockOutboxRepository_Expecter) Claim(ctx interface{}, e interface{}, until interface{}) *MockOutboxRepository_Claim_Call {
+	return &MockOutboxRepository_Claim_Call{Call: _e.mock.On("Claim", ctx, e, until)}
+}
+
+func (_c *MockOutboxRepository_Claim_Call) Run(run func(ctx context.Context, e domain.OutboxEntry, until time.Time)) *MockOutboxRepository_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.OutboxEntry
+		if args[1] != nil {
+			arg1 = args[1].(domain.OutboxEntry)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_Claim_Call) Return(outboxEntry *domain.OutboxEntry, err error) *MockOutboxRepository_Claim_Call {
+	_c.Call.Return(outboxEntry, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_Claim_Call) RunAndReturn(run func(ctx context.Context, e domain.OutboxEntry, until time.Time) (*domain.OutboxEntry, error)) *MockOutboxRepository_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) Delete(ctx context.Context, e domain.OutboxEntry) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEntry) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockOutboxRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.OutboxEntry
+func (_e *MockOutboxRepository_Expecter) Delete(ctx interface{}, e interface{}) *MockOutboxRepository_Delete_Call {
+	return &MockOutboxRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, e)}
+}
+
+func (_c *MockOutboxRepository_Delete_Call) Run(run func(ctx context.Context, e domain.OutboxEntry)) *MockOutboxRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.OutboxEntry
+		if args[1] != nil {
+			arg1 = args[1].(domain.OutboxEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_Delete_Call) Return(err error) *MockOutboxRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, e domain.OutboxEntry) error) *MockOutboxRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Due provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) Due(ctx context.Context, at time.Time, limit int) ([]domain.OutboxEntry, error) {
+	ret := _mock.Called(ctx, at, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Due")
+	}
+
+	var r0 []domain.OutboxEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.OutboxEntry, error)); ok {
+		return returnFunc(ctx, at, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.OutboxEntry); ok {
+		r0 = returnFunc(ctx, at, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, at, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_Due_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Due'
+type MockOutboxRepository_Due_Call struct {
+	*mock.Call
+}
+
+// Due is a helper method to define mock.On call
+//   - ctx context.Context
+//   - at time.Time
+//   - limit int
+func (_e *MockOutboxRepository_Expecter) Due(ctx interface{}, at interface{}, limit interface{}) *MockOutboxRepository_Due_Call {
+	return &MockOutboxRepository_Due_Call{Call: _e.mock.On("Due", ctx, at, limit)}
+}
+
+func (_c *MockOutboxRepository_Due_Call) Run(run func(ctx context.Context, at time.Time, limit int)) *MockOutboxRepository_Due_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_Due_Call) Return(outboxEntries []domain.OutboxEntry, err error) *MockOutboxRepository_Due_Call {
+	_c.Call.Return(outboxEntries, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_Due_Call) RunAndReturn(run func(ctx context.Context, at time.Time, limit int) ([]domain.OutboxEntry, error)) *MockOutboxRepository_Due_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) Release(ctx context.Context, e domain.OutboxEntry, retryAt time.Time, cause error) error {
+	ret := _mock.Called(ctx, e, retryAt, cause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.OutboxEntry, time.Time, error) error); ok {
+		r0 = returnFunc(ctx, e, retryAt, cause)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockOutboxRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.OutboxEntry
+//   - retryAt time.Time
+//   - cause error
+func (_e *MockOutboxRepository_Expecter) Release(ctx interface{}, e interface{}, retryAt interface{}, cause interface{}) *MockOutboxRepository_Release_Call {
+	return &MockOutboxRepository_Release_Call{Call: _e.mock.On("Release", ctx, e, retryAt, cause)}
+}
+
+func (_c *MockOutboxRepository_Release_Call) Run(run func(ctx context.Context, e domain.OutboxEntry, retryAt time.Time, cause error)) *MockOutboxRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.OutboxEntry
+		if args[1] != nil {
+			arg1 = args[1].(domain.OutboxEntry)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 error
+		if args[3] != nil {
+			arg3 = args[3].(error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_Release_Call) Return(err error) *MockOutboxRepository_Release_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_Release_Call) RunAndReturn(run func(ctx context.Context, e domain.OutboxEntry, retryAt time.Time, cause error) error) *MockOutboxRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
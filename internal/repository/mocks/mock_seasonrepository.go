@@ -0,0 +1,295 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSeasonRepository creates a new instance of MockSeasonRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSeasonRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSeasonRepository {
+	mock := &MockSeasonRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSeasonRepository is an autogenerated mock type for the SeasonRepository type
+type MockSeasonRepository struct {
+	mock.Mock
+}
+
+type MockSeasonRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSeasonRepository) EXPECT() *MockSeasonRepository_Expecter {
+	return &MockSeasonRepository_Expecter{mock: &_m.Mock}
+}
+
+// ListEpisodes provides a mock function for the type MockSeasonRepository
+func (_mock *MockSeasonRepository) ListEpisodes(ctx context.Context, showSlug string, season int) ([]domain.Episode, error) {
+	ret := _mock.Called(ctx, showSlug, season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEpisodes")
+	}
+
+	var r0 []domain.Episode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Episode, error)); ok {
+		return returnFunc(ctx, showSlug, season)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []domain.Episode); ok {
+		r0 = returnFunc(ctx, showSlug, season)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Episode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, showSlug, season)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSeasonRepository_ListEpisodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEpisodes'
+type MockSeasonRepository_ListEpisodes_Call struct {
+	*mock.Call
+}
+
+// ListEpisodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - showSlug string
+//   - season int
+func (_e *MockSeasonRepository_Expecter) ListEpisodes(ctx interface{}, showSlug interface{}, season interface{}) *MockSeasonRepository_ListEpisodes_Call {
+	return &MockSeasonRepository_ListEpisodes_Call{Call: _e.mock.On("ListEpisodes", ctx, showSlug, season)}
+}
+
+func (_c *MockSeasonRepository_ListEpisodes_Call) Run(run func(ctx context.Context, showSlug string, season int)) *MockSeasonRepository_ListEpisodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonRepository_ListEpisodes_Call) Return(episodes []domain.Episode, err error) *MockSeasonRepository_ListEpisodes_Call {
+	_c.Call.Return(episodes, err)
+	return _c
+}
+
+func (_c *MockSeasonRepository_ListEpisodes_Call) RunAndReturn(run func(ctx context.Context, showSlug string, season int) ([]domain.Episode, error)) *MockSeasonRepository_ListEpisodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSeasons provides a mock function for the type MockSeasonRepository
+func (_mock *MockSeasonRepository) ListSeasons(ctx context.Context, showSlug string) ([]domain.SeasonDetail, error) {
+	ret := _mock.Called(ctx, showSlug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSeasons")
+	}
+
+	var r0 []domain.SeasonDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SeasonDetail, error)); ok {
+		return returnFunc(ctx, showSlug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SeasonDetail); ok {
+		r0 = returnFunc(ctx, showSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SeasonDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, showSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSeasonRepository_ListSeasons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSeasons'
+type MockSeasonRepository_ListSeasons_Call struct {
+	*mock.Call
+}
+
+// ListSeasons is a helper method to define mock.On call
+//   - ctx context.Context
+//   - showSlug string
+func (_e *MockSeasonRepository_Expecter) ListSeasons(ctx interface{}, showSlug interface{}) *MockSeasonRepository_ListSeasons_Call {
+	return &MockSeasonRepository_ListSeasons_Call{Call: _e.mock.On("ListSeasons", ctx, showSlug)}
+}
+
+func (_c *MockSeasonRepository_ListSeasons_Call) Run(run func(ctx context.Context, showSlug string)) *MockSeasonRepository_ListSeasons_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonRepository_ListSeasons_Call) Return(seasonDetails []domain.SeasonDetail, err error) *MockSeasonRepository_ListSeasons_Call {
+	_c.Call.Return(seasonDetails, err)
+	return _c
+}
+
+func (_c *MockSeasonRepository_ListSeasons_Call) RunAndReturn(run func(ctx context.Context, showSlug string) ([]domain.SeasonDetail, error)) *MockSeasonRepository_ListSeasons_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutEpisode provides a mock function for the type MockSeasonRepository
+func (_mock *MockSeasonRepository) PutEpisode(ctx context.Context, e domain.Episode) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutEpisode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Episode) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSeasonRepository_PutEpisode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutEpisode'
+type MockSeasonRepository_PutEpisode_Call struct {
+	*mock.Call
+}
+
+// PutEpisode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Episode
+func (_e *MockSeasonRepository_Expecter) PutEpisode(ctx interface{}, e interface{}) *MockSeasonRepository_PutEpisode_Call {
+	return &MockSeasonRepository_PutEpisode_Call{Call: _e.mock.On("PutEpisode", ctx, e)}
+}
+
+func (_c *MockSeasonRepository_PutEpisode_Call) Run(run func(ctx context.Context, e domain.Episode)) *MockSeasonRepository_PutEpisode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Episode
+		if args[1] != nil {
+			arg1 = args[1].(domain.Episode)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonRepository_PutEpisode_Call) Return(err error) *MockSeasonRepository_PutEpisode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSeasonRepository_PutEpisode_Call) RunAndReturn(run func(ctx context.Context, e domain.Episode) error) *MockSeasonRepository_PutEpisode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutSeason provides a mock function for the type MockSeasonRepository
+func (_mock *MockSeasonRepository) PutSeason(ctx context.Context, s domain.SeasonDetail) error {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutSeason")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SeasonDetail) error); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSeasonRepository_PutSeason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutSeason'
+type MockSeasonRepository_PutSeason_Call struct {
+	*mock.Call
+}
+
+// PutSeason is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s domain.SeasonDetail
+func (_e *MockSeasonRepository_Expecter) PutSeason(ctx interface{}, s interface{}) *MockSeasonRepository_PutSeason_Call {
+	return &MockSeasonRepository_PutSeason_Call{Call: _e.mock.On("PutSeason", ctx, s)}
+}
+
+func (_c *MockSeasonRepository_PutSeason_Call) Run(run func(ctx context.Context, s domain.SeasonDetail)) *MockSeasonRepository_PutSeason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SeasonDetail
+		if args[1] != nil {
+			arg1 = args[1].(domain.SeasonDetail)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonRepository_PutSeason_Call) Return(err error) *MockSeasonRepository_PutSeason_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSeasonRepository_PutSeason_Call) RunAndReturn(run func(ctx context.Context, s domain.SeasonDetail) error) *MockSeasonRepository_PutSeason_Call {
+	_c.Call.Return(run)
+	return _c
+}
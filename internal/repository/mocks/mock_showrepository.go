@@ -5,6 +5,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/marciomarinho/show-service/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,9 +39,151 @@ func (_m *MockShowRepository) EXPECT() *MockShowRepository_Expecter {
 	return &MockShowRepository_Expecter{mock: &_m.Mock}
 }
 
+// Get provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) Get(ctx context.Context, slug string) (*domain.Show, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Show, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Show); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockShowRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockShowRepository_Expecter) Get(ctx interface{}, slug interface{}) *MockShowRepository_Get_Call {
+	return &MockShowRepository_Get_Call{Call: _e.mock.On("Get", ctx, slug)}
+}
+
+func (_c *MockShowRepository_Get_Call) Run(run func(ctx context.Context, slug string)) *MockShowRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_Get_Call) Return(show *domain.Show, err error) *MockShowRepository_Get_Call {
+	_c.Call.Return(show, err)
+	return _c
+}
+
+func (_c *MockShowRepository_Get_Call) RunAndReturn(run func(ctx context.Context, slug string) (*domain.Show, error)) *MockShowRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevision provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) GetRevision(ctx context.Context, slug string, n int) (*domain.Revision, error) {
+	ret := _mock.Called(ctx, slug, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *domain.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*domain.Revision, error)); ok {
+		return returnFunc(ctx, slug, n)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *domain.Revision); ok {
+		r0 = returnFunc(ctx, slug, n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, slug, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type MockShowRepository_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - n int
+func (_e *MockShowRepository_Expecter) GetRevision(ctx interface{}, slug interface{}, n interface{}) *MockShowRepository_GetRevision_Call {
+	return &MockShowRepository_GetRevision_Call{Call: _e.mock.On("GetRevision", ctx, slug, n)}
+}
+
+func (_c *MockShowRepository_GetRevision_Call) Run(run func(ctx context.Context, slug string, n int)) *MockShowRepository_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_GetRevision_Call) Return(revision *domain.Revision, err error) *MockShowRepository_GetRevision_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *MockShowRepository_GetRevision_Call) RunAndReturn(run func(ctx context.Context, slug string, n int) (*domain.Revision, error)) *MockShowRepository_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockShowRepository
-func (_mock *MockShowRepository) List() ([]domain.Show, error) {
-	ret := _mock.Called()
+func (_mock *MockShowRepository) List(ctx context.Context) ([]domain.Show, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -46,18 +191,18 @@ func (_mock *MockShowRepository) List() ([]domain.Show, error) {
 
 	var r0 []domain.Show
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]domain.Show, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Show, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []domain.Show); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Show); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Show)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,13 +215,20 @@ type MockShowRepository_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
-func (_e *MockShowRepository_Expecter) List() *MockShowRepository_List_Call {
-	return &MockShowRepository_List_Call{Call: _e.mock.On("List")}
+//   - ctx context.Context
+func (_e *MockShowRepository_Expecter) List(ctx interface{}) *MockShowRepository_List_Call {
+	return &MockShowRepository_List_Call{Call: _e.mock.On("List", ctx)}
 }
 
-func (_c *MockShowRepository_List_Call) Run(run func()) *MockShowRepository_List_Call {
+func (_c *MockShowRepository_List_Call) Run(run func(ctx context.Context)) *MockShowRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -86,22 +238,226 @@ func (_c *MockShowRepository_List_Call) Return(shows []domain.Show, err error) *
 	return _c
 }
 
-func (_c *MockShowRepository_List_Call) RunAndReturn(run func() ([]domain.Show, error)) *MockShowRepository_List_Call {
+func (_c *MockShowRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Show, error)) *MockShowRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAiring provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) ListAiring(ctx context.Context, from time.Time, to time.Time) ([]domain.Show, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAiring")
+	}
+
+	var r0 []domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]domain.Show, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []domain.Show); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_ListAiring_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAiring'
+type MockShowRepository_ListAiring_Call struct {
+	*mock.Call
+}
+
+// ListAiring is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *MockShowRepository_Expecter) ListAiring(ctx interface{}, from interface{}, to interface{}) *MockShowRepository_ListAiring_Call {
+	return &MockShowRepository_ListAiring_Call{Call: _e.mock.On("ListAiring", ctx, from, to)}
+}
+
+func (_c *MockShowRepository_ListAiring_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *MockShowRepository_ListAiring_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_ListAiring_Call) Return(shows []domain.Show, err error) *MockShowRepository_ListAiring_Call {
+	_c.Call.Return(shows, err)
+	return _c
+}
+
+func (_c *MockShowRepository_ListAiring_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time) ([]domain.Show, error)) *MockShowRepository_ListAiring_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAll provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) ListAll(ctx context.Context) ([]domain.Show, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAll")
+	}
+
+	var r0 []domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Show, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Show); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_ListAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAll'
+type MockShowRepository_ListAll_Call struct {
+	*mock.Call
+}
+
+// ListAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShowRepository_Expecter) ListAll(ctx interface{}) *MockShowRepository_ListAll_Call {
+	return &MockShowRepository_ListAll_Call{Call: _e.mock.On("ListAll", ctx)}
+}
+
+func (_c *MockShowRepository_ListAll_Call) Run(run func(ctx context.Context)) *MockShowRepository_ListAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_ListAll_Call) Return(shows []domain.Show, err error) *MockShowRepository_ListAll_Call {
+	_c.Call.Return(shows, err)
+	return _c
+}
+
+func (_c *MockShowRepository_ListAll_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Show, error)) *MockShowRepository_ListAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) ListRevisions(ctx context.Context, slug string) ([]domain.Revision, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []domain.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Revision, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Revision); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type MockShowRepository_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockShowRepository_Expecter) ListRevisions(ctx interface{}, slug interface{}) *MockShowRepository_ListRevisions_Call {
+	return &MockShowRepository_ListRevisions_Call{Call: _e.mock.On("ListRevisions", ctx, slug)}
+}
+
+func (_c *MockShowRepository_ListRevisions_Call) Run(run func(ctx context.Context, slug string)) *MockShowRepository_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_ListRevisions_Call) Return(revisions []domain.Revision, err error) *MockShowRepository_ListRevisions_Call {
+	_c.Call.Return(revisions, err)
+	return _c
+}
+
+func (_c *MockShowRepository_ListRevisions_Call) RunAndReturn(run func(ctx context.Context, slug string) ([]domain.Revision, error)) *MockShowRepository_ListRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Put provides a mock function for the type MockShowRepository
-func (_mock *MockShowRepository) Put(s domain.Show) error {
-	ret := _mock.Called(s)
+func (_mock *MockShowRepository) Put(ctx context.Context, s domain.Show, revisions []domain.Revision) error {
+	ret := _mock.Called(ctx, s, revisions)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Put")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(domain.Show) error); ok {
-		r0 = returnFunc(s)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Show, []domain.Revision) error); ok {
+		r0 = returnFunc(ctx, s, revisions)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -114,19 +470,31 @@ type MockShowRepository_Put_Call struct {
 }
 
 // Put is a helper method to define mock.On call
+//   - ctx context.Context
 //   - s domain.Show
-func (_e *MockShowRepository_Expecter) Put(s interface{}) *MockShowRepository_Put_Call {
-	return &MockShowRepository_Put_Call{Call: _e.mock.On("Put", s)}
+//   - revisions []domain.Revision
+func (_e *MockShowRepository_Expecter) Put(ctx interface{}, s interface{}, revisions interface{}) *MockShowRepository_Put_Call {
+	return &MockShowRepository_Put_Call{Call: _e.mock.On("Put", ctx, s, revisions)}
 }
 
-func (_c *MockShowRepository_Put_Call) Run(run func(s domain.Show)) *MockShowRepository_Put_Call {
+func (_c *MockShowRepository_Put_Call) Run(run func(ctx context.Context, s domain.Show, revisions []domain.Revision)) *MockShowRepository_Put_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 domain.Show
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(domain.Show)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Show
+		if args[1] != nil {
+			arg1 = args[1].(domain.Show)
+		}
+		var arg2 []domain.Revision
+		if args[2] != nil {
+			arg2 = args[2].([]domain.Revision)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -137,7 +505,144 @@ func (_c *MockShowRepository_Put_Call) Return(err error) *MockShowRepository_Put
 	return _c
 }
 
-func (_c *MockShowRepository_Put_Call) RunAndReturn(run func(s domain.Show) error) *MockShowRepository_Put_Call {
+func (_c *MockShowRepository_Put_Call) RunAndReturn(run func(ctx context.Context, s domain.Show, revisions []domain.Revision) error) *MockShowRepository_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) Rename(ctx context.Context, from string, to string) (*domain.Show, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 *domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Show, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Show); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockShowRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+func (_e *MockShowRepository_Expecter) Rename(ctx interface{}, from interface{}, to interface{}) *MockShowRepository_Rename_Call {
+	return &MockShowRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, from, to)}
+}
+
+func (_c *MockShowRepository_Rename_Call) Run(run func(ctx context.Context, from string, to string)) *MockShowRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_Rename_Call) Return(show *domain.Show, err error) *MockShowRepository_Rename_Call {
+	_c.Call.Return(show, err)
+	return _c
+}
+
+func (_c *MockShowRepository_Rename_Call) RunAndReturn(run func(ctx context.Context, from string, to string) (*domain.Show, error)) *MockShowRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockShowRepository
+func (_mock *MockShowRepository) Update(ctx context.Context, s domain.Show, revisions []domain.Revision) error {
+	ret := _mock.Called(ctx, s, revisions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Show, []domain.Revision) error); ok {
+		r0 = returnFunc(ctx, s, revisions)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockShowRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockShowRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s domain.Show
+//   - revisions []domain.Revision
+func (_e *MockShowRepository_Expecter) Update(ctx interface{}, s interface{}, revisions interface{}) *MockShowRepository_Update_Call {
+	return &MockShowRepository_Update_Call{Call: _e.mock.On("Update", ctx, s, revisions)}
+}
+
+func (_c *MockShowRepository_Update_Call) Run(run func(ctx context.Context, s domain.Show, revisions []domain.Revision)) *MockShowRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Show
+		if args[1] != nil {
+			arg1 = args[1].(domain.Show)
+		}
+		var arg2 []domain.Revision
+		if args[2] != nil {
+			arg2 = args[2].([]domain.Revision)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowRepository_Update_Call) Return(err error) *MockShowRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockShowRepository_Update_Call) RunAndReturn(run func(ctx context.Context, s domain.Show, revisions []domain.Revision) error) *MockShowRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
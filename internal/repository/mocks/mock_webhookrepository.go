@@ -0,0 +1,751 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookRepository creates a new instance of MockWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRepository {
+	mock := &MockWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookRepository is an autogenerated mock type for the WebhookRepository type
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+type MockWebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRepository) EXPECT() *MockWebhookRepository_Expecter {
+	return &MockWebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) AddDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	ret := _mock.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDelivery")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_AddDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDelivery'
+type MockWebhookRepository_AddDelivery_Call struct {
+	*mock.Call
+}
+
+// AddDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d domain.WebhookDelivery
+func (_e *MockWebhookRepository_Expecter) AddDelivery(ctx interface{}, d interface{}) *MockWebhookRepository_AddDelivery_Call {
+	return &MockWebhookRepository_AddDelivery_Call{Call: _e.mock.On("AddDelivery", ctx, d)}
+}
+
+func (_c *MockWebhookRepository_AddDelivery_Call) Run(run func(ctx context.Context, d domain.WebhookDelivery)) *MockWebhookRepository_AddDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].(domain.WebhookDelivery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_AddDelivery_Call) Return(err error) *MockWebhookRepository_AddDelivery_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_AddDelivery_Call) RunAndReturn(run func(ctx context.Context, d domain.WebhookDelivery) error) *MockWebhookRepository_AddDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ClaimDelivery(ctx context.Context, d domain.WebhookDelivery, until time.Time) (*domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, d, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDelivery")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery, time.Time) (*domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, d, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery, time.Time) *domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, d, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.WebhookDelivery, time.Time) error); ok {
+		r1 = returnFunc(ctx, d, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ClaimDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDelivery'
+type MockWebhookRepository_ClaimDelivery_Call struct {
+	*mock.Call
+}
+
+// ClaimDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d domain.WebhookDelivery
+//   - until time.Time
+func (_e *MockWebhookRepository_Expecter) ClaimDelivery(ctx interface{}, d interface{}, until interface{}) *MockWebhookRepository_ClaimDelivery_Call {
+	return &MockWebhookRepository_ClaimDelivery_Call{Call: _e.mock.On("ClaimDelivery", ctx, d, until)}
+}
+
+func (_c *MockWebhookRepository_ClaimDelivery_Call) Run(run func(ctx context.Context, d domain.WebhookDelivery, until time.Time)) *MockWebhookRepository_ClaimDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].(domain.WebhookDelivery)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ClaimDelivery_Call) Return(webhookDelivery *domain.WebhookDelivery, err error) *MockWebhookRepository_ClaimDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ClaimDelivery_Call) RunAndReturn(run func(ctx context.Context, d domain.WebhookDelivery, until time.Time) (*domain.WebhookDelivery, error)) *MockWebhookRepository_ClaimDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) Create(ctx context.Context, w domain.Webhook) error {
+	ret := _mock.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Webhook) error); ok {
+		r0 = returnFunc(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w domain.Webhook
+func (_e *MockWebhookRepository_Expecter) Create(ctx interface{}, w interface{}) *MockWebhookRepository_Create_Call {
+	return &MockWebhookRepository_Create_Call{Call: _e.mock.On("Create", ctx, w)}
+}
+
+func (_c *MockWebhookRepository_Create_Call) Run(run func(ctx context.Context, w domain.Webhook)) *MockWebhookRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Webhook
+		if args[1] != nil {
+			arg1 = args[1].(domain.Webhook)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) Return(err error) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Create_Call) RunAndReturn(run func(ctx context.Context, w domain.Webhook) error) *MockWebhookRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockWebhookRepository_Delete_Call {
+	return &MockWebhookRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockWebhookRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) Return(err error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockWebhookRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DueDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) DueDeliveries(ctx context.Context, at time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, at, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DueDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, at, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, at, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, at, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_DueDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DueDeliveries'
+type MockWebhookRepository_DueDeliveries_Call struct {
+	*mock.Call
+}
+
+// DueDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - at time.Time
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) DueDeliveries(ctx interface{}, at interface{}, limit interface{}) *MockWebhookRepository_DueDeliveries_Call {
+	return &MockWebhookRepository_DueDeliveries_Call{Call: _e.mock.On("DueDeliveries", ctx, at, limit)}
+}
+
+func (_c *MockWebhookRepository_DueDeliveries_Call) Run(run func(ctx context.Context, at time.Time, limit int)) *MockWebhookRepository_DueDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DueDeliveries_Call) Return(webhookDeliveries []domain.WebhookDelivery, err error) *MockWebhookRepository_DueDeliveries_Call {
+	_c.Call.Return(webhookDeliveries, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DueDeliveries_Call) RunAndReturn(run func(ctx context.Context, at time.Time, limit int) ([]domain.WebhookDelivery, error)) *MockWebhookRepository_DueDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) Get(ctx context.Context, id string) (*domain.Webhook, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Webhook, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Webhook); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockWebhookRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookRepository_Expecter) Get(ctx interface{}, id interface{}) *MockWebhookRepository_Get_Call {
+	return &MockWebhookRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_Get_Call) Run(run func(ctx context.Context, id string)) *MockWebhookRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Get_Call) Return(webhook *domain.Webhook, err error) *MockWebhookRepository_Get_Call {
+	_c.Call.Return(webhook, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.Webhook, error)) *MockWebhookRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) GetDelivery(ctx context.Context, webhookID string, id string) (*domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, webhookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, webhookID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, webhookID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, webhookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_GetDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelivery'
+type MockWebhookRepository_GetDelivery_Call struct {
+	*mock.Call
+}
+
+// GetDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+func (_e *MockWebhookRepository_Expecter) GetDelivery(ctx interface{}, webhookID interface{}, id interface{}) *MockWebhookRepository_GetDelivery_Call {
+	return &MockWebhookRepository_GetDelivery_Call{Call: _e.mock.On("GetDelivery", ctx, webhookID, id)}
+}
+
+func (_c *MockWebhookRepository_GetDelivery_Call) Run(run func(ctx context.Context, webhookID string, id string)) *MockWebhookRepository_GetDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDelivery_Call) Return(webhookDelivery *domain.WebhookDelivery, err error) *MockWebhookRepository_GetDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetDelivery_Call) RunAndReturn(run func(ctx context.Context, webhookID string, id string) (*domain.WebhookDelivery, error)) *MockWebhookRepository_GetDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) List(ctx context.Context) ([]domain.Webhook, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.Webhook, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.Webhook); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookRepository_Expecter) List(ctx interface{}) *MockWebhookRepository_List_Call {
+	return &MockWebhookRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockWebhookRepository_List_Call) Run(run func(ctx context.Context)) *MockWebhookRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_List_Call) Return(webhooks []domain.Webhook, err error) *MockWebhookRepository_List_Call {
+	_c.Call.Return(webhooks, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]domain.Webhook, error)) *MockWebhookRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 []domain.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, webhookID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockWebhookRepository_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) ListDeliveries(ctx interface{}, webhookID interface{}, limit interface{}) *MockWebhookRepository_ListDeliveries_Call {
+	return &MockWebhookRepository_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, webhookID, limit)}
+}
+
+func (_c *MockWebhookRepository_ListDeliveries_Call) Run(run func(ctx context.Context, webhookID string, limit int)) *MockWebhookRepository_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListDeliveries_Call) Return(webhookDeliveries []domain.WebhookDelivery, err error) *MockWebhookRepository_ListDeliveries_Call {
+	_c.Call.Return(webhookDeliveries, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListDeliveries_Call) RunAndReturn(run func(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error)) *MockWebhookRepository_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettleDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) SettleDelivery(ctx context.Context, d domain.WebhookDelivery) error {
+	ret := _mock.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleDelivery")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_SettleDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleDelivery'
+type MockWebhookRepository_SettleDelivery_Call struct {
+	*mock.Call
+}
+
+// SettleDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d domain.WebhookDelivery
+func (_e *MockWebhookRepository_Expecter) SettleDelivery(ctx interface{}, d interface{}) *MockWebhookRepository_SettleDelivery_Call {
+	return &MockWebhookRepository_SettleDelivery_Call{Call: _e.mock.On("SettleDelivery", ctx, d)}
+}
+
+func (_c *MockWebhookRepository_SettleDelivery_Call) Run(run func(ctx context.Context, d domain.WebhookDelivery)) *MockWebhookRepository_SettleDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].(domain.WebhookDelivery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_SettleDelivery_Call) Return(err error) *MockWebhookRepository_SettleDelivery_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_SettleDelivery_Call) RunAndReturn(run func(ctx context.Context, d domain.WebhookDelivery) error) *MockWebhookRepository_SettleDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) Update(ctx context.Context, w domain.Webhook) error {
+	ret := _mock.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Webhook) error); ok {
+		r0 = returnFunc(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWebhookRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w domain.Webhook
+func (_e *MockWebhookRepository_Expecter) Update(ctx interface{}, w interface{}) *MockWebhookRepository_Update_Call {
+	return &MockWebhookRepository_Update_Call{Call: _e.mock.On("Update", ctx, w)}
+}
+
+func (_c *MockWebhookRepository_Update_Call) Run(run func(ctx context.Context, w domain.Webhook)) *MockWebhookRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Webhook
+		if args[1] != nil {
+			arg1 = args[1].(domain.Webhook)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_Update_Call) Return(err error) *MockWebhookRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_Update_Call) RunAndReturn(run func(ctx context.Context, w domain.Webhook) error) *MockWebhookRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
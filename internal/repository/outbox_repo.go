@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// OutboxRepository reads and settles the change events ShowRepo writes.
+// Several dispatchers may share it: an entry is claimed before it is
+// published, and each later write is conditional on that claim.
+type OutboxRepository interface {
+	Due(ctx context.Context, at time.Time, limit int) ([]domain.OutboxEntry, error)
+	Claim(ctx context.Context, e domain.OutboxEntry, until time.Time) (*domain.OutboxEntry, error)
+	Release(ctx context.Context, e domain.OutboxEntry, retryAt time.Time, cause error) error
+	Delete(ctx context.Context, e domain.OutboxEntry) error
+}
+
+type OutboxRepo struct {
+	db database.DynamoAPI
+}
+
+var _ OutboxRepository = (*OutboxRepo)(nil)
+
+func NewOutboxRepository(db database.DynamoAPI) OutboxRepository {
+	return &OutboxRepo{db: db}
+}
+
+// Due returns up to limit entries due at or before at, earliest due first.
+// Entries are keyed by when they are due, so the query reads due entries
+// only.
+func (r *OutboxRepo) Due(ctx context.Context, at time.Time, limit int) (_ []domain.OutboxEntry, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "OutboxRepository.Due")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	entries := []domain.OutboxEntry{}
+	var start map[string]types.AttributeValue
+	for len(entries) < limit {
+		out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              awsString(r.db.TableName()),
+			KeyConditionExpression: awsString("slug = :slug AND sk BETWEEN :first AND :last"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":slug":  &types.AttributeValueMemberS{Value: outboxPK},
+				":first": &types.AttributeValueMemberS{Value: outboxSKPrefix},
+				":last":  &types.AttributeValueMemberS{Value: outboxDueSK(at)},
+			},
+			Limit:             awsInt32(int32(limit - len(entries))),
+			ExclusiveStartKey: start,
+		})
+		if err != nil {
+			return nil, translateError(err, "")
+		}
+		var page []domain.OutboxEntry
+		if err := attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, err
+		}
+		entries = append(entries, page[:min(len(page), limit-len(entries))]...)
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		start = out.LastEvaluatedKey
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(entries)))
+	return entries, nil
+}
+
+// Claim counts a delivery attempt and leases e until then, so that no other
+// dispatcher takes it meanwhile. It fails with ErrConflict if e was claimed
+// or settled since it was read.
+func (r *OutboxRepo) Claim(ctx context.Context, e domain.OutboxEntry, until time.Time) (_ *domain.OutboxEntry, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "OutboxRepository.Claim")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	claimed := e
+	claimed.Attempts++
+	claimed.NextAttemptAt = until.UTC().Format(domain.SortableTime)
+	claimed.SK = outboxSK(until, e.Event.ID)
+	if err := r.replace(ctx, e, claimed); err != nil {
+		return nil, err
+	}
+	return &claimed, nil
+}
+
+// Release records a failed delivery of claimed entry e and makes it due
+// again at retryAt
+func (r *OutboxRepo) Release(ctx context.Context, e domain.OutboxEntry, retryAt time.Time, cause error) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "OutboxRepository.Release")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	released := e
+	released.NextAttemptAt = retryAt.UTC().Format(domain.SortableTime)
+	released.SK = outboxSK(retryAt, e.Event.ID)
+	released.LastError = cause.Error()
+	return r.replace(ctx, e, released)
+}
+
+// Delete removes a delivered entry
+func (r *OutboxRepo) Delete(ctx context.Context, e domain.OutboxEntry) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "OutboxRepository.Delete")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	_, err = r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(outboxPK, e.SK),
+	})
+	return translateError(err, "")
+}
+
+// replace writes e in place of the stored entry old, provided old is
+// unchanged since it was read. An entry whose due time changes moves to the
+// sort key of its new one.
+func (r *OutboxRepo) replace(ctx context.Context, old, e domain.OutboxEntry) error {
+	e.PK = outboxPK
+	item, err := attributevalue.MarshalMap(e)
+	if err != nil {
+		return err
+	}
+	unchanged := map[string]types.AttributeValue{
+		":attempts": &types.AttributeValueMemberN{Value: strconv.Itoa(old.Attempts)},
+	}
+	conflict := fmt.Errorf("outbox event %s: %w", e.Event.ID, apperror.ErrConflict)
+	if e.SK == old.SK {
+		_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:                 awsString(r.db.TableName()),
+			Item:                      item,
+			ConditionExpression:       awsString("attempts = :attempts"),
+			ExpressionAttributeValues: unchanged,
+		})
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return conflict
+		}
+		return translateError(err, "")
+	}
+
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Delete: &types.Delete{
+				TableName:                 awsString(r.db.TableName()),
+				Key:                       itemKey(outboxPK, old.SK),
+				ConditionExpression:       awsString("attempts = :attempts"),
+				ExpressionAttributeValues: unchanged,
+			}},
+			{Put: &types.Put{
+				TableName:           awsString(r.db.TableName()),
+				Item:                item,
+				ConditionExpression: awsString("attribute_not_exists(slug)"),
+			}},
+		},
+	})
+	return translateTransactError(err, conflict, conflict)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func outboxEntry(id string, attempts int) domain.OutboxEntry {
+	return domain.OutboxEntry{
+		Event:         domain.ChangeEvent{ID: id, Type: domain.EventShowCreated, Slug: "show/a", Revision: 1, At: "2024-06-15T00:00:00Z"},
+		Attempts:      attempts,
+		NextAttemptAt: "2024-06-15T00:00:00Z",
+		PK:            outboxPK,
+		SK:            outboxSKPrefix + "2024-06-15T00:00:00Z#" + id,
+	}
+}
+
+func outboxItem(t *testing.T, e domain.OutboxEntry) map[string]types.AttributeValue {
+	item, err := attributevalue.MarshalMap(e)
+	require.NoError(t, err)
+	return item
+}
+
+func TestOutboxRepo_Due(t *testing.T) {
+	at := time.Date(2024, 6, 15, 0, 0, 30, 0, time.UTC)
+
+	t.Run("pages until the limit", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExclusiveStartKey == nil &&
+				*in.KeyConditionExpression == "slug = :slug AND sk BETWEEN :first AND :last" &&
+				in.FilterExpression == nil &&
+				*in.Limit == 2 &&
+				in.ExpressionAttributeValues[":slug"].(*types.AttributeValueMemberS).Value == "outbox" &&
+				in.ExpressionAttributeValues[":first"].(*types.AttributeValueMemberS).Value == "EVENT#" &&
+				in.ExpressionAttributeValues[":last"].(*types.AttributeValueMemberS).Value == "EVENT#2024-06-15T00:00:30Z$"
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]types.AttributeValue{outboxItem(t, outboxEntry("e1", 0))},
+			LastEvaluatedKey: itemKey(outboxPK, "EVENT#1"),
+		}, nil).Once()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExclusiveStartKey != nil && *in.Limit == 1
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]types.AttributeValue{outboxItem(t, outboxEntry("e2", 3)), outboxItem(t, outboxEntry("e3", 0))},
+			LastEvaluatedKey: itemKey(outboxPK, "EVENT#3"),
+		}, nil).Once()
+
+		entries, err := NewOutboxRepository(mockDB).Due(context.Background(), at, 2)
+		require.NoError(t, err)
+		require.Equal(t, []domain.OutboxEntry{outboxEntry("e1", 0), outboxEntry("e2", 3)}, entries)
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+		entries, err := NewOutboxRepository(mockDB).Due(context.Background(), at, 10)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
+
+func TestOutboxRepo_Claim(t *testing.T) {
+	until := time.Date(2024, 6, 15, 0, 1, 0, 0, time.UTC)
+
+	t.Run("counts the attempt and moves the entry to its lease", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var stored domain.OutboxEntry
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+			require.Len(t, items, 2)
+			require.Equal(t, itemKey(outboxPK, "EVENT#2024-06-15T00:00:00Z#e1"), items[0].Delete.Key)
+			require.Equal(t, "attempts = :attempts", *items[0].Delete.ConditionExpression)
+			require.Equal(t, "2", items[0].Delete.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value)
+			require.Equal(t, "attribute_not_exists(slug)", *items[1].Put.ConditionExpression)
+			require.NoError(t, attributevalue.UnmarshalMap(items[1].Put.Item, &stored))
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		claimed, err := NewOutboxRepository(mockDB).Claim(context.Background(), outboxEntry("e1", 2), until)
+		require.NoError(t, err)
+		require.Equal(t, 3, claimed.Attempts)
+		require.Equal(t, "2024-06-15T00:01:00Z", claimed.NextAttemptAt)
+		require.Equal(t, "EVENT#2024-06-15T00:01:00Z#e1", claimed.SK)
+		require.Equal(t, *claimed, stored)
+	})
+
+	t.Run("a lease ending when the entry is due keeps its key", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attempts = :attempts" &&
+				in.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value == "0"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		due := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+		claimed, err := NewOutboxRepository(mockDB).Claim(context.Background(), outboxEntry("e1", 0), due)
+		require.NoError(t, err)
+		require.Equal(t, outboxEntry("e1", 0).SK, claimed.SK)
+	})
+
+	t.Run("claimed elsewhere", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, cancelled("ConditionalCheckFailed", "None"))
+
+		_, err := NewOutboxRepository(mockDB).Claim(context.Background(), outboxEntry("e1", 0), until)
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestOutboxRepo_Release(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	var stored domain.OutboxEntry
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+		require.Equal(t, itemKey(outboxPK, "EVENT#2024-06-15T00:00:00Z#e1"), items[0].Delete.Key)
+		require.Equal(t, "1", items[0].Delete.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value)
+		require.NoError(t, attributevalue.UnmarshalMap(items[1].Put.Item, &stored))
+	}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	retryAt := time.Date(2024, 6, 15, 0, 0, 2, 0, time.UTC)
+	err := NewOutboxRepository(mockDB).Release(context.Background(), outboxEntry("e1", 1), retryAt, errors.New("topic unavailable"))
+	require.NoError(t, err)
+	require.Equal(t, 1, stored.Attempts)
+	require.Equal(t, "2024-06-15T00:00:02Z", stored.NextAttemptAt)
+	require.Equal(t, "EVENT#2024-06-15T00:00:02Z#e1", stored.SK)
+	require.Equal(t, "topic unavailable", stored.LastError)
+}
+
+func TestOutboxRepo_Delete(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("DeleteItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+		return *in.TableName == "test-table"
+	})).Run(func(args mock.Arguments) {
+		require.Equal(t, itemKey(outboxPK, "EVENT#2024-06-15T00:00:00Z#e1"), args.Get(1).(*dynamodb.DeleteItemInput).Key)
+	}).Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	require.NoError(t, NewOutboxRepository(mockDB).Delete(context.Background(), outboxEntry("e1", 1)))
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// liveShowCondition holds for a show item but not for the alias stub left
+// at a former slug
+const liveShowCondition = "attribute_exists(slug) AND attribute_not_exists(aliasOf)"
+
+type SeasonRepository interface {
+	PutSeason(ctx context.Context, s domain.SeasonDetail) error
+	ListSeasons(ctx context.Context, showSlug string) ([]domain.SeasonDetail, error)
+	PutEpisode(ctx context.Context, e domain.Episode) error
+	ListEpisodes(ctx context.Context, showSlug string, season int) ([]domain.Episode, error)
+}
+
+type SeasonRepo struct {
+	db database.DynamoAPI
+}
+
+var _ SeasonRepository = (*SeasonRepo)(nil)
+
+func NewSeasonRepository(db database.DynamoAPI) SeasonRepository {
+	return &SeasonRepo{db: db}
+}
+
+// PutSeason stores a season under an existing show. It fails with
+// ErrNotFound when the show is missing and ErrDuplicateSlug when the season
+// already exists.
+func (r *SeasonRepo) PutSeason(ctx context.Context, s domain.SeasonDetail) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonRepository.PutSeason")
+	span.SetAttributes(telemetry.AttrShowSlug.String(s.ShowSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	s.Slug = domain.SeasonSlug(s.ShowSlug, s.Number)
+	s.SK = seasonSK(s.Number)
+
+	item, err := attributevalue.MarshalMap(s)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{ConditionCheck: &types.ConditionCheck{
+				TableName:           awsString(r.db.TableName()),
+				Key:                 itemKey(s.ShowSlug, showSK),
+				ConditionExpression: awsString(liveShowCondition),
+			}},
+			{Put: &types.Put{
+				TableName:           awsString(r.db.TableName()),
+				Item:                stamped(item),
+				ConditionExpression: awsString("attribute_not_exists(slug)"),
+			}},
+		},
+	})
+	return translateTransactError(err,
+		fmt.Errorf("show %s: %w", s.ShowSlug, apperror.ErrNotFound),
+		fmt.Errorf("season %s: %w", s.Slug, apperror.ErrDuplicateSlug),
+	)
+}
+
+// ListSeasons returns a show's seasons in number order, or ErrNotFound when
+// the show does not exist
+func (r *SeasonRepo) ListSeasons(ctx context.Context, showSlug string) (_ []domain.SeasonDetail, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonRepository.ListSeasons")
+	span.SetAttributes(telemetry.AttrShowSlug.String(showSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	// SEASON#... sorts just before SHOW, so one query returns the seasons
+	// followed by the show item, which proves the show exists unless it is
+	// an alias stub
+	out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND sk BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug": &types.AttributeValueMemberS{Value: showSlug},
+			":from": &types.AttributeValueMemberS{Value: seasonSKPrefix},
+			":to":   &types.AttributeValueMemberS{Value: showSK},
+		},
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+
+	found := false
+	seasons := []domain.SeasonDetail{}
+	for _, item := range out.Items {
+		if sk, ok := item["sk"].(*types.AttributeValueMemberS); ok && sk.Value == showSK {
+			_, alias := item["aliasOf"]
+			found = !alias
+			continue
+		}
+		var s domain.SeasonDetail
+		if err := attributevalue.UnmarshalMap(item, &s); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, s)
+	}
+	if !found {
+		return nil, fmt.Errorf("show %s: %w", showSlug, apperror.ErrNotFound)
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(seasons)))
+	return seasons, nil
+}
+
+// PutEpisode stores an episode under an existing season and, in the same
+// transaction, sets the show's episodeCount to the number of episode items
+// stored. It fails with ErrNotFound when the season is missing and
+// ErrDuplicateSlug when the episode already exists.
+//
+// The count is kept in episodeItems, added to only when the episode item is
+// new, and copied to episodeCount, which show writes may overwrite with a
+// feed's figure. episodeItems is seeded from the episode items stored the
+// first time, or again after a show write has dropped it.
+func (r *SeasonRepo) PutEpisode(ctx context.Context, e domain.Episode) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonRepository.PutEpisode")
+	span.SetAttributes(telemetry.AttrShowSlug.String(e.ShowSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	e.Slug = domain.EpisodeSlug(e.ShowSlug, e.SeasonNumber, e.Number)
+	e.SK = episodeSK(e.SeasonNumber, e.Number)
+
+	item, err := attributevalue.MarshalMap(e)
+	if err != nil {
+		return err
+	}
+	stored, err := r.countEpisodes(ctx, e.ShowSlug)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{ConditionCheck: &types.ConditionCheck{
+				TableName:           awsString(r.db.TableName()),
+				Key:                 itemKey(e.ShowSlug, seasonSK(e.SeasonNumber)),
+				ConditionExpression: awsString("attribute_exists(slug)"),
+			}},
+			{Put: &types.Put{
+				TableName:           awsString(r.db.TableName()),
+				Item:                stamped(item),
+				ConditionExpression: awsString("attribute_not_exists(slug)"),
+			}},
+			{Update: &types.Update{
+				TableName: awsString(r.db.TableName()),
+				Key:       itemKey(e.ShowSlug, showSK),
+				UpdateExpression: awsString("SET episodeItems = if_not_exists(episodeItems, :stored) + :one, " +
+					"episodeCount = if_not_exists(episodeItems, :stored) + :one, #writeId = :writeId"),
+				ConditionExpression:      awsString(liveShowCondition),
+				ExpressionAttributeNames: map[string]string{"#writeId": WriteIDAttr},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":stored":  &types.AttributeValueMemberN{Value: strconv.Itoa(stored)},
+					":one":     &types.AttributeValueMemberN{Value: "1"},
+					":writeId": &types.AttributeValueMemberS{Value: domain.NewID()},
+				},
+			}},
+		},
+	})
+	return translateTransactError(err,
+		fmt.Errorf("season %s: %w", domain.SeasonSlug(e.ShowSlug, e.SeasonNumber), apperror.ErrNotFound),
+		fmt.Errorf("episode %s: %w", e.Slug, apperror.ErrDuplicateSlug),
+		fmt.Errorf("show %s: %w", e.ShowSlug, apperror.ErrNotFound),
+	)
+}
+
+// countEpisodes returns the number of episode items stored for a show
+func (r *SeasonRepo) countEpisodes(ctx context.Context, showSlug string) (int, error) {
+	count := 0
+	var start map[string]types.AttributeValue
+	for {
+		out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              awsString(r.db.TableName()),
+			KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":slug":   &types.AttributeValueMemberS{Value: showSlug},
+				":prefix": &types.AttributeValueMemberS{Value: episodeSKPrefix},
+			},
+			Select:            types.SelectCount,
+			ExclusiveStartKey: start,
+		})
+		if err != nil {
+			return 0, translateError(err, "")
+		}
+		count += int(out.Count)
+		if len(out.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		start = out.LastEvaluatedKey
+	}
+}
+
+// ListEpisodes returns a season's episodes in number order, or ErrNotFound
+// when the season does not exist
+func (r *SeasonRepo) ListEpisodes(ctx context.Context, showSlug string, season int) (_ []domain.Episode, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonRepository.ListEpisodes")
+	span.SetAttributes(telemetry.AttrShowSlug.String(showSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	seasonOut, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND sk = :sk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug": &types.AttributeValueMemberS{Value: showSlug},
+			":sk":   &types.AttributeValueMemberS{Value: seasonSK(season)},
+		},
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	if len(seasonOut.Items) == 0 {
+		return nil, fmt.Errorf("season %s: %w", domain.SeasonSlug(showSlug, season), apperror.ErrNotFound)
+	}
+
+	out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug":   &types.AttributeValueMemberS{Value: showSlug},
+			":prefix": &types.AttributeValueMemberS{Value: episodeSKSeasonPrefix(season)},
+		},
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	episodes := []domain.Episode{}
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &episodes); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(episodes)))
+	return episodes, nil
+}
+
+func itemKey(slug, sk string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"slug": &types.AttributeValueMemberS{Value: slug},
+		"sk":   &types.AttributeValueMemberS{Value: sk},
+	}
+}
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func TestSeasonRepo_PutSeason(t *testing.T) {
+	season := domain.SeasonDetail{ShowSlug: "show/a", Number: 2, Title: awsString("Season 2")}
+
+	t.Run("checks the show and writes the season item", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.TransactWriteItemsInput)
+				require.Len(t, in.TransactItems, 2)
+				require.Equal(t, itemKey("show/a", "SHOW"), in.TransactItems[0].ConditionCheck.Key)
+
+				item := in.TransactItems[1].Put.Item
+				require.Equal(t, &types.AttributeValueMemberS{Value: "show/a"}, item["slug"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "SEASON#0002"}, item["sk"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "show/a/season/2"}, item["seasonSlug"])
+				require.Equal(t, "attribute_not_exists(slug)", *in.TransactItems[1].Put.ConditionExpression)
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		err := NewSeasonRepository(mockDB).PutSeason(context.Background(), season)
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name    string
+		reasons []string
+		want    error
+	}{
+		{name: "missing show", reasons: []string{"ConditionalCheckFailed", "None"}, want: apperror.ErrNotFound},
+		{name: "duplicate season", reasons: []string{"None", "ConditionalCheckFailed"}, want: apperror.ErrDuplicateSlug},
+		{name: "throttled", reasons: []string{"None", "ThrottlingError"}, want: apperror.ErrThrottled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+			mockDB.On("TableName").Return("test-table").Maybe()
+			mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, cancelled(tt.reasons...))
+
+			err := NewSeasonRepository(mockDB).PutSeason(context.Background(), season)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("invalid season", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		err := NewSeasonRepository(mockDB).PutSeason(context.Background(), domain.SeasonDetail{ShowSlug: "show/a"})
+		require.Error(t, err)
+	})
+}
+
+func TestSeasonRepo_ListSeasons(t *testing.T) {
+	seasonItem := map[string]types.AttributeValue{
+		"slug":       &types.AttributeValueMemberS{Value: "show/a"},
+		"sk":         &types.AttributeValueMemberS{Value: "SEASON#0001"},
+		"number":     &types.AttributeValueMemberN{Value: "1"},
+		"seasonSlug": &types.AttributeValueMemberS{Value: "show/a/season/1"},
+	}
+	showItem := map[string]types.AttributeValue{
+		"slug": &types.AttributeValueMemberS{Value: "show/a"},
+		"sk":   &types.AttributeValueMemberS{Value: "SHOW"},
+	}
+
+	t.Run("returns seasons and skips the show item", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.QueryInput)
+				require.Nil(t, in.IndexName)
+				require.Equal(t, &types.AttributeValueMemberS{Value: "SEASON#"}, in.ExpressionAttributeValues[":from"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "SHOW"}, in.ExpressionAttributeValues[":to"])
+			}).
+			Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{seasonItem, showItem}}, nil)
+
+		got, err := NewSeasonRepository(mockDB).ListSeasons(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, 1, got[0].Number)
+		require.Equal(t, "show/a/season/1", got[0].Slug)
+	})
+
+	t.Run("show without seasons", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).
+			Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{showItem}}, nil)
+
+		got, err := NewSeasonRepository(mockDB).ListSeasons(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.Empty(t, got)
+	})
+
+	t.Run("former slug", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).
+			Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{aliasItem("show/a", "show/b")}}, nil)
+
+		_, err := NewSeasonRepository(mockDB).ListSeasons(context.Background(), "show/a")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("missing show", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil)
+
+		_, err := NewSeasonRepository(mockDB).ListSeasons(context.Background(), "show/a")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestSeasonRepo_PutEpisode(t *testing.T) {
+	episode := domain.Episode{ShowSlug: "show/a", SeasonNumber: 2, Number: 5}
+
+	// countsEpisodes answers the count of stored episode items over two pages
+	countsEpisodes := func(mockDB *dynamoMocks.MockDynamoAPI) {
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.Select == types.SelectCount && in.ExclusiveStartKey == nil
+		})).Return(&dynamodb.QueryOutput{Count: 2, LastEvaluatedKey: itemKey("show/a", "EPISODE#0001#0002")}, nil).Once()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.Select == types.SelectCount && in.ExclusiveStartKey != nil
+		})).Return(&dynamodb.QueryOutput{Count: 1}, nil).Once()
+	}
+
+	t.Run("writes the episode and counts the show's episodes", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		countsEpisodes(mockDB)
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.TransactWriteItemsInput)
+				require.Len(t, in.TransactItems, 3)
+				require.Equal(t, itemKey("show/a", "SEASON#0002"), in.TransactItems[0].ConditionCheck.Key)
+				require.Equal(t, &types.AttributeValueMemberS{Value: "EPISODE#0002#0005"}, in.TransactItems[1].Put.Item["sk"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "show/a/season/2/episode/5"}, in.TransactItems[1].Put.Item["episodeSlug"])
+				require.Equal(t, "attribute_not_exists(slug)", *in.TransactItems[1].Put.ConditionExpression)
+				require.Contains(t, in.TransactItems[1].Put.Item, WriteIDAttr)
+				update := in.TransactItems[2].Update
+				require.Equal(t, itemKey("show/a", "SHOW"), update.Key)
+				require.Equal(t, "SET episodeItems = if_not_exists(episodeItems, :stored) + :one, "+
+					"episodeCount = if_not_exists(episodeItems, :stored) + :one, #writeId = :writeId", *update.UpdateExpression)
+				require.Equal(t, &types.AttributeValueMemberN{Value: "3"}, update.ExpressionAttributeValues[":stored"])
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		err := NewSeasonRepository(mockDB).PutEpisode(context.Background(), episode)
+		require.NoError(t, err)
+	})
+
+	t.Run("counting fails", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		err := NewSeasonRepository(mockDB).PutEpisode(context.Background(), episode)
+		require.EqualError(t, err, "boom")
+	})
+
+	tests := []struct {
+		name    string
+		reasons []string
+		want    error
+	}{
+		{name: "missing season", reasons: []string{"ConditionalCheckFailed", "None", "None"}, want: apperror.ErrNotFound},
+		{name: "duplicate episode", reasons: []string{"None", "ConditionalCheckFailed", "None"}, want: apperror.ErrDuplicateSlug},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+			mockDB.On("TableName").Return("test-table").Maybe()
+			countsEpisodes(mockDB)
+			mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, cancelled(tt.reasons...))
+
+			err := NewSeasonRepository(mockDB).PutEpisode(context.Background(), episode)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("other errors pass through", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		countsEpisodes(mockDB)
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+		err := NewSeasonRepository(mockDB).PutEpisode(context.Background(), episode)
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestSeasonRepo_ListEpisodes(t *testing.T) {
+	t.Run("lists the season's episodes", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExpressionAttributeValues[":sk"] != nil
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{"slug": &types.AttributeValueMemberS{Value: "show/a"}, "sk": &types.AttributeValueMemberS{Value: "SEASON#0002"}},
+		}}, nil)
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			prefix, ok := in.ExpressionAttributeValues[":prefix"].(*types.AttributeValueMemberS)
+			return ok && prefix.Value == "EPISODE#0002#"
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{
+				"slug":         &types.AttributeValueMemberS{Value: "show/a"},
+				"sk":           &types.AttributeValueMemberS{Value: "EPISODE#0002#0001"},
+				"number":       &types.AttributeValueMemberN{Value: "1"},
+				"seasonNumber": &types.AttributeValueMemberN{Value: "2"},
+			},
+		}}, nil)
+
+		got, err := NewSeasonRepository(mockDB).ListEpisodes(context.Background(), "show/a", 2)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, 2, got[0].SeasonNumber)
+	})
+
+	t.Run("season without episodes", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{"slug": &types.AttributeValueMemberS{Value: "show/a"}, "sk": &types.AttributeValueMemberS{Value: "SEASON#0002"}},
+		}}, nil).Once()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+		got, err := NewSeasonRepository(mockDB).ListEpisodes(context.Background(), "show/a", 2)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.Empty(t, got)
+	})
+
+	t.Run("missing season", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+		_, err := NewSeasonRepository(mockDB).ListEpisodes(context.Background(), "show/a", 2)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+// cancelled builds the error DynamoDB returns when a transaction's
+// conditions fail, one reason code per item
+func cancelled(codes ...string) error {
+	reasons := make([]types.CancellationReason, len(codes))
+	for i, code := range codes {
+		reasons[i] = types.CancellationReason{Code: awsString(code)}
+	}
+	return &types.TransactionCanceledException{CancellationReasons: reasons}
+}
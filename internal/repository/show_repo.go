@@ -2,34 +2,119 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/marciomarinho/show-service/internal/apperror"
 	"github.com/marciomarinho/show-service/internal/database"
 	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
 )
 
+// IndexDRMEpisode is the GSI backing List: hash_key=drmKey, range_key=episodeCount
+const IndexDRMEpisode = "gsi_drm_episode"
+
+// IndexAiring is the sparse GSI backing ListAiring: hash_key=airingKey,
+// range_key=nextEpisodeAt. Only shows with a next episode date are indexed.
+const IndexAiring = "gsi_airing"
+
+// maxTransactItems is DynamoDB's limit on actions in one transaction
+const maxTransactItems = 100
+
 type ShowRepository interface {
-	Put(s domain.Show) error
-	List() ([]domain.Show, error)
+	Put(ctx context.Context, s domain.Show, revisions []domain.Revision) error
+	Get(ctx context.Context, slug string) (*domain.Show, error)
+	Rename(ctx context.Context, from, to string) (*domain.Show, error)
+	Update(ctx context.Context, s domain.Show, revisions []domain.Revision) error
+	ListRevisions(ctx context.Context, slug string) ([]domain.Revision, error)
+	GetRevision(ctx context.Context, slug string, n int) (*domain.Revision, error)
+	List(ctx context.Context) ([]domain.Show, error)
+	// ListAll returns every show, whatever its DRM flag or episode count,
+	// without alias stubs. It scans the table.
+	ListAll(ctx context.Context) ([]domain.Show, error)
+	ListAiring(ctx context.Context, from, to time.Time) ([]domain.Show, error)
 }
 
+// ShowRepo writes a change event to the outbox in the same transaction as
+// every show write, for the outbox dispatcher to publish
 type ShowRepo struct {
-	db database.DynamoAPI
+	db  database.DynamoAPI
+	now func() time.Time
 }
 
 var _ ShowRepository = (*ShowRepo)(nil)
 
 func NewShowRepository(db database.DynamoAPI) ShowRepository {
-	return &ShowRepo{db: db}
+	return &ShowRepo{db: db, now: time.Now}
 }
 
-func (r *ShowRepo) Put(s domain.Show) error {
+// Put stores a new show together with its first revisions
+func (r *ShowRepo) Put(ctx context.Context, s domain.Show, revisions []domain.Revision) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.Put")
+	span.SetAttributes(telemetry.AttrShowSlug.String(s.Slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
 	if err := s.Validate(); err != nil {
 		return err
 	}
 
+	actions, err := r.writeActions(s, "attribute_not_exists(slug)", nil, revisions)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: actions})
+	return translateTransactError(err, fmt.Errorf("show %s: %w", s.Slug, apperror.ErrDuplicateSlug))
+}
+
+// Update replaces a show with the next revision of it, as read by Get and
+// numbered by domain.Revise, and stores the new revisions. It fails with
+// ErrConflict when the stored show has been written since.
+func (r *ShowRepo) Update(ctx context.Context, s domain.Show, revisions []domain.Revision) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.Update")
+	span.SetAttributes(telemetry.AttrShowSlug.String(s.Slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if len(revisions) == 0 {
+		return fmt.Errorf("show %s: update without a revision", s.Slug)
+	}
+	// A baseline revision means the stored show had no number yet
+	condition := liveShowCondition + " AND revision = :revision"
+	values := map[string]types.AttributeValue{
+		":revision": &types.AttributeValueMemberN{Value: strconv.Itoa(revisions[0].Number - 1)},
+	}
+	if revisions[0].Action == domain.RevisionBaseline {
+		condition = liveShowCondition + " AND attribute_not_exists(revision)"
+		values = nil
+	}
+
+	actions, err := r.writeActions(s, condition, values, revisions)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: actions})
+	conflict := fmt.Errorf("show %s: %w", s.Slug, apperror.ErrConflict)
+	return translateTransactError(err, slices.Repeat([]error{conflict}, len(actions))...)
+}
+
+// writeActions puts the show, with its index attributes set, under
+// condition, each revision beside it and the event announcing the last
+func (r *ShowRepo) writeActions(s domain.Show, condition string, values map[string]types.AttributeValue, revisions []domain.Revision) ([]types.TransactWriteItem, error) {
+	s.SK = showSK
 	var k int
 	if s.DRM != nil && *s.DRM {
 		k = 1
@@ -39,25 +124,339 @@ func (r *ShowRepo) Put(s domain.Show) error {
 		zero := 0
 		s.EpisodeCount = &zero
 	}
+	s.AiringKey, s.NextEpisodeAt = nil, nil
+	if s.NextEpisode != nil {
+		if at, ok := s.NextEpisode.AirsAt(); ok {
+			one := 1
+			sortable := at.UTC().Format(domain.SortableTime)
+			s.AiringKey = &one
+			s.NextEpisodeAt = &sortable
+		}
+	}
 
 	item, err := attributevalue.MarshalMap(s)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	actions := []types.TransactWriteItem{{Put: &types.Put{
+		TableName:                 awsString(r.db.TableName()),
+		Item:                      stamped(item),
+		ConditionExpression:       awsString(condition),
+		ExpressionAttributeValues: values,
+	}}}
+	for _, rev := range revisions {
+		rev.Slug = s.Slug
+		rev.SK = revisionSK(rev.Number)
+		item, err := attributevalue.MarshalMap(rev)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, types.TransactWriteItem{Put: &types.Put{
+			TableName:           awsString(r.db.TableName()),
+			Item:                item,
+			ConditionExpression: awsString("attribute_not_exists(slug)"), // revisions are never rewritten
+		}})
+	}
+	if len(revisions) > 0 {
+		event, err := r.outboxPut(domain.RevisionEvent(s.Slug, revisions[len(revisions)-1]))
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, event)
+	}
+	return actions, nil
+}
+
+// outboxPut adds e to the outbox, due at once
+func (r *ShowRepo) outboxPut(e domain.ChangeEvent) (types.TransactWriteItem, error) {
+	now := r.now().UTC()
+	e.ID = domain.NewID()
+	item, err := attributevalue.MarshalMap(domain.OutboxEntry{
+		Event:         e,
+		NextAttemptAt: now.Format(domain.SortableTime),
+		PK:            outboxPK,
+		SK:            outboxSK(now, e.ID),
+	})
+	if err != nil {
+		return types.TransactWriteItem{}, err
 	}
-	_, err = r.db.PutItem(context.Background(), &dynamodb.PutItemInput{
+	return types.TransactWriteItem{Put: &types.Put{
 		TableName:           awsString(r.db.TableName()),
 		Item:                item,
 		ConditionExpression: awsString("attribute_not_exists(slug)"),
+	}}, nil
+}
+
+// ListRevisions returns the revisions stored beside the show at slug, oldest
+// first, without their documents
+func (r *ShowRepo) ListRevisions(ctx context.Context, slug string) (_ []domain.Revision, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.ListRevisions")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	revisions := []domain.Revision{}
+	var start map[string]types.AttributeValue
+	for {
+		out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              awsString(r.db.TableName()),
+			KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+			ProjectionExpression:   awsString("#number, #action, actor, #at, revertedFrom, diff"),
+			ExpressionAttributeNames: map[string]string{ // reserved words
+				"#number": "number",
+				"#action": "action",
+				"#at":     "at",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":slug":   &types.AttributeValueMemberS{Value: slug},
+				":prefix": &types.AttributeValueMemberS{Value: revisionSKPrefix},
+			},
+			ExclusiveStartKey: start,
+		})
+		if err != nil {
+			return nil, translateError(err, slug)
+		}
+		var page []domain.Revision
+		if err := unmarshalItems(out.Items, &page); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, page...)
+		if len(out.LastEvaluatedKey) == 0 {
+			span.SetAttributes(telemetry.AttrItemCount.Int(len(revisions)))
+			return revisions, nil
+		}
+		start = out.LastEvaluatedKey
+	}
+}
+
+// GetRevision returns revision n of the show at slug, or ErrNotFound
+func (r *ShowRepo) GetRevision(ctx context.Context, slug string, n int) (_ *domain.Revision, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.GetRevision")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(slug, revisionSK(n)),
 	})
-	return err
+	if err != nil {
+		return nil, translateError(err, slug)
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("revision %d of show %s: %w", n, slug, apperror.ErrNotFound)
+	}
+	var rev domain.Revision
+	if err := UnmarshalItem(out.Item, &rev); err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// Get returns the show item stored at slug, or ErrNotFound. At a former slug
+// this is the alias stub, with only Slug and AliasOf set.
+func (r *ShowRepo) Get(ctx context.Context, slug string) (_ *domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.Get")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(slug, showSK),
+	})
+	if err != nil {
+		return nil, translateError(err, slug)
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("show %s: %w", slug, apperror.ErrNotFound)
+	}
+	var show domain.Show
+	if err := UnmarshalItem(out.Item, &show); err != nil {
+		return nil, err
+	}
+	return &show, nil
+}
+
+// Rename moves a show and its seasons and episodes to a new slug, leaves an
+// alias stub at the old one and returns the show as renamed. Older aliases
+// are repointed so redirects never chain, and renaming back to a former slug
+// reclaims its alias.
+//
+// DynamoDB caps transactions at 100 items, so only the show and alias items
+// move atomically, with the event announcing the rename. Children are copied
+// before that swap and the originals deleted after it; a failure in between
+// leaves stray copies, never a show without its children.
+func (r *ShowRepo) Rename(ctx context.Context, from, to string) (_ *domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.Rename")
+	span.SetAttributes(telemetry.AttrShowSlug.String(from))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	show, err := r.Get(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if show.AliasOf != nil {
+		return nil, fmt.Errorf("show %s: %w", from, apperror.ErrNotFound)
+	}
+	target, err := r.Get(ctx, to)
+	switch {
+	case err == nil && (target.AliasOf == nil || *target.AliasOf != from):
+		return nil, fmt.Errorf("show %s: %w", to, apperror.ErrDuplicateSlug)
+	case err != nil && !errors.Is(err, apperror.ErrNotFound):
+		return nil, err
+	}
+
+	children, err := r.children(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	copies := make([]types.TransactWriteItem, 0, len(children))
+	marks := make([]types.TransactWriteItem, 0, len(children))
+	deletes := make([]types.TransactWriteItem, 0, len(children))
+	for _, item := range children {
+		key := map[string]types.AttributeValue{"slug": item["slug"], "sk": item["sk"]}
+		copies = append(copies, types.TransactWriteItem{Put: &types.Put{
+			TableName: awsString(r.db.TableName()),
+			Item:      stamped(movedItem(item, from, to)),
+		}})
+		marks = append(marks, markDeleting(r.db.TableName(), key))
+		deletes = append(deletes, types.TransactWriteItem{Delete: &types.Delete{
+			TableName: awsString(r.db.TableName()),
+			Key:       key,
+		}})
+	}
+	if err := r.transact(ctx, copies); err != nil {
+		return nil, err
+	}
+
+	show.Rename(to)
+	aliases := []string{from}
+	for _, alias := range show.Aliases {
+		if alias != to && alias != from {
+			aliases = append(aliases, alias)
+		}
+	}
+	show.Aliases = aliases
+	item, err := attributevalue.MarshalMap(show)
+	if err != nil {
+		return nil, err
+	}
+
+	// The show must still be the one read, or an update made meanwhile
+	// would be lost
+	unchanged := liveShowCondition + " AND attribute_not_exists(revision)"
+	var unchangedValues map[string]types.AttributeValue
+	if show.Revision > 0 {
+		unchanged = liveShowCondition + " AND revision = :revision"
+		unchangedValues = map[string]types.AttributeValue{
+			":revision": &types.AttributeValueMemberN{Value: strconv.Itoa(show.Revision)},
+		}
+	}
+	swap := []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:           awsString(r.db.TableName()),
+			Item:                stamped(item),
+			ConditionExpression: awsString("attribute_not_exists(slug) OR aliasOf = :from"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":from": &types.AttributeValueMemberS{Value: from},
+			},
+		}},
+		{Put: &types.Put{
+			TableName:                 awsString(r.db.TableName()),
+			Item:                      stamped(aliasItem(from, to)),
+			ConditionExpression:       awsString(unchanged),
+			ExpressionAttributeValues: unchangedValues,
+		}},
+	}
+	for _, alias := range aliases[1:] {
+		swap = append(swap, types.TransactWriteItem{Put: &types.Put{
+			TableName: awsString(r.db.TableName()),
+			Item:      stamped(aliasItem(alias, to)),
+		}})
+	}
+	event, err := r.outboxPut(domain.RenameEvent(from, *show, r.now()))
+	if err != nil {
+		return nil, err
+	}
+	swap = append(swap, event)
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: swap})
+	if err := translateTransactError(err,
+		fmt.Errorf("show %s: %w", to, apperror.ErrDuplicateSlug),
+		fmt.Errorf("show %s: %w", from, apperror.ErrConflict),
+	); err != nil {
+		return nil, err
+	}
+
+	// Marked first, so that the stream can tell these deletes are the
+	// service's
+	if err := r.transact(ctx, marks); err != nil {
+		return nil, err
+	}
+	if err := r.transact(ctx, deletes); err != nil {
+		return nil, err
+	}
+	return show, nil
+}
+
+// children returns every season and episode item in a show's partition
+func (r *ShowRepo) children(ctx context.Context, slug string) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+	var start map[string]types.AttributeValue
+	for {
+		out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+			TableName:              awsString(r.db.TableName()),
+			KeyConditionExpression: awsString("slug = :slug AND sk < :show"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":slug": &types.AttributeValueMemberS{Value: slug},
+				":show": &types.AttributeValueMemberS{Value: showSK},
+			},
+			ExclusiveStartKey: start,
+		})
+		if err != nil {
+			return nil, translateError(err, slug)
+		}
+		items = append(items, out.Items...)
+		if len(out.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		start = out.LastEvaluatedKey
+	}
+}
+
+// transact writes actions in as many transactions as the item limit needs
+func (r *ShowRepo) transact(ctx context.Context, actions []types.TransactWriteItem) error {
+	for len(actions) > 0 {
+		n := min(len(actions), maxTransactItems)
+		if _, err := r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: actions[:n]}); err != nil {
+			return translateTransactError(err)
+		}
+		actions = actions[n:]
+	}
+	return nil
 }
 
-func (r *ShowRepo) List() ([]domain.Show, error) {
+func (r *ShowRepo) List(ctx context.Context) (_ []domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
 	// Query using GSI for DRM=true shows with episodeCount > 0
 	// GSI: gsi_drm_episode with hash_key=drmKey, range_key=episodeCount
-	out, err := r.db.Query(context.Background(), &dynamodb.QueryInput{
+	out, err := r.db.Query(ctx, &dynamodb.QueryInput{
 		TableName:              awsString(r.db.TableName()),
-		IndexName:              awsString("gsi_drm_episode"),
+		IndexName:              awsString(IndexDRMEpisode),
 		KeyConditionExpression: awsString("drmKey = :drmKey AND episodeCount > :episodeCount"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":drmKey":       &types.AttributeValueMemberN{Value: "1"},
@@ -65,15 +464,188 @@ func (r *ShowRepo) List() ([]domain.Show, error) {
 		},
 	})
 	if err != nil {
+		return nil, translateError(err, "")
+	}
+	var items []domain.Show
+	if err := unmarshalItems(out.Items, &items); err != nil {
 		return nil, err
 	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(items)))
+	return items, nil
+}
+
+func (r *ShowRepo) ListAll(ctx context.Context) (_ []domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.ListAll")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	shows := []domain.Show{}
+	in := &dynamodb.ScanInput{
+		TableName:        awsString(r.db.TableName()),
+		FilterExpression: awsString("sk = :sk AND attribute_not_exists(aliasOf)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":sk": &types.AttributeValueMemberS{Value: showSK},
+		},
+	}
+	for {
+		out, err := r.db.Scan(ctx, in)
+		if err != nil {
+			return nil, translateError(err, "")
+		}
+		var page []domain.Show
+		if err := unmarshalItems(out.Items, &page); err != nil {
+			return nil, err
+		}
+		shows = append(shows, page...)
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(shows)))
+	return shows, nil
+}
+
+// ListAiring returns shows whose next episode airs within [from, to], soonest
+// first
+func (r *ShowRepo) ListAiring(ctx context.Context, from, to time.Time) (_ []domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowRepository.ListAiring")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.Query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		IndexName:              awsString(IndexAiring),
+		KeyConditionExpression: awsString("airingKey = :airingKey AND nextEpisodeAt BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":airingKey": &types.AttributeValueMemberN{Value: "1"},
+			":from":      &types.AttributeValueMemberS{Value: from.UTC().Format(domain.SortableTime)},
+			":to":        &types.AttributeValueMemberS{Value: to.UTC().Format(domain.SortableTime)},
+		},
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
 	var items []domain.Show
-	if err := attributevalue.UnmarshalListOfMaps(out.Items, &items); err != nil {
+	if err := unmarshalItems(out.Items, &items); err != nil {
 		return nil, err
 	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(items)))
 	return items, nil
 }
 
+// throttlingCodes are the DynamoDB error codes returned once the SDK has
+// exhausted its own retries
+var throttlingCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+}
+
+// translateError maps DynamoDB errors onto the apperror sentinels so the HTTP
+// layer can pick a status without knowing about DynamoDB
+func translateError(err error, slug string) error {
+	if err == nil {
+		return nil
+	}
+
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("show %s: %w", slug, apperror.ErrDuplicateSlug)
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && throttlingCodes[apiErr.ErrorCode()] {
+		return fmt.Errorf("%w: %v", apperror.ErrThrottled, err)
+	}
+	return err
+}
+
+// translateTransactError maps a cancelled transaction onto onFailed[i] for
+// the first item i whose condition failed
+func translateTransactError(err error, onFailed ...error) error {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return translateError(err, "")
+	}
+	for i, reason := range tce.CancellationReasons {
+		code := awsValue(reason.Code)
+		if code == "ConditionalCheckFailed" && i < len(onFailed) {
+			return onFailed[i]
+		}
+		if code == "ThrottlingError" || code == "ProvisionedThroughputExceeded" {
+			return fmt.Errorf("%w: %v", apperror.ErrThrottled, err)
+		}
+	}
+	return err
+}
+
+// aliasItem is the stub left at a former slug. It has no index attributes,
+// so it never shows up in List or ListAiring.
+func aliasItem(slug, canonical string) map[string]types.AttributeValue {
+	item := itemKey(slug, showSK)
+	item["aliasOf"] = &types.AttributeValueMemberS{Value: canonical}
+	return item
+}
+
+// movedItem copies a season or episode item into the partition of the
+// renamed show, rewriting the slugs derived from the show's
+func movedItem(item map[string]types.AttributeValue, from, to string) map[string]types.AttributeValue {
+	moved := maps.Clone(item)
+	moved["slug"] = &types.AttributeValueMemberS{Value: to}
+	for _, attr := range []string{"seasonSlug", "episodeSlug"} {
+		if v, ok := item[attr].(*types.AttributeValueMemberS); ok {
+			if rest, found := strings.CutPrefix(v.Value, from+"/"); found {
+				moved[attr] = &types.AttributeValueMemberS{Value: to + "/" + rest}
+			}
+		}
+	}
+	return moved
+}
+
+// stamped sets a new write ID on item, marking it as written by the service
+func stamped(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	item[WriteIDAttr] = &types.AttributeValueMemberS{Value: domain.NewID()}
+	return item
+}
+
+// markDeleting stamps the item at key in table and marks it as about to be
+// deleted by the service
+func markDeleting(table string, key map[string]types.AttributeValue) types.TransactWriteItem {
+	return types.TransactWriteItem{Update: &types.Update{
+		TableName:        awsString(table),
+		Key:              key,
+		UpdateExpression: awsString("SET #writeId = :writeId, #deleting = :deleting"),
+		ExpressionAttributeNames: map[string]string{
+			"#writeId":  WriteIDAttr,
+			"#deleting": DeletingAttr,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":writeId":  &types.AttributeValueMemberS{Value: domain.NewID()},
+			":deleting": &types.AttributeValueMemberBOOL{Value: true},
+		},
+	}}
+}
+
+func awsValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func awsString(s string) *string {
 	return &s
 }
+
+func awsBool(b bool) *bool {
+	return &b
+}
+
+func awsInt32(n int32) *int32 {
+	return &n
+}
@@ -1,39 +1,119 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"maps"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/marciomarinho/show-service/internal/apperror"
 	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
 	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/jsondiff"
 )
 
+// putOf returns the show Put of the transaction a write sent
+func putOf(args mock.Arguments) *types.Put {
+	return args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems[0].Put
+}
+
 func TestShowRepo_Put(t *testing.T) {
+	created := []domain.Revision{{Number: 1, Action: domain.RevisionCreated, Actor: "ed", At: "2024-06-15T00:00:00Z", Diff: []jsondiff.Op{}}}
+
 	t.Run("valid show insertion", func(t *testing.T) {
 		mockDB := dynamoMocks.NewMockDynamoAPI(t)
 
 		mockDB.On("TableName").Return("test-table").Maybe()
-		mockDB.On("PutItem", mock.Anything, mock.AnythingOfType("*dynamodb.PutItemInput")).
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
 			Run(func(args mock.Arguments) {
-				in := args.Get(1).(*dynamodb.PutItemInput)
+				items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+				require.Len(t, items, 3)
+				in := items[0].Put
 				require.Equal(t, "test-table", *in.TableName)
 				require.NotEmpty(t, in.Item["slug"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "SHOW"}, in.Item["sk"])
+				require.Equal(t, "attribute_not_exists(slug)", *in.ConditionExpression)
+
+				rev := items[1].Put
+				require.Equal(t, &types.AttributeValueMemberS{Value: "show/testshow"}, rev.Item["slug"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "REV#000001"}, rev.Item["sk"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "ed"}, rev.Item["actor"])
+				require.Equal(t, "attribute_not_exists(slug)", *rev.ConditionExpression)
+
+				var event domain.OutboxEntry
+				require.NoError(t, attributevalue.UnmarshalMap(items[2].Put.Item, &event))
+				require.Equal(t, outboxPK, event.PK)
+				require.Equal(t, outboxSKPrefix+event.NextAttemptAt+"#"+event.Event.ID, event.SK)
+				require.Len(t, event.Event.ID, 32)
+				require.Equal(t, domain.ChangeEvent{
+					ID: event.Event.ID, Type: domain.EventShowCreated, Slug: "show/testshow", Revision: 1, Actor: "ed", At: "2024-06-15T00:00:00Z",
+				}, event.Event)
+				require.Zero(t, event.Attempts)
+				require.Equal(t, "attribute_not_exists(slug)", *items[2].Put.ConditionExpression)
 			}).
-			Return(&dynamodb.PutItemOutput{}, nil)
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
 
 		repo := NewShowRepository(mockDB)
 
-		err := repo.Put(domain.Show{
+		err := repo.Put(context.Background(), domain.Show{
 			Slug:    "show/testshow",
 			Title:   "Test Show",
 			DRM:     boolPtr(true),
 			Seasons: &[]domain.Season{},
-		})
+		}, created)
+		require.NoError(t, err)
+	})
+
+	t.Run("next episode date populates airing index keys", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Run(func(args mock.Arguments) {
+				in := putOf(args)
+				require.Equal(t, &types.AttributeValueMemberN{Value: "1"}, in.Item["airingKey"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "2024-06-01T10:00:00Z"}, in.Item["nextEpisodeAt"])
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		repo := NewShowRepository(mockDB)
+
+		err := repo.Put(context.Background(), domain.Show{
+			Slug:  "show/testshow",
+			Title: "Test Show",
+			NextEpisode: &domain.NextEpisode{
+				ChannelLogo: "http://example.com/logo.gif",
+				Date:        awsString("2024-06-01T20:00:00+10:00"),
+				HTML:        "next",
+				URL:         "http://example.com/next",
+			},
+		}, created)
+		require.NoError(t, err)
+	})
+
+	t.Run("no next episode date leaves show out of airing index", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Run(func(args mock.Arguments) {
+				in := putOf(args)
+				require.NotContains(t, in.Item, "airingKey")
+				require.NotContains(t, in.Item, "nextEpisodeAt")
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		repo := NewShowRepository(mockDB)
+
+		err := repo.Put(context.Background(), domain.Show{Slug: "show/testshow", Title: "Test Show"}, created)
 		require.NoError(t, err)
 	})
 
@@ -41,26 +121,56 @@ func TestShowRepo_Put(t *testing.T) {
 		mockDB := dynamoMocks.NewMockDynamoAPI(t)
 		repo := NewShowRepository(mockDB)
 
-		err := repo.Put(domain.Show{
+		err := repo.Put(context.Background(), domain.Show{
 			Slug:    "",
 			Title:   "Test Show",
 			Seasons: &[]domain.Season{},
-		})
+		}, created)
 		require.Error(t, err)
-		mockDB.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+		mockDB.AssertNotCalled(t, "TransactWriteItems", mock.Anything, mock.Anything)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
 		mockDB := dynamoMocks.NewMockDynamoAPI(t)
 		repo := NewShowRepository(mockDB)
 
-		err := repo.Put(domain.Show{
+		err := repo.Put(context.Background(), domain.Show{
 			Slug:    "show/testshow",
 			Title:   "",
 			Seasons: &[]domain.Season{},
-		})
+		}, created)
 		require.Error(t, err)
-		mockDB.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+		mockDB.AssertNotCalled(t, "TransactWriteItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicate slug", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Return(nil, &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+				{Code: awsString("ConditionalCheckFailed")},
+				{Code: awsString("None")},
+			}})
+
+		repo := NewShowRepository(mockDB)
+
+		err := repo.Put(context.Background(), domain.Show{Slug: "show/testshow", Title: "Test Show"}, created)
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+		require.ErrorContains(t, err, "show/testshow")
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.AnythingOfType("*dynamodb.TransactWriteItemsInput")).
+			Return(nil, &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"})
+
+		repo := NewShowRepository(mockDB)
+
+		err := repo.Put(context.Background(), domain.Show{Slug: "show/testshow", Title: "Test Show"}, created)
+		require.ErrorIs(t, err, apperror.ErrThrottled)
 	})
 }
 
@@ -89,7 +199,7 @@ func TestShowRepo_List(t *testing.T) {
 
 		repo := NewShowRepository(mockDB)
 
-		got, err := repo.List()
+		got, err := repo.List(context.Background())
 		require.NoError(t, err)
 		require.Len(t, got, 1)
 		require.Equal(t, "show/a", got[0].Slug)
@@ -104,7 +214,7 @@ func TestShowRepo_List(t *testing.T) {
 
 		repo := NewShowRepository(mockDB)
 
-		got, err := repo.List()
+		got, err := repo.List(context.Background())
 		require.NoError(t, err)
 		require.Len(t, got, 0)
 	})
@@ -118,7 +228,106 @@ func TestShowRepo_List(t *testing.T) {
 
 		repo := NewShowRepository(mockDB)
 
-		got, err := repo.List()
+		got, err := repo.List(context.Background())
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+			Return(nil, &types.ProvisionedThroughputExceededException{Message: awsString("slow down")})
+
+		repo := NewShowRepository(mockDB)
+
+		got, err := repo.List(context.Background())
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+		require.Nil(t, got)
+	})
+}
+
+func TestShowRepo_ListAll(t *testing.T) {
+	t.Run("scans every page", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Scan", mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+			return in.ExclusiveStartKey == nil &&
+				*in.FilterExpression == "sk = :sk AND attribute_not_exists(aliasOf)" &&
+				in.ExpressionAttributeValues[":sk"].(*types.AttributeValueMemberS).Value == "SHOW"
+		})).Return(&dynamodb.ScanOutput{
+			Items:            []map[string]types.AttributeValue{{"slug": &types.AttributeValueMemberS{Value: "show/a"}}},
+			LastEvaluatedKey: itemKey("show/a", showSK),
+		}, nil).Once()
+		mockDB.On("Scan", mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+			return in.ExclusiveStartKey != nil
+		})).Return(&dynamodb.ScanOutput{
+			Items: []map[string]types.AttributeValue{{"slug": &types.AttributeValueMemberS{Value: "show/b"}}},
+		}, nil).Once()
+
+		got, err := NewShowRepository(mockDB).ListAll(context.Background())
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, "show/b", got[1].Slug)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Scan", mock.Anything, mock.Anything).
+			Return(nil, &types.ProvisionedThroughputExceededException{Message: awsString("slow down")})
+
+		_, err := NewShowRepository(mockDB).ListAll(context.Background())
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+	})
+}
+
+func TestShowRepo_ListAiring(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.FixedZone("AEST", 10*60*60))
+	to := from.Add(24 * time.Hour)
+
+	t.Run("queries the airing index between the bounds", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+			Run(func(args mock.Arguments) {
+				in := args.Get(1).(*dynamodb.QueryInput)
+				require.Equal(t, IndexAiring, *in.IndexName)
+				require.Equal(t, &types.AttributeValueMemberS{Value: "2024-05-31T14:00:00Z"}, in.ExpressionAttributeValues[":from"])
+				require.Equal(t, &types.AttributeValueMemberS{Value: "2024-06-01T14:00:00Z"}, in.ExpressionAttributeValues[":to"])
+			}).
+			Return(&dynamodb.QueryOutput{
+				Items: []map[string]types.AttributeValue{
+					{
+						"slug":          &types.AttributeValueMemberS{Value: "show/a"},
+						"title":         &types.AttributeValueMemberS{Value: "A"},
+						"nextEpisodeAt": &types.AttributeValueMemberS{Value: "2024-06-01T10:00:00Z"},
+					},
+				},
+			}, nil)
+
+		repo := NewShowRepository(mockDB)
+
+		got, err := repo.ListAiring(context.Background(), from, to)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "show/a", got[0].Slug)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.AnythingOfType("*dynamodb.QueryInput")).
+			Return(nil, errors.New("DynamoDB query failed"))
+
+		repo := NewShowRepository(mockDB)
+
+		got, err := repo.ListAiring(context.Background(), from, to)
 		require.Error(t, err)
 		require.Nil(t, got)
 	})
@@ -128,3 +337,318 @@ func TestShowRepo_List(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// getItemFor matches a GetItem call for the show item at slug
+func getItemFor(slug string) interface{} {
+	return mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+		return in.Key["slug"].(*types.AttributeValueMemberS).Value == slug
+	})
+}
+
+func TestShowRepo_Get(t *testing.T) {
+	t.Run("show", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+			return in.Key["sk"].(*types.AttributeValueMemberS).Value == "SHOW"
+		})).Return(&dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+			"slug":  &types.AttributeValueMemberS{Value: "show/a"},
+			"sk":    &types.AttributeValueMemberS{Value: "SHOW"},
+			"title": &types.AttributeValueMemberS{Value: "A"},
+		}}, nil)
+
+		got, err := NewShowRepository(mockDB).Get(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.Equal(t, "A", got.Title)
+		require.Nil(t, got.AliasOf)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/old")).
+			Return(&dynamodb.GetItemOutput{Item: aliasItem("show/old", "show/new")}, nil)
+
+		got, err := NewShowRepository(mockDB).Get(context.Background(), "show/old")
+		require.NoError(t, err)
+		require.Equal(t, "show/new", *got.AliasOf)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		_, err := NewShowRepository(mockDB).Get(context.Background(), "show/a")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestShowRepo_Rename(t *testing.T) {
+	showItem := map[string]types.AttributeValue{
+		"slug":    &types.AttributeValueMemberS{Value: "show/a"},
+		"sk":      &types.AttributeValueMemberS{Value: "SHOW"},
+		"title":   &types.AttributeValueMemberS{Value: "A"},
+		"aliases": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "show/older"}}},
+		"seasons": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"slug": &types.AttributeValueMemberS{Value: "show/a/season/1"},
+			}},
+		}},
+	}
+	seasonItem := map[string]types.AttributeValue{
+		"slug":       &types.AttributeValueMemberS{Value: "show/a"},
+		"sk":         &types.AttributeValueMemberS{Value: "SEASON#0001"},
+		"seasonSlug": &types.AttributeValueMemberS{Value: "show/a/season/1"},
+	}
+
+	t.Run("moves the show, its children and its aliases", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/a")).Return(&dynamodb.GetItemOutput{Item: showItem}, nil)
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/b")).Return(&dynamodb.GetItemOutput{}, nil)
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return *in.KeyConditionExpression == "slug = :slug AND sk < :show"
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{seasonItem}}, nil)
+
+		var transactions [][]types.TransactWriteItem
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				transactions = append(transactions, args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems)
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		renamed, err := NewShowRepository(mockDB).Rename(context.Background(), "show/a", "show/b")
+		require.NoError(t, err)
+		require.Equal(t, "show/b", renamed.Slug)
+		require.Equal(t, "show/b/season/1", (*renamed.Seasons)[0].Slug)
+		require.Len(t, transactions, 4)
+
+		copies := transactions[0]
+		require.Len(t, copies, 1)
+		require.Equal(t, &types.AttributeValueMemberS{Value: "show/b"}, copies[0].Put.Item["slug"])
+		require.Equal(t, &types.AttributeValueMemberS{Value: "show/b/season/1"}, copies[0].Put.Item["seasonSlug"])
+		require.Contains(t, copies[0].Put.Item, WriteIDAttr)
+
+		swap := transactions[1]
+		require.Len(t, swap, 4)
+		require.Equal(t, &types.AttributeValueMemberS{Value: "show/b"}, swap[0].Put.Item["slug"])
+		require.Equal(t, &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "show/a"},
+			&types.AttributeValueMemberS{Value: "show/older"},
+		}}, swap[0].Put.Item["aliases"])
+		require.Contains(t, swap[0].Put.Item, WriteIDAttr)
+		require.Equal(t, aliasItem("show/a", "show/b"), unstamped(swap[1].Put.Item))
+		require.Equal(t, liveShowCondition+" AND attribute_not_exists(revision)", *swap[1].Put.ConditionExpression)
+		require.Equal(t, aliasItem("show/older", "show/b"), unstamped(swap[2].Put.Item))
+		var event domain.OutboxEntry
+		require.NoError(t, attributevalue.UnmarshalMap(swap[3].Put.Item, &event))
+		require.Equal(t, domain.EventShowRenamed, event.Event.Type)
+		require.Equal(t, "show/b", event.Event.Slug)
+		require.Equal(t, "show/a", event.Event.PreviousSlug)
+
+		marks := transactions[2]
+		require.Len(t, marks, 1)
+		require.Equal(t, itemKey("show/a", "SEASON#0001"), marks[0].Update.Key)
+		require.Equal(t, &types.AttributeValueMemberBOOL{Value: true}, marks[0].Update.ExpressionAttributeValues[":deleting"])
+
+		deletes := transactions[3]
+		require.Len(t, deletes, 1)
+		require.Equal(t, itemKey("show/a", "SEASON#0001"), deletes[0].Delete.Key)
+	})
+
+	t.Run("back to a former slug", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/a")).Return(&dynamodb.GetItemOutput{Item: showItem}, nil)
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/older")).
+			Return(&dynamodb.GetItemOutput{Item: aliasItem("show/older", "show/a")}, nil)
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil)
+
+		var swap []types.TransactWriteItem
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				swap = args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+			}).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+		_, err := NewShowRepository(mockDB).Rename(context.Background(), "show/a", "show/older")
+		require.NoError(t, err)
+		require.Len(t, swap, 3)
+		require.Equal(t, &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "show/a"},
+		}}, swap[0].Put.Item["aliases"])
+	})
+
+	t.Run("target taken", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/a")).Return(&dynamodb.GetItemOutput{Item: showItem}, nil)
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/b")).
+			Return(&dynamodb.GetItemOutput{Item: aliasItem("show/b", "show/c")}, nil)
+
+		_, err := NewShowRepository(mockDB).Rename(context.Background(), "show/a", "show/b")
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+
+	t.Run("renaming an alias", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/older")).
+			Return(&dynamodb.GetItemOutput{Item: aliasItem("show/older", "show/a")}, nil)
+
+		_, err := NewShowRepository(mockDB).Rename(context.Background(), "show/older", "show/b")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("lost a race for the target", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/a")).Return(&dynamodb.GetItemOutput{Item: showItem}, nil)
+		mockDB.On("GetItem", mock.Anything, getItemFor("show/b")).Return(&dynamodb.GetItemOutput{}, nil)
+		mockDB.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{}, nil)
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Return(nil, &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+				{Code: awsString("ConditionalCheckFailed")},
+				{Code: awsString("None")},
+			}})
+
+		_, err := NewShowRepository(mockDB).Rename(context.Background(), "show/a", "show/b")
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+}
+
+func TestShowRepo_Update(t *testing.T) {
+	show := domain.Show{Slug: "show/a", Title: "A", Status: domain.StatusArchived}
+
+	t.Run("first write of a show stored before revisions", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var items []types.TransactWriteItem
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { items = args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems }).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		show := show
+		show.Revision = 2
+		revisions := []domain.Revision{
+			{Number: 1, Action: domain.RevisionBaseline, Diff: []jsondiff.Op{}},
+			{Number: 2, Action: domain.RevisionTransitioned, Diff: []jsondiff.Op{{Op: jsondiff.OpAdd, Path: "/status", Value: "archived"}}},
+		}
+		require.NoError(t, NewShowRepository(mockDB).Update(context.Background(), show, revisions))
+
+		require.Len(t, items, 4)
+		require.Equal(t, liveShowCondition+" AND attribute_not_exists(revision)", *items[0].Put.ConditionExpression)
+		require.Nil(t, items[0].Put.ExpressionAttributeValues)
+		require.Equal(t, &types.AttributeValueMemberS{Value: "archived"}, items[0].Put.Item["status"])
+		require.Equal(t, &types.AttributeValueMemberN{Value: "2"}, items[0].Put.Item["revision"])
+		require.Equal(t, &types.AttributeValueMemberS{Value: "REV#000001"}, items[1].Put.Item["sk"])
+		require.Equal(t, &types.AttributeValueMemberS{Value: "REV#000002"}, items[2].Put.Item["sk"])
+		require.Equal(t, &types.AttributeValueMemberS{Value: domain.EventShowTransitioned}, items[3].Put.Item["event"].(*types.AttributeValueMemberM).Value["type"])
+	})
+
+	t.Run("guards the revision read", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var in *types.Put
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { in = putOf(args) }).
+			Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		show := show
+		show.Revision = 4
+		require.NoError(t, NewShowRepository(mockDB).Update(context.Background(), show, []domain.Revision{{Number: 4, Action: domain.RevisionReverted}}))
+
+		require.Equal(t, liveShowCondition+" AND revision = :revision", *in.ConditionExpression)
+		require.Equal(t, &types.AttributeValueMemberN{Value: "3"}, in.ExpressionAttributeValues[":revision"])
+	})
+
+	t.Run("written concurrently", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).
+			Return(nil, &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+				{Code: awsString("ConditionalCheckFailed")},
+				{Code: awsString("None")},
+			}})
+
+		err := NewShowRepository(mockDB).Update(context.Background(), show, []domain.Revision{{Number: 2, Action: domain.RevisionTransitioned}})
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestShowRepo_Revisions(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExpressionAttributeValues[":prefix"].(*types.AttributeValueMemberS).Value == "REV#" && in.ExclusiveStartKey == nil
+		})).Return(&dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{{
+				"number": &types.AttributeValueMemberN{Value: "1"},
+				"action": &types.AttributeValueMemberS{Value: "created"},
+			}},
+			LastEvaluatedKey: itemKey("show/a", "REV#000001"),
+		}, nil).Once()
+		mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+			return in.ExclusiveStartKey != nil
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{{
+			"number": &types.AttributeValueMemberN{Value: "2"},
+			"action": &types.AttributeValueMemberS{Value: "transitioned"},
+		}}}, nil).Once()
+
+		got, err := NewShowRepository(mockDB).ListRevisions(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, 2, got[1].Number)
+		require.Equal(t, domain.RevisionTransitioned, got[1].Action)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+			return in.Key["sk"].(*types.AttributeValueMemberS).Value == "REV#000003"
+		})).Return(&dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+			"number": &types.AttributeValueMemberN{Value: "3"},
+			"show": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"slug":  &types.AttributeValueMemberS{Value: "show/a"},
+				"title": &types.AttributeValueMemberS{Value: "A"},
+			}},
+		}}, nil)
+
+		got, err := NewShowRepository(mockDB).GetRevision(context.Background(), "show/a", 3)
+		require.NoError(t, err)
+		require.Equal(t, "A", got.Show.Title)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		_, err := NewShowRepository(mockDB).GetRevision(context.Background(), "show/a", 9)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+// unstamped returns item without its write ID
+func unstamped(item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	item = maps.Clone(item)
+	delete(item, WriteIDAttr)
+	return item
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// UnmarshalItem decodes a stored item into out, as attributevalue.UnmarshalMap
+// does, and brings shows stored in an older shape up to date
+func UnmarshalItem(item map[string]types.AttributeValue, out any) error {
+	if err := attributevalue.UnmarshalMap(item, out); err != nil {
+		return err
+	}
+	upgrade(out)
+	return nil
+}
+
+// unmarshalItems is UnmarshalItem for a page of items
+func unmarshalItems(items []map[string]types.AttributeValue, out any) error {
+	if err := attributevalue.UnmarshalListOfMaps(items, out); err != nil {
+		return err
+	}
+	upgrade(out)
+	return nil
+}
+
+// upgrade folds the legacy genre of the shows decoded into out, alone or
+// inside revisions
+func upgrade(out any) {
+	switch v := out.(type) {
+	case *domain.Show:
+		v.FoldLegacyGenre()
+	case *[]domain.Show:
+		for i := range *v {
+			(*v)[i].FoldLegacyGenre()
+		}
+	case *domain.Revision:
+		if v.Show != nil {
+			v.Show.FoldLegacyGenre()
+		}
+	case *[]domain.Revision:
+		for i := range *v {
+			if show := (*v)[i].Show; show != nil {
+				show.FoldLegacyGenre()
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// TestUnmarshalItem_LegacyGenre reads items stored before the genre
+// taxonomy, which hold only the free-text genre attribute
+func TestUnmarshalItem_LegacyGenre(t *testing.T) {
+	item := func(attrs map[string]types.AttributeValue) map[string]types.AttributeValue {
+		attrs["slug"] = &types.AttributeValueMemberS{Value: "show/a"}
+		attrs["title"] = &types.AttributeValueMemberS{Value: "A"}
+		return attrs
+	}
+	genres := func(codes ...string) types.AttributeValue {
+		list := &types.AttributeValueMemberL{}
+		for _, code := range codes {
+			list.Value = append(list.Value, &types.AttributeValueMemberS{Value: code})
+		}
+		return list
+	}
+
+	tests := []struct {
+		name       string
+		item       map[string]types.AttributeValue
+		wantGenre  *string
+		wantGenres []string
+	}{
+		{
+			name:       "known genre is folded into genres",
+			item:       item(map[string]types.AttributeValue{"genre": &types.AttributeValueMemberS{Value: "Cooking"}}),
+			wantGenres: []string{"reality/cooking"},
+		},
+		{
+			name: "folded once",
+			item: item(map[string]types.AttributeValue{
+				"genre":  &types.AttributeValueMemberS{Value: "Reality"},
+				"genres": genres("reality"),
+			}),
+			wantGenres: []string{"reality"},
+		},
+		{
+			name:      "unknown genre is kept",
+			item:      item(map[string]types.AttributeValue{"genre": &types.AttributeValueMemberS{Value: "Polka"}}),
+			wantGenre: awsString("Polka"),
+		},
+		{
+			name:       "taxonomy shows are read as stored",
+			item:       item(map[string]types.AttributeValue{"genres": genres("kids", "comedy")}),
+			wantGenres: []string{"kids", "comedy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var show domain.Show
+			require.NoError(t, UnmarshalItem(tt.item, &show))
+			require.Equal(t, "show/a", show.Slug)
+			require.Equal(t, "A", show.Title)
+			require.Equal(t, tt.wantGenre, show.Genre)
+			require.Equal(t, tt.wantGenres, show.Genres)
+
+			// Writing the show back keeps its genre
+			out, err := attributevalue.MarshalMap(show)
+			require.NoError(t, err)
+			var again domain.Show
+			require.NoError(t, UnmarshalItem(out, &again))
+			require.Equal(t, tt.wantGenre, again.Genre)
+			require.Equal(t, tt.wantGenres, again.Genres)
+		})
+	}
+
+	legacy := item(map[string]types.AttributeValue{"genre": &types.AttributeValueMemberS{Value: "Cooking"}})
+
+	t.Run("in a page", func(t *testing.T) {
+		var shows []domain.Show
+		require.NoError(t, unmarshalItems([]map[string]types.AttributeValue{legacy}, &shows))
+		require.Equal(t, []string{"reality/cooking"}, shows[0].Genres)
+	})
+
+	t.Run("inside revisions", func(t *testing.T) {
+		stored := map[string]types.AttributeValue{
+			"number": &types.AttributeValueMemberN{Value: "1"},
+			"show":   &types.AttributeValueMemberM{Value: legacy},
+		}
+		var rev domain.Revision
+		require.NoError(t, UnmarshalItem(stored, &rev))
+		require.Equal(t, []string{"reality/cooking"}, rev.Show.Genres)
+
+		var revs []domain.Revision
+		require.NoError(t, unmarshalItems([]map[string]types.AttributeValue{stored}, &revs))
+		require.Equal(t, []string{"reality/cooking"}, revs[0].Show.Genres)
+	})
+}
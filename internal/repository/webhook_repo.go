@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/database"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// WebhookRepository stores webhooks and their deliveries. A delivery is
+// logged under its webhook and, while pending, kept in the due partition as
+// well. Several dispatchers may share it: a due delivery is claimed before
+// it is sent, and settling it is conditional on that claim.
+type WebhookRepository interface {
+	Create(ctx context.Context, w domain.Webhook) error
+	Update(ctx context.Context, w domain.Webhook) error
+	Get(ctx context.Context, id string) (*domain.Webhook, error)
+	List(ctx context.Context) ([]domain.Webhook, error)
+	Delete(ctx context.Context, id string) error
+
+	AddDelivery(ctx context.Context, d domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error)
+	DueDeliveries(ctx context.Context, at time.Time, limit int) ([]domain.WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, d domain.WebhookDelivery, until time.Time) (*domain.WebhookDelivery, error)
+	SettleDelivery(ctx context.Context, d domain.WebhookDelivery) error
+}
+
+type WebhookRepo struct {
+	db database.DynamoAPI
+}
+
+var _ WebhookRepository = (*WebhookRepo)(nil)
+
+func NewWebhookRepository(db database.DynamoAPI) WebhookRepository {
+	return &WebhookRepo{db: db}
+}
+
+// Create stores a new webhook, failing with ErrConflict if the ID is taken
+func (r *WebhookRepo) Create(ctx context.Context, w domain.Webhook) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.Create")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	return r.put(ctx, w, "attribute_not_exists(slug)", apperror.ErrConflict)
+}
+
+// Update replaces an existing webhook, failing with ErrNotFound if there is
+// none
+func (r *WebhookRepo) Update(ctx context.Context, w domain.Webhook) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.Update")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	return r.put(ctx, w, "attribute_exists(slug)", apperror.ErrNotFound)
+}
+
+func (r *WebhookRepo) put(ctx context.Context, w domain.Webhook, condition string, onFailed error) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+	w.PK = webhooksPK
+	w.SK = webhookSK(w.ID)
+
+	item, err := attributevalue.MarshalMap(w)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           awsString(r.db.TableName()),
+		Item:                item,
+		ConditionExpression: awsString(condition),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("webhook %s: %w", w.ID, onFailed)
+	}
+	return translateError(err, "")
+}
+
+func (r *WebhookRepo) Get(ctx context.Context, id string) (_ *domain.Webhook, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.Get")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(webhooksPK, webhookSK(id)),
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("webhook %s: %w", id, apperror.ErrNotFound)
+	}
+	var w domain.Webhook
+	if err := attributevalue.UnmarshalMap(out.Item, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// List returns every webhook in ID order
+func (r *WebhookRepo) List(ctx context.Context) (_ []domain.Webhook, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	webhooks := []domain.Webhook{}
+	err = r.query(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug":   &types.AttributeValueMemberS{Value: webhooksPK},
+			":prefix": &types.AttributeValueMemberS{Value: webhookSKPrefix},
+		},
+	}, 0, func(items []map[string]types.AttributeValue) error {
+		var page []domain.Webhook
+		if err := attributevalue.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		webhooks = append(webhooks, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(webhooks)))
+	return webhooks, nil
+}
+
+// Delete removes a webhook, failing with ErrNotFound if there is none. Its
+// delivery log is kept; deliveries still pending fail when next due.
+func (r *WebhookRepo) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.Delete")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	_, err = r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           awsString(r.db.TableName()),
+		Key:                 itemKey(webhooksPK, webhookSK(id)),
+		ConditionExpression: awsString("attribute_exists(slug)"),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return fmt.Errorf("webhook %s: %w", id, apperror.ErrNotFound)
+	}
+	return translateError(err, "")
+}
+
+// AddDelivery logs a new pending delivery and makes it due. It fails with
+// ErrConflict if a delivery with the same ID exists.
+func (r *WebhookRepo) AddDelivery(ctx context.Context, d domain.WebhookDelivery) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.AddDelivery")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	logged, err := deliveryItem(d, deliveriesPK(d.WebhookID), deliverySK(d.ID))
+	if err != nil {
+		return err
+	}
+	due, err := deliveryItem(d, webhookDuePK, dueDeliverySK(d.WebhookID, d.ID))
+	if err != nil {
+		return err
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		{Put: &types.Put{TableName: awsString(r.db.TableName()), Item: logged, ConditionExpression: awsString("attribute_not_exists(slug)")}},
+		{Put: &types.Put{TableName: awsString(r.db.TableName()), Item: due, ConditionExpression: awsString("attribute_not_exists(slug)")}},
+	}})
+	conflict := fmt.Errorf("delivery %s: %w", d.ID, apperror.ErrConflict)
+	return translateTransactError(err, conflict, conflict)
+}
+
+func (r *WebhookRepo) GetDelivery(ctx context.Context, webhookID, id string) (_ *domain.WebhookDelivery, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.GetDelivery")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	out, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(r.db.TableName()),
+		Key:       itemKey(deliveriesPK(webhookID), deliverySK(id)),
+	})
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("delivery %s: %w", id, apperror.ErrNotFound)
+	}
+	var d domain.WebhookDelivery
+	if err := attributevalue.UnmarshalMap(out.Item, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ListDeliveries returns up to limit of a webhook's deliveries, newest first
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, webhookID string, limit int) (_ []domain.WebhookDelivery, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.ListDeliveries")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := r.deliveries(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug":   &types.AttributeValueMemberS{Value: deliveriesPK(webhookID)},
+			":prefix": &types.AttributeValueMemberS{Value: deliverySKPrefix},
+		},
+		ScanIndexForward: awsBool(false),
+	}, limit)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(deliveries)))
+	return deliveries, nil
+}
+
+// DueDeliveries returns up to limit pending deliveries due at or before at
+func (r *WebhookRepo) DueDeliveries(ctx context.Context, at time.Time, limit int) (_ []domain.WebhookDelivery, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.DueDeliveries")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := r.deliveries(ctx, &dynamodb.QueryInput{
+		TableName:              awsString(r.db.TableName()),
+		KeyConditionExpression: awsString("slug = :slug AND begins_with(sk, :prefix)"),
+		FilterExpression:       awsString("nextAttemptAt <= :at"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":slug":   &types.AttributeValueMemberS{Value: webhookDuePK},
+			":prefix": &types.AttributeValueMemberS{Value: deliverySKPrefix},
+			":at":     &types.AttributeValueMemberS{Value: at.UTC().Format(domain.SortableTime)},
+		},
+	}, limit)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(deliveries)))
+	return deliveries, nil
+}
+
+// ClaimDelivery counts an attempt at due delivery d and leases it until
+// then, so that no other dispatcher sends it meanwhile. It fails with
+// ErrConflict if d was claimed or settled since it was read.
+func (r *WebhookRepo) ClaimDelivery(ctx context.Context, d domain.WebhookDelivery, until time.Time) (_ *domain.WebhookDelivery, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.ClaimDelivery")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	claimed := d
+	claimed.Attempts++
+	claimed.NextAttemptAt = until.UTC().Format(domain.SortableTime)
+	item, err := deliveryItem(claimed, webhookDuePK, dueDeliverySK(d.WebhookID, d.ID))
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 awsString(r.db.TableName()),
+		Item:                      item,
+		ConditionExpression:       awsString("attempts = :attempts"),
+		ExpressionAttributeValues: attemptsValue(d.Attempts),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return nil, fmt.Errorf("delivery %s: %w", d.ID, apperror.ErrConflict)
+	}
+	if err != nil {
+		return nil, translateError(err, "")
+	}
+	return &claimed, nil
+}
+
+// SettleDelivery records the outcome of the attempt at claimed delivery d.
+// A delivery still pending stays due at its NextAttemptAt; any other leaves
+// the due partition.
+func (r *WebhookRepo) SettleDelivery(ctx context.Context, d domain.WebhookDelivery) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookRepository.SettleDelivery")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	logged, err := deliveryItem(d, deliveriesPK(d.WebhookID), deliverySK(d.ID))
+	if err != nil {
+		return err
+	}
+	due := types.TransactWriteItem{Delete: &types.Delete{
+		TableName:                 awsString(r.db.TableName()),
+		Key:                       itemKey(webhookDuePK, dueDeliverySK(d.WebhookID, d.ID)),
+		ConditionExpression:       awsString("attempts = :attempts"),
+		ExpressionAttributeValues: attemptsValue(d.Attempts),
+	}}
+	if d.Status == domain.DeliveryPending {
+		item, err := deliveryItem(d, webhookDuePK, dueDeliverySK(d.WebhookID, d.ID))
+		if err != nil {
+			return err
+		}
+		due = types.TransactWriteItem{Put: &types.Put{
+			TableName:                 awsString(r.db.TableName()),
+			Item:                      item,
+			ConditionExpression:       awsString("attempts = :attempts"),
+			ExpressionAttributeValues: attemptsValue(d.Attempts),
+		}}
+	}
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+		due,
+		{Put: &types.Put{TableName: awsString(r.db.TableName()), Item: logged}},
+	}})
+	return translateTransactError(err, fmt.Errorf("delivery %s: %w", d.ID, apperror.ErrConflict))
+}
+
+// deliveries runs a query for deliveries, stopping at limit
+func (r *WebhookRepo) deliveries(ctx context.Context, in *dynamodb.QueryInput, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+	err := r.query(ctx, in, limit, func(items []map[string]types.AttributeValue) error {
+		var page []domain.WebhookDelivery
+		if err := attributevalue.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		deliveries = append(deliveries, page[:min(len(page), limit-len(deliveries))]...)
+		return nil
+	})
+	return deliveries, err
+}
+
+// query hands each page of in to read until the results run out or, with a
+// positive limit, read has been given limit items
+func (r *WebhookRepo) query(ctx context.Context, in *dynamodb.QueryInput, limit int, read func([]map[string]types.AttributeValue) error) error {
+	seen := 0
+	for {
+		out, err := r.db.Query(ctx, in)
+		if err != nil {
+			return translateError(err, "")
+		}
+		if err := read(out.Items); err != nil {
+			return err
+		}
+		seen += len(out.Items)
+		if len(out.LastEvaluatedKey) == 0 || (limit > 0 && seen >= limit) {
+			return nil
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// deliveryItem marshals d under the given keys
+func deliveryItem(d domain.WebhookDelivery, pk, sk string) (map[string]types.AttributeValue, error) {
+	d.PK, d.SK = pk, sk
+	return attributevalue.MarshalMap(d)
+}
+
+func attemptsValue(attempts int) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		":attempts": &types.AttributeValueMemberN{Value: strconv.Itoa(attempts)},
+	}
+}
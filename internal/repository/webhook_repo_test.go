@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	dynamoMocks "github.com/marciomarinho/show-service/internal/database/mocks"
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+const testWebhookID = "0123456789abcdef0123456789abcdef"
+
+func testWebhook() domain.Webhook {
+	return domain.Webhook{
+		ID:        testWebhookID,
+		URL:       "https://partner.example.com/hooks",
+		Events:    []string{domain.WebhookShowPublished},
+		Secret:    "0123456789abcdef",
+		CreatedAt: "2024-06-15T00:00:00Z",
+	}
+}
+
+func testDelivery(id string, attempts int) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:            id,
+		WebhookID:     testWebhookID,
+		Event:         domain.WebhookShowPublished,
+		Payload:       json.RawMessage(`{"id":"e1"}`),
+		Status:        domain.DeliveryPending,
+		Attempts:      attempts,
+		NextAttemptAt: "2024-06-15T00:00:00Z",
+		CreatedAt:     "2024-06-15T00:00:00Z",
+	}
+}
+
+func deliveryAttrs(t *testing.T, d domain.WebhookDelivery, pk, sk string) map[string]types.AttributeValue {
+	item, err := deliveryItem(d, pk, sk)
+	require.NoError(t, err)
+	return item
+}
+
+func TestWebhookRepo_Create(t *testing.T) {
+	t.Run("stores under the webhooks partition", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var stored domain.Webhook
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attribute_not_exists(slug)"
+		})).Run(func(args mock.Arguments) {
+			require.NoError(t, attributevalue.UnmarshalMap(args.Get(1).(*dynamodb.PutItemInput).Item, &stored))
+		}).Return(&dynamodb.PutItemOutput{}, nil)
+
+		require.NoError(t, NewWebhookRepository(mockDB).Create(context.Background(), testWebhook()))
+		require.Equal(t, "webhooks", stored.PK)
+		require.Equal(t, "WEBHOOK#"+testWebhookID, stored.SK)
+		require.Equal(t, "0123456789abcdef", stored.Secret)
+	})
+
+	t.Run("ID taken", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		err := NewWebhookRepository(mockDB).Create(context.Background(), testWebhook())
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		err := NewWebhookRepository(mockDB).Create(context.Background(), domain.Webhook{ID: testWebhookID})
+		require.Error(t, err)
+		mockDB.AssertNotCalled(t, "PutItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookRepo_Update(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return *in.ConditionExpression == "attribute_exists(slug)"
+	})).Return(nil, &types.ConditionalCheckFailedException{})
+
+	err := NewWebhookRepository(mockDB).Update(context.Background(), testWebhook())
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+}
+
+func TestWebhookRepo_Get(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		item, err := attributevalue.MarshalMap(testWebhook())
+		require.NoError(t, err)
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+			return in.Key["sk"].(*types.AttributeValueMemberS).Value == "WEBHOOK#"+testWebhookID
+		})).Return(&dynamodb.GetItemOutput{Item: item}, nil)
+
+		got, err := NewWebhookRepository(mockDB).Get(context.Background(), testWebhookID)
+		require.NoError(t, err)
+		require.Equal(t, testWebhook(), *got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		_, err := NewWebhookRepository(mockDB).Get(context.Background(), testWebhookID)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func TestWebhookRepo_List(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	first, second := testWebhook(), testWebhook()
+	second.ID = "fedcba9876543210fedcba9876543210"
+	firstItem, err := attributevalue.MarshalMap(first)
+	require.NoError(t, err)
+	secondItem, err := attributevalue.MarshalMap(second)
+	require.NoError(t, err)
+
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey == nil &&
+			in.ExpressionAttributeValues[":slug"].(*types.AttributeValueMemberS).Value == "webhooks" &&
+			in.ExpressionAttributeValues[":prefix"].(*types.AttributeValueMemberS).Value == "WEBHOOK#"
+	})).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]types.AttributeValue{firstItem},
+		LastEvaluatedKey: itemKey(webhooksPK, webhookSK(first.ID)),
+	}, nil).Once()
+	mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey != nil
+	})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{secondItem}}, nil).Once()
+
+	webhooks, err := NewWebhookRepository(mockDB).List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []domain.Webhook{first, second}, webhooks)
+}
+
+func TestWebhookRepo_Delete(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("DeleteItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+		return *in.ConditionExpression == "attribute_exists(slug)"
+	})).Return(nil, &types.ConditionalCheckFailedException{})
+
+	err := NewWebhookRepository(mockDB).Delete(context.Background(), testWebhookID)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+}
+
+func TestWebhookRepo_AddDelivery(t *testing.T) {
+	d := testDelivery("20240615T000000Z-0123456789abcdef", 0)
+
+	t.Run("logs the delivery and makes it due", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+			require.Len(t, items, 2)
+			require.Equal(t, deliveryAttrs(t, d, "webhook/"+testWebhookID, "DELIVERY#"+d.ID), items[0].Put.Item)
+			require.Equal(t, deliveryAttrs(t, d, "webhook-due", "DELIVERY#"+testWebhookID+"#"+d.ID), items[1].Put.Item)
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		require.NoError(t, NewWebhookRepository(mockDB).AddDelivery(context.Background(), d))
+	})
+
+	t.Run("already added", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, cancelled("ConditionalCheckFailed", "None"))
+
+		err := NewWebhookRepository(mockDB).AddDelivery(context.Background(), d)
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestWebhookRepo_ListDeliveries(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	newer := testDelivery("20240615T000002Z-0123456789abcdef", 1)
+	older := testDelivery("20240615T000001Z-0123456789abcdef", 1)
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return !*in.ScanIndexForward &&
+			in.ExpressionAttributeValues[":slug"].(*types.AttributeValueMemberS).Value == "webhook/"+testWebhookID
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{
+			deliveryAttrs(t, newer, deliveriesPK(testWebhookID), deliverySK(newer.ID)),
+			deliveryAttrs(t, older, deliveriesPK(testWebhookID), deliverySK(older.ID)),
+		},
+		LastEvaluatedKey: itemKey(deliveriesPK(testWebhookID), deliverySK(older.ID)),
+	}, nil).Once()
+
+	deliveries, err := NewWebhookRepository(mockDB).ListDeliveries(context.Background(), testWebhookID, 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, newer.ID, deliveries[0].ID)
+}
+
+func TestWebhookRepo_DueDeliveries(t *testing.T) {
+	mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+	due := testDelivery("20240615T000000Z-0123456789abcdef", 0)
+	mockDB.On("TableName").Return("test-table").Maybe()
+	mockDB.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return *in.FilterExpression == "nextAttemptAt <= :at" &&
+			in.ExpressionAttributeValues[":slug"].(*types.AttributeValueMemberS).Value == "webhook-due" &&
+			in.ExpressionAttributeValues[":at"].(*types.AttributeValueMemberS).Value == "2024-06-15T00:00:30Z"
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]types.AttributeValue{deliveryAttrs(t, due, webhookDuePK, dueDeliverySK(testWebhookID, due.ID))},
+	}, nil).Once()
+
+	deliveries, err := NewWebhookRepository(mockDB).DueDeliveries(context.Background(), time.Date(2024, 6, 15, 0, 0, 30, 0, time.UTC), 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, due.ID, deliveries[0].ID)
+}
+
+func TestWebhookRepo_ClaimDelivery(t *testing.T) {
+	until := time.Date(2024, 6, 15, 0, 1, 0, 0, time.UTC)
+	d := testDelivery("20240615T000000Z-0123456789abcdef", 2)
+
+	t.Run("counts the attempt and leases the delivery", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		var stored domain.WebhookDelivery
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+			return *in.ConditionExpression == "attempts = :attempts" &&
+				in.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value == "2"
+		})).Run(func(args mock.Arguments) {
+			require.NoError(t, attributevalue.UnmarshalMap(args.Get(1).(*dynamodb.PutItemInput).Item, &stored))
+		}).Return(&dynamodb.PutItemOutput{}, nil)
+
+		claimed, err := NewWebhookRepository(mockDB).ClaimDelivery(context.Background(), d, until)
+		require.NoError(t, err)
+		require.Equal(t, 3, claimed.Attempts)
+		require.Equal(t, "2024-06-15T00:01:00Z", claimed.NextAttemptAt)
+		require.Equal(t, "webhook-due", stored.PK)
+		require.Equal(t, 3, stored.Attempts)
+	})
+
+	t.Run("claimed elsewhere", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("PutItem", mock.Anything, mock.Anything).Return(nil, &types.ConditionalCheckFailedException{})
+
+		_, err := NewWebhookRepository(mockDB).ClaimDelivery(context.Background(), d, until)
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestWebhookRepo_SettleDelivery(t *testing.T) {
+	t.Run("pending stays due", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		d := testDelivery("20240615T000000Z-0123456789abcdef", 1)
+		d.NextAttemptAt = "2024-06-15T00:00:10Z"
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+			require.Len(t, items, 2)
+			require.Equal(t, deliveryAttrs(t, d, webhookDuePK, dueDeliverySK(testWebhookID, d.ID)), items[0].Put.Item)
+			require.Equal(t, "attempts = :attempts", *items[0].Put.ConditionExpression)
+			require.Equal(t, deliveryAttrs(t, d, deliveriesPK(testWebhookID), deliverySK(d.ID)), items[1].Put.Item)
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		require.NoError(t, NewWebhookRepository(mockDB).SettleDelivery(context.Background(), d))
+	})
+
+	t.Run("settled leaves the due partition", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		d := testDelivery("20240615T000000Z-0123456789abcdef", 1)
+		d.Status, d.NextAttemptAt, d.ResponseStatus = domain.DeliverySucceeded, "", 200
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			items := args.Get(1).(*dynamodb.TransactWriteItemsInput).TransactItems
+			require.Len(t, items, 2)
+			require.Equal(t, itemKey(webhookDuePK, dueDeliverySK(testWebhookID, d.ID)), items[0].Delete.Key)
+			require.Equal(t, "1", items[0].Delete.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value)
+			require.Equal(t, deliveryAttrs(t, d, deliveriesPK(testWebhookID), deliverySK(d.ID)), items[1].Put.Item)
+		}).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+		require.NoError(t, NewWebhookRepository(mockDB).SettleDelivery(context.Background(), d))
+	})
+
+	t.Run("claim lost", func(t *testing.T) {
+		mockDB := dynamoMocks.NewMockDynamoAPI(t)
+
+		mockDB.On("TableName").Return("test-table").Maybe()
+		mockDB.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, cancelled("ConditionalCheckFailed", "None"))
+
+		err := NewWebhookRepository(mockDB).SettleDelivery(context.Background(), testDelivery("20240615T000000Z-0123456789abcdef", 1))
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
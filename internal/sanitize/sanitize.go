@@ -0,0 +1,189 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// urlAttrs hold URLs and only keep http(s) values
+var urlAttrs = map[string]bool{"href": true, "src": true}
+
+// dropContent elements are removed together with their text
+var dropContent = map[string]bool{"script": true, "style": true, "iframe": true, "object": true, "noscript": true, "template": true}
+
+// voidElements never have a closing tag
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true, "wbr": true}
+
+// blockElements start a new line in the plain-text rendering
+var blockElements = map[string]bool{"br": true, "p": true, "div": true, "li": true, "hr": true}
+
+var matchRule = regexp.MustCompile(`^([a-z][a-z0-9]*)(?:\[([a-z-]+)\])?$`)
+
+// Policy is an element/attribute allowlist
+type Policy struct {
+	elements map[string]map[string]bool
+}
+
+// NewPolicy parses rules such as "br" or "span[class]". Each rule allows one
+// attribute; repeat the element to allow more, e.g. "a[href]", "a[title]".
+func NewPolicy(rules []string) (*Policy, error) {
+	p := &Policy{elements: map[string]map[string]bool{}}
+	for _, rule := range rules {
+		m := matchRule.FindStringSubmatch(strings.ToLower(strings.TrimSpace(rule)))
+		if m == nil {
+			return nil, fmt.Errorf("sanitize: invalid allowlist rule %q", rule)
+		}
+		attrs := p.elements[m[1]]
+		if attrs == nil {
+			attrs = map[string]bool{}
+			p.elements[m[1]] = attrs
+		}
+		if m[2] != "" {
+			attrs[m[2]] = true
+		}
+	}
+	return p, nil
+}
+
+// Result is the outcome of sanitizing one fragment
+type Result struct {
+	HTML    string // markup with only allowlisted elements and attributes
+	Removed bool   // something was stripped; strict callers reject the input
+}
+
+// Sanitize re-renders s keeping allowlisted elements and attributes and
+// escaping all text. Unclosed allowed elements are closed at the end.
+func (p *Policy) Sanitize(s string) Result {
+	var b strings.Builder
+	var open []string
+	removed := false
+	skipping := ""
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break // io.EOF; the input is an in-memory string
+		}
+		tok := z.Token()
+
+		if skipping != "" {
+			if tt == html.EndTagToken && tok.Data == skipping {
+				skipping = ""
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			b.WriteString(html.EscapeString(tok.Data))
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			allowed, ok := p.elements[tok.Data]
+			if !ok {
+				removed = true
+				if dropContent[tok.Data] && tt == html.StartTagToken {
+					skipping = tok.Data
+				}
+				continue
+			}
+			b.WriteString("<" + tok.Data)
+			for _, a := range tok.Attr {
+				if !allowed[a.Key] || a.Namespace != "" || (urlAttrs[a.Key] && !isHTTPURL(a.Val)) {
+					removed = true
+					continue
+				}
+				b.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+			}
+			b.WriteString(">")
+			if tt == html.StartTagToken && !voidElements[tok.Data] {
+				open = append(open, tok.Data)
+			}
+
+		case html.EndTagToken:
+			if _, ok := p.elements[tok.Data]; !ok {
+				removed = true
+				continue
+			}
+			i := lastIndex(open, tok.Data)
+			if i < 0 {
+				continue // stray closing tag, harmless
+			}
+			// Close anything left open inside this element first
+			for j := len(open) - 1; j >= i; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			open = open[:i]
+
+		default: // comments and doctypes
+			removed = true
+		}
+	}
+
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("</" + open[j] + ">")
+	}
+	return Result{HTML: b.String(), Removed: removed}
+}
+
+// PlainText renders the text content of s, turning line-breaking elements
+// into newlines and collapsing other whitespace
+func PlainText(s string) string {
+	var lines []string
+	var line strings.Builder
+	flush := func() {
+		if text := strings.Join(strings.Fields(line.String()), " "); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+
+	skipping := ""
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+		if skipping != "" {
+			if tt == html.EndTagToken && tok.Data == skipping {
+				skipping = ""
+			}
+			continue
+		}
+		switch tt {
+		case html.TextToken:
+			line.WriteString(tok.Data)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if dropContent[tok.Data] && tt == html.StartTagToken {
+				skipping = tok.Data
+			} else if blockElements[tok.Data] {
+				flush()
+			}
+		case html.EndTagToken:
+			if blockElements[tok.Data] {
+				flush()
+			}
+		}
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
+
+func isHTTPURL(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+func lastIndex(stack []string, tag string) int {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == tag {
+			return i
+		}
+	}
+	return -1
+}
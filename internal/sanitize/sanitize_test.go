@@ -0,0 +1,116 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	p, err := NewPolicy([]string{"br", " SPAN[class] ", "a[href]", "a[title]"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]bool{
+		"br":   {},
+		"span": {"class": true},
+		"a":    {"href": true, "title": true},
+	}, p.elements)
+
+	for _, rule := range []string{"", "span[", "span[class,id]", "<br>", "1a"} {
+		_, err := NewPolicy([]string{rule})
+		require.Error(t, err, rule)
+	}
+}
+
+func TestPolicy_Sanitize(t *testing.T) {
+	p, err := NewPolicy([]string{"br", "span[class]", "a[href]"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		in          string
+		want        string
+		wantRemoved bool
+	}{
+		{
+			name: "feed markup is kept",
+			in:   `Next episode airs: <span> 10:00pm Monday on<br><span class="visit">GO!</span></span>`,
+			want: `Next episode airs: <span> 10:00pm Monday on<br><span class="visit">GO!</span></span>`,
+		},
+		{
+			name: "unclosed elements are closed",
+			in:   `<span>6:30pm Sunday on<br><span class="visit">GEM`,
+			want: `<span>6:30pm Sunday on<br><span class="visit">GEM</span></span>`,
+		},
+		{
+			name:        "script removed with its content",
+			in:          `Soon<script>alert(1)</script>!`,
+			want:        `Soon!`,
+			wantRemoved: true,
+		},
+		{
+			name:        "disallowed element unwrapped",
+			in:          `<div onclick="x()">Monday</div>`,
+			want:        `Monday`,
+			wantRemoved: true,
+		},
+		{
+			name:        "disallowed attribute dropped",
+			in:          `<span class="a" style="color:red" onmouseover="x()">9pm</span>`,
+			want:        `<span class="a">9pm</span>`,
+			wantRemoved: true,
+		},
+		{
+			name:        "javascript href dropped",
+			in:          `<a href="javascript:alert(1)">watch</a>`,
+			want:        `<a>watch</a>`,
+			wantRemoved: true,
+		},
+		{
+			name: "http href kept and escaped",
+			in:   `<a href="https://example.com/?a=1&b=2">watch</a>`,
+			want: `<a href="https://example.com/?a=1&amp;b=2">watch</a>`,
+		},
+		{
+			name:        "comments removed",
+			in:          `a<!-- hidden -->b`,
+			want:        `ab`,
+			wantRemoved: true,
+		},
+		{
+			name: "text is escaped",
+			in:   `Tom &amp; Jerry > 3`,
+			want: `Tom &amp; Jerry &gt; 3`,
+		},
+		{
+			name: "stray closing tag dropped",
+			in:   `9pm</span>`,
+			want: `9pm`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.Sanitize(tt.in)
+			require.Equal(t, tt.want, got.HTML)
+			require.Equal(t, tt.wantRemoved, got.Removed)
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `Next episode airs: <span> 10:00pm Monday on<br><span class="visit">GO!</span></span>`, want: "Next episode airs: 10:00pm Monday on\nGO!"},
+		{in: `<b>Tom</b> &amp; <i>Jerry</i>`, want: "Tom & Jerry"},
+		{in: `<p>one</p><p>two</p><script>three</script>`, want: "one\ntwo"},
+		{in: ``, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			require.Equal(t, tt.want, PlainText(tt.in))
+		})
+	}
+}
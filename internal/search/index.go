@@ -0,0 +1,285 @@
+// Package search keeps an embedded full-text index of shows. Titles,
+// descriptions, genres and channel names are folded into words the way
+// slugs are, so accents and case don't matter, and each query word also
+// matches the words it begins. Hits are ranked with BM25, titles weighing
+// most.
+package search
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/genre"
+)
+
+// Fields indexed, and how much a match in each counts
+const (
+	fieldTitle = iota
+	fieldDescription
+	fieldGenre
+	fieldChannel
+	numFields
+)
+
+var boosts = [numFields]float64{fieldTitle: 3, fieldDescription: 1, fieldGenre: 1.5, fieldChannel: 1.5}
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// prefixWeight discounts a word matched by its beginning only, so that
+// "taste" ranks a show about tasting below one called "The Taste"
+const prefixWeight = 0.5
+
+// Hit is a show matching a query, with its relevance
+type Hit struct {
+	Show  domain.Show
+	Score float64
+}
+
+// Index is safe for concurrent use
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]*document           // by slug
+	postings map[string]map[string]*posting // word, then slug
+	words    []string                       // the words in postings, sorted for prefix lookups
+	total    [numFields]int                 // words per field across documents
+}
+
+type document struct {
+	show    domain.Show
+	lengths [numFields]int
+}
+
+// posting counts one word's occurrences in each field of one document
+type posting [numFields]int
+
+func New() *Index {
+	return &Index{docs: map[string]*document{}, postings: map[string]map[string]*posting{}}
+}
+
+// Put indexes show, replacing what was indexed at its slug. Alias stubs are
+// not indexed.
+func (ix *Index) Put(show domain.Show) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(show.Slug)
+	if show.AliasOf == nil {
+		ix.enter(ix.add(show))
+	}
+}
+
+// Delete removes the show at slug, if indexed
+func (ix *Index) Delete(slug string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(slug)
+}
+
+// Rename moves the show indexed at from to slug to, as ShowRepo.Rename does
+func (ix *Index) Rename(from, to string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	d, ok := ix.docs[from]
+	if !ok {
+		return
+	}
+	show := d.show
+	ix.remove(from)
+	show.Rename(to)
+	show.Aliases = append(slices.Clip(show.Aliases), from)
+	ix.remove(to)
+	ix.enter(ix.add(show))
+}
+
+// Replace indexes shows in place of everything indexed so far. Searches
+// see either the old index or the new one.
+func (ix *Index) Replace(shows []domain.Show) {
+	fresh := New()
+	for _, show := range shows {
+		if show.AliasOf == nil {
+			fresh.remove(show.Slug)
+			fresh.add(show)
+		}
+	}
+	// Sorting the words once keeps a rebuild O(n log n) in their number
+	fresh.words = slices.Sorted(maps.Keys(fresh.postings))
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.docs, ix.postings, ix.words, ix.total = fresh.docs, fresh.postings, fresh.words, fresh.total
+}
+
+// Len returns the number of shows indexed
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Search returns the shows matching every word of query, best first, and
+// at most limit of them unless limit is 0. Ties are broken by title.
+func (ix *Index) Search(query string, limit int) []Hit {
+	terms := slices.Compact(slices.Sorted(slices.Values(domain.FoldWords(query))))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	var avg [numFields]float64
+	for f := range numFields {
+		avg[f] = math.Max(float64(ix.total[f])/math.Max(float64(len(ix.docs)), 1), 1)
+	}
+
+	var scores map[string]float64
+	for _, term := range terms {
+		// A document scores its best match for each term
+		best := map[string]float64{}
+		i, _ := slices.BinarySearch(ix.words, term)
+		for ; i < len(ix.words) && strings.HasPrefix(ix.words[i], term); i++ {
+			word := ix.words[i]
+			weight := 1.0
+			if word != term {
+				weight = prefixWeight
+			}
+			docs := ix.postings[word]
+			idf := math.Log(1 + (float64(len(ix.docs))-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+			for slug, p := range docs {
+				score := 0.0
+				for f, tf := range p {
+					if tf == 0 {
+						continue
+					}
+					norm := float64(ix.docs[slug].lengths[f]) / avg[f]
+					score += boosts[f] * float64(tf) * (k1 + 1) / (float64(tf) + k1*(1-b+b*norm))
+				}
+				best[slug] = max(best[slug], weight*idf*score)
+			}
+		}
+
+		// Every term must match
+		if scores == nil {
+			scores = best
+			continue
+		}
+		for slug := range scores {
+			if s, ok := best[slug]; ok {
+				scores[slug] += s
+			} else {
+				delete(scores, slug)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for slug, score := range scores {
+		hits = append(hits, Hit{Show: ix.docs[slug].show, Score: score})
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Show.Title, b.Show.Title), cmp.Compare(a.Show.Slug, b.Show.Slug))
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// add indexes show, whose slug must not be indexed, and returns the words
+// it added to postings. The caller enters them in words.
+func (ix *Index) add(show domain.Show) (added []string) {
+	d := &document{show: show}
+	for f, texts := range fields(show) {
+		for _, text := range texts {
+			for _, word := range domain.FoldWords(text) {
+				docs, ok := ix.postings[word]
+				if !ok {
+					docs = map[string]*posting{}
+					ix.postings[word] = docs
+					added = append(added, word)
+				}
+				p, ok := docs[show.Slug]
+				if !ok {
+					p = &posting{}
+					docs[show.Slug] = p
+				}
+				p[f]++
+				d.lengths[f]++
+				ix.total[f]++
+			}
+		}
+	}
+	ix.docs[show.Slug] = d
+	return added
+}
+
+// enter inserts words, which are new to postings, into the sorted words
+func (ix *Index) enter(words []string) {
+	for _, word := range words {
+		i, _ := slices.BinarySearch(ix.words, word)
+		ix.words = slices.Insert(ix.words, i, word)
+	}
+}
+
+// remove unindexes the show at slug, if any
+func (ix *Index) remove(slug string) {
+	d, ok := ix.docs[slug]
+	if !ok {
+		return
+	}
+	delete(ix.docs, slug)
+	for f := range numFields {
+		ix.total[f] -= d.lengths[f]
+	}
+	for _, texts := range fields(d.show) {
+		for _, text := range texts {
+			for _, word := range domain.FoldWords(text) {
+				docs := ix.postings[word]
+				if docs == nil {
+					continue
+				}
+				delete(docs, slug)
+				if len(docs) == 0 {
+					delete(ix.postings, word)
+					if i, found := slices.BinarySearch(ix.words, word); found {
+						ix.words = slices.Delete(ix.words, i, i+1)
+					}
+				}
+			}
+		}
+	}
+}
+
+// fields returns the texts indexed in each field of show: its title and
+// description in every locale, its genres by code and English name, and
+// its channel's name
+func fields(show domain.Show) [numFields][]string {
+	var texts [numFields][]string
+	texts[fieldTitle] = append(texts[fieldTitle], show.Title)
+	for _, title := range show.Titles {
+		texts[fieldTitle] = append(texts[fieldTitle], title)
+	}
+	if show.Description != nil {
+		texts[fieldDescription] = append(texts[fieldDescription], *show.Description)
+	}
+	for _, description := range show.Descriptions {
+		texts[fieldDescription] = append(texts[fieldDescription], description)
+	}
+	if show.Genre != nil {
+		texts[fieldGenre] = append(texts[fieldGenre], *show.Genre)
+	}
+	for _, code := range show.Genres {
+		texts[fieldGenre] = append(texts[fieldGenre], code, genre.Name(code, "en"))
+	}
+	if show.TVChannel != nil {
+		texts[fieldChannel] = append(texts[fieldChannel], *show.TVChannel)
+	}
+	return texts
+}
@@ -0,0 +1,171 @@
+package search
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func ptr(s string) *string { return &s }
+
+func slugs(hits []Hit) []string {
+	out := []string{}
+	for _, h := range hits {
+		out = append(out, h.Show.Slug)
+	}
+	return out
+}
+
+func catalogue() *Index {
+	ix := New()
+	ix.Put(domain.Show{Slug: "show/the-taste", Title: "The Taste (Le Goût)", Description: ptr("Chefs cook blind for a panel of judges"), Genres: []string{"reality/cooking"}})
+	ix.Put(domain.Show{Slug: "show/tasting-australia", Title: "Tasting Australia", Description: ptr("A food festival tour"), TVChannel: ptr("SBS Food")})
+	ix.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol", Description: ptr("Navy drama with a taste for adventure"), TVChannel: ptr("Nine")})
+	ix.Put(domain.Show{Slug: "show/worlds", Title: "World's Most Extreme", Titles: map[string]string{"fr": "Les Plus Extrêmes"}})
+	return ix
+}
+
+func TestIndex_Search(t *testing.T) {
+	ix := catalogue()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "taste", want: []string{"show/the-taste", "show/sea-patrol"}},
+		{query: "TASTE", want: []string{"show/the-taste", "show/sea-patrol"}},
+		{query: "tast", want: []string{"show/tasting-australia", "show/the-taste", "show/sea-patrol"}},
+		{query: "goût", want: []string{"show/the-taste"}},
+		{query: "gout", want: []string{"show/the-taste"}},
+		{query: "tast austr", want: []string{"show/tasting-australia"}},
+		{query: "cooking", want: []string{"show/the-taste"}},
+		{query: "nine", want: []string{"show/sea-patrol"}},
+		{query: "extremes", want: []string{"show/worlds"}},
+		{query: "worlds", want: []string{"show/worlds"}},
+		{query: "taste nine", want: []string{"show/sea-patrol"}},
+		{query: "taste zebra", want: []string{}},
+		{query: "!!!", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			require.Equal(t, tt.want, slugs(ix.Search(tt.query, 0)))
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		require.Equal(t, []string{"show/tasting-australia"}, slugs(ix.Search("tast", 1)))
+	})
+
+	t.Run("ranked by relevance", func(t *testing.T) {
+		// A title match outranks a description match
+		hits := ix.Search("taste", 0)
+		require.Greater(t, hits[0].Score, hits[1].Score)
+
+		// A whole word outranks a prefix
+		ix.Put(domain.Show{Slug: "show/tasty", Title: "Tasty"})
+		ix.Put(domain.Show{Slug: "show/tas", Title: "Tas"})
+		require.Equal(t, "show/tas", ix.Search("tas", 0)[0].Show.Slug)
+	})
+}
+
+func TestIndex_Writes(t *testing.T) {
+	t.Run("put replaces the show", func(t *testing.T) {
+		ix := catalogue()
+		ix.Put(domain.Show{Slug: "show/the-taste", Title: "The Flavour"})
+		require.Empty(t, ix.Search("gout", 0))
+		require.Equal(t, []string{"show/the-taste"}, slugs(ix.Search("flavour", 0)))
+		require.Equal(t, 4, ix.Len())
+	})
+
+	t.Run("alias stubs are not indexed", func(t *testing.T) {
+		ix := catalogue()
+		ix.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol", AliasOf: ptr("show/patrol")})
+		require.Empty(t, ix.Search("patrol", 0))
+		require.Equal(t, 3, ix.Len())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ix := catalogue()
+		ix.Delete("show/sea-patrol")
+		ix.Delete("show/missing")
+		require.Empty(t, ix.Search("navy", 0))
+		require.Equal(t, []string{"show/tasting-australia", "show/the-taste"}, slugs(ix.Search("tast", 0)))
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		ix := catalogue()
+		ix.Rename("show/sea-patrol", "show/patrol")
+		hits := ix.Search("navy", 0)
+		require.Equal(t, []string{"show/patrol"}, slugs(hits))
+		require.Equal(t, []string{"show/sea-patrol"}, hits[0].Show.Aliases)
+		require.Equal(t, 4, ix.Len())
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		ix := catalogue()
+		ix.Replace([]domain.Show{
+			{Slug: "show/bluey", Title: "Bluey"},
+			{Slug: "show/old", Title: "Bluey", AliasOf: ptr("show/bluey")},
+		})
+		require.Empty(t, ix.Search("taste", 0))
+		require.Equal(t, []string{"show/bluey"}, slugs(ix.Search("blu", 0)))
+		require.Equal(t, 1, ix.Len())
+	})
+}
+
+// TestIndex_Words checks that the words searched by prefix stay sorted and
+// complete however the index is written
+func TestIndex_Words(t *testing.T) {
+	shows := []domain.Show{
+		{Slug: "show/b", Title: "Zebra Crossing", Description: ptr("A tasting tour")},
+		{Slug: "show/a", Title: "The Taste (Le Goût)"},
+		{Slug: "show/c", Title: "Apple Taste"},
+		{Slug: "show/b", Title: "Zebra"},
+	}
+	check := func(t *testing.T, ix *Index) {
+		t.Helper()
+		require.IsIncreasing(t, ix.words)
+		require.Len(t, ix.words, len(ix.postings))
+		for _, word := range ix.words {
+			require.Contains(t, ix.postings, word)
+		}
+	}
+
+	replaced := New()
+	replaced.Replace(shows)
+	check(t, replaced)
+
+	put := New()
+	for _, show := range shows {
+		put.Put(show)
+	}
+	check(t, put)
+	require.Equal(t, put.words, replaced.words)
+	require.NotContains(t, put.words, "tasting", "words of a replaced show are dropped")
+
+	put.Rename("show/a", "show/d")
+	put.Delete("show/c")
+	check(t, put)
+	require.Equal(t, []string{"show/d"}, slugs(put.Search("tast", 0)))
+}
+
+func TestIndex_Concurrent(t *testing.T) {
+	ix := catalogue()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 50 {
+				slug := fmt.Sprintf("show/s-%d-%d", i, j)
+				ix.Put(domain.Show{Slug: slug, Title: "Taste Test"})
+				ix.Search("taste", 10)
+				ix.Delete(slug)
+			}
+		})
+	}
+	wg.Wait()
+	require.Equal(t, 4, ix.Len())
+}
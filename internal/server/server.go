@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+// Server wraps http.Server with the configured timeouts and a shutdown
+// sequence suited to ECS: fail readiness, wait for the load balancer to stop
+// routing, drain in-flight requests, then close.
+type Server struct {
+	http    *http.Server
+	cfg     config.Server
+	onDrain []func()
+}
+
+func New(cfg config.Server, handler http.Handler) *Server {
+	return &Server{
+		cfg: cfg,
+		http: &http.Server{
+			Addr:           fmt.Sprintf(":%d", cfg.Port),
+			Handler:        handler,
+			ReadTimeout:    cfg.ReadTimeout,
+			WriteTimeout:   cfg.WriteTimeout,
+			IdleTimeout:    cfg.IdleTimeout,
+			MaxHeaderBytes: cfg.MaxHeaderBytes,
+		},
+	}
+}
+
+// OnDrain registers a hook run as soon as shutdown starts, before the
+// listener closes. Use it to fail readiness probes.
+func (s *Server) OnDrain(fn func()) {
+	s.onDrain = append(s.onDrain, fn)
+}
+
+// OnShutdown registers a hook run once in-flight requests start draining.
+// Use it to end long-lived responses, such as event streams, which would
+// otherwise hold shutdown up for the whole grace period.
+func (s *Server) OnShutdown(fn func()) {
+	s.http.RegisterOnShutdown(fn)
+}
+
+// Run listens on the configured port and serves until ctx is cancelled
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves on ln until ctx is cancelled, then shuts down gracefully.
+// It returns nil when every in-flight request finished within the grace period.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.http.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutdown: draining for %s", s.cfg.DrainDelay)
+	for _, fn := range s.onDrain {
+		fn()
+	}
+
+	// Keep accepting while the load balancer notices the failing readiness probe
+	select {
+	case <-time.After(s.cfg.DrainDelay):
+	case err := <-errCh:
+		return err
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownGracePeriod)
+	defer cancel()
+
+	log.Printf("shutdown: waiting up to %s for in-flight requests", s.cfg.ShutdownGracePeriod)
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		_ = s.http.Close()
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+func testConfig() config.Server {
+	return config.Server{
+		ReadTimeout:         time.Second,
+		WriteTimeout:        5 * time.Second,
+		IdleTimeout:         time.Second,
+		MaxHeaderBytes:      1 << 20,
+		DrainDelay:          50 * time.Millisecond,
+		ShutdownGracePeriod: 2 * time.Second,
+	}
+}
+
+func TestNew(t *testing.T) {
+	cfg := testConfig()
+	cfg.Port = 9090
+
+	s := New(cfg, http.NotFoundHandler())
+
+	require.Equal(t, ":9090", s.http.Addr)
+	require.Equal(t, cfg.ReadTimeout, s.http.ReadTimeout)
+	require.Equal(t, cfg.WriteTimeout, s.http.WriteTimeout)
+	require.Equal(t, cfg.IdleTimeout, s.http.IdleTimeout)
+	require.Equal(t, cfg.MaxHeaderBytes, s.http.MaxHeaderBytes)
+}
+
+func TestServer_Serve_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte("done"))
+	})
+
+	s := New(testConfig(), handler)
+	var drained atomic.Bool
+	s.OnDrain(func() { drained.Store(true) })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(ctx, ln) }()
+
+	respCh := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			respCh <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		respCh <- string(body)
+	}()
+
+	<-started
+	cancel()
+
+	require.Eventually(t, drained.Load, time.Second, 5*time.Millisecond, "drain hook should run first")
+
+	close(release)
+	require.Equal(t, "done", <-respCh, "in-flight request must complete")
+	require.NoError(t, <-served)
+}
+
+func TestServer_Serve_GracePeriodExceeded(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(time.Second)
+	})
+
+	cfg := testConfig()
+	cfg.DrainDelay = 0
+	cfg.ShutdownGracePeriod = 50 * time.Millisecond
+	s := New(cfg, handler)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(ctx, ln) }()
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	err = <-served
+	require.ErrorContains(t, err, "shutdown")
+}
+
+func TestServer_Serve_ShutdownHookEndsStreams(t *testing.T) {
+	started := make(chan struct{})
+	stop := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-stop
+	})
+
+	cfg := testConfig()
+	cfg.DrainDelay = 0
+	s := New(cfg, handler)
+	s.OnShutdown(func() { close(stop) })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(ctx, ln) }()
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	// Without the hook the stream would outlast the grace period
+	require.NoError(t, <-served)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+type ChannelService interface {
+	Create(ctx context.Context, c domain.Channel) error
+	Update(ctx context.Context, c domain.Channel) error
+	Get(ctx context.Context, id string) (*domain.Channel, error)
+	List(ctx context.Context) (*domain.ChannelsResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ChannelSvc struct {
+	repo repository.ChannelRepository
+}
+
+func NewChannelService(repo repository.ChannelRepository) ChannelService {
+	return &ChannelSvc{repo: repo}
+}
+
+func (s *ChannelSvc) Create(ctx context.Context, c domain.Channel) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelService.Create")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.Create(ctx, c); err != nil {
+		log.Printf("Error creating channel %s: %v", c.ID, err)
+		return fmt.Errorf("failed to create channel: %w", err)
+	}
+	return nil
+}
+
+func (s *ChannelSvc) Update(ctx context.Context, c domain.Channel) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelService.Update")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.Update(ctx, c); err != nil {
+		log.Printf("Error updating channel %s: %v", c.ID, err)
+		return fmt.Errorf("failed to update channel: %w", err)
+	}
+	return nil
+}
+
+func (s *ChannelSvc) Get(ctx context.Context, id string) (_ *domain.Channel, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelService.Get")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	c, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve channel: %w", err)
+	}
+	return c, nil
+}
+
+func (s *ChannelSvc) List(ctx context.Context) (_ *domain.ChannelsResponse, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelService.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	channels, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Error listing channels: %v", err)
+		return nil, fmt.Errorf("failed to retrieve channels: %w", err)
+	}
+	return &domain.ChannelsResponse{Response: channels}, nil
+}
+
+func (s *ChannelSvc) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ChannelService.Delete")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Error deleting channel %s: %v", id, err)
+		return fmt.Errorf("failed to delete channel: %w", err)
+	}
+	return nil
+}
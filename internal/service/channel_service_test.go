@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+func TestChannelSvc(t *testing.T) {
+	channel := domain.Channel{ID: "nine", Name: "Nine"}
+
+	t.Run("create and update pass through", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockChannelRepository(t)
+		mockRepo.EXPECT().Create(mock.Anything, channel).Return(nil)
+		mockRepo.EXPECT().Update(mock.Anything, channel).Return(apperror.ErrNotFound)
+
+		svc := NewChannelService(mockRepo)
+		require.NoError(t, svc.Create(context.Background(), channel))
+		require.ErrorIs(t, svc.Update(context.Background(), channel), apperror.ErrNotFound)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockChannelRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "nine").Return(&channel, nil)
+
+		got, err := NewChannelService(mockRepo).Get(context.Background(), "nine")
+		require.NoError(t, err)
+		require.Equal(t, &channel, got)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockChannelRepository(t)
+		mockRepo.EXPECT().List(mock.Anything).Return([]domain.Channel{channel}, nil)
+
+		got, err := NewChannelService(mockRepo).List(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.Channel{channel}, got.Response)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockChannelRepository(t)
+		mockRepo.EXPECT().Delete(mock.Anything, "nine").Return(errors.New("boom"))
+
+		err := NewChannelService(mockRepo).Delete(context.Background(), "nine")
+		require.EqualError(t, err, "failed to delete channel: boom")
+	})
+}
+
+func TestShowSvc_Create_Channels(t *testing.T) {
+	request := domain.Request{Payload: []domain.Show{
+		{Slug: "show/a", Title: "A", ChannelID: stringPtr("nine"), TVChannel: stringPtr("Channel 9")},
+		{Slug: "show/b", Title: "B", NextEpisode: &domain.NextEpisode{
+			ChannelID:   stringPtr("gem"),
+			Channel:     stringPtr("GEM"),
+			ChannelLogo: "http://example.com/gem.png",
+		}},
+	}}
+
+	t.Run("known channels", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockChannels := repoMocks.NewMockChannelRepository(t)
+		mockChannels.EXPECT().List(mock.Anything).Return([]domain.Channel{{ID: "nine"}, {ID: "gem"}}, nil)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
+
+		_, err := NewShowService(mockRepo, mockChannels, ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown channels are rejected before anything is written", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockChannels := repoMocks.NewMockChannelRepository(t)
+		mockChannels.EXPECT().List(mock.Anything).Return([]domain.Channel{}, nil)
+
+		_, err := NewShowService(mockRepo, mockChannels, ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.Error(t, err)
+
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, []apperror.FieldError{
+			{Path: "/payload/0/channelId", Code: "channel_unknown", Message: "channel nine does not exist"},
+			{Path: "/payload/1/nextEpisode/channelId", Code: "channel_unknown", Message: "channel gem does not exist"},
+		}, appErr.Fields)
+	})
+
+	t.Run("unknown channels are created in lenient mode", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockChannels := repoMocks.NewMockChannelRepository(t)
+		mockChannels.EXPECT().List(mock.Anything).Return([]domain.Channel{}, nil)
+		mockChannels.EXPECT().Create(mock.Anything, domain.Channel{ID: "nine", Name: "Channel 9"}).Return(nil)
+		mockChannels.EXPECT().Create(mock.Anything, domain.Channel{ID: "gem", Name: "GEM", Logo: stringPtr("http://example.com/gem.png")}).
+			Return(apperror.ErrDuplicateSlug) // created concurrently
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
+
+		_, err := NewShowService(mockRepo, mockChannels, ShowOptions{AutoCreateChannels: true}).Create(context.Background(), request, domain.Actor{})
+		require.NoError(t, err)
+	})
+
+	t.Run("channel lookup fails", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockChannels := repoMocks.NewMockChannelRepository(t)
+		mockChannels.EXPECT().List(mock.Anything).Return(nil, errors.New("boom"))
+
+		_, err := NewShowService(mockRepo, mockChannels, ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.Error(t, err)
+	})
+}
+
+func TestShowSvc_List_EmbedsChannels(t *testing.T) {
+	mockRepo := repoMocks.NewMockShowRepository(t)
+	mockChannels := repoMocks.NewMockChannelRepository(t)
+	mockRepo.EXPECT().List(mock.Anything).Return([]domain.Show{
+		{Slug: "show/a", Title: "A", ChannelID: stringPtr("nine")},
+		{Slug: "show/b", Title: "B", ChannelID: stringPtr("deleted")},
+		{Slug: "show/c", Title: "C"},
+	}, nil)
+	mockChannels.EXPECT().List(mock.Anything).Return([]domain.Channel{{ID: "nine", Name: "Nine"}}, nil)
+
+	got, err := NewShowService(mockRepo, mockChannels, ShowOptions{}).List(context.Background(), domain.Viewer{})
+	require.NoError(t, err)
+	require.Equal(t, &domain.Channel{ID: "nine", Name: "Nine"}, got.Response[0].Channel)
+	require.Nil(t, got.Response[1].Channel)
+	require.Nil(t, got.Response[2].Channel)
+}
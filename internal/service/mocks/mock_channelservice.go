@@ -0,0 +1,340 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockChannelService creates a new instance of MockChannelService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChannelService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChannelService {
+	mock := &MockChannelService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChannelService is an autogenerated mock type for the ChannelService type
+type MockChannelService struct {
+	mock.Mock
+}
+
+type MockChannelService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChannelService) EXPECT() *MockChannelService_Expecter {
+	return &MockChannelService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockChannelService
+func (_mock *MockChannelService) Create(ctx context.Context, c domain.Channel) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Channel) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChannelService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockChannelService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c domain.Channel
+func (_e *MockChannelService_Expecter) Create(ctx interface{}, c interface{}) *MockChannelService_Create_Call {
+	return &MockChannelService_Create_Call{Call: _e.mock.On("Create", ctx, c)}
+}
+
+func (_c *MockChannelService_Create_Call) Run(run func(ctx context.Context, c domain.Channel)) *MockChannelService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Channel
+		if args[1] != nil {
+			arg1 = args[1].(domain.Channel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelService_Create_Call) Return(err error) *MockChannelService_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChannelService_Create_Call) RunAndReturn(run func(ctx context.Context, c domain.Channel) error) *MockChannelService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockChannelService
+func (_mock *MockChannelService) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChannelService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockChannelService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockChannelService_Expecter) Delete(ctx interface{}, id interface{}) *MockChannelService_Delete_Call {
+	return &MockChannelService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockChannelService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockChannelService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelService_Delete_Call) Return(err error) *MockChannelService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChannelService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockChannelService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockChannelService
+func (_mock *MockChannelService) Get(ctx context.Context, id string) (*domain.Channel, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Channel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Channel, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Channel); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Channel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChannelService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockChannelService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockChannelService_Expecter) Get(ctx interface{}, id interface{}) *MockChannelService_Get_Call {
+	return &MockChannelService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockChannelService_Get_Call) Run(run func(ctx context.Context, id string)) *MockChannelService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelService_Get_Call) Return(channel *domain.Channel, err error) *MockChannelService_Get_Call {
+	_c.Call.Return(channel, err)
+	return _c
+}
+
+func (_c *MockChannelService_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.Channel, error)) *MockChannelService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockChannelService
+func (_mock *MockChannelService) List(ctx context.Context) (*domain.ChannelsResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.ChannelsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.ChannelsResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.ChannelsResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChannelsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChannelService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockChannelService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockChannelService_Expecter) List(ctx interface{}) *MockChannelService_List_Call {
+	return &MockChannelService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockChannelService_List_Call) Run(run func(ctx context.Context)) *MockChannelService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelService_List_Call) Return(channelsResponse *domain.ChannelsResponse, err error) *MockChannelService_List_Call {
+	_c.Call.Return(channelsResponse, err)
+	return _c
+}
+
+func (_c *MockChannelService_List_Call) RunAndReturn(run func(ctx context.Context) (*domain.ChannelsResponse, error)) *MockChannelService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockChannelService
+func (_mock *MockChannelService) Update(ctx context.Context, c domain.Channel) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Channel) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChannelService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockChannelService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c domain.Channel
+func (_e *MockChannelService_Expecter) Update(ctx interface{}, c interface{}) *MockChannelService_Update_Call {
+	return &MockChannelService_Update_Call{Call: _e.mock.On("Update", ctx, c)}
+}
+
+func (_c *MockChannelService_Update_Call) Run(run func(ctx context.Context, c domain.Channel)) *MockChannelService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Channel
+		if args[1] != nil {
+			arg1 = args[1].(domain.Channel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChannelService_Update_Call) Return(err error) *MockChannelService_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChannelService_Update_Call) RunAndReturn(run func(ctx context.Context, c domain.Channel) error) *MockChannelService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
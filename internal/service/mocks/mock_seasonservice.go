@@ -0,0 +1,295 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSeasonService creates a new instance of MockSeasonService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSeasonService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSeasonService {
+	mock := &MockSeasonService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSeasonService is an autogenerated mock type for the SeasonService type
+type MockSeasonService struct {
+	mock.Mock
+}
+
+type MockSeasonService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSeasonService) EXPECT() *MockSeasonService_Expecter {
+	return &MockSeasonService_Expecter{mock: &_m.Mock}
+}
+
+// CreateEpisode provides a mock function for the type MockSeasonService
+func (_mock *MockSeasonService) CreateEpisode(ctx context.Context, episode domain.Episode) error {
+	ret := _mock.Called(ctx, episode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEpisode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Episode) error); ok {
+		r0 = returnFunc(ctx, episode)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSeasonService_CreateEpisode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEpisode'
+type MockSeasonService_CreateEpisode_Call struct {
+	*mock.Call
+}
+
+// CreateEpisode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - episode domain.Episode
+func (_e *MockSeasonService_Expecter) CreateEpisode(ctx interface{}, episode interface{}) *MockSeasonService_CreateEpisode_Call {
+	return &MockSeasonService_CreateEpisode_Call{Call: _e.mock.On("CreateEpisode", ctx, episode)}
+}
+
+func (_c *MockSeasonService_CreateEpisode_Call) Run(run func(ctx context.Context, episode domain.Episode)) *MockSeasonService_CreateEpisode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Episode
+		if args[1] != nil {
+			arg1 = args[1].(domain.Episode)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonService_CreateEpisode_Call) Return(err error) *MockSeasonService_CreateEpisode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSeasonService_CreateEpisode_Call) RunAndReturn(run func(ctx context.Context, episode domain.Episode) error) *MockSeasonService_CreateEpisode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSeason provides a mock function for the type MockSeasonService
+func (_mock *MockSeasonService) CreateSeason(ctx context.Context, season domain.SeasonDetail) error {
+	ret := _mock.Called(ctx, season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeason")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SeasonDetail) error); ok {
+		r0 = returnFunc(ctx, season)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSeasonService_CreateSeason_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSeason'
+type MockSeasonService_CreateSeason_Call struct {
+	*mock.Call
+}
+
+// CreateSeason is a helper method to define mock.On call
+//   - ctx context.Context
+//   - season domain.SeasonDetail
+func (_e *MockSeasonService_Expecter) CreateSeason(ctx interface{}, season interface{}) *MockSeasonService_CreateSeason_Call {
+	return &MockSeasonService_CreateSeason_Call{Call: _e.mock.On("CreateSeason", ctx, season)}
+}
+
+func (_c *MockSeasonService_CreateSeason_Call) Run(run func(ctx context.Context, season domain.SeasonDetail)) *MockSeasonService_CreateSeason_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SeasonDetail
+		if args[1] != nil {
+			arg1 = args[1].(domain.SeasonDetail)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonService_CreateSeason_Call) Return(err error) *MockSeasonService_CreateSeason_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSeasonService_CreateSeason_Call) RunAndReturn(run func(ctx context.Context, season domain.SeasonDetail) error) *MockSeasonService_CreateSeason_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEpisodes provides a mock function for the type MockSeasonService
+func (_mock *MockSeasonService) ListEpisodes(ctx context.Context, showSlug string, season int) (*domain.EpisodesResponse, error) {
+	ret := _mock.Called(ctx, showSlug, season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEpisodes")
+	}
+
+	var r0 *domain.EpisodesResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*domain.EpisodesResponse, error)); ok {
+		return returnFunc(ctx, showSlug, season)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *domain.EpisodesResponse); ok {
+		r0 = returnFunc(ctx, showSlug, season)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EpisodesResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, showSlug, season)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSeasonService_ListEpisodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEpisodes'
+type MockSeasonService_ListEpisodes_Call struct {
+	*mock.Call
+}
+
+// ListEpisodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - showSlug string
+//   - season int
+func (_e *MockSeasonService_Expecter) ListEpisodes(ctx interface{}, showSlug interface{}, season interface{}) *MockSeasonService_ListEpisodes_Call {
+	return &MockSeasonService_ListEpisodes_Call{Call: _e.mock.On("ListEpisodes", ctx, showSlug, season)}
+}
+
+func (_c *MockSeasonService_ListEpisodes_Call) Run(run func(ctx context.Context, showSlug string, season int)) *MockSeasonService_ListEpisodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonService_ListEpisodes_Call) Return(episodesResponse *domain.EpisodesResponse, err error) *MockSeasonService_ListEpisodes_Call {
+	_c.Call.Return(episodesResponse, err)
+	return _c
+}
+
+func (_c *MockSeasonService_ListEpisodes_Call) RunAndReturn(run func(ctx context.Context, showSlug string, season int) (*domain.EpisodesResponse, error)) *MockSeasonService_ListEpisodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSeasons provides a mock function for the type MockSeasonService
+func (_mock *MockSeasonService) ListSeasons(ctx context.Context, showSlug string) (*domain.SeasonsResponse, error) {
+	ret := _mock.Called(ctx, showSlug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSeasons")
+	}
+
+	var r0 *domain.SeasonsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.SeasonsResponse, error)); ok {
+		return returnFunc(ctx, showSlug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.SeasonsResponse); ok {
+		r0 = returnFunc(ctx, showSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SeasonsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, showSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSeasonService_ListSeasons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSeasons'
+type MockSeasonService_ListSeasons_Call struct {
+	*mock.Call
+}
+
+// ListSeasons is a helper method to define mock.On call
+//   - ctx context.Context
+//   - showSlug string
+func (_e *MockSeasonService_Expecter) ListSeasons(ctx interface{}, showSlug interface{}) *MockSeasonService_ListSeasons_Call {
+	return &MockSeasonService_ListSeasons_Call{Call: _e.mock.On("ListSeasons", ctx, showSlug)}
+}
+
+func (_c *MockSeasonService_ListSeasons_Call) Run(run func(ctx context.Context, showSlug string)) *MockSeasonService_ListSeasons_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSeasonService_ListSeasons_Call) Return(seasonsResponse *domain.SeasonsResponse, err error) *MockSeasonService_ListSeasons_Call {
+	_c.Call.Return(seasonsResponse, err)
+	return _c
+}
+
+func (_c *MockSeasonService_ListSeasons_Call) RunAndReturn(run func(ctx context.Context, showSlug string) (*domain.SeasonsResponse, error)) *MockSeasonService_ListSeasons_Call {
+	_c.Call.Return(run)
+	return _c
+}
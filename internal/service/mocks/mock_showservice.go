@@ -5,6 +5,9 @@
 package service
 
 import (
+	"context"
+	"time"
+
 	"github.com/marciomarinho/show-service/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,21 +39,106 @@ func (_m *MockShowService) EXPECT() *MockShowService_Expecter {
 	return &MockShowService_Expecter{mock: &_m.Mock}
 }
 
+// AiringSoon provides a mock function for the type MockShowService
+func (_mock *MockShowService) AiringSoon(ctx context.Context, within time.Duration, viewer domain.Viewer) (*domain.Response, error) {
+	ret := _mock.Called(ctx, within, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AiringSoon")
+	}
+
+	var r0 *domain.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, domain.Viewer) (*domain.Response, error)); ok {
+		return returnFunc(ctx, within, viewer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, domain.Viewer) *domain.Response); ok {
+		r0 = returnFunc(ctx, within, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration, domain.Viewer) error); ok {
+		r1 = returnFunc(ctx, within, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_AiringSoon_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AiringSoon'
+type MockShowService_AiringSoon_Call struct {
+	*mock.Call
+}
+
+// AiringSoon is a helper method to define mock.On call
+//   - ctx context.Context
+//   - within time.Duration
+//   - viewer domain.Viewer
+func (_e *MockShowService_Expecter) AiringSoon(ctx interface{}, within interface{}, viewer interface{}) *MockShowService_AiringSoon_Call {
+	return &MockShowService_AiringSoon_Call{Call: _e.mock.On("AiringSoon", ctx, within, viewer)}
+}
+
+func (_c *MockShowService_AiringSoon_Call) Run(run func(ctx context.Context, within time.Duration, viewer domain.Viewer)) *MockShowService_AiringSoon_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 domain.Viewer
+		if args[2] != nil {
+			arg2 = args[2].(domain.Viewer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_AiringSoon_Call) Return(response *domain.Response, err error) *MockShowService_AiringSoon_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockShowService_AiringSoon_Call) RunAndReturn(run func(ctx context.Context, within time.Duration, viewer domain.Viewer) (*domain.Response, error)) *MockShowService_AiringSoon_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockShowService
-func (_mock *MockShowService) Create(request domain.Request) error {
-	ret := _mock.Called(request)
+func (_mock *MockShowService) Create(ctx context.Context, request domain.Request, actor domain.Actor) (*domain.Created, error) {
+	ret := _mock.Called(ctx, request, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(domain.Request) error); ok {
-		r0 = returnFunc(request)
+	var r0 *domain.Created
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Request, domain.Actor) (*domain.Created, error)); ok {
+		return returnFunc(ctx, request, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Request, domain.Actor) *domain.Created); ok {
+		r0 = returnFunc(ctx, request, actor)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Created)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Request, domain.Actor) error); ok {
+		r1 = returnFunc(ctx, request, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockShowService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
@@ -59,37 +147,197 @@ type MockShowService_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - request domain.Request
-func (_e *MockShowService_Expecter) Create(request interface{}) *MockShowService_Create_Call {
-	return &MockShowService_Create_Call{Call: _e.mock.On("Create", request)}
+//   - actor domain.Actor
+func (_e *MockShowService_Expecter) Create(ctx interface{}, request interface{}, actor interface{}) *MockShowService_Create_Call {
+	return &MockShowService_Create_Call{Call: _e.mock.On("Create", ctx, request, actor)}
 }
 
-func (_c *MockShowService_Create_Call) Run(run func(request domain.Request)) *MockShowService_Create_Call {
+func (_c *MockShowService_Create_Call) Run(run func(ctx context.Context, request domain.Request, actor domain.Actor)) *MockShowService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 domain.Request
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(domain.Request)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Request
+		if args[1] != nil {
+			arg1 = args[1].(domain.Request)
+		}
+		var arg2 domain.Actor
+		if args[2] != nil {
+			arg2 = args[2].(domain.Actor)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockShowService_Create_Call) Return(err error) *MockShowService_Create_Call {
-	_c.Call.Return(err)
+func (_c *MockShowService_Create_Call) Return(created *domain.Created, err error) *MockShowService_Create_Call {
+	_c.Call.Return(created, err)
 	return _c
 }
 
-func (_c *MockShowService_Create_Call) RunAndReturn(run func(request domain.Request) error) *MockShowService_Create_Call {
+func (_c *MockShowService_Create_Call) RunAndReturn(run func(ctx context.Context, request domain.Request, actor domain.Actor) (*domain.Created, error)) *MockShowService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Duplicates provides a mock function for the type MockShowService
+func (_mock *MockShowService) Duplicates(ctx context.Context, after string, limit int) (*domain.DuplicatesResponse, error) {
+	ret := _mock.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Duplicates")
+	}
+
+	var r0 *domain.DuplicatesResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*domain.DuplicatesResponse, error)); ok {
+		return returnFunc(ctx, after, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *domain.DuplicatesResponse); ok {
+		r0 = returnFunc(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DuplicatesResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Duplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Duplicates'
+type MockShowService_Duplicates_Call struct {
+	*mock.Call
+}
+
+// Duplicates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after string
+//   - limit int
+func (_e *MockShowService_Expecter) Duplicates(ctx interface{}, after interface{}, limit interface{}) *MockShowService_Duplicates_Call {
+	return &MockShowService_Duplicates_Call{Call: _e.mock.On("Duplicates", ctx, after, limit)}
+}
+
+func (_c *MockShowService_Duplicates_Call) Run(run func(ctx context.Context, after string, limit int)) *MockShowService_Duplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Duplicates_Call) Return(duplicatesResponse *domain.DuplicatesResponse, err error) *MockShowService_Duplicates_Call {
+	_c.Call.Return(duplicatesResponse, err)
+	return _c
+}
+
+func (_c *MockShowService_Duplicates_Call) RunAndReturn(run func(ctx context.Context, after string, limit int) (*domain.DuplicatesResponse, error)) *MockShowService_Duplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockShowService
+func (_mock *MockShowService) Get(ctx context.Context, slug string, viewer domain.Viewer) (*domain.Show, error) {
+	ret := _mock.Called(ctx, slug, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Viewer) (*domain.Show, error)); ok {
+		return returnFunc(ctx, slug, viewer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Viewer) *domain.Show); ok {
+		r0 = returnFunc(ctx, slug, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Viewer) error); ok {
+		r1 = returnFunc(ctx, slug, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockShowService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - viewer domain.Viewer
+func (_e *MockShowService_Expecter) Get(ctx interface{}, slug interface{}, viewer interface{}) *MockShowService_Get_Call {
+	return &MockShowService_Get_Call{Call: _e.mock.On("Get", ctx, slug, viewer)}
+}
+
+func (_c *MockShowService_Get_Call) Run(run func(ctx context.Context, slug string, viewer domain.Viewer)) *MockShowService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.Viewer
+		if args[2] != nil {
+			arg2 = args[2].(domain.Viewer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Get_Call) Return(show *domain.Show, err error) *MockShowService_Get_Call {
+	_c.Call.Return(show, err)
+	return _c
+}
+
+func (_c *MockShowService_Get_Call) RunAndReturn(run func(ctx context.Context, slug string, viewer domain.Viewer) (*domain.Show, error)) *MockShowService_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // List provides a mock function for the type MockShowService
-func (_mock *MockShowService) List() (*domain.Response, error) {
-	ret := _mock.Called()
+func (_mock *MockShowService) List(ctx context.Context, viewer domain.Viewer) (*domain.Response, error) {
+	ret := _mock.Called(ctx, viewer)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -97,18 +345,18 @@ func (_mock *MockShowService) List() (*domain.Response, error) {
 
 	var r0 *domain.Response
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Viewer) (*domain.Response, error)); ok {
+		return returnFunc(ctx, viewer)
 	}
-	if returnFunc, ok := ret.Get(0).(func() *domain.Response); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Viewer) *domain.Response); ok {
+		r0 = returnFunc(ctx, viewer)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Viewer) error); ok {
+		r1 = returnFunc(ctx, viewer)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +369,26 @@ type MockShowService_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
-func (_e *MockShowService_Expecter) List() *MockShowService_List_Call {
-	return &MockShowService_List_Call{Call: _e.mock.On("List")}
+//   - ctx context.Context
+//   - viewer domain.Viewer
+func (_e *MockShowService_Expecter) List(ctx interface{}, viewer interface{}) *MockShowService_List_Call {
+	return &MockShowService_List_Call{Call: _e.mock.On("List", ctx, viewer)}
 }
 
-func (_c *MockShowService_List_Call) Run(run func()) *MockShowService_List_Call {
+func (_c *MockShowService_List_Call) Run(run func(ctx context.Context, viewer domain.Viewer)) *MockShowService_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Viewer
+		if args[1] != nil {
+			arg1 = args[1].(domain.Viewer)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
@@ -137,7 +398,652 @@ func (_c *MockShowService_List_Call) Return(response *domain.Response, err error
 	return _c
 }
 
-func (_c *MockShowService_List_Call) RunAndReturn(run func() (*domain.Response, error)) *MockShowService_List_Call {
+func (_c *MockShowService_List_Call) RunAndReturn(run func(ctx context.Context, viewer domain.Viewer) (*domain.Response, error)) *MockShowService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebuildSuggestions provides a mock function for the type MockShowService
+func (_mock *MockShowService) RebuildSuggestions(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildSuggestions")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_RebuildSuggestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebuildSuggestions'
+type MockShowService_RebuildSuggestions_Call struct {
+	*mock.Call
+}
+
+// RebuildSuggestions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShowService_Expecter) RebuildSuggestions(ctx interface{}) *MockShowService_RebuildSuggestions_Call {
+	return &MockShowService_RebuildSuggestions_Call{Call: _e.mock.On("RebuildSuggestions", ctx)}
+}
+
+func (_c *MockShowService_RebuildSuggestions_Call) Run(run func(ctx context.Context)) *MockShowService_RebuildSuggestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_RebuildSuggestions_Call) Return(n int, err error) *MockShowService_RebuildSuggestions_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockShowService_RebuildSuggestions_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockShowService_RebuildSuggestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reindex provides a mock function for the type MockShowService
+func (_mock *MockShowService) Reindex(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reindex")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Reindex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reindex'
+type MockShowService_Reindex_Call struct {
+	*mock.Call
+}
+
+// Reindex is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShowService_Expecter) Reindex(ctx interface{}) *MockShowService_Reindex_Call {
+	return &MockShowService_Reindex_Call{Call: _e.mock.On("Reindex", ctx)}
+}
+
+func (_c *MockShowService_Reindex_Call) Run(run func(ctx context.Context)) *MockShowService_Reindex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Reindex_Call) Return(n int, err error) *MockShowService_Reindex_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockShowService_Reindex_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockShowService_Reindex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockShowService
+func (_mock *MockShowService) Rename(ctx context.Context, from string, to string) error {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockShowService_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockShowService_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from string
+//   - to string
+func (_e *MockShowService_Expecter) Rename(ctx interface{}, from interface{}, to interface{}) *MockShowService_Rename_Call {
+	return &MockShowService_Rename_Call{Call: _e.mock.On("Rename", ctx, from, to)}
+}
+
+func (_c *MockShowService_Rename_Call) Run(run func(ctx context.Context, from string, to string)) *MockShowService_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Rename_Call) Return(err error) *MockShowService_Rename_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockShowService_Rename_Call) RunAndReturn(run func(ctx context.Context, from string, to string) error) *MockShowService_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revert provides a mock function for the type MockShowService
+func (_mock *MockShowService) Revert(ctx context.Context, slug string, n int, actor domain.Actor) (*domain.Show, error) {
+	ret := _mock.Called(ctx, slug, n, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 *domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Actor) (*domain.Show, error)); ok {
+		return returnFunc(ctx, slug, n, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Actor) *domain.Show); ok {
+		r0 = returnFunc(ctx, slug, n, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, domain.Actor) error); ok {
+		r1 = returnFunc(ctx, slug, n, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Revert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revert'
+type MockShowService_Revert_Call struct {
+	*mock.Call
+}
+
+// Revert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - n int
+//   - actor domain.Actor
+func (_e *MockShowService_Expecter) Revert(ctx interface{}, slug interface{}, n interface{}, actor interface{}) *MockShowService_Revert_Call {
+	return &MockShowService_Revert_Call{Call: _e.mock.On("Revert", ctx, slug, n, actor)}
+}
+
+func (_c *MockShowService_Revert_Call) Run(run func(ctx context.Context, slug string, n int, actor domain.Actor)) *MockShowService_Revert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 domain.Actor
+		if args[3] != nil {
+			arg3 = args[3].(domain.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Revert_Call) Return(show *domain.Show, err error) *MockShowService_Revert_Call {
+	_c.Call.Return(show, err)
+	return _c
+}
+
+func (_c *MockShowService_Revert_Call) RunAndReturn(run func(ctx context.Context, slug string, n int, actor domain.Actor) (*domain.Show, error)) *MockShowService_Revert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revision provides a mock function for the type MockShowService
+func (_mock *MockShowService) Revision(ctx context.Context, slug string, n int) (*domain.Revision, error) {
+	ret := _mock.Called(ctx, slug, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revision")
+	}
+
+	var r0 *domain.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*domain.Revision, error)); ok {
+		return returnFunc(ctx, slug, n)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *domain.Revision); ok {
+		r0 = returnFunc(ctx, slug, n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, slug, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Revision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revision'
+type MockShowService_Revision_Call struct {
+	*mock.Call
+}
+
+// Revision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - n int
+func (_e *MockShowService_Expecter) Revision(ctx interface{}, slug interface{}, n interface{}) *MockShowService_Revision_Call {
+	return &MockShowService_Revision_Call{Call: _e.mock.On("Revision", ctx, slug, n)}
+}
+
+func (_c *MockShowService_Revision_Call) Run(run func(ctx context.Context, slug string, n int)) *MockShowService_Revision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Revision_Call) Return(revision *domain.Revision, err error) *MockShowService_Revision_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *MockShowService_Revision_Call) RunAndReturn(run func(ctx context.Context, slug string, n int) (*domain.Revision, error)) *MockShowService_Revision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revisions provides a mock function for the type MockShowService
+func (_mock *MockShowService) Revisions(ctx context.Context, slug string) ([]domain.Revision, error) {
+	ret := _mock.Called(ctx, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revisions")
+	}
+
+	var r0 []domain.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Revision, error)); ok {
+		return returnFunc(ctx, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Revision); ok {
+		r0 = returnFunc(ctx, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Revisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revisions'
+type MockShowService_Revisions_Call struct {
+	*mock.Call
+}
+
+// Revisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *MockShowService_Expecter) Revisions(ctx interface{}, slug interface{}) *MockShowService_Revisions_Call {
+	return &MockShowService_Revisions_Call{Call: _e.mock.On("Revisions", ctx, slug)}
+}
+
+func (_c *MockShowService_Revisions_Call) Run(run func(ctx context.Context, slug string)) *MockShowService_Revisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Revisions_Call) Return(revisions []domain.Revision, err error) *MockShowService_Revisions_Call {
+	_c.Call.Return(revisions, err)
+	return _c
+}
+
+func (_c *MockShowService_Revisions_Call) RunAndReturn(run func(ctx context.Context, slug string) ([]domain.Revision, error)) *MockShowService_Revisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type MockShowService
+func (_mock *MockShowService) Search(ctx context.Context, query string, limit int, viewer domain.Viewer) (*domain.Response, error) {
+	ret := _mock.Called(ctx, query, limit, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *domain.Response
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Viewer) (*domain.Response, error)); ok {
+		return returnFunc(ctx, query, limit, viewer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Viewer) *domain.Response); ok {
+		r0 = returnFunc(ctx, query, limit, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, domain.Viewer) error); ok {
+		r1 = returnFunc(ctx, query, limit, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockShowService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+//   - viewer domain.Viewer
+func (_e *MockShowService_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, viewer interface{}) *MockShowService_Search_Call {
+	return &MockShowService_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, viewer)}
+}
+
+func (_c *MockShowService_Search_Call) Run(run func(ctx context.Context, query string, limit int, viewer domain.Viewer)) *MockShowService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 domain.Viewer
+		if args[3] != nil {
+			arg3 = args[3].(domain.Viewer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Search_Call) Return(response *domain.Response, err error) *MockShowService_Search_Call {
+	_c.Call.Return(response, err)
+	return _c
+}
+
+func (_c *MockShowService_Search_Call) RunAndReturn(run func(ctx context.Context, query string, limit int, viewer domain.Viewer) (*domain.Response, error)) *MockShowService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function for the type MockShowService
+func (_mock *MockShowService) Suggest(ctx context.Context, prefix string, limit int, viewer domain.Viewer) (*domain.SuggestionsResponse, error) {
+	ret := _mock.Called(ctx, prefix, limit, viewer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suggest")
+	}
+
+	var r0 *domain.SuggestionsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Viewer) (*domain.SuggestionsResponse, error)); ok {
+		return returnFunc(ctx, prefix, limit, viewer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.Viewer) *domain.SuggestionsResponse); ok {
+		r0 = returnFunc(ctx, prefix, limit, viewer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SuggestionsResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, domain.Viewer) error); ok {
+		r1 = returnFunc(ctx, prefix, limit, viewer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type MockShowService_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+//   - limit int
+//   - viewer domain.Viewer
+func (_e *MockShowService_Expecter) Suggest(ctx interface{}, prefix interface{}, limit interface{}, viewer interface{}) *MockShowService_Suggest_Call {
+	return &MockShowService_Suggest_Call{Call: _e.mock.On("Suggest", ctx, prefix, limit, viewer)}
+}
+
+func (_c *MockShowService_Suggest_Call) Run(run func(ctx context.Context, prefix string, limit int, viewer domain.Viewer)) *MockShowService_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 domain.Viewer
+		if args[3] != nil {
+			arg3 = args[3].(domain.Viewer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Suggest_Call) Return(suggestionsResponse *domain.SuggestionsResponse, err error) *MockShowService_Suggest_Call {
+	_c.Call.Return(suggestionsResponse, err)
+	return _c
+}
+
+func (_c *MockShowService_Suggest_Call) RunAndReturn(run func(ctx context.Context, prefix string, limit int, viewer domain.Viewer) (*domain.SuggestionsResponse, error)) *MockShowService_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transition provides a mock function for the type MockShowService
+func (_mock *MockShowService) Transition(ctx context.Context, slug string, change domain.StatusChange, actor domain.Actor) (*domain.Show, error) {
+	ret := _mock.Called(ctx, slug, change, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 *domain.Show
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.StatusChange, domain.Actor) (*domain.Show, error)); ok {
+		return returnFunc(ctx, slug, change, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.StatusChange, domain.Actor) *domain.Show); ok {
+		r0 = returnFunc(ctx, slug, change, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Show)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.StatusChange, domain.Actor) error); ok {
+		r1 = returnFunc(ctx, slug, change, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShowService_Transition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transition'
+type MockShowService_Transition_Call struct {
+	*mock.Call
+}
+
+// Transition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - change domain.StatusChange
+//   - actor domain.Actor
+func (_e *MockShowService_Expecter) Transition(ctx interface{}, slug interface{}, change interface{}, actor interface{}) *MockShowService_Transition_Call {
+	return &MockShowService_Transition_Call{Call: _e.mock.On("Transition", ctx, slug, change, actor)}
+}
+
+func (_c *MockShowService_Transition_Call) Run(run func(ctx context.Context, slug string, change domain.StatusChange, actor domain.Actor)) *MockShowService_Transition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.StatusChange
+		if args[2] != nil {
+			arg2 = args[2].(domain.StatusChange)
+		}
+		var arg3 domain.Actor
+		if args[3] != nil {
+			arg3 = args[3].(domain.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShowService_Transition_Call) Return(show *domain.Show, err error) *MockShowService_Transition_Call {
+	_c.Call.Return(show, err)
+	return _c
+}
+
+func (_c *MockShowService_Transition_Call) RunAndReturn(run func(ctx context.Context, slug string, change domain.StatusChange, actor domain.Actor) (*domain.Show, error)) *MockShowService_Transition_Call {
 	_c.Call.Return(run)
 	return _c
 }
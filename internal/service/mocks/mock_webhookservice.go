@@ -0,0 +1,499 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookService is an autogenerated mock type for the WebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+type MockWebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookService) EXPECT() *MockWebhookService_Expecter {
+	return &MockWebhookService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Create(ctx context.Context, w domain.Webhook) (*domain.Webhook, error) {
+	ret := _mock.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Webhook) (*domain.Webhook, error)); ok {
+		return returnFunc(ctx, w)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Webhook) *domain.Webhook); ok {
+		r0 = returnFunc(ctx, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Webhook) error); ok {
+		r1 = returnFunc(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockWebhookService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w domain.Webhook
+func (_e *MockWebhookService_Expecter) Create(ctx interface{}, w interface{}) *MockWebhookService_Create_Call {
+	return &MockWebhookService_Create_Call{Call: _e.mock.On("Create", ctx, w)}
+}
+
+func (_c *MockWebhookService_Create_Call) Run(run func(ctx context.Context, w domain.Webhook)) *MockWebhookService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Webhook
+		if args[1] != nil {
+			arg1 = args[1].(domain.Webhook)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Create_Call) Return(webhook *domain.Webhook, err error) *MockWebhookService_Create_Call {
+	_c.Call.Return(webhook, err)
+	return _c
+}
+
+func (_c *MockWebhookService_Create_Call) RunAndReturn(run func(ctx context.Context, w domain.Webhook) (*domain.Webhook, error)) *MockWebhookService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockWebhookService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookService_Expecter) Delete(ctx interface{}, id interface{}) *MockWebhookService_Delete_Call {
+	return &MockWebhookService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockWebhookService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockWebhookService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Delete_Call) Return(err error) *MockWebhookService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockWebhookService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deliveries provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Deliveries(ctx context.Context, id string, limit int) (*domain.WebhookDeliveriesResponse, error) {
+	ret := _mock.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliveries")
+	}
+
+	var r0 *domain.WebhookDeliveriesResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (*domain.WebhookDeliveriesResponse, error)); ok {
+		return returnFunc(ctx, id, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) *domain.WebhookDeliveriesResponse); ok {
+		r0 = returnFunc(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDeliveriesResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_Deliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deliveries'
+type MockWebhookService_Deliveries_Call struct {
+	*mock.Call
+}
+
+// Deliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - limit int
+func (_e *MockWebhookService_Expecter) Deliveries(ctx interface{}, id interface{}, limit interface{}) *MockWebhookService_Deliveries_Call {
+	return &MockWebhookService_Deliveries_Call{Call: _e.mock.On("Deliveries", ctx, id, limit)}
+}
+
+func (_c *MockWebhookService_Deliveries_Call) Run(run func(ctx context.Context, id string, limit int)) *MockWebhookService_Deliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Deliveries_Call) Return(webhookDeliveriesResponse *domain.WebhookDeliveriesResponse, err error) *MockWebhookService_Deliveries_Call {
+	_c.Call.Return(webhookDeliveriesResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_Deliveries_Call) RunAndReturn(run func(ctx context.Context, id string, limit int) (*domain.WebhookDeliveriesResponse, error)) *MockWebhookService_Deliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Get(ctx context.Context, id string) (*domain.Webhook, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Webhook, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Webhook); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockWebhookService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookService_Expecter) Get(ctx interface{}, id interface{}) *MockWebhookService_Get_Call {
+	return &MockWebhookService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockWebhookService_Get_Call) Run(run func(ctx context.Context, id string)) *MockWebhookService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Get_Call) Return(webhook *domain.Webhook, err error) *MockWebhookService_Get_Call {
+	_c.Call.Return(webhook, err)
+	return _c
+}
+
+func (_c *MockWebhookService_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.Webhook, error)) *MockWebhookService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) List(ctx context.Context) (*domain.WebhooksResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.WebhooksResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*domain.WebhooksResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *domain.WebhooksResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhooksResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockWebhookService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) List(ctx interface{}) *MockWebhookService_List_Call {
+	return &MockWebhookService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockWebhookService_List_Call) Run(run func(ctx context.Context)) *MockWebhookService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_List_Call) Return(webhooksResponse *domain.WebhooksResponse, err error) *MockWebhookService_List_Call {
+	_c.Call.Return(webhooksResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_List_Call) RunAndReturn(run func(ctx context.Context) (*domain.WebhooksResponse, error)) *MockWebhookService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Redeliver provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Redeliver(ctx context.Context, id string, deliveryID string) (*domain.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, id, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, id, deliveryID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, id, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type MockWebhookService_Redeliver_Call struct {
+	*mock.Call
+}
+
+// Redeliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - deliveryID string
+func (_e *MockWebhookService_Expecter) Redeliver(ctx interface{}, id interface{}, deliveryID interface{}) *MockWebhookService_Redeliver_Call {
+	return &MockWebhookService_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, id, deliveryID)}
+}
+
+func (_c *MockWebhookService_Redeliver_Call) Run(run func(ctx context.Context, id string, deliveryID string)) *MockWebhookService_Redeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Redeliver_Call) Return(webhookDelivery *domain.WebhookDelivery, err error) *MockWebhookService_Redeliver_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookService_Redeliver_Call) RunAndReturn(run func(ctx context.Context, id string, deliveryID string) (*domain.WebhookDelivery, error)) *MockWebhookService_Redeliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Update(ctx context.Context, w domain.Webhook) error {
+	ret := _mock.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Webhook) error); ok {
+		r0 = returnFunc(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockWebhookService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w domain.Webhook
+func (_e *MockWebhookService_Expecter) Update(ctx interface{}, w interface{}) *MockWebhookService_Update_Call {
+	return &MockWebhookService_Update_Call{Call: _e.mock.On("Update", ctx, w)}
+}
+
+func (_c *MockWebhookService_Update_Call) Run(run func(ctx context.Context, w domain.Webhook)) *MockWebhookService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Webhook
+		if args[1] != nil {
+			arg1 = args[1].(domain.Webhook)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Update_Call) Return(err error) *MockWebhookService_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookService_Update_Call) RunAndReturn(run func(ctx context.Context, w domain.Webhook) error) *MockWebhookService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
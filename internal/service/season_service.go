@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+type SeasonService interface {
+	CreateSeason(ctx context.Context, season domain.SeasonDetail) error
+	ListSeasons(ctx context.Context, showSlug string) (*domain.SeasonsResponse, error)
+	CreateEpisode(ctx context.Context, episode domain.Episode) error
+	ListEpisodes(ctx context.Context, showSlug string, season int) (*domain.EpisodesResponse, error)
+}
+
+type SeasonSvc struct {
+	repo repository.SeasonRepository
+}
+
+func NewSeasonService(repo repository.SeasonRepository) SeasonService {
+	return &SeasonSvc{repo: repo}
+}
+
+func (s *SeasonSvc) CreateSeason(ctx context.Context, season domain.SeasonDetail) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonService.CreateSeason")
+	span.SetAttributes(telemetry.AttrShowSlug.String(season.ShowSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.PutSeason(ctx, season); err != nil {
+		log.Printf("Error creating season %d of %s: %v", season.Number, season.ShowSlug, err)
+		return fmt.Errorf("failed to create season: %w", err)
+	}
+	return nil
+}
+
+func (s *SeasonSvc) ListSeasons(ctx context.Context, showSlug string) (_ *domain.SeasonsResponse, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonService.ListSeasons")
+	span.SetAttributes(telemetry.AttrShowSlug.String(showSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	seasons, err := s.repo.ListSeasons(ctx, showSlug)
+	if err != nil {
+		log.Printf("Error listing seasons of %s: %v", showSlug, err)
+		return nil, fmt.Errorf("failed to retrieve seasons: %w", err)
+	}
+	return &domain.SeasonsResponse{Response: seasons}, nil
+}
+
+func (s *SeasonSvc) CreateEpisode(ctx context.Context, episode domain.Episode) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonService.CreateEpisode")
+	span.SetAttributes(telemetry.AttrShowSlug.String(episode.ShowSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.PutEpisode(ctx, episode); err != nil {
+		log.Printf("Error creating episode %d/%d of %s: %v", episode.SeasonNumber, episode.Number, episode.ShowSlug, err)
+		return fmt.Errorf("failed to create episode: %w", err)
+	}
+	return nil
+}
+
+func (s *SeasonSvc) ListEpisodes(ctx context.Context, showSlug string, season int) (_ *domain.EpisodesResponse, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "SeasonService.ListEpisodes")
+	span.SetAttributes(telemetry.AttrShowSlug.String(showSlug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	episodes, err := s.repo.ListEpisodes(ctx, showSlug, season)
+	if err != nil {
+		log.Printf("Error listing episodes of %s season %d: %v", showSlug, season, err)
+		return nil, fmt.Errorf("failed to retrieve episodes: %w", err)
+	}
+	return &domain.EpisodesResponse{Response: episodes}, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+func TestSeasonSvc_CreateSeason(t *testing.T) {
+	season := domain.SeasonDetail{ShowSlug: "show/a", Number: 1}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().PutSeason(mock.Anything, season).Return(nil)
+
+		require.NoError(t, NewSeasonService(mockRepo).CreateSeason(context.Background(), season))
+	})
+
+	t.Run("repository error keeps its sentinel", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().PutSeason(mock.Anything, season).Return(apperror.ErrNotFound)
+
+		err := NewSeasonService(mockRepo).CreateSeason(context.Background(), season)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+		require.Contains(t, err.Error(), "failed to create season")
+	})
+}
+
+func TestSeasonSvc_ListSeasons(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().ListSeasons(mock.Anything, "show/a").Return([]domain.SeasonDetail{{Number: 1}}, nil)
+
+		got, err := NewSeasonService(mockRepo).ListSeasons(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.Len(t, got.Response, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().ListSeasons(mock.Anything, "show/a").Return(nil, errors.New("boom"))
+
+		got, err := NewSeasonService(mockRepo).ListSeasons(context.Background(), "show/a")
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func TestSeasonSvc_CreateEpisode(t *testing.T) {
+	episode := domain.Episode{ShowSlug: "show/a", SeasonNumber: 1, Number: 1}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().PutEpisode(mock.Anything, episode).Return(nil)
+
+		require.NoError(t, NewSeasonService(mockRepo).CreateEpisode(context.Background(), episode))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().PutEpisode(mock.Anything, episode).Return(apperror.ErrDuplicateSlug)
+
+		err := NewSeasonService(mockRepo).CreateEpisode(context.Background(), episode)
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+}
+
+func TestSeasonSvc_ListEpisodes(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().ListEpisodes(mock.Anything, "show/a", 2).Return([]domain.Episode{{Number: 1, SeasonNumber: 2}}, nil)
+
+		got, err := NewSeasonService(mockRepo).ListEpisodes(context.Background(), "show/a", 2)
+		require.NoError(t, err)
+		require.Len(t, got.Response, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockSeasonRepository(t)
+		mockRepo.EXPECT().ListEpisodes(mock.Anything, "show/a", 2).Return(nil, apperror.ErrNotFound)
+
+		_, err := NewSeasonService(mockRepo).ListEpisodes(context.Background(), "show/a", 2)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
@@ -1,53 +1,476 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/dedupe"
 	"github.com/marciomarinho/show-service/internal/domain"
 	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/search"
+	"github.com/marciomarinho/show-service/internal/suggest"
+	"github.com/marciomarinho/show-service/internal/telemetry"
 )
 
+// maxSlugSuffix bounds the attempts at a free slug for a show whose slug
+// was generated from its title: base, base-2, ... base-maxSlugSuffix
+const maxSlugSuffix = 20
+
 type ShowService interface {
-	Create(request domain.Request) error
-	List() (*domain.Response, error)
+	// Create stores every show in the request as a draft, with actor as the
+	// author of its first revision, and returns their slugs in payload
+	// order; generated slugs may have gained a collision suffix. Shows that
+	// likely duplicate stored ones, or earlier ones in the payload, are
+	// flagged, or rejected with StrictDuplicates.
+	Create(ctx context.Context, request domain.Request, actor domain.Actor) (*domain.Created, error)
+	// Get returns the show at slug. At a former slug it returns the alias
+	// stub, whose AliasOf names the current one.
+	Get(ctx context.Context, slug string, viewer domain.Viewer) (*domain.Show, error)
+	Rename(ctx context.Context, from, to string) error
+	// Transition moves the show at slug through the editorial workflow on
+	// behalf of actor and returns the show as updated
+	Transition(ctx context.Context, slug string, change domain.StatusChange, actor domain.Actor) (*domain.Show, error)
+	// Revisions lists the show's revisions, oldest first, without their
+	// documents
+	Revisions(ctx context.Context, slug string) ([]domain.Revision, error)
+	Revision(ctx context.Context, slug string, n int) (*domain.Revision, error)
+	// Revert restores the content of revision n as a new revision. The
+	// show's slug and workflow state are kept.
+	Revert(ctx context.Context, slug string, n int, actor domain.Actor) (*domain.Show, error)
+	// Reads localize titles, descriptions and images to the first of the
+	// viewer's locales a show has a variant for, then to the configured
+	// fallback locales. Shows unpublished or unavailable to the viewer are
+	// left out, or marked with the reason when the viewer includes hidden
+	// shows.
+	List(ctx context.Context, viewer domain.Viewer) (*domain.Response, error)
+	AiringSoon(ctx context.Context, within time.Duration, viewer domain.Viewer) (*domain.Response, error)
+	// Search returns up to limit shows matching query, best first, among
+	// those the viewer may see
+	Search(ctx context.Context, query string, limit int, viewer domain.Viewer) (*domain.Response, error)
+	// Reindex rebuilds the search and duplicate indexes from every stored
+	// show and returns how many were indexed
+	Reindex(ctx context.Context) (int, error)
+	// Suggest returns up to limit shows whose title, in any locale, or
+	// former title completes prefix, most popular first, among those the
+	// viewer may see
+	Suggest(ctx context.Context, prefix string, limit int, viewer domain.Viewer) (*domain.SuggestionsResponse, error)
+	// RebuildSuggestions rebuilds the suggestions from every stored show and
+	// returns how many are held
+	RebuildSuggestions(ctx context.Context) (int, error)
+	// Duplicates lists up to limit stored shows that likely duplicate
+	// others, by slug, starting after the slug after
+	Duplicates(ctx context.Context, after string, limit int) (*domain.DuplicatesResponse, error)
+}
+
+// ShowOptions tunes how the show service treats channel references and
+// localized reads
+type ShowOptions struct {
+	// AutoCreateChannels creates channels referenced on ingest that do not
+	// exist yet instead of rejecting the request
+	AutoCreateChannels bool
+	// FallbackLocales are tried, in order, after the client's locales
+	FallbackLocales []string
+	// OnChange, when set, hears of every change the service stores, once it
+	// is stored. It must not block.
+	OnChange func(domain.ChangeEvent)
+	// Index serves Search and is kept in sync with every show the service
+	// stores; a new one is used when nil
+	Index *search.Index
+	// Suggestions serves Suggest and is kept in sync like Index; a new,
+	// uncapped one is used when nil
+	Suggestions *suggest.Trie
+	// Duplicates finds likely duplicates on ingest and for Duplicates, and
+	// is kept in sync and rebuilt like Index; a new one with the default
+	// threshold is used when nil
+	Duplicates *dedupe.Index
+	// StrictDuplicates rejects ingests with likely duplicates instead of
+	// flagging them
+	StrictDuplicates bool
 }
 
 type ShowSvc struct {
-	repo repository.ShowRepository
+	repo     repository.ShowRepository
+	channels repository.ChannelRepository
+	opts     ShowOptions
+	now      func() time.Time
+}
+
+func NewShowService(repo repository.ShowRepository, channels repository.ChannelRepository, opts ShowOptions) ShowService {
+	if opts.Index == nil {
+		opts.Index = search.New()
+	}
+	if opts.Suggestions == nil {
+		opts.Suggestions = suggest.New(suggest.Options{})
+	}
+	if opts.Duplicates == nil {
+		opts.Duplicates = dedupe.New(dedupe.Options{})
+	}
+	return &ShowSvc{repo: repo, channels: channels, opts: opts, now: time.Now}
+}
+
+func (s *ShowSvc) Create(ctx context.Context, request domain.Request, actor domain.Actor) (_ *domain.Created, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Create")
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(request.Payload)))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.checkChannels(ctx, request.Payload); err != nil {
+		return nil, err
+	}
+	matches, err := s.checkDuplicates(request.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &domain.Created{Slugs: make([]string, 0, len(request.Payload))}
+	for i, show := range request.Payload {
+		show.Status = domain.StatusDraft
+		slug, err := s.put(ctx, show, actor)
+		if err != nil {
+			log.Printf("Error creating show %s: %v", slug, err)
+			return nil, fmt.Errorf("failed to create show %s: %w", slug, err)
+		}
+		created.Slugs = append(created.Slugs, slug)
+		if len(matches[i]) > 0 {
+			created.Duplicates = append(created.Duplicates, domain.Duplicate{
+				Path:    "/payload/" + strconv.Itoa(i),
+				Slug:    slug,
+				Title:   show.Title,
+				Matches: matches[i],
+			})
+		}
+	}
+	return created, nil
+}
+
+// checkDuplicates returns, for each show in the payload, the stored shows
+// and earlier ones in the payload it likely duplicates. With
+// StrictDuplicates, all shows with any are rejected together instead. A
+// stored show at a client-chosen slug is not a match; storing over it
+// fails with a duplicate slug.
+func (s *ShowSvc) checkDuplicates(shows []domain.Show) ([][]domain.DuplicateMatch, error) {
+	matches := make([][]domain.DuplicateMatch, len(shows))
+	if s.opts.Duplicates == nil {
+		return matches, nil
+	}
+	items := validation.Errors{}
+	for i, show := range shows {
+		probe := show
+		if show.SlugGenerated {
+			probe.Slug = ""
+		}
+		matches[i] = s.opts.Duplicates.Find(probe, shows[:i]...)
+		if len(matches[i]) > 0 {
+			best := matches[i][0]
+			setNested(items, strconv.Itoa(i)+"/title", validation.NewError("duplicate_likely",
+				fmt.Sprintf("title is %.0f%% similar to %s (%s)", best.Score*100, best.Slug, best.Title)))
+		}
+	}
+	if s.opts.StrictDuplicates && len(items) > 0 {
+		return nil, apperror.Validation(validation.Errors{"payload": items})
+	}
+	return matches, nil
+}
+
+// put stores a show, moving a generated slug along base-2, base-3, ... until
+// it finds a free one. Slugs the client chose are never changed.
+func (s *ShowSvc) put(ctx context.Context, show domain.Show, actor domain.Actor) (string, error) {
+	base := show.Slug
+	for n := 2; ; n++ {
+		revisions, err := domain.Revise(nil, &show, domain.RevisionCreated, actor.ID, s.now())
+		if err != nil {
+			return show.Slug, err
+		}
+		err = s.repo.Put(ctx, show, revisions)
+		if err == nil {
+			s.indexed(show)
+			s.changed(domain.RevisionEvent(show.Slug, revisions[len(revisions)-1]))
+		}
+		if err == nil || !show.SlugGenerated || !errors.Is(err, apperror.ErrDuplicateSlug) || n > maxSlugSuffix {
+			return show.Slug, err
+		}
+		show.Rename(base + "-" + strconv.Itoa(n))
+	}
+}
+
+func (s *ShowSvc) Get(ctx context.Context, slug string, viewer domain.Viewer) (_ *domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Get")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	show, err := s.repo.Get(ctx, slug)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			log.Printf("Error getting show %s: %v", slug, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve show: %w", err)
+	}
+
+	// A show the viewer may not see is reported as missing, not forbidden
+	hidden, visible := s.visible(*show, viewer)
+	if !visible {
+		return nil, fmt.Errorf("failed to retrieve show: show %s: %w", slug, apperror.ErrNotFound)
+	}
+	localized := show.Localize(s.chain(viewer.Locales))
+	localized.Hidden = hidden
+	return &localized, nil
+}
+
+func (s *ShowSvc) Rename(ctx context.Context, from, to string) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Rename")
+	span.SetAttributes(telemetry.AttrShowSlug.String(from))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	show, err := s.repo.Rename(ctx, from, to)
+	if err != nil {
+		log.Printf("Error renaming show %s to %s: %v", from, to, err)
+		return fmt.Errorf("failed to rename show %s: %w", from, err)
+	}
+	if s.opts.Index != nil {
+		s.opts.Index.Rename(from, to)
+	}
+	if s.opts.Suggestions != nil {
+		s.opts.Suggestions.Rename(from, to)
+	}
+	if s.opts.Duplicates != nil {
+		s.opts.Duplicates.Rename(from, to)
+	}
+	s.changed(domain.RenameEvent(from, *show, s.now()))
+	return nil
+}
+
+func (s *ShowSvc) Transition(ctx context.Context, slug string, change domain.StatusChange, actor domain.Actor) (_ *domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Transition")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	show, err := s.live(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	from := show.EffectiveStatus()
+	role, ok := domain.RequiredRole(from, change.Status)
+	if !ok {
+		return nil, apperror.Validation(validation.Errors{
+			"status": validation.NewError("transition_invalid", fmt.Sprintf("a %s show cannot move to %s", from, change.Status)),
+		})
+	}
+	if !actor.Can(role) {
+		return nil, apperror.Forbidden(fmt.Sprintf("moving a show from %s to %s requires the %s role", from, change.Status, role))
+	}
+
+	t := domain.Transition{From: from, To: change.Status, Actor: actor.ID, At: s.now().UTC().Format(time.RFC3339)}
+	if change.PublishAt != nil {
+		at, err := domain.ParseAirDate(*change.PublishAt, time.UTC)
+		if err != nil {
+			return nil, apperror.Validation(validation.Errors{"publishAt": domain.DateInvalid()})
+		}
+		publishAt := at.UTC().Format(time.RFC3339)
+		t.PublishAt = &publishAt
+	}
+	next := *show
+	next.Status = t.To
+	next.PublishAt = t.PublishAt
+	next.Transitions = append(slices.Clip(show.Transitions), t)
+	if err := s.update(ctx, show, &next, domain.RevisionTransitioned, actor, nil); err != nil {
+		log.Printf("Error moving show %s from %s to %s: %v", slug, from, change.Status, err)
+		return nil, fmt.Errorf("failed to update show status: %w", err)
+	}
+	return &next, nil
 }
 
-func NewShowService(repo repository.ShowRepository) ShowService {
-	return &ShowSvc{repo: repo}
+func (s *ShowSvc) Revisions(ctx context.Context, slug string) (_ []domain.Revision, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Revisions")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if _, err := s.live(ctx, slug); err != nil {
+		return nil, err
+	}
+	revisions, err := s.repo.ListRevisions(ctx, slug)
+	if err != nil {
+		log.Printf("Error listing revisions of show %s: %v", slug, err)
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+	return revisions, nil
 }
 
-func (s *ShowSvc) Create(request domain.Request) error {
-	for _, show := range request.Payload {
-		if err := s.repo.Put(show); err != nil {
-			log.Printf("Error creating show %s: %v", show.Slug, err)
-			return errors.New("failed to create show")
+func (s *ShowSvc) Revision(ctx context.Context, slug string, n int) (_ *domain.Revision, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Revision")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if _, err := s.live(ctx, slug); err != nil {
+		return nil, err
+	}
+	rev, err := s.repo.GetRevision(ctx, slug, n)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			log.Printf("Error getting revision %d of show %s: %v", n, slug, err)
 		}
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
+	}
+	return rev, nil
+}
+
+// Revert needs the editor role, or the publisher role while the show is
+// published, since the restored content goes live at once
+func (s *ShowSvc) Revert(ctx context.Context, slug string, n int, actor domain.Actor) (_ *domain.Show, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Revert")
+	span.SetAttributes(telemetry.AttrShowSlug.String(slug))
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	show, err := s.live(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	role := domain.RoleEditor
+	if show.EffectiveStatus() == domain.StatusPublished {
+		role = domain.RolePublisher
+	}
+	if !actor.Can(role) {
+		return nil, apperror.Forbidden(fmt.Sprintf("reverting a %s show requires the %s role", show.EffectiveStatus(), role))
+	}
+
+	rev, err := s.repo.GetRevision(ctx, slug, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
 	}
+	if rev.Show == nil {
+		return nil, fmt.Errorf("revision %d of show %s has no document", n, slug)
+	}
+
+	// The earlier document passed the rules of its day, which may since
+	// have tightened
+	next := show.Restore(*rev.Show)
+	if err := next.Validate(); err != nil {
+		return nil, apperror.Validation(err)
+	}
+	if err := s.update(ctx, show, &next, domain.RevisionReverted, actor, &n); err != nil {
+		log.Printf("Error reverting show %s to revision %d: %v", slug, n, err)
+		return nil, fmt.Errorf("failed to revert show: %w", err)
+	}
+	return &next, nil
+}
+
+// live returns the show at slug, reporting an alias stub as missing
+func (s *ShowSvc) live(ctx context.Context, slug string) (*domain.Show, error) {
+	show, err := s.repo.Get(ctx, slug)
+	if err == nil && show.AliasOf != nil {
+		err = fmt.Errorf("show %s: %w", slug, apperror.ErrNotFound)
+	}
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			log.Printf("Error getting show %s: %v", slug, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve show: %w", err)
+	}
+	return show, nil
+}
+
+// update writes next over prev, recording it as a new revision made by
+// actor. revertedFrom is the revision a revert restores.
+func (s *ShowSvc) update(ctx context.Context, prev, next *domain.Show, action string, actor domain.Actor, revertedFrom *int) error {
+	revisions, err := domain.Revise(prev, next, action, actor.ID, s.now())
+	if err != nil {
+		return err
+	}
+	revisions[len(revisions)-1].RevertedFrom = revertedFrom
+	if err := s.repo.Update(ctx, *next, revisions); err != nil {
+		return err
+	}
+	s.indexed(*next)
+	event := domain.RevisionEvent(next.Slug, revisions[len(revisions)-1])
+	if from := prev.EffectiveStatus(); from != event.Status {
+		event.PreviousStatus = from
+	}
+	s.changed(event)
 	return nil
 }
 
-func (s *ShowSvc) List() (*domain.Response, error) {
-	shows, err := s.repo.List()
+// indexed keeps the search index, suggestions and duplicate index in step
+// with show, as stored
+func (s *ShowSvc) indexed(show domain.Show) {
+	if s.opts.Index != nil {
+		s.opts.Index.Put(show)
+	}
+	if s.opts.Suggestions != nil {
+		s.opts.Suggestions.Put(show)
+	}
+	if s.opts.Duplicates != nil {
+		s.opts.Duplicates.Put(show)
+	}
+}
+
+// changed tells OnChange of e
+func (s *ShowSvc) changed(e domain.ChangeEvent) {
+	if s.opts.OnChange == nil {
+		return
+	}
+	e.ID = domain.NewID()
+	s.opts.OnChange(e)
+}
+
+func (s *ShowSvc) List(ctx context.Context, viewer domain.Viewer) (_ *domain.Response, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	shows, err := s.repo.List(ctx)
 	if err != nil {
 		log.Printf("Error listing shows: %v", err)
-		return nil, errors.New("failed to retrieve shows")
+		return nil, fmt.Errorf("failed to retrieve shows: %w", err)
+	}
+
+	channels, err := s.resolveChannels(ctx, shows)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert domain.Show to domain.ShowResponse for API response
+	chain := s.chain(viewer.Locales)
 	showResponses := []domain.ShowResponse{}
 	for _, show := range shows {
-		showResponse := domain.ShowResponse{
-			Image: getImageURL(show.Image),
-			Slug:  show.Slug,
-			Title: show.Title,
+		hidden, visible := s.visible(show, viewer)
+		if !visible {
+			continue
 		}
+		showResponse := showResponseOf(show.Localize(chain), channels)
+		showResponse.Hidden = hidden
 		showResponses = append(showResponses, showResponse)
 	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(showResponses)))
 
 	response := &domain.Response{
 		Response: showResponses,
@@ -56,6 +479,316 @@ func (s *ShowSvc) List() (*domain.Response, error) {
 	return response, nil
 }
 
+// AiringSoon lists shows whose next episode airs between now and now+within,
+// soonest first
+func (s *ShowSvc) AiringSoon(ctx context.Context, within time.Duration, viewer domain.Viewer) (_ *domain.Response, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.AiringSoon")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	from := s.now()
+	shows, err := s.repo.ListAiring(ctx, from, from.Add(within))
+	if err != nil {
+		log.Printf("Error listing airing shows: %v", err)
+		return nil, fmt.Errorf("failed to retrieve airing shows: %w", err)
+	}
+
+	channels, err := s.resolveChannels(ctx, shows)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := s.chain(viewer.Locales)
+	showResponses := []domain.ShowResponse{}
+	for _, show := range shows {
+		hidden, visible := s.visible(show, viewer)
+		if !visible {
+			continue
+		}
+		showResponse := showResponseOf(show.Localize(chain), channels)
+		showResponse.Hidden = hidden
+		if show.NextEpisode != nil {
+			if at, ok := show.NextEpisode.AirsAt(); ok {
+				at = at.UTC()
+				showResponse.AirsAt = &at
+			}
+		}
+		showResponses = append(showResponses, showResponse)
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(showResponses)))
+
+	return &domain.Response{Response: showResponses}, nil
+}
+
+func (s *ShowSvc) Search(ctx context.Context, query string, limit int, viewer domain.Viewer) (_ *domain.Response, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Search")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if s.opts.Index == nil {
+		return nil, errors.New("search is not configured")
+	}
+	var shows []domain.Show
+	for _, hit := range s.opts.Index.Search(query, 0) {
+		if _, visible := s.visible(hit.Show, viewer); visible {
+			shows = append(shows, hit.Show)
+		}
+		if len(shows) == limit {
+			break
+		}
+	}
+
+	channels, err := s.resolveChannels(ctx, shows)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := s.chain(viewer.Locales)
+	showResponses := []domain.ShowResponse{}
+	for _, show := range shows {
+		hidden, _ := s.visible(show, viewer)
+		showResponse := showResponseOf(show.Localize(chain), channels)
+		showResponse.Hidden = hidden
+		showResponses = append(showResponses, showResponse)
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(showResponses)))
+
+	return &domain.Response{Response: showResponses}, nil
+}
+
+func (s *ShowSvc) Reindex(ctx context.Context) (_ int, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.Reindex")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if s.opts.Index == nil {
+		return 0, errors.New("search is not configured")
+	}
+	shows, err := s.repo.ListAll(ctx)
+	if err != nil {
+		log.Printf("Error listing shows to index: %v", err)
+		return 0, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+	s.opts.Index.Replace(shows)
+	if s.opts.Duplicates != nil {
+		s.opts.Duplicates.Replace(shows)
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(s.opts.Index.Len()))
+	return s.opts.Index.Len(), nil
+}
+
+// Duplicates reads the duplicate index only; it never reaches the
+// repository
+func (s *ShowSvc) Duplicates(ctx context.Context, after string, limit int) (_ *domain.DuplicatesResponse, err error) {
+	_, span := telemetry.Tracer().Start(ctx, "ShowService.Duplicates")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if s.opts.Duplicates == nil {
+		return nil, errors.New("duplicate detection is not configured")
+	}
+	duplicates, next := s.opts.Duplicates.Report(after, limit)
+	if duplicates == nil {
+		duplicates = []domain.Duplicate{}
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(duplicates)))
+	return &domain.DuplicatesResponse{Response: duplicates, Next: next}, nil
+}
+
+// Suggest reads the suggestions only; it never reaches the repository
+func (s *ShowSvc) Suggest(ctx context.Context, prefix string, limit int, viewer domain.Viewer) (_ *domain.SuggestionsResponse, err error) {
+	_, span := telemetry.Tracer().Start(ctx, "ShowService.Suggest")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if s.opts.Suggestions == nil {
+		return nil, errors.New("suggestions are not configured")
+	}
+	// Visibility is checked as the trie ranks, so that shows the viewer may
+	// not see never take the places of those they may
+	visible := func(show domain.Show) bool {
+		_, ok := s.visible(show, viewer)
+		return ok
+	}
+	chain := s.chain(viewer.Locales)
+	suggestions := []domain.Suggestion{}
+	for _, show := range s.opts.Suggestions.LookupFunc(prefix, limit, visible) {
+		hidden, _ := s.visible(show, viewer)
+		show = show.Localize(chain)
+		suggestions = append(suggestions, domain.Suggestion{Slug: show.Slug, Title: show.Title, Locales: show.Locales, Hidden: hidden})
+	}
+	span.SetAttributes(telemetry.AttrItemCount.Int(len(suggestions)))
+	return &domain.SuggestionsResponse{Response: suggestions}, nil
+}
+
+func (s *ShowSvc) RebuildSuggestions(ctx context.Context) (_ int, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "ShowService.RebuildSuggestions")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if s.opts.Suggestions == nil {
+		return 0, errors.New("suggestions are not configured")
+	}
+	shows, err := s.repo.ListAll(ctx)
+	if err != nil {
+		log.Printf("Error listing shows to suggest: %v", err)
+		return 0, fmt.Errorf("failed to rebuild suggestions: %w", err)
+	}
+	s.opts.Suggestions.Replace(shows)
+	span.SetAttributes(telemetry.AttrItemCount.Int(s.opts.Suggestions.Len()))
+	return s.opts.Suggestions.Len(), nil
+}
+
+// channelRef is one channel reference found in an ingest payload, with the
+// free-text fields used to seed the channel when it is auto-created
+type channelRef struct {
+	index int
+	field string // path below the payload item, e.g. "nextEpisode/channelId"
+	seed  domain.Channel
+}
+
+// checkChannels rejects references to unknown channels, or creates them when
+// AutoCreateChannels is set. All unknown references are reported together.
+func (s *ShowSvc) checkChannels(ctx context.Context, shows []domain.Show) error {
+	var refs []channelRef
+	for i, show := range shows {
+		if show.ChannelID != nil {
+			seed := domain.Channel{ID: *show.ChannelID, Name: *show.ChannelID}
+			if show.TVChannel != nil && *show.TVChannel != "" {
+				seed.Name = *show.TVChannel
+			}
+			refs = append(refs, channelRef{index: i, field: "channelId", seed: seed})
+		}
+		if ne := show.NextEpisode; ne != nil && ne.ChannelID != nil {
+			seed := domain.Channel{ID: *ne.ChannelID, Name: *ne.ChannelID}
+			if ne.Channel != nil && *ne.Channel != "" {
+				seed.Name = *ne.Channel
+			}
+			if ne.ChannelLogo != "" {
+				logo := ne.ChannelLogo
+				seed.Logo = &logo
+			}
+			refs = append(refs, channelRef{index: i, field: "nextEpisode/channelId", seed: seed})
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	known, err := s.channelsByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	items := validation.Errors{}
+	for _, ref := range refs {
+		if known[ref.seed.ID] != nil {
+			continue
+		}
+		if !s.opts.AutoCreateChannels {
+			setNested(items, strconv.Itoa(ref.index)+"/"+ref.field,
+				validation.NewError("channel_unknown", "channel "+ref.seed.ID+" does not exist"))
+			continue
+		}
+		seed := ref.seed
+		if seed.Validate() != nil {
+			// Free-text feed values too long or malformed for a channel
+			seed = domain.Channel{ID: seed.ID, Name: seed.ID}
+		}
+		// A concurrent ingest may create the same channel first
+		if err := s.channels.Create(ctx, seed); err != nil && !errors.Is(err, apperror.ErrDuplicateSlug) {
+			return fmt.Errorf("failed to create channel %s: %w", seed.ID, err)
+		}
+		known[seed.ID] = &seed
+	}
+	if len(items) > 0 {
+		return apperror.Validation(validation.Errors{"payload": items})
+	}
+	return nil
+}
+
+// resolveChannels loads the channels the given shows refer to, skipping the
+// lookup entirely when none do
+func (s *ShowSvc) resolveChannels(ctx context.Context, shows []domain.Show) (map[string]*domain.Channel, error) {
+	for _, show := range shows {
+		if show.ChannelID != nil {
+			return s.channelsByID(ctx)
+		}
+	}
+	return nil, nil
+}
+
+func (s *ShowSvc) channelsByID(ctx context.Context) (map[string]*domain.Channel, error) {
+	channels, err := s.channels.List(ctx)
+	if err != nil {
+		log.Printf("Error listing channels: %v", err)
+		return nil, fmt.Errorf("failed to retrieve channels: %w", err)
+	}
+	byID := make(map[string]*domain.Channel, len(channels))
+	for i := range channels {
+		byID[channels[i].ID] = &channels[i]
+	}
+	return byID, nil
+}
+
+// visible reports why the workflow or availability hides the show from
+// viewer, if either does, and whether it should be returned at all
+func (s *ShowSvc) visible(show domain.Show, viewer domain.Viewer) (string, bool) {
+	hidden := show.HiddenFrom(s.now(), viewer.Country)
+	return hidden, hidden == "" || viewer.IncludeHidden
+}
+
+// chain appends the fallback locales to the client's
+func (s *ShowSvc) chain(locales []string) []string {
+	return append(slices.Clip(locales), s.opts.FallbackLocales...)
+}
+
+func showResponseOf(show domain.Show, channels map[string]*domain.Channel) domain.ShowResponse {
+	return domain.ShowResponse{
+		Image:   getImageURL(show.Image),
+		Slug:    show.Slug,
+		Title:   show.Title,
+		Channel: channelOf(show, channels),
+		Locales: show.Locales,
+	}
+}
+
+// channelOf returns the show's resolved channel, or nil when it has none or
+// the channel has since been deleted
+func channelOf(show domain.Show, channels map[string]*domain.Channel) *domain.Channel {
+	if show.ChannelID == nil {
+		return nil
+	}
+	return channels[*show.ChannelID]
+}
+
+// setNested stores err in errs under a slash-separated path, creating the
+// intermediate validation.Errors maps
+func setNested(errs validation.Errors, path string, err error) {
+	keys := strings.Split(path, "/")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := errs[key].(validation.Errors)
+		if !ok {
+			next = validation.Errors{}
+			errs[key] = next
+		}
+		errs = next
+	}
+	errs[keys[len(keys)-1]] = err
+}
+
 func getImageURL(img *domain.Image) string {
 	if img == nil {
 		return ""
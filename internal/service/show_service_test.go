@@ -1,14 +1,21 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/marciomarinho/show-service/internal/apperror"
 	"github.com/marciomarinho/show-service/internal/domain"
 	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+	"github.com/marciomarinho/show-service/internal/search"
+	"github.com/marciomarinho/show-service/internal/suggest"
 )
 
 func TestShowSvc_Create(t *testing.T) {
@@ -35,7 +42,7 @@ func TestShowSvc_Create(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *repoMocks.MockShowRepository) {
-				m.On("Put", mock.AnythingOfType("domain.Show")).Return(nil).Times(2)
+				m.On("Put", mock.Anything, mock.AnythingOfType("domain.Show"), mock.Anything).Return(nil).Times(2)
 			},
 			expectError: false,
 		},
@@ -56,7 +63,7 @@ func TestShowSvc_Create(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *repoMocks.MockShowRepository) {
-				m.On("Put", mock.AnythingOfType("domain.Show")).Return(errors.New("database error")).Once()
+				m.On("Put", mock.Anything, mock.AnythingOfType("domain.Show"), mock.Anything).Return(errors.New("database error")).Once()
 			},
 			expectError: true,
 		},
@@ -77,8 +84,8 @@ func TestShowSvc_Create(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *repoMocks.MockShowRepository) {
-				m.On("Put", mock.AnythingOfType("domain.Show")).Return(nil).Once()
-				m.On("Put", mock.AnythingOfType("domain.Show")).Return(errors.New("database error")).Once()
+				m.On("Put", mock.Anything, mock.AnythingOfType("domain.Show"), mock.Anything).Return(nil).Once()
+				m.On("Put", mock.Anything, mock.AnythingOfType("domain.Show"), mock.Anything).Return(errors.New("database error")).Once()
 			},
 			expectError: true,
 		},
@@ -103,7 +110,7 @@ func TestShowSvc_Create(t *testing.T) {
 				},
 			},
 			mockSetup: func(m *repoMocks.MockShowRepository) {
-				m.On("Put", mock.AnythingOfType("domain.Show")).Return(nil).Once()
+				m.On("Put", mock.Anything, mock.AnythingOfType("domain.Show"), mock.Anything).Return(nil).Once()
 			},
 			expectError: false,
 		},
@@ -114,13 +121,14 @@ func TestShowSvc_Create(t *testing.T) {
 			mockRepo := repoMocks.NewMockShowRepository(t)
 			tt.mockSetup(mockRepo)
 
-			svc := NewShowService(mockRepo)
-			err := svc.Create(tt.request)
+			svc := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{})
+			created, err := svc.Create(context.Background(), tt.request, domain.Actor{})
 
 			if tt.expectError {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				require.Len(t, created.Slugs, len(tt.request.Payload))
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -128,6 +136,143 @@ func TestShowSvc_Create(t *testing.T) {
 	}
 }
 
+func TestShowSvc_Create_GeneratedSlugs(t *testing.T) {
+	t.Run("generated slugs are suffixed until free", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool { return s.Slug == "show/taste" }), mock.Anything).
+			Return(fmt.Errorf("show show/taste: %w", apperror.ErrDuplicateSlug))
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool { return s.Slug == "show/taste-2" }), mock.Anything).
+			Return(fmt.Errorf("show show/taste-2: %w", apperror.ErrDuplicateSlug))
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool {
+			return s.Slug == "show/taste-3" && (*s.Seasons)[0].Slug == "show/taste-3/season/1"
+		}), mock.Anything).Return(nil)
+
+		request := domain.Request{Payload: []domain.Show{{
+			Slug:          "show/taste",
+			Title:         "Taste",
+			Seasons:       &[]domain.Season{{Slug: "show/taste/season/1"}},
+			SlugGenerated: true,
+		}}}
+		created, err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"show/taste-3"}, created.Slugs)
+	})
+
+	t.Run("chosen slugs are not suffixed", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("show show/taste: %w", apperror.ErrDuplicateSlug)).Once()
+
+		request := domain.Request{Payload: []domain.Show{{Slug: "show/taste", Title: "Taste"}}}
+		_, err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+
+	t.Run("gives up after maxSlugSuffix", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).
+			Return(apperror.ErrDuplicateSlug).Times(maxSlugSuffix)
+
+		request := domain.Request{Payload: []domain.Show{{Slug: "show/taste", Title: "Taste", SlugGenerated: true}}}
+		_, err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Create(context.Background(), request, domain.Actor{})
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+}
+
+func TestShowSvc_GetAndRename(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a", Title: "A"}, nil)
+
+		got, err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Get(context.Background(), "show/a", domain.Viewer{})
+		require.NoError(t, err)
+		require.Equal(t, "A", got.Title)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(nil, apperror.ErrNotFound)
+
+		_, err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Get(context.Background(), "show/a", domain.Viewer{})
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("rename conflict", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Rename(mock.Anything, "show/a", "show/b").Return(nil, apperror.ErrDuplicateSlug)
+
+		err := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{}).Rename(context.Background(), "show/a", "show/b")
+		require.ErrorIs(t, err, apperror.ErrDuplicateSlug)
+	})
+}
+
+func TestShowSvc_OnChange(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	publisher := domain.Actor{ID: "pub", Roles: []domain.Role{domain.RolePublisher}}
+
+	var changes []domain.ChangeEvent
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		changes = nil
+		return &ShowSvc{
+			repo:     repo,
+			channels: repoMocks.NewMockChannelRepository(t),
+			opts:     ShowOptions{OnChange: func(e domain.ChangeEvent) { changes = append(changes, e) }},
+			now:      func() time.Time { return now },
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := newSvc(mockRepo).Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/a", Title: "A"}}}, publisher)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Regexp(t, `^[0-9a-f]{32}$`, changes[0].ID)
+		changes[0].ID = ""
+		require.Equal(t, domain.ChangeEvent{
+			Type: domain.EventShowCreated, Slug: "show/a", Revision: 1, Actor: "pub", Status: domain.StatusDraft, At: "2024-06-15T00:00:00Z",
+		}, changes[0])
+	})
+
+	t.Run("transition", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a", Title: "A", Status: domain.StatusInReview, Revision: 2}, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := newSvc(mockRepo).Transition(context.Background(), "show/a", domain.StatusChange{Status: domain.StatusPublished}, publisher)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, domain.EventShowTransitioned, changes[0].Type)
+		require.Equal(t, domain.StatusPublished, changes[0].Status)
+		require.Equal(t, domain.StatusInReview, changes[0].PreviousStatus)
+		require.Equal(t, 3, changes[0].Revision)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Rename(mock.Anything, "show/a", "show/b").
+			Return(&domain.Show{Slug: "show/b", Title: "A", Status: domain.StatusPublished, Revision: 4}, nil)
+
+		require.NoError(t, newSvc(mockRepo).Rename(context.Background(), "show/a", "show/b"))
+		require.Len(t, changes, 1)
+		require.Equal(t, domain.EventShowRenamed, changes[0].Type)
+		require.Equal(t, "show/b", changes[0].Slug)
+		require.Equal(t, "show/a", changes[0].PreviousSlug)
+		require.Equal(t, domain.StatusPublished, changes[0].Status)
+		require.Equal(t, 4, changes[0].Revision)
+	})
+
+	t.Run("failed writes are not announced", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(apperror.ErrDuplicateSlug)
+
+		_, err := newSvc(mockRepo).Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/a", Title: "A"}}}, publisher)
+		require.Error(t, err)
+		require.Empty(t, changes)
+	})
+}
+
 func TestShowSvc_List(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -204,10 +349,10 @@ func TestShowSvc_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := repoMocks.NewMockShowRepository(t)
-			mockRepo.On("List").Return(tt.mockShows, tt.mockError)
+			mockRepo.On("List", mock.Anything).Return(tt.mockShows, tt.mockError)
 
-			svc := NewShowService(mockRepo)
-			response, err := svc.List()
+			svc := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{})
+			response, err := svc.List(context.Background(), domain.Viewer{})
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -233,3 +378,628 @@ func TestShowSvc_List(t *testing.T) {
 		})
 	}
 }
+
+func TestShowSvc_AiringSoon(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("window starts now", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.On("ListAiring", mock.Anything, now, now.Add(6*time.Hour)).Return([]domain.Show{
+			{
+				Slug:        "show/a",
+				Title:       "A",
+				Image:       &domain.Image{ShowImage: "http://example.com/a.jpg"},
+				NextEpisode: &domain.NextEpisode{Date: stringPtr("2024-06-01T20:00:00+10:00")},
+			},
+			{Slug: "show/b", Title: "B"},
+		}, nil)
+
+		svc := &ShowSvc{repo: mockRepo, channels: repoMocks.NewMockChannelRepository(t), now: func() time.Time { return now }}
+
+		got, err := svc.AiringSoon(context.Background(), 6*time.Hour, domain.Viewer{})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 2)
+
+		airsAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+		require.Equal(t, domain.ShowResponse{
+			Image:  "http://example.com/a.jpg",
+			Slug:   "show/a",
+			Title:  "A",
+			AirsAt: &airsAt,
+		}, got.Response[0])
+		require.Nil(t, got.Response[1].AirsAt)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.On("ListAiring", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		svc := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{})
+
+		got, err := svc.AiringSoon(context.Background(), time.Hour, domain.Viewer{})
+		require.Error(t, err)
+		require.Nil(t, got)
+		require.Contains(t, err.Error(), "failed to retrieve airing shows")
+	})
+}
+
+func TestShowSvc_Search(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+	stored := []domain.Show{
+		{Slug: "show/the-taste", Title: "The Taste (Le Goût)", Titles: map[string]string{"fr": "Le Goût"}, Status: domain.StatusPublished},
+		{Slug: "show/tasty", Title: "Tasty", Status: domain.StatusPublished},
+		{Slug: "show/taste-draft", Title: "Taste Draft", Status: domain.StatusDraft},
+	}
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		return &ShowSvc{repo: repo, channels: repoMocks.NewMockChannelRepository(t), opts: ShowOptions{Index: search.New()}, now: func() time.Time { return now }}
+	}
+	titles := func(r *domain.Response) []string {
+		out := []string{}
+		for _, show := range r.Response {
+			out = append(out, show.Title)
+		}
+		return out
+	}
+
+	t.Run("reindex then search what the viewer may see", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return(stored, nil)
+		svc := newSvc(mockRepo)
+
+		n, err := svc.Reindex(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+
+		got, err := svc.Search(context.Background(), "gout", 10, domain.Viewer{Locales: []string{"fr"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Le Goût"}, titles(got))
+
+		got, err = svc.Search(context.Background(), "tast", 10, domain.Viewer{})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 2, "drafts are hidden")
+
+		got, err = svc.Search(context.Background(), "tast", 1, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 1)
+	})
+
+	t.Run("writes keep the index in step", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.EXPECT().Rename(mock.Anything, "show/bluey", "show/bluey-2").Return(&domain.Show{Slug: "show/bluey-2", Title: "Bluey"}, nil)
+		svc := newSvc(mockRepo)
+
+		_, err := svc.Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/bluey", Title: "Bluey"}}}, editor)
+		require.NoError(t, err)
+		got, err := svc.Search(context.Background(), "bluey", 10, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, "show/bluey", got.Response[0].Slug)
+		got, err = svc.Search(context.Background(), "bluey", 10, domain.Viewer{})
+		require.NoError(t, err)
+		require.Empty(t, got.Response, "a new show is a draft")
+
+		require.NoError(t, svc.Rename(context.Background(), "show/bluey", "show/bluey-2"))
+		got, err = svc.Search(context.Background(), "bluey", 10, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, "show/bluey-2", got.Response[0].Slug)
+	})
+
+	t.Run("reindex error keeps the index", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return(nil, errors.New("database error"))
+		svc := newSvc(mockRepo)
+		svc.opts.Index.Put(stored[0])
+
+		_, err := svc.Reindex(context.Background())
+		require.EqualError(t, err, "failed to rebuild search index: database error")
+		require.Equal(t, 1, svc.opts.Index.Len())
+	})
+}
+
+func TestShowSvc_Suggest(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+	popular := 10
+	stored := []domain.Show{
+		{Slug: "show/the-taste", Title: "The Taste (Le Goût)", Titles: map[string]string{"fr": "Le Goût"}, Status: domain.StatusPublished},
+		{Slug: "show/tasty", Title: "Tasty", Popularity: &popular, Status: domain.StatusPublished},
+		{Slug: "show/taste-draft", Title: "Taste Draft", Popularity: &popular, Status: domain.StatusDraft},
+	}
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		return &ShowSvc{repo: repo, channels: repoMocks.NewMockChannelRepository(t), opts: ShowOptions{Suggestions: suggest.New(suggest.Options{})}, now: func() time.Time { return now }}
+	}
+
+	t.Run("rebuild then suggest what the viewer may see", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return(stored, nil)
+		svc := newSvc(mockRepo)
+
+		n, err := svc.RebuildSuggestions(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+
+		got, err := svc.Suggest(context.Background(), "tast", 10, domain.Viewer{})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Suggestion{
+			{Slug: "show/tasty", Title: "Tasty"},
+			{Slug: "show/the-taste", Title: "The Taste (Le Goût)"},
+		}, got.Response, "drafts are hidden; the popular come first")
+
+		got, err = svc.Suggest(context.Background(), "tast", 2, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Suggestion{
+			{Slug: "show/tasty", Title: "Tasty"},
+			{Slug: "show/taste-draft", Title: "Taste Draft", Hidden: "draft"},
+		}, got.Response)
+
+		got, err = svc.Suggest(context.Background(), "gou", 10, domain.Viewer{Locales: []string{"fr"}})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Suggestion{{Slug: "show/the-taste", Title: "Le Goût", Locales: []string{"fr"}}}, got.Response)
+
+		got, err = svc.Suggest(context.Background(), "zebra", 10, domain.Viewer{})
+		require.NoError(t, err)
+		require.Empty(t, got.Response)
+	})
+
+	t.Run("hidden shows do not crowd out visible ones", func(t *testing.T) {
+		shows := []domain.Show{{Slug: "show/tasty", Title: "Tasty", Status: domain.StatusPublished}}
+		for i := range 40 {
+			shows = append(shows, domain.Show{Slug: fmt.Sprintf("show/taste-%d", i), Title: fmt.Sprintf("Taste %d", i), Popularity: &popular, Status: domain.StatusDraft})
+		}
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return(shows, nil)
+		svc := newSvc(mockRepo)
+		_, err := svc.RebuildSuggestions(context.Background())
+		require.NoError(t, err)
+
+		got, err := svc.Suggest(context.Background(), "tast", 10, domain.Viewer{})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Suggestion{{Slug: "show/tasty", Title: "Tasty"}}, got.Response)
+
+		got, err = svc.Suggest(context.Background(), "tast", 10, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 10)
+		require.Equal(t, "draft", got.Response[0].Hidden)
+	})
+
+	t.Run("writes keep the suggestions in step", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.EXPECT().Rename(mock.Anything, "show/bluey", "show/bluey-2").Return(&domain.Show{Slug: "show/bluey-2", Title: "Bluey"}, nil)
+		svc := newSvc(mockRepo)
+
+		_, err := svc.Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/bluey", Title: "Bluey"}}}, editor)
+		require.NoError(t, err)
+		got, err := svc.Suggest(context.Background(), "blu", 10, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Suggestion{{Slug: "show/bluey", Title: "Bluey", Hidden: "draft"}}, got.Response)
+
+		require.NoError(t, svc.Rename(context.Background(), "show/bluey", "show/bluey-2"))
+		got, err = svc.Suggest(context.Background(), "blu", 10, domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, "show/bluey-2", got.Response[0].Slug)
+	})
+
+	t.Run("rebuild error keeps the suggestions", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return(nil, errors.New("database error"))
+		svc := newSvc(mockRepo)
+		svc.opts.Suggestions.Put(stored[0])
+
+		_, err := svc.RebuildSuggestions(context.Background())
+		require.EqualError(t, err, "failed to rebuild suggestions: database error")
+		require.Equal(t, 1, svc.opts.Suggestions.Len())
+	})
+}
+
+func TestShowSvc_Duplicates(t *testing.T) {
+	stored := []domain.Show{
+		{Slug: "show/sea-patrol", Title: "Sea Patrol", Country: stringPtr("AU")},
+		{Slug: "show/bluey", Title: "Bluey"},
+	}
+	newSvc := func(repo *repoMocks.MockShowRepository, strict bool) *ShowSvc {
+		svc := NewShowService(repo, repoMocks.NewMockChannelRepository(t), ShowOptions{StrictDuplicates: strict}).(*ShowSvc)
+		svc.opts.Duplicates.Replace(stored)
+		return svc
+	}
+
+	t.Run("likely duplicates are flagged and stored", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(3)
+		svc := newSvc(mockRepo, false)
+
+		created, err := svc.Create(context.Background(), domain.Request{Payload: []domain.Show{
+			{Slug: "show/seapatrol", Title: "SeaPatrol", Country: stringPtr("AU")},
+			{Slug: "show/sea-patrol-nz", Title: "Sea Patrol", Country: stringPtr("NZ")},
+			{Slug: "show/sea-patrols", Title: "Sea Patrols", Country: stringPtr("AU")},
+		}}, domain.Actor{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"show/seapatrol", "show/sea-patrol-nz", "show/sea-patrols"}, created.Slugs)
+		require.Equal(t, []domain.Duplicate{
+			{Path: "/payload/0", Slug: "show/seapatrol", Title: "SeaPatrol", Matches: []domain.DuplicateMatch{
+				{Slug: "show/sea-patrol", Title: "Sea Patrol", Score: 1},
+			}},
+			{Path: "/payload/2", Slug: "show/sea-patrols", Title: "Sea Patrols", Matches: []domain.DuplicateMatch{
+				{Slug: "show/sea-patrol", Title: "Sea Patrol", Score: 0.86},
+				{Slug: "show/seapatrol", Title: "SeaPatrol", Score: 0.86},
+			}},
+		}, created.Duplicates, "another country is another show; earlier items count")
+	})
+
+	t.Run("generated slugs are flagged against their own", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool { return s.Slug == "show/bluey" }), mock.Anything).
+			Return(fmt.Errorf("show show/bluey: %w", apperror.ErrDuplicateSlug))
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool { return s.Slug == "show/bluey-2" }), mock.Anything).Return(nil)
+		svc := newSvc(mockRepo, false)
+
+		created, err := svc.Create(context.Background(), domain.Request{Payload: []domain.Show{
+			{Slug: "show/bluey", Title: "Bluey", SlugGenerated: true},
+		}}, domain.Actor{})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Duplicate{{Path: "/payload/0", Slug: "show/bluey-2", Title: "Bluey", Matches: []domain.DuplicateMatch{
+			{Slug: "show/bluey", Title: "Bluey", Score: 1},
+		}}}, created.Duplicates)
+	})
+
+	t.Run("strict mode rejects before anything is written", func(t *testing.T) {
+		svc := newSvc(repoMocks.NewMockShowRepository(t), true)
+
+		_, err := svc.Create(context.Background(), domain.Request{Payload: []domain.Show{
+			{Slug: "show/the-taste", Title: "The Taste"},
+			{Slug: "show/seapatrol", Title: "SeaPatrol"},
+			{Slug: "show/thetaste", Title: "The  Taste!"},
+		}}, domain.Actor{})
+
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, []apperror.FieldError{
+			{Path: "/payload/1/title", Code: "duplicate_likely", Message: "title is 100% similar to show/sea-patrol (Sea Patrol)"},
+			{Path: "/payload/2/title", Code: "duplicate_likely", Message: "title is 100% similar to show/the-taste (The Taste)"},
+		}, appErr.Fields)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.EXPECT().Rename(mock.Anything, "show/bluey", "show/bluey-au").Return(&domain.Show{Slug: "show/bluey-au", Title: "Bluey"}, nil)
+		svc := newSvc(mockRepo, false)
+
+		got, err := svc.Duplicates(context.Background(), "", 0)
+		require.NoError(t, err)
+		require.Equal(t, []domain.Duplicate{}, got.Response)
+
+		_, err = svc.Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/bluey-nz", Title: "Bluey"}}}, domain.Actor{})
+		require.NoError(t, err)
+		require.NoError(t, svc.Rename(context.Background(), "show/bluey", "show/bluey-au"))
+		got, err = svc.Duplicates(context.Background(), "", 0)
+		require.NoError(t, err)
+		require.Equal(t, []domain.Duplicate{{Slug: "show/bluey-au", Title: "Bluey", Matches: []domain.DuplicateMatch{
+			{Slug: "show/bluey-nz", Title: "Bluey", Score: 1},
+		}}}, got.Response)
+	})
+
+	t.Run("reindex rebuilds the report", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAll(mock.Anything).Return([]domain.Show{
+			{Slug: "show/sea-patrol", Title: "Sea Patrol"},
+			{Slug: "show/seapatrol", Title: "SeaPatrol"},
+		}, nil)
+		svc := newSvc(mockRepo, false)
+
+		_, err := svc.Reindex(context.Background())
+		require.NoError(t, err)
+		got, err := svc.Duplicates(context.Background(), "", 0)
+		require.NoError(t, err)
+		require.Len(t, got.Response, 1)
+		require.Equal(t, "show/sea-patrol", got.Response[0].Slug)
+	})
+}
+
+func TestShowSvc_Localized(t *testing.T) {
+	shows := []domain.Show{
+		{Slug: "show/a", Title: "A", Titles: map[string]string{"fr": "A (fr)", "en-AU": "A (au)"}},
+		{Slug: "show/b", Title: "B", Titles: map[string]string{"en-AU": "B (au)"}},
+		{Slug: "show/c", Title: "C"},
+	}
+
+	t.Run("client locales before fallback", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().List(mock.Anything).Return(shows, nil)
+		svc := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{FallbackLocales: []string{"en-AU"}})
+
+		got, err := svc.List(context.Background(), domain.Viewer{Locales: []string{"fr-CA"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"A (fr)", "B (au)", "C"}, []string{got.Response[0].Title, got.Response[1].Title, got.Response[2].Title})
+		require.Equal(t, []string{"fr", "en-AU"}, got.Locales())
+	})
+
+	t.Run("no fallback keeps defaults", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/b").Return(&shows[1], nil)
+		svc := NewShowService(mockRepo, repoMocks.NewMockChannelRepository(t), ShowOptions{})
+
+		got, err := svc.Get(context.Background(), "show/b", domain.Viewer{Locales: []string{"de"}})
+		require.NoError(t, err)
+		require.Equal(t, "B", got.Title)
+		require.Empty(t, got.Locales)
+		require.Equal(t, map[string]string{"en-AU": "B (au)"}, got.Titles, "variants stay on the full show")
+	})
+}
+
+func TestShowSvc_Availability(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	shows := []domain.Show{
+		{Slug: "show/everywhere", Title: "Everywhere"},
+		{Slug: "show/au", Title: "AU only", Availability: &domain.Availability{Countries: []string{"AU"}}},
+		{Slug: "show/expired", Title: "Expired", Availability: &domain.Availability{End: stringPtr("2024-06-01T00:00:00Z")}},
+	}
+
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		return &ShowSvc{repo: repo, channels: repoMocks.NewMockChannelRepository(t), now: func() time.Time { return now }}
+	}
+
+	t.Run("list hides unavailable shows", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().List(mock.Anything).Return(shows, nil)
+
+		got, err := newSvc(mockRepo).List(context.Background(), domain.Viewer{Country: "AU"})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 2)
+		require.Equal(t, "show/everywhere", got.Response[0].Slug)
+		require.Equal(t, "show/au", got.Response[1].Slug)
+	})
+
+	t.Run("admins see hidden shows with the reason", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().List(mock.Anything).Return(shows, nil)
+
+		got, err := newSvc(mockRepo).List(context.Background(), domain.Viewer{Country: "NZ", IncludeHidden: true})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 3)
+		require.Equal(t, "", got.Response[0].Hidden)
+		require.Equal(t, domain.HiddenCountryNotAllowed, got.Response[1].Hidden)
+		require.Equal(t, domain.HiddenExpired, got.Response[2].Hidden)
+	})
+
+	t.Run("airing soon hides unavailable shows", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().ListAiring(mock.Anything, now, now.Add(time.Hour)).Return(shows, nil)
+
+		got, err := newSvc(mockRepo).AiringSoon(context.Background(), time.Hour, domain.Viewer{})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 1)
+		require.Equal(t, "show/everywhere", got.Response[0].Slug)
+	})
+
+	t.Run("get reports a hidden show as missing", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/expired").Return(&shows[2], nil)
+
+		_, err := newSvc(mockRepo).Get(context.Background(), "show/expired", domain.Viewer{})
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("get marks a hidden show for admins", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/expired").Return(&shows[2], nil)
+
+		got, err := newSvc(mockRepo).Get(context.Background(), "show/expired", domain.Viewer{IncludeHidden: true})
+		require.NoError(t, err)
+		require.Equal(t, domain.HiddenExpired, got.Hidden)
+	})
+}
+
+func TestShowSvc_Workflow(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+	publisher := domain.Actor{ID: "pub", Roles: []domain.Role{domain.RolePublisher}}
+
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		return &ShowSvc{repo: repo, channels: repoMocks.NewMockChannelRepository(t), now: func() time.Time { return now }}
+	}
+
+	t.Run("new shows are drafts", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool {
+			return s.Status == domain.StatusDraft
+		}), mock.Anything).Return(nil)
+
+		_, err := newSvc(mockRepo).Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/a", Title: "A"}}}, domain.Actor{})
+		require.NoError(t, err)
+	})
+
+	t.Run("list only publishes published shows", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().List(mock.Anything).Return([]domain.Show{
+			{Slug: "show/legacy", Title: "Legacy"},
+			{Slug: "show/draft", Title: "Draft", Status: domain.StatusDraft},
+			{Slug: "show/live", Title: "Live", Status: domain.StatusPublished},
+			{Slug: "show/later", Title: "Later", Status: domain.StatusPublished, PublishAt: stringPtr("2024-06-16T00:00:00Z")},
+		}, nil)
+
+		got, err := newSvc(mockRepo).List(context.Background(), domain.Viewer{})
+		require.NoError(t, err)
+		require.Len(t, got.Response, 2)
+		require.Equal(t, "show/legacy", got.Response[0].Slug)
+		require.Equal(t, "show/live", got.Response[1].Slug)
+	})
+
+	t.Run("submit for review", func(t *testing.T) {
+		show := domain.Show{Slug: "show/a", Title: "A", Status: domain.StatusDraft, Revision: 1}
+		want := domain.Transition{From: domain.StatusDraft, To: domain.StatusInReview, Actor: "ed", At: "2024-06-15T00:00:00Z"}
+
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&show, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.Anything, mock.MatchedBy(func(revs []domain.Revision) bool {
+			return len(revs) == 1 && revs[0].Number == 2 && revs[0].Action == domain.RevisionTransitioned && revs[0].Actor == "ed"
+		})).Return(nil)
+
+		got, err := newSvc(mockRepo).Transition(context.Background(), "show/a", domain.StatusChange{Status: domain.StatusInReview}, editor)
+		require.NoError(t, err)
+		require.Equal(t, domain.StatusInReview, got.Status)
+		require.Equal(t, 2, got.Revision)
+		require.Equal(t, []domain.Transition{want}, got.Transitions)
+	})
+
+	t.Run("scheduled publish is stored in UTC", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a", Status: domain.StatusInReview}, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(s domain.Show) bool {
+			return s.PublishAt != nil && *s.PublishAt == "2024-06-30T23:00:00Z" && *s.Transitions[0].PublishAt == *s.PublishAt
+		}), mock.Anything).Return(nil)
+
+		got, err := newSvc(mockRepo).Transition(context.Background(), "show/a",
+			domain.StatusChange{Status: domain.StatusPublished, PublishAt: stringPtr("2024-07-01T09:00:00+10:00")}, publisher)
+		require.NoError(t, err)
+		require.Equal(t, "2024-06-30T23:00:00Z", *got.PublishAt)
+	})
+
+	t.Run("editors cannot publish", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a", Status: domain.StatusInReview}, nil)
+
+		_, err := newSvc(mockRepo).Transition(context.Background(), "show/a", domain.StatusChange{Status: domain.StatusPublished}, editor)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperror.CodeForbidden, appErr.Code)
+	})
+
+	t.Run("transition outside the workflow", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a"}, nil)
+
+		_, err := newSvc(mockRepo).Transition(context.Background(), "show/a", domain.StatusChange{Status: domain.StatusInReview}, publisher)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperror.CodeValidationFailed, appErr.Code)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/old").Return(&domain.Show{Slug: "show/old", AliasOf: stringPtr("show/new")}, nil)
+
+		_, err := newSvc(mockRepo).Transition(context.Background(), "show/old", domain.StatusChange{Status: domain.StatusArchived}, publisher)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("concurrent change", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a", Status: domain.StatusDraft}, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("show show/a: %w", apperror.ErrConflict))
+
+		_, err := newSvc(mockRepo).Transition(context.Background(), "show/a", domain.StatusChange{Status: domain.StatusInReview}, editor)
+		require.ErrorIs(t, err, apperror.ErrConflict)
+	})
+}
+
+func TestShowSvc_Revisions(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	editor := domain.Actor{ID: "ed", Roles: []domain.Role{domain.RoleEditor}}
+	publisher := domain.Actor{ID: "pub", Roles: []domain.Role{domain.RolePublisher}}
+	draft := domain.Show{Slug: "show/a", Title: "B", Status: domain.StatusDraft, Revision: 3}
+	second := domain.Revision{Number: 2, Show: &domain.Show{Slug: "show/a", Title: "A", Status: domain.StatusInReview, Revision: 2}}
+
+	newSvc := func(repo *repoMocks.MockShowRepository) *ShowSvc {
+		return &ShowSvc{repo: repo, channels: repoMocks.NewMockChannelRepository(t), now: func() time.Time { return now }}
+	}
+
+	t.Run("created shows get their first revision", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Put(mock.Anything, mock.MatchedBy(func(s domain.Show) bool { return s.Revision == 1 }),
+			mock.MatchedBy(func(revs []domain.Revision) bool {
+				return len(revs) == 1 && revs[0].Action == domain.RevisionCreated && revs[0].Actor == "ed" && revs[0].Show.Slug == "show/a"
+			})).Return(nil)
+
+		_, err := newSvc(mockRepo).Create(context.Background(), domain.Request{Payload: []domain.Show{{Slug: "show/a", Title: "A"}}}, editor)
+		require.NoError(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&draft, nil)
+		mockRepo.EXPECT().ListRevisions(mock.Anything, "show/a").Return([]domain.Revision{{Number: 1}, {Number: 2}, {Number: 3}}, nil)
+
+		got, err := newSvc(mockRepo).Revisions(context.Background(), "show/a")
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+	})
+
+	t.Run("revisions of a former slug", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/old").Return(&domain.Show{Slug: "show/old", AliasOf: stringPtr("show/a")}, nil)
+
+		_, err := newSvc(mockRepo).Revision(context.Background(), "show/old", 1)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("revert keeps the workflow state", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&draft, nil)
+		mockRepo.EXPECT().GetRevision(mock.Anything, "show/a", 2).Return(&second, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(s domain.Show) bool {
+			return s.Title == "A" && s.Status == domain.StatusDraft && s.Revision == 4
+		}), mock.MatchedBy(func(revs []domain.Revision) bool {
+			return len(revs) == 1 && revs[0].Action == domain.RevisionReverted && *revs[0].RevertedFrom == 2
+		})).Return(nil)
+
+		got, err := newSvc(mockRepo).Revert(context.Background(), "show/a", 2, editor)
+		require.NoError(t, err)
+		require.Equal(t, 4, got.Revision)
+	})
+
+	t.Run("revert keeps episodes added since the revision", func(t *testing.T) {
+		episodes := 5
+		current := draft
+		current.EpisodeCount = &episodes
+		current.Seasons = &[]domain.Season{{Slug: "show/a/season/1"}}
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&current, nil)
+		mockRepo.EXPECT().GetRevision(mock.Anything, "show/a", 2).Return(&second, nil)
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(s domain.Show) bool {
+			return s.Title == "A" && *s.EpisodeCount == 5 && len(*s.Seasons) == 1
+		}), mock.Anything).Return(nil)
+
+		got, err := newSvc(mockRepo).Revert(context.Background(), "show/a", 2, editor)
+		require.NoError(t, err)
+		require.Equal(t, 5, *got.EpisodeCount)
+	})
+
+	t.Run("revert to a revision that no longer validates", func(t *testing.T) {
+		invalid := domain.Revision{Number: 1, Show: &domain.Show{Slug: "show/a", Title: strings.Repeat("A", 121), Revision: 1}}
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&draft, nil)
+		mockRepo.EXPECT().GetRevision(mock.Anything, "show/a", 1).Return(&invalid, nil)
+
+		_, err := newSvc(mockRepo).Revert(context.Background(), "show/a", 1, editor)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperror.CodeValidationFailed, appErr.Code)
+	})
+
+	t.Run("reverting a published show needs a publisher", func(t *testing.T) {
+		published := draft
+		published.Status = domain.StatusPublished
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&published, nil)
+
+		_, err := newSvc(mockRepo).Revert(context.Background(), "show/a", 2, editor)
+		var appErr *apperror.Error
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, apperror.CodeForbidden, appErr.Code)
+	})
+
+	t.Run("revert to a missing revision", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockShowRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, "show/a").Return(&draft, nil)
+		mockRepo.EXPECT().GetRevision(mock.Anything, "show/a", 9).Return(nil, fmt.Errorf("revision 9 of show show/a: %w", apperror.ErrNotFound))
+
+		_, err := newSvc(mockRepo).Revert(context.Background(), "show/a", 9, publisher)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
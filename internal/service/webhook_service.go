@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+	"github.com/marciomarinho/show-service/internal/telemetry"
+)
+
+// WebhookService manages webhooks. Webhooks it returns never carry their
+// secret.
+type WebhookService interface {
+	Create(ctx context.Context, w domain.Webhook) (*domain.Webhook, error)
+	Update(ctx context.Context, w domain.Webhook) error
+	Get(ctx context.Context, id string) (*domain.Webhook, error)
+	List(ctx context.Context) (*domain.WebhooksResponse, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, id string, limit int) (*domain.WebhookDeliveriesResponse, error)
+	Redeliver(ctx context.Context, id, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+type WebhookSvc struct {
+	repo repository.WebhookRepository
+	now  func() time.Time
+}
+
+func NewWebhookService(repo repository.WebhookRepository) WebhookService {
+	return &WebhookSvc{repo: repo, now: time.Now}
+}
+
+// Create stores w under a new ID
+func (s *WebhookSvc) Create(ctx context.Context, w domain.Webhook) (_ *domain.Webhook, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Create")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	w.ID = domain.NewID()
+	w.CreatedAt = s.now().UTC().Format(time.RFC3339)
+	if err := s.repo.Create(ctx, w); err != nil {
+		log.Printf("Error creating webhook for %s: %v", w.URL, err)
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	created := w.Redacted()
+	return &created, nil
+}
+
+// Update replaces the URL, events and secret of an existing webhook
+func (s *WebhookSvc) Update(ctx context.Context, w domain.Webhook) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Update")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	stored, err := s.repo.Get(ctx, w.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	w.CreatedAt = stored.CreatedAt
+	if err := s.repo.Update(ctx, w); err != nil {
+		log.Printf("Error updating webhook %s: %v", w.ID, err)
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookSvc) Get(ctx context.Context, id string) (_ *domain.Webhook, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Get")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	w, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	redacted := w.Redacted()
+	return &redacted, nil
+}
+
+func (s *WebhookSvc) List(ctx context.Context) (_ *domain.WebhooksResponse, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.List")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	webhooks, err := s.repo.List(ctx)
+	if err != nil {
+		log.Printf("Error listing webhooks: %v", err)
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+	for i := range webhooks {
+		webhooks[i] = webhooks[i].Redacted()
+	}
+	return &domain.WebhooksResponse{Response: webhooks}, nil
+}
+
+func (s *WebhookSvc) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Delete")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		log.Printf("Error deleting webhook %s: %v", id, err)
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// Deliveries returns up to limit of a webhook's deliveries, newest first
+func (s *WebhookSvc) Deliveries(ctx context.Context, id string, limit int) (_ *domain.WebhookDeliveriesResponse, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Deliveries")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	deliveries, err := s.repo.ListDeliveries(ctx, id, limit)
+	if err != nil {
+		log.Printf("Error listing deliveries of webhook %s: %v", id, err)
+		return nil, fmt.Errorf("failed to retrieve deliveries: %w", err)
+	}
+	return &domain.WebhookDeliveriesResponse{Response: deliveries}, nil
+}
+
+// Redeliver sends the payload of an earlier delivery again, as a new
+// delivery due at once
+func (s *WebhookSvc) Redeliver(ctx context.Context, id, deliveryID string) (_ *domain.WebhookDelivery, err error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "WebhookService.Redeliver")
+	defer func() {
+		telemetry.RecordError(span, err)
+		span.End()
+	}()
+
+	if _, err := s.repo.Get(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	original, err := s.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve delivery: %w", err)
+	}
+
+	now := s.now().UTC()
+	d := domain.WebhookDelivery{
+		ID:            domain.DeliveryID(now, domain.NewID()),
+		WebhookID:     id,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        domain.DeliveryPending,
+		NextAttemptAt: now.Format(domain.SortableTime),
+		CreatedAt:     now.Format(time.RFC3339),
+		RedeliveryOf:  original.ID,
+	}
+	if err := s.repo.AddDelivery(ctx, d); err != nil {
+		log.Printf("Error redelivering %s to webhook %s: %v", deliveryID, id, err)
+		return nil, fmt.Errorf("failed to redeliver: %w", err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+func TestWebhookSvc(t *testing.T) {
+	now := time.Date(2024, 6, 15, 1, 0, 0, 0, time.UTC)
+	stored := domain.Webhook{
+		ID:        "0123456789abcdef0123456789abcdef",
+		URL:       "https://partner.example.com/hooks",
+		Events:    []string{domain.WebhookShowPublished},
+		Secret:    "0123456789abcdef",
+		CreatedAt: "2024-06-01T00:00:00Z",
+	}
+	newSvc := func(repo *repoMocks.MockWebhookRepository) *WebhookSvc {
+		return &WebhookSvc{repo: repo, now: func() time.Time { return now }}
+	}
+
+	t.Run("create assigns an ID and hides the secret", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		var saved domain.Webhook
+		mockRepo.EXPECT().Create(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, w domain.Webhook) { saved = w }).
+			Return(nil)
+
+		got, err := newSvc(mockRepo).Create(context.Background(), domain.Webhook{URL: stored.URL, Events: stored.Events, Secret: stored.Secret})
+		require.NoError(t, err)
+		require.Regexp(t, domain.MatchWebhookID, saved.ID)
+		require.Equal(t, "2024-06-15T01:00:00Z", saved.CreatedAt)
+		require.Equal(t, stored.Secret, saved.Secret)
+		require.Equal(t, saved.Redacted(), *got)
+	})
+
+	t.Run("update keeps the creation time", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(&stored, nil)
+		update := domain.Webhook{ID: stored.ID, URL: "https://partner.example.com/v2", Events: stored.Events, Secret: stored.Secret}
+		want := update
+		want.CreatedAt = stored.CreatedAt
+		mockRepo.EXPECT().Update(mock.Anything, want).Return(nil)
+
+		require.NoError(t, newSvc(mockRepo).Update(context.Background(), update))
+	})
+
+	t.Run("update of a missing webhook", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(nil, apperror.ErrNotFound)
+
+		err := newSvc(mockRepo).Update(context.Background(), domain.Webhook{ID: stored.ID})
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("get and list hide secrets", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(&stored, nil)
+		mockRepo.EXPECT().List(mock.Anything).Return([]domain.Webhook{stored}, nil)
+
+		got, err := newSvc(mockRepo).Get(context.Background(), stored.ID)
+		require.NoError(t, err)
+		require.Empty(t, got.Secret)
+		list, err := newSvc(mockRepo).List(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.Webhook{stored.Redacted()}, list.Response)
+		require.Equal(t, "0123456789abcdef", stored.Secret)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Delete(mock.Anything, stored.ID).Return(errors.New("boom"))
+
+		err := newSvc(mockRepo).Delete(context.Background(), stored.ID)
+		require.EqualError(t, err, "failed to delete webhook: boom")
+	})
+
+	t.Run("deliveries of a missing webhook", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(nil, apperror.ErrNotFound)
+
+		_, err := newSvc(mockRepo).Deliveries(context.Background(), stored.ID, 10)
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		deliveries := []domain.WebhookDelivery{{ID: "20240615T000000Z-0123456789abcdef", WebhookID: stored.ID}}
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(&stored, nil)
+		mockRepo.EXPECT().ListDeliveries(mock.Anything, stored.ID, 10).Return(deliveries, nil)
+
+		got, err := newSvc(mockRepo).Deliveries(context.Background(), stored.ID, 10)
+		require.NoError(t, err)
+		require.Equal(t, deliveries, got.Response)
+	})
+
+	t.Run("redeliver copies the payload into a new delivery", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		original := domain.WebhookDelivery{
+			ID:        "20240614T000000Z-0123456789abcdef",
+			WebhookID: stored.ID,
+			Event:     domain.WebhookShowPublished,
+			Payload:   json.RawMessage(`{"id":"e1"}`),
+			Status:    domain.DeliveryFailed,
+			Attempts:  8,
+		}
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(&stored, nil)
+		mockRepo.EXPECT().GetDelivery(mock.Anything, stored.ID, original.ID).Return(&original, nil)
+		mockRepo.EXPECT().AddDelivery(mock.Anything, mock.Anything).Return(nil)
+
+		got, err := newSvc(mockRepo).Redeliver(context.Background(), stored.ID, original.ID)
+		require.NoError(t, err)
+		require.Regexp(t, `^20240615T010000Z-`, got.ID)
+		require.Equal(t, domain.WebhookDelivery{
+			ID:            got.ID,
+			WebhookID:     stored.ID,
+			Event:         domain.WebhookShowPublished,
+			Payload:       original.Payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: "2024-06-15T01:00:00Z",
+			CreatedAt:     "2024-06-15T01:00:00Z",
+			RedeliveryOf:  original.ID,
+		}, *got)
+	})
+
+	t.Run("redeliver of a missing delivery", func(t *testing.T) {
+		mockRepo := repoMocks.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().Get(mock.Anything, stored.ID).Return(&stored, nil)
+		mockRepo.EXPECT().GetDelivery(mock.Anything, stored.ID, "x").Return(nil, apperror.ErrNotFound)
+
+		_, err := newSvc(mockRepo).Redeliver(context.Background(), stored.ID, "x")
+		require.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+}
@@ -0,0 +1,157 @@
+// Package stream fans show changes out to live subscribers, such as the
+// Server-Sent Events endpoint. A Broker numbers each change and keeps the
+// most recent ones, so a client that reconnects can resume where it left
+// off.
+package stream
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// Event is a change as numbered by a Broker
+type Event struct {
+	// ID is unique to the broker's process: the time it started and a
+	// sequence number, so that IDs from before a restart are recognised as
+	// stale rather than mistaken for recent ones
+	ID     string
+	Change domain.ChangeEvent
+}
+
+// Broker delivers every change published to it to each subscriber, in
+// order. Publishing never blocks: a subscriber that falls a whole buffer
+// behind is dropped, and can resume from the replay buffer when it
+// subscribes again.
+type Broker struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	replay []Event // the most recent events, oldest first
+	size   int
+	buffer int
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewBroker returns a broker that keeps the last replay events and buffers
+// up to buffer events per subscriber
+func NewBroker(replay, buffer int) *Broker {
+	return &Broker{
+		epoch:  strconv.FormatInt(time.Now().UnixMilli(), 36),
+		size:   replay,
+		buffer: buffer,
+		subs:   map[*Subscription]struct{}{},
+	}
+}
+
+// Publish numbers e and hands it to every subscriber
+func (b *Broker) Publish(e domain.ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.seq++
+	event := Event{ID: b.epoch + "-" + strconv.FormatUint(b.seq, 10), Change: e}
+	if b.size > 0 {
+		if len(b.replay) == b.size {
+			b.replay = append(b.replay[:0], b.replay[1:]...)
+		}
+		b.replay = append(b.replay, event)
+	}
+	for sub := range b.subs {
+		select {
+		case sub.events <- event:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// Subscribe starts a subscription to events published from now on. With
+// the ID of the last event a client saw, replay holds the buffered events
+// that followed it. complete is false when some may be missing: lastID is
+// from before a restart, or older than anything still buffered.
+func (b *Broker) Subscribe(lastID string) (sub *Subscription, replay []Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = &Subscription{broker: b, events: make(chan Event, b.buffer)}
+	if b.closed {
+		close(sub.events)
+		return sub, nil, false
+	}
+	b.subs[sub] = struct{}{}
+
+	if lastID == "" {
+		return sub, nil, true
+	}
+	seq, ok := b.sequence(lastID)
+	if !ok || seq > b.seq {
+		return sub, nil, false
+	}
+	if seq == b.seq {
+		return sub, nil, true
+	}
+	if len(b.replay) == 0 {
+		return sub, nil, false
+	}
+	first := b.seq - uint64(len(b.replay)) + 1
+	if seq+1 < first {
+		// Resume from what is left, but tell the client of the gap
+		return sub, append([]Event(nil), b.replay...), false
+	}
+	return sub, append([]Event(nil), b.replay[seq+1-first:]...), true
+}
+
+// Close ends every subscription, and any made later, so streams finish
+// and the server can shut down
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		b.drop(sub)
+	}
+}
+
+// sequence reads the sequence number from id, if this broker issued it
+func (b *Broker) sequence(id string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != b.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+// drop ends sub; b.mu must be held
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Subscription receives a broker's events until it is closed or dropped
+type Subscription struct {
+	broker *Broker
+	events chan Event
+}
+
+// Events is closed when the subscription ends, whether because the
+// subscriber fell behind, the broker closed or Close was called
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func change(slug string) domain.ChangeEvent {
+	return domain.ChangeEvent{Type: domain.EventShowCreated, Slug: slug}
+}
+
+func slugs(events []Event) []string {
+	out := []string{}
+	for _, e := range events {
+		out = append(out, e.Change.Slug)
+	}
+	return out
+}
+
+func TestBroker_Publish(t *testing.T) {
+	b := NewBroker(10, 10)
+	first, _, _ := b.Subscribe("")
+	second, _, _ := b.Subscribe("")
+
+	b.Publish(change("show/a"))
+	b.Publish(change("show/b"))
+
+	for _, sub := range []*Subscription{first, second} {
+		a, b := <-sub.Events(), <-sub.Events()
+		require.Equal(t, "show/a", a.Change.Slug)
+		require.Equal(t, "show/b", b.Change.Slug)
+		require.NotEqual(t, a.ID, b.ID)
+	}
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	b := NewBroker(3, 10)
+	var ids []string
+	for _, slug := range []string{"show/a", "show/b", "show/c", "show/d", "show/e"} {
+		sub, _, _ := b.Subscribe("")
+		b.Publish(change(slug))
+		ids = append(ids, (<-sub.Events()).ID)
+		sub.Close()
+	}
+
+	tests := []struct {
+		name         string
+		lastID       string
+		wantReplay   []string
+		wantComplete bool
+	}{
+		{name: "fresh", wantReplay: []string{}, wantComplete: true},
+		{name: "resumes after the last seen", lastID: ids[2], wantReplay: []string{"show/d", "show/e"}, wantComplete: true},
+		{name: "up to date", lastID: ids[4], wantReplay: []string{}, wantComplete: true},
+		{name: "older than the buffer", lastID: ids[0], wantReplay: []string{"show/c", "show/d", "show/e"}},
+		{name: "from before a restart", lastID: "abc-3", wantReplay: []string{}},
+		{name: "from the future", lastID: ids[4] + "0", wantReplay: []string{}},
+		{name: "malformed", lastID: "nope", wantReplay: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay, complete := b.Subscribe(tt.lastID)
+			defer sub.Close()
+			require.Equal(t, tt.wantReplay, slugs(replay))
+			require.Equal(t, tt.wantComplete, complete)
+		})
+	}
+}
+
+func TestBroker_DropsSlowSubscribers(t *testing.T) {
+	b := NewBroker(10, 1)
+	slow, _, _ := b.Subscribe("")
+
+	b.Publish(change("show/a"))
+	b.Publish(change("show/b"))
+
+	require.Equal(t, "show/a", (<-slow.Events()).Change.Slug)
+	_, open := <-slow.Events()
+	require.False(t, open)
+
+	// The dropped subscriber can resume from the replay buffer
+	resumed, replay, complete := b.Subscribe(b.replay[0].ID)
+	defer resumed.Close()
+	require.True(t, complete)
+	require.Equal(t, []string{"show/b"}, slugs(replay))
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker(10, 10)
+	sub, _, _ := b.Subscribe("")
+
+	b.Close()
+	_, open := <-sub.Events()
+	require.False(t, open)
+	sub.Close()
+
+	late, _, complete := b.Subscribe("")
+	_, open = <-late.Events()
+	require.False(t, open)
+	require.False(t, complete)
+	b.Publish(change("show/a"))
+}
@@ -0,0 +1,431 @@
+// Package suggest completes show titles as they are typed. Titles, their
+// localized variants and the titles implied by former slugs are folded into
+// keys the way slugs are, and each key is also entered from its later words,
+// so "taste" completes "The Taste (Le Goût)". Every node of the trie keeps
+// its best shows, so a lookup costs the length of the prefix only.
+package suggest
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+// Bounds on what one show adds to the trie
+const (
+	maxKeyLen     = 64 // bytes of a key kept, enough to tell titles apart
+	maxWordStarts = 4  // words of a title a key may start from
+	maxKeys       = 32 // keys per show, across titles and aliases
+	// maxCollect bounds the shows a filtered lookup ranks once a node's
+	// best are turned down, so that a short prefix never walks the trie
+	maxCollect = 1024
+)
+
+// Options bound a Trie
+type Options struct {
+	// MaxShows caps the shows held; when full, the lowest ranked give way.
+	// 0 means no cap.
+	MaxShows int
+	// TopK is how many shows each node keeps, and so the most a lookup
+	// returns; 32 when 0
+	TopK int
+}
+
+// Trie is safe for concurrent use
+type Trie struct {
+	mu      sync.RWMutex
+	opts    Options
+	root    *node
+	entries map[string]*entry // by slug
+	ranks   ranks             // the entries, lowest ranked on top
+}
+
+type node struct {
+	children map[byte]*node
+	ends     []*entry // shows with a key ending here
+	top      []*entry // the best shows with a key at or below here, ranked
+}
+
+// entry is the part of a show kept for suggesting it
+type entry struct {
+	show  domain.Show
+	keys  []string
+	index int // in Trie.ranks
+}
+
+func New(opts Options) *Trie {
+	if opts.TopK <= 0 {
+		opts.TopK = 32
+	}
+	return &Trie{opts: opts, root: &node{}, entries: map[string]*entry{}}
+}
+
+// Put enters show, replacing what was entered at its slug. Alias stubs are
+// not entered, nor is a new show ranked below every other when the trie is
+// full.
+func (t *Trie) Put(show domain.Show) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remove(show.Slug)
+	if show.AliasOf != nil {
+		return
+	}
+	e := newEntry(show)
+	if t.opts.MaxShows > 0 && len(t.entries) >= t.opts.MaxShows {
+		worst := t.ranks[0]
+		if compare(e, worst) > 0 {
+			return
+		}
+		t.remove(worst.show.Slug)
+	}
+	t.add(e)
+}
+
+// Delete removes the show at slug, if entered
+func (t *Trie) Delete(slug string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remove(slug)
+}
+
+// Rename moves the show entered at from to slug to, as ShowRepo.Rename
+// does, so that it is still suggested by its former title
+func (t *Trie) Rename(from, to string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[from]
+	if !ok {
+		return
+	}
+	show := e.show
+	t.remove(from)
+	show.Rename(to)
+	show.Aliases = append(slices.Clip(show.Aliases), from)
+	t.remove(to)
+	t.add(newEntry(show))
+}
+
+// Replace enters shows in place of everything entered so far, keeping the
+// best ranked when there are more than MaxShows. Lookups see either the old
+// trie or the new one.
+func (t *Trie) Replace(shows []domain.Show) {
+	entries := make([]*entry, 0, len(shows))
+	for _, show := range shows {
+		if show.AliasOf == nil {
+			entries = append(entries, newEntry(show))
+		}
+	}
+	slices.SortFunc(entries, compare)
+	entries = slices.CompactFunc(entries, func(a, b *entry) bool { return a.show.Slug == b.show.Slug })
+	if t.opts.MaxShows > 0 && len(entries) > t.opts.MaxShows {
+		entries = entries[:t.opts.MaxShows]
+	}
+
+	fresh := New(t.opts)
+	for _, e := range entries {
+		fresh.add(e)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root, t.entries, t.ranks = fresh.root, fresh.entries, fresh.ranks
+}
+
+// Len returns the number of shows entered
+func (t *Trie) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.entries)
+}
+
+// Lookup returns the best ranked shows with a title or former title
+// beginning with prefix, at most n of them and never more than TopK. The
+// shows hold their slug, titles, aliases, popularity and what decides
+// whether they are visible only.
+func (t *Trie) Lookup(prefix string, n int) []domain.Show {
+	return t.LookupFunc(prefix, n, nil)
+}
+
+// LookupFunc is Lookup keeping only the shows keep accepts, e.g. those a
+// viewer may see. When keep turns down enough of a node's best shows, the
+// rest of the node's subtree is ranked, up to the first 1024 shows found in
+// it, so that shows kept out never crowd out the shows after them; up to n
+// shows are then returned, beyond TopK. keep runs under the trie's read
+// lock.
+func (t *Trie) LookupFunc(prefix string, n int, keep func(domain.Show) bool) []domain.Show {
+	key := foldKey(prefix)
+	if key == "" || n <= 0 {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	nd := t.root
+	for i := 0; i < len(key); i++ {
+		if nd = nd.children[key[i]]; nd == nil {
+			return nil
+		}
+	}
+	if keep == nil {
+		return pick(nd.top, n, nil)
+	}
+	shows := pick(nd.top, n, keep)
+	if len(shows) == n || len(nd.top) < t.opts.TopK {
+		return shows // nd.top holds the whole subtree when not full
+	}
+	ranked := nd.collect(map[*entry]bool{}, nil, maxCollect)
+	slices.SortFunc(ranked, compare)
+	return pick(ranked, n, keep)
+}
+
+// Refresh calls rebuild every interval until ctx is done, logging failures.
+// Writes keep the trie current in between; refreshing picks up writes made
+// to the table from elsewhere.
+func Refresh(ctx context.Context, every time.Duration, rebuild func(context.Context) (int, error)) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := rebuild(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("suggest refresh: %v", err)
+			}
+		}
+	}
+}
+
+// add enters e, whose slug must not be entered
+func (t *Trie) add(e *entry) {
+	t.entries[e.show.Slug] = e
+	heap.Push(&t.ranks, e)
+	for _, key := range e.keys {
+		nd := t.root
+		t.offer(nd, e)
+		for i := 0; i < len(key); i++ {
+			next := nd.children[key[i]]
+			if next == nil {
+				if nd.children == nil {
+					nd.children = map[byte]*node{}
+				}
+				next = &node{}
+				nd.children[key[i]] = next
+			}
+			nd = next
+			t.offer(nd, e)
+		}
+		nd.ends = append(nd.ends, e)
+	}
+}
+
+// offer ranks e among nd's best shows
+func (t *Trie) offer(nd *node, e *entry) {
+	if slices.Contains(nd.top, e) {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(nd.top, e, compare)
+	if i >= t.opts.TopK {
+		return
+	}
+	nd.top = slices.Insert(nd.top, i, e)
+	if len(nd.top) > t.opts.TopK {
+		nd.top = nd.top[:t.opts.TopK]
+	}
+}
+
+// remove takes the show at slug out of the trie, if entered. The best
+// shows of each node on its keys' paths are ranked again from the node's
+// own shows and its children's best, deepest first, and nodes left empty
+// are pruned.
+func (t *Trie) remove(slug string) {
+	e, ok := t.entries[slug]
+	if !ok {
+		return
+	}
+	delete(t.entries, slug)
+	heap.Remove(&t.ranks, e.index)
+	for _, key := range e.keys {
+		path := make([]*node, 0, len(key)+1)
+		nd := t.root
+		for i := 0; nd != nil; i++ {
+			path = append(path, nd)
+			if i == len(key) {
+				break
+			}
+			nd = nd.children[key[i]]
+		}
+		if len(path) != len(key)+1 {
+			continue // not entered under key
+		}
+		last := path[len(path)-1]
+		last.ends = slices.DeleteFunc(last.ends, func(x *entry) bool { return x == e })
+		for i := len(path) - 1; i >= 0; i-- {
+			nd := path[i]
+			if i > 0 && len(nd.ends) == 0 && len(nd.children) == 0 {
+				delete(path[i-1].children, key[i-1])
+				continue
+			}
+			if slices.Contains(nd.top, e) {
+				t.rerank(nd)
+			}
+		}
+	}
+}
+
+// rerank rebuilds nd's best shows from its own and its children's
+func (t *Trie) rerank(nd *node) {
+	candidates := slices.Clone(nd.ends)
+	for _, child := range nd.children {
+		candidates = append(candidates, child.top...)
+	}
+	slices.SortFunc(candidates, compare)
+	candidates = slices.Compact(candidates)
+	if len(candidates) > t.opts.TopK {
+		candidates = candidates[:t.opts.TopK]
+	}
+	nd.top = slices.Clip(candidates)
+}
+
+// collect appends the shows with a key at or below nd, each once, until out
+// holds limit shows
+func (nd *node) collect(seen map[*entry]bool, out []*entry, limit int) []*entry {
+	for _, e := range nd.ends {
+		if len(out) == limit {
+			return out
+		}
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	for _, child := range nd.children {
+		if out = child.collect(seen, out, limit); len(out) == limit {
+			return out
+		}
+	}
+	return out
+}
+
+// pick returns the shows of the first n ranked entries keep accepts, or of
+// the first n when keep is nil
+func pick(ranked []*entry, n int, keep func(domain.Show) bool) []domain.Show {
+	shows := make([]domain.Show, 0, min(n, len(ranked)))
+	for _, e := range ranked {
+		if len(shows) == n {
+			break
+		}
+		if keep == nil || keep(e.show) {
+			shows = append(shows, e.show)
+		}
+	}
+	return shows
+}
+
+// ranks is a heap of entries, lowest ranked first, so that a full trie
+// finds the show to give way without scanning them all
+type ranks []*entry
+
+func (r ranks) Len() int           { return len(r) }
+func (r ranks) Less(i, j int) bool { return compare(r[i], r[j]) > 0 }
+
+func (r ranks) Swap(i, j int) {
+	r[i], r[j] = r[j], r[i]
+	r[i].index, r[j].index = i, j
+}
+
+func (r *ranks) Push(x any) {
+	e := x.(*entry)
+	e.index = len(*r)
+	*r = append(*r, e)
+}
+
+func (r *ranks) Pop() any {
+	old := *r
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*r = old[:len(old)-1]
+	return e
+}
+
+// compare ranks the more popular show first, then the shorter title, then
+// by title and slug
+func compare(a, b *entry) int {
+	return cmp.Or(
+		cmp.Compare(popularity(b.show), popularity(a.show)),
+		cmp.Compare(len(a.show.Title), len(b.show.Title)),
+		cmp.Compare(a.show.Title, b.show.Title),
+		cmp.Compare(a.show.Slug, b.show.Slug),
+	)
+}
+
+func popularity(show domain.Show) int {
+	if show.Popularity == nil {
+		return 0
+	}
+	return *show.Popularity
+}
+
+// newEntry keeps what suggesting show needs and derives its keys: each
+// title and former slug, from each of its first few words
+func newEntry(show domain.Show) *entry {
+	kept := domain.Show{
+		Slug:         show.Slug,
+		Title:        show.Title,
+		Titles:       show.Titles,
+		Aliases:      show.Aliases,
+		Popularity:   show.Popularity,
+		Status:       show.Status,
+		PublishAt:    show.PublishAt,
+		Availability: show.Availability,
+	}
+
+	texts := []string{show.Title}
+	for _, tag := range slices.Sorted(maps.Keys(show.Titles)) {
+		texts = append(texts, show.Titles[tag])
+	}
+	for _, alias := range show.Aliases {
+		texts = append(texts, strings.TrimPrefix(alias, "show/"))
+	}
+
+	var keys []string
+	for _, text := range texts {
+		words := domain.FoldWords(text)
+		for i := 0; i < len(words) && i < maxWordStarts; i++ {
+			key := truncate(strings.Join(words[i:], " "))
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+	}
+	return &entry{show: kept, keys: keys}
+}
+
+// foldKey folds a typed prefix as keys are folded. A trailing separator
+// is kept so that "the " no longer completes "theatre".
+func foldKey(prefix string) string {
+	words := domain.FoldWords(prefix)
+	key := strings.Join(words, " ")
+	if key != "" && len(domain.FoldWords(prefix+"x")) > len(words) {
+		key += " "
+	}
+	return truncate(key)
+}
+
+func truncate(key string) string {
+	if len(key) > maxKeyLen {
+		return key[:maxKeyLen]
+	}
+	return key
+}
@@ -0,0 +1,307 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/domain"
+)
+
+func ptr(n int) *int { return &n }
+
+func slugs(shows []domain.Show) []string {
+	out := []string{}
+	for _, s := range shows {
+		out = append(out, s.Slug)
+	}
+	return out
+}
+
+func catalogue(opts Options) *Trie {
+	t := New(opts)
+	t.Put(domain.Show{Slug: "show/the-taste", Title: "The Taste (Le Goût)", Popularity: ptr(50)})
+	t.Put(domain.Show{Slug: "show/tasting-australia", Title: "Tasting Australia", Popularity: ptr(80)})
+	t.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol", Popularity: ptr(10)})
+	t.Put(domain.Show{Slug: "show/theatre", Title: "Theatre Live"})
+	t.Put(domain.Show{Slug: "show/worlds", Title: "World's Most Extreme", Titles: map[string]string{"fr": "Les Plus Extrêmes"}})
+	return t
+}
+
+func TestTrie_Lookup(t *testing.T) {
+	trie := catalogue(Options{})
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "tast", want: []string{"show/tasting-australia", "show/the-taste"}},
+		{prefix: "TAST", want: []string{"show/tasting-australia", "show/the-taste"}},
+		{prefix: "the", want: []string{"show/the-taste", "show/theatre"}},
+		{prefix: "the ", want: []string{"show/the-taste"}},
+		{prefix: "the t", want: []string{"show/the-taste"}},
+		{prefix: "le go", want: []string{"show/the-taste"}},
+		{prefix: "goû", want: []string{"show/the-taste"}},
+		{prefix: "gou", want: []string{"show/the-taste"}},
+		{prefix: "extrêm", want: []string{"show/worlds"}},
+		{prefix: "worlds m", want: []string{"show/worlds"}},
+		{prefix: "patrol", want: []string{"show/sea-patrol"}},
+		{prefix: "zebra", want: []string{}},
+		{prefix: "!!!", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			require.Equal(t, tt.want, slugs(trie.Lookup(tt.prefix, 10)))
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		require.Equal(t, []string{"show/tasting-australia"}, slugs(trie.Lookup("t", 1)))
+		require.Empty(t, trie.Lookup("t", 0))
+	})
+
+	t.Run("keeps what visibility needs only", func(t *testing.T) {
+		trie := New(Options{})
+		trie.Put(domain.Show{Slug: "show/a", Title: "A", Description: new(string), Status: domain.StatusDraft, Seasons: &[]domain.Season{}})
+		got := trie.Lookup("a", 1)[0]
+		require.Equal(t, domain.StatusDraft, got.Status)
+		require.Nil(t, got.Description)
+		require.Nil(t, got.Seasons)
+	})
+}
+
+func TestTrie_LookupFunc(t *testing.T) {
+	hidden := func(show domain.Show) bool { return show.Status != domain.StatusDraft }
+
+	t.Run("shows kept out do not crowd out the rest", func(t *testing.T) {
+		trie := New(Options{TopK: 3})
+		for i := range 5 {
+			trie.Put(domain.Show{Slug: fmt.Sprintf("show/draft-%d", i), Title: fmt.Sprintf("Sea Draft %d", i), Popularity: ptr(10 + i), Status: domain.StatusDraft})
+		}
+		trie.Put(domain.Show{Slug: "show/sea-patrol", Title: "Sea Patrol", Popularity: ptr(1)})
+		trie.Put(domain.Show{Slug: "show/seal", Title: "Seal"})
+
+		require.NotContains(t, slugs(trie.Lookup("sea", 10)), "show/sea-patrol", "the best three are all drafts")
+		require.Equal(t, []string{"show/sea-patrol", "show/seal"}, slugs(trie.LookupFunc("sea", 10, hidden)))
+		require.Equal(t, []string{"show/sea-patrol"}, slugs(trie.LookupFunc("sea", 1, hidden)))
+		require.Equal(t, []string{"show/seal"}, slugs(trie.LookupFunc("seal", 10, hidden)))
+	})
+
+	t.Run("nothing kept", func(t *testing.T) {
+		trie := catalogue(Options{TopK: 2})
+		require.Empty(t, trie.LookupFunc("t", 10, func(domain.Show) bool { return false }))
+	})
+
+	t.Run("beyond TopK", func(t *testing.T) {
+		trie := catalogue(Options{TopK: 1})
+		require.Equal(t, []string{"show/tasting-australia", "show/the-taste", "show/theatre"}, slugs(trie.LookupFunc("t", 10, func(domain.Show) bool { return true })))
+	})
+}
+
+func TestTrie_Writes(t *testing.T) {
+	t.Run("put re-ranks the show", func(t *testing.T) {
+		trie := catalogue(Options{})
+		trie.Put(domain.Show{Slug: "show/the-taste", Title: "The Taste (Le Goût)", Popularity: ptr(90)})
+		require.Equal(t, []string{"show/the-taste", "show/tasting-australia"}, slugs(trie.Lookup("tast", 10)))
+		require.Equal(t, 5, trie.Len())
+	})
+
+	t.Run("put replaces the title", func(t *testing.T) {
+		trie := catalogue(Options{})
+		trie.Put(domain.Show{Slug: "show/the-taste", Title: "The Flavour"})
+		require.Equal(t, []string{"show/tasting-australia"}, slugs(trie.Lookup("tast", 10)))
+		require.Equal(t, []string{"show/the-taste"}, slugs(trie.Lookup("flav", 10)))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		trie := catalogue(Options{})
+		trie.Delete("show/tasting-australia")
+		trie.Delete("show/missing")
+		require.Equal(t, []string{"show/the-taste"}, slugs(trie.Lookup("tast", 10)))
+		require.Empty(t, trie.Lookup("austr", 10))
+		require.Equal(t, 4, trie.Len())
+	})
+
+	t.Run("alias stubs are not entered", func(t *testing.T) {
+		trie := New(Options{})
+		trie.Put(domain.Show{Slug: "show/old", Title: "Old", AliasOf: new(string)})
+		require.Zero(t, trie.Len())
+	})
+
+	t.Run("rename keeps the former title", func(t *testing.T) {
+		trie := catalogue(Options{})
+		trie.Rename("show/sea-patrol", "show/sea-patrol-2007")
+		trie.Rename("show/missing", "show/elsewhere")
+		require.Equal(t, []string{"show/sea-patrol-2007"}, slugs(trie.Lookup("sea p", 10)))
+		require.Equal(t, []string{"show/sea-patrol-2007"}, slugs(trie.Lookup("patrol", 10)))
+	})
+
+	t.Run("aliases suggest the show", func(t *testing.T) {
+		trie := New(Options{})
+		trie.Put(domain.Show{Slug: "show/bluey", Title: "Bluey", Aliases: []string{"show/blue-heeler"}})
+		require.Equal(t, []string{"show/bluey"}, slugs(trie.Lookup("heel", 10)))
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		trie := catalogue(Options{})
+		trie.Replace([]domain.Show{
+			{Slug: "show/bluey", Title: "Bluey"},
+			{Slug: "show/old", Title: "Old", AliasOf: new(string)},
+		})
+		require.Equal(t, 1, trie.Len())
+		require.Empty(t, trie.Lookup("tast", 10))
+		require.Equal(t, []string{"show/bluey"}, slugs(trie.Lookup("blu", 10)))
+	})
+}
+
+func TestTrie_Bounds(t *testing.T) {
+	t.Run("the least popular give way when full", func(t *testing.T) {
+		trie := catalogue(Options{MaxShows: 3})
+		require.Equal(t, 3, trie.Len())
+		require.Equal(t, []string{"show/sea-patrol"}, slugs(trie.Lookup("sea", 10)))
+		require.Empty(t, trie.Lookup("theatre", 10))
+
+		trie.Put(domain.Show{Slug: "show/bluey", Title: "Bluey", Popularity: ptr(100)})
+		require.Equal(t, 3, trie.Len())
+		require.Empty(t, trie.Lookup("sea", 10))
+		require.Equal(t, []string{"show/bluey"}, slugs(trie.Lookup("blu", 10)))
+	})
+
+	t.Run("a full trie keeps the best ranked", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		trie := New(Options{MaxShows: 5})
+		var all []*entry
+		for i := range 200 {
+			show := domain.Show{Slug: fmt.Sprintf("show/s%d", i), Title: fmt.Sprintf("Show %d", i), Popularity: ptr(rng.Intn(50))}
+			trie.Put(show)
+			all = append(all, newEntry(show))
+		}
+		slices.SortFunc(all, compare)
+		want := []string{}
+		for _, e := range all[:5] {
+			want = append(want, e.show.Slug)
+		}
+		require.Equal(t, want, slugs(trie.Lookup("show", 10)))
+	})
+
+	t.Run("replace keeps the most popular", func(t *testing.T) {
+		trie := New(Options{MaxShows: 2})
+		trie.Replace([]domain.Show{
+			{Slug: "show/a", Title: "Alpha", Popularity: ptr(1)},
+			{Slug: "show/b", Title: "Bravo", Popularity: ptr(3)},
+			{Slug: "show/c", Title: "Charlie", Popularity: ptr(2)},
+		})
+		require.Equal(t, 2, trie.Len())
+		require.Empty(t, trie.Lookup("alp", 10))
+	})
+
+	t.Run("lookups return at most TopK", func(t *testing.T) {
+		trie := New(Options{TopK: 2})
+		for i := range 5 {
+			trie.Put(domain.Show{Slug: fmt.Sprintf("show/s%d", i), Title: fmt.Sprintf("Show %d", i), Popularity: ptr(i)})
+		}
+		require.Equal(t, []string{"show/s4", "show/s3"}, slugs(trie.Lookup("show", 10)))
+
+		// Each node's best are ranked again when one of them goes
+		trie.Delete("show/s4")
+		require.Equal(t, []string{"show/s3", "show/s2"}, slugs(trie.Lookup("show", 10)))
+	})
+
+	t.Run("keys are capped", func(t *testing.T) {
+		trie := New(Options{})
+		long := "Alpha Bravo Charlie Delta Echo Foxtrot Golf Hotel India Juliett Kilo Lima Mike November"
+		trie.Put(domain.Show{Slug: "show/long", Title: long})
+		require.Len(t, trie.Lookup("delta", 10), 1)
+		require.Empty(t, trie.Lookup("echo", 10), "keys start from the first words only")
+		require.Len(t, trie.Lookup(long, 10), 1, "prefixes are cut to the key length")
+	})
+}
+
+// TestTrie_Consistent checks that, however writes interleave, each lookup
+// returns what ranking every entered show afresh would
+func TestTrie_Consistent(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"sea", "seal", "patrol", "taste", "tasting", "the", "theatre", "blue"}
+	trie := New(Options{TopK: 3})
+	shows := map[string]domain.Show{}
+	for range 2000 {
+		slug := fmt.Sprintf("show/s%d", rng.Intn(30))
+		if rng.Intn(4) == 0 {
+			trie.Delete(slug)
+			delete(shows, slug)
+			continue
+		}
+		show := domain.Show{
+			Slug:       slug,
+			Title:      words[rng.Intn(len(words))] + " " + words[rng.Intn(len(words))],
+			Popularity: ptr(rng.Intn(5)),
+		}
+		trie.Put(show)
+		shows[slug] = show
+	}
+
+	for _, prefix := range []string{"s", "se", "sea", "sea ", "t", "tast", "the", "b", "patrol"} {
+		var want []*entry
+		for _, show := range shows {
+			e := newEntry(show)
+			key := foldKey(prefix)
+			if slices.ContainsFunc(e.keys, func(k string) bool { return len(k) >= len(key) && k[:len(key)] == key }) {
+				want = append(want, e)
+			}
+		}
+		slices.SortFunc(want, compare)
+		wantSlugs := []string{}
+		for _, e := range want[:min(3, len(want))] {
+			wantSlugs = append(wantSlugs, e.show.Slug)
+		}
+		require.Equal(t, wantSlugs, slugs(trie.Lookup(prefix, 3)), prefix)
+	}
+}
+
+func TestTrie_Concurrent(t *testing.T) {
+	trie := catalogue(Options{MaxShows: 10})
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			for j := range 100 {
+				slug := fmt.Sprintf("show/%d-%d", i, j)
+				trie.Put(domain.Show{Slug: slug, Title: "Taste " + slug, Popularity: ptr(j)})
+				trie.Lookup("tas", 5)
+				trie.Rename(slug, slug+"-x")
+			}
+		})
+	}
+	wg.Go(func() { trie.Replace(nil) })
+	wg.Wait()
+	require.LessOrEqual(t, trie.Len(), 10)
+}
+
+func TestRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		Refresh(ctx, time.Millisecond, func(context.Context) (int, error) {
+			if calls.Add(1) == 3 {
+				cancel()
+			}
+			return 0, errors.New("database error")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Refresh did not stop")
+	}
+	require.GreaterOrEqual(t, calls.Load(), int32(3))
+}
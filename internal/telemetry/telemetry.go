@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+// InstrumentationName identifies spans created by this service
+const InstrumentationName = "github.com/marciomarinho/show-service"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Shutdown flushes pending spans and releases exporter resources
+type Shutdown func(ctx context.Context) error
+
+// Tracer returns the service tracer from the global provider. It is looked up
+// on every call so tests can swap the provider for an in-memory recorder.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Setup installs the global tracer provider and W3C propagators according to
+// cfg.Telemetry. With the "none" exporter the global no-op provider is kept.
+func Setup(ctx context.Context, cfg *config.Config) (Shutdown, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch cfg.Telemetry.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if cfg.Telemetry.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Telemetry.Endpoint))
+		}
+		if cfg.Telemetry.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown telemetry exporter %q", cfg.Telemetry.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("telemetry exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName(cfg.Telemetry.ServiceName),
+		semconv.DeploymentEnvironmentName(string(cfg.Env)),
+	)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Telemetry.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// RecordError marks the span as failed when err is not nil
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Attribute keys shared by the service and repository spans
+const (
+	AttrShowSlug  = attribute.Key("show.slug")
+	AttrItemCount = attribute.Key("show.count")
+)
@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/marciomarinho/show-service/internal/config"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *config.Config
+		expectError bool
+	}{
+		{
+			name:        "nil config",
+			cfg:         nil,
+			expectError: true,
+		},
+		{
+			name: "none exporter",
+			cfg:  &config.Config{Telemetry: config.Telemetry{Exporter: ExporterNone}},
+		},
+		{
+			name: "empty exporter defaults to none",
+			cfg:  &config.Config{},
+		},
+		{
+			name: "stdout exporter",
+			cfg:  &config.Config{Telemetry: config.Telemetry{Exporter: ExporterStdout, ServiceName: "test", SampleRatio: 1}},
+		},
+		{
+			name: "otlp exporter",
+			cfg:  &config.Config{Telemetry: config.Telemetry{Exporter: ExporterOTLP, Endpoint: "localhost:4318", Insecure: true, SampleRatio: 1}},
+		},
+		{
+			name:        "unknown exporter",
+			cfg:         &config.Config{Telemetry: config.Telemetry{Exporter: "zipkin"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := otel.GetTracerProvider()
+			t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+			shutdown, err := Setup(context.Background(), tt.cfg)
+			if tt.expectError {
+				require.Error(t, err)
+				require.Nil(t, shutdown)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, shutdown)
+			require.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	_, ok := tp.Tracer("test").Start(context.Background(), "ok")
+	RecordError(ok, nil)
+	ok.End()
+
+	_, failed := tp.Tracer("test").Start(context.Background(), "failed")
+	RecordError(failed, errors.New("boom"))
+	failed.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Equal(t, "boom", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+}
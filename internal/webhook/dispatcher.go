@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// userAgent identifies deliveries to receivers
+const userAgent = "show-service-webhooks"
+
+type DispatcherOptions struct {
+	Interval    time.Duration // time between polls
+	BatchSize   int           // deliveries sent per poll
+	Lease       time.Duration // how long a claimed delivery is reserved; must exceed the client timeout
+	MinBackoff  time.Duration // delay before the first retry, doubled per attempt
+	MaxBackoff  time.Duration // longest delay between retries
+	MaxAttempts int           // attempts before a delivery fails
+}
+
+// Dispatcher sends due deliveries. A delivery succeeds on any 2xx response.
+// Anything else is retried with exponential backoff until MaxAttempts, when
+// the delivery fails; it can still be redelivered by hand. A dispatcher that
+// dies mid-request leaves its claim to expire, so the delivery is sent
+// again.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	opts   DispatcherOptions
+	now    func() time.Time
+}
+
+func NewDispatcher(repo repository.WebhookRepository, client *http.Client, opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{repo: repo, client: client, opts: opts, now: time.Now}
+}
+
+// NewClient returns a client for deliveries. Redirects are not followed:
+// a receiver that moved must have its webhook updated.
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Run dispatches every Interval until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch sends up to BatchSize deliveries due now and returns how many
+// succeeded. Receivers' failures are recorded on their deliveries, not
+// returned.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	due, err := d.repo.DueDeliveries(ctx, d.now(), d.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Settle deliveries even when shutdown cancels ctx mid-batch
+	settle := context.WithoutCancel(ctx)
+	webhooks := map[string]*domain.Webhook{}
+	delivered := 0
+	var errs []error
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		// Each lease starts at its claim: earlier sends in the batch may have
+		// taken longer than a lease
+		claimed, err := d.repo.ClaimDelivery(ctx, delivery, d.now().Add(d.opts.Lease))
+		if errors.Is(err, apperror.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		w, seen := webhooks[claimed.WebhookID]
+		if !seen {
+			w, err = d.repo.Get(ctx, claimed.WebhookID)
+			if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+				// The claim expires and the delivery is tried again
+				errs = append(errs, err)
+				continue
+			}
+			webhooks[claimed.WebhookID] = w
+		}
+
+		result := d.attempt(ctx, w, *claimed)
+		if err := d.repo.SettleDelivery(settle, result); err != nil && !errors.Is(err, apperror.ErrConflict) {
+			errs = append(errs, err)
+			continue
+		}
+		if result.Status == domain.DeliverySucceeded {
+			delivered++
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// attempt sends a claimed delivery to w, which is nil if it was deleted, and
+// returns the delivery updated with the outcome
+func (d *Dispatcher) attempt(ctx context.Context, w *domain.Webhook, delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	var err error
+	retry := true
+	if w == nil {
+		err, retry = errors.New("webhook deleted"), false
+	} else {
+		delivery.ResponseStatus, err = d.send(ctx, *w, delivery)
+	}
+
+	done := d.now().UTC()
+	delivery.LastError = ""
+	switch {
+	case err == nil:
+		delivery.Status = domain.DeliverySucceeded
+	case !retry || delivery.Attempts >= d.opts.MaxAttempts:
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = domain.DeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = done.Add(d.backoff(delivery.Attempts)).Format(domain.SortableTime)
+		return delivery
+	}
+	delivery.NextAttemptAt = ""
+	delivery.CompletedAt = done.Format(time.RFC3339)
+	return delivery
+}
+
+// send posts the delivery's payload to w and returns the response status
+func (d *Dispatcher) send(ctx context.Context, w domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff is the delay after a failed attempt, numbered from 1
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.opts.MinBackoff
+	for i := 1; i < attempt && delay < d.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.opts.MaxBackoff)
+}
@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+const testSecret = "0123456789abcdef"
+
+var dispatchNow = time.Date(2024, 6, 15, 1, 0, 0, 0, time.UTC)
+
+func testDispatcher(repo *repoMocks.MockWebhookRepository) *Dispatcher {
+	d := NewDispatcher(repo, NewClient(5*time.Second), DispatcherOptions{
+		Interval:    time.Second,
+		BatchSize:   10,
+		Lease:       time.Minute,
+		MinBackoff:  10 * time.Second,
+		MaxBackoff:  time.Hour,
+		MaxAttempts: 3,
+	})
+	d.now = func() time.Time { return dispatchNow }
+	return d
+}
+
+func pending(attempts int) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:            "20240615T010000Z-0123456789abcdef",
+		WebhookID:     "h1",
+		Event:         domain.WebhookShowPublished,
+		Payload:       json.RawMessage(`{"id":"e1","event":"show.published"}`),
+		Status:        domain.DeliveryPending,
+		Attempts:      attempts,
+		NextAttemptAt: "2024-06-15T01:00:00Z",
+	}
+}
+
+// expectDelivery sets up repo to hand out d, claimed, and captures how it
+// is settled
+func expectDelivery(repo *repoMocks.MockWebhookRepository, d domain.WebhookDelivery, hook *domain.Webhook, settled *domain.WebhookDelivery) {
+	claimed := d
+	claimed.Attempts++
+	claimed.NextAttemptAt = "2024-06-15T01:01:00Z"
+	repo.EXPECT().DueDeliveries(mock.Anything, dispatchNow, 10).Return([]domain.WebhookDelivery{d}, nil)
+	repo.EXPECT().ClaimDelivery(mock.Anything, d, dispatchNow.Add(time.Minute)).Return(&claimed, nil)
+	if hook != nil {
+		repo.EXPECT().Get(mock.Anything, "h1").Return(hook, nil)
+	} else {
+		repo.EXPECT().Get(mock.Anything, "h1").Return(nil, apperror.ErrNotFound)
+	}
+	repo.EXPECT().SettleDelivery(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, d domain.WebhookDelivery) { *settled = d }).
+		Return(nil)
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Run("signed delivery succeeds", func(t *testing.T) {
+		var got *http.Request
+		var body []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer receiver.Close()
+
+		repo := repoMocks.NewMockWebhookRepository(t)
+		var settled domain.WebhookDelivery
+		expectDelivery(repo, pending(0), &domain.Webhook{ID: "h1", URL: receiver.URL + "/hooks", Secret: testSecret}, &settled)
+
+		delivered, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, delivered)
+
+		require.Equal(t, http.MethodPost, got.Method)
+		require.Equal(t, "/hooks", got.URL.Path)
+		require.Equal(t, "application/json", got.Header.Get("Content-Type"))
+		require.Equal(t, "show.published", got.Header.Get(EventHeader))
+		require.Equal(t, "20240615T010000Z-0123456789abcdef", got.Header.Get(DeliveryHeader))
+		require.JSONEq(t, `{"id":"e1","event":"show.published"}`, string(body))
+		timestamp, err := strconv.ParseInt(got.Header.Get(TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, dispatchNow.Unix(), timestamp)
+		require.True(t, Verify(testSecret, timestamp, body, got.Header.Get(SignatureHeader)))
+
+		require.Equal(t, domain.DeliverySucceeded, settled.Status)
+		require.Equal(t, 1, settled.Attempts)
+		require.Equal(t, http.StatusAccepted, settled.ResponseStatus)
+		require.Empty(t, settled.NextAttemptAt)
+		require.Empty(t, settled.LastError)
+		require.Equal(t, "2024-06-15T01:00:00Z", settled.CompletedAt)
+	})
+
+	t.Run("failure is retried with backoff", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		repo := repoMocks.NewMockWebhookRepository(t)
+		var settled domain.WebhookDelivery
+		expectDelivery(repo, pending(1), &domain.Webhook{ID: "h1", URL: receiver.URL, Secret: testSecret}, &settled)
+
+		delivered, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+		require.Equal(t, domain.DeliveryPending, settled.Status)
+		require.Equal(t, 2, settled.Attempts)
+		require.Equal(t, http.StatusServiceUnavailable, settled.ResponseStatus)
+		require.Equal(t, "receiver answered 503", settled.LastError)
+		// The second attempt failed, so the retry waits 10s doubled once
+		require.Equal(t, "2024-06-15T01:00:20Z", settled.NextAttemptAt)
+		require.Empty(t, settled.CompletedAt)
+	})
+
+	t.Run("redirects are failures", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		repo := repoMocks.NewMockWebhookRepository(t)
+		var settled domain.WebhookDelivery
+		expectDelivery(repo, pending(0), &domain.Webhook{ID: "h1", URL: receiver.URL, Secret: testSecret}, &settled)
+
+		_, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusFound, settled.ResponseStatus)
+		require.Equal(t, domain.DeliveryPending, settled.Status)
+	})
+
+	t.Run("last attempt fails the delivery", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		repo := repoMocks.NewMockWebhookRepository(t)
+		var settled domain.WebhookDelivery
+		expectDelivery(repo, pending(2), &domain.Webhook{ID: "h1", URL: receiver.URL, Secret: testSecret}, &settled)
+
+		_, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, domain.DeliveryFailed, settled.Status)
+		require.Equal(t, 3, settled.Attempts)
+		require.Empty(t, settled.NextAttemptAt)
+		require.Equal(t, "2024-06-15T01:00:00Z", settled.CompletedAt)
+	})
+
+	t.Run("deleted webhook fails the delivery", func(t *testing.T) {
+		repo := repoMocks.NewMockWebhookRepository(t)
+		var settled domain.WebhookDelivery
+		expectDelivery(repo, pending(0), nil, &settled)
+
+		_, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, domain.DeliveryFailed, settled.Status)
+		require.Equal(t, 1, settled.Attempts)
+		require.Equal(t, "webhook deleted", settled.LastError)
+	})
+
+	t.Run("skips deliveries claimed elsewhere", func(t *testing.T) {
+		repo := repoMocks.NewMockWebhookRepository(t)
+		repo.EXPECT().DueDeliveries(mock.Anything, dispatchNow, 10).Return([]domain.WebhookDelivery{pending(0)}, nil)
+		repo.EXPECT().ClaimDelivery(mock.Anything, pending(0), mock.Anything).Return(nil, apperror.ErrConflict)
+
+		delivered, err := testDispatcher(repo).Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+	})
+
+	t.Run("each lease starts when its delivery is claimed", func(t *testing.T) {
+		var mu sync.Mutex
+		clock := dispatchNow
+		// The receiver outlasts a lease on every delivery
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			clock = clock.Add(2 * time.Minute)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		first, second := pending(0), pending(0)
+		second.ID = "20240615T010000Z-fedcba9876543210"
+		repo := repoMocks.NewMockWebhookRepository(t)
+		repo.EXPECT().DueDeliveries(mock.Anything, dispatchNow, 10).Return([]domain.WebhookDelivery{first, second}, nil)
+		repo.EXPECT().ClaimDelivery(mock.Anything, first, dispatchNow.Add(time.Minute)).Return(&first, nil)
+		repo.EXPECT().ClaimDelivery(mock.Anything, second, dispatchNow.Add(3*time.Minute)).Return(&second, nil)
+		repo.EXPECT().Get(mock.Anything, "h1").Return(&domain.Webhook{ID: "h1", URL: receiver.URL, Secret: testSecret}, nil)
+		repo.EXPECT().SettleDelivery(mock.Anything, mock.Anything).Return(nil).Times(2)
+
+		d := testDispatcher(repo)
+		d.now = func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return clock
+		}
+		delivered, err := d.Dispatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, delivered)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		repo := repoMocks.NewMockWebhookRepository(t)
+		repo.EXPECT().DueDeliveries(mock.Anything, dispatchNow, 10).Return(nil, apperror.ErrThrottled)
+
+		_, err := testDispatcher(repo).Dispatch(context.Background())
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+	})
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := testDispatcher(nil)
+	require.Equal(t, 10*time.Second, d.backoff(1))
+	require.Equal(t, 40*time.Second, d.backoff(3))
+	require.Equal(t, time.Hour, d.backoff(20))
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	"github.com/marciomarinho/show-service/internal/outbox"
+	"github.com/marciomarinho/show-service/internal/repository"
+)
+
+// Fanout is an outbox publisher that queues a delivery of each change event
+// to every webhook subscribed to it. Delivery IDs derive from the event and
+// webhook, so an event published twice is still delivered once.
+type Fanout struct {
+	webhooks repository.WebhookRepository
+	shows    repository.ShowRepository
+	now      func() time.Time
+}
+
+var _ outbox.EventPublisher = (*Fanout)(nil)
+
+func NewFanout(webhooks repository.WebhookRepository, shows repository.ShowRepository) *Fanout {
+	return &Fanout{webhooks: webhooks, shows: shows, now: time.Now}
+}
+
+func (f *Fanout) Publish(ctx context.Context, e domain.ChangeEvent) error {
+	event := domain.WebhookEventOf(e)
+	webhooks, err := f.webhooks.List(ctx)
+	if err != nil {
+		return err
+	}
+	var targets []domain.Webhook
+	for _, w := range webhooks {
+		if w.Wants(event) {
+			targets = append(targets, w)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	payload := domain.WebhookPayload{
+		ID:           e.ID,
+		Event:        event,
+		Slug:         e.Slug,
+		PreviousSlug: e.PreviousSlug,
+		Revision:     e.Revision,
+		Actor:        e.Actor,
+		At:           e.At,
+	}
+	show, err := f.shows.Get(ctx, e.Slug)
+	switch {
+	case err == nil:
+		payload.Show = show
+	case !errors.Is(err, apperror.ErrNotFound):
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := f.now().UTC()
+	at, err := time.Parse(time.RFC3339, e.At)
+	if err != nil {
+		at = now
+	}
+	var errs []error
+	for _, w := range targets {
+		d := domain.WebhookDelivery{
+			ID:            domain.DeliveryID(at, e.ID+"#"+w.ID),
+			WebhookID:     w.ID,
+			Event:         event,
+			Payload:       body,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now.Format(domain.SortableTime),
+			CreatedAt:     now.Format(time.RFC3339),
+		}
+		if err := f.webhooks.AddDelivery(ctx, d); err != nil && !errors.Is(err, apperror.ErrConflict) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
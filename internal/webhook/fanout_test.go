@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marciomarinho/show-service/internal/apperror"
+	"github.com/marciomarinho/show-service/internal/domain"
+	repoMocks "github.com/marciomarinho/show-service/internal/repository/mocks"
+)
+
+func TestFanout_Publish(t *testing.T) {
+	now := time.Date(2024, 6, 15, 1, 0, 5, 0, time.UTC)
+	published := domain.ChangeEvent{
+		ID: "e1", Type: domain.EventShowTransitioned, Slug: "show/a", Revision: 3, Actor: "ed",
+		Status: domain.StatusPublished, At: "2024-06-15T01:00:00Z",
+	}
+	hooks := []domain.Webhook{
+		{ID: "h1", Events: []string{domain.WebhookShowPublished}},
+		{ID: "h2", Events: []string{domain.WebhookShowCreated}},
+		{ID: "h3", Events: []string{domain.WebhookShowUpdated, domain.WebhookShowPublished}},
+	}
+	newFanout := func(webhooks *repoMocks.MockWebhookRepository, shows *repoMocks.MockShowRepository) *Fanout {
+		f := NewFanout(webhooks, shows)
+		f.now = func() time.Time { return now }
+		return f
+	}
+
+	t.Run("queues a delivery per subscribed webhook", func(t *testing.T) {
+		webhooks := repoMocks.NewMockWebhookRepository(t)
+		shows := repoMocks.NewMockShowRepository(t)
+		show := &domain.Show{Slug: "show/a", Title: "A", Status: domain.StatusPublished}
+		webhooks.EXPECT().List(mock.Anything).Return(hooks, nil)
+		shows.EXPECT().Get(mock.Anything, "show/a").Return(show, nil)
+
+		var added []domain.WebhookDelivery
+		webhooks.EXPECT().AddDelivery(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, d domain.WebhookDelivery) { added = append(added, d) }).
+			Return(nil).Times(2)
+
+		require.NoError(t, newFanout(webhooks, shows).Publish(context.Background(), published))
+		require.Len(t, added, 2)
+		require.Equal(t, "h1", added[0].WebhookID)
+		require.Equal(t, "h3", added[1].WebhookID)
+
+		d := added[0]
+		require.Equal(t, domain.DeliveryID(now.Add(-5*time.Second), "e1#h1"), d.ID)
+		require.Equal(t, domain.WebhookShowPublished, d.Event)
+		require.Equal(t, domain.DeliveryPending, d.Status)
+		require.Equal(t, "2024-06-15T01:00:05Z", d.NextAttemptAt)
+		require.Equal(t, "2024-06-15T01:00:05Z", d.CreatedAt)
+		require.Zero(t, d.Attempts)
+
+		var payload domain.WebhookPayload
+		require.NoError(t, json.Unmarshal(d.Payload, &payload))
+		require.Equal(t, domain.WebhookPayload{
+			ID: "e1", Event: domain.WebhookShowPublished, Slug: "show/a", Revision: 3, Actor: "ed", At: "2024-06-15T01:00:00Z", Show: show,
+		}, payload)
+		require.Equal(t, d.Payload, added[1].Payload)
+	})
+
+	t.Run("no subscribers", func(t *testing.T) {
+		webhooks := repoMocks.NewMockWebhookRepository(t)
+		webhooks.EXPECT().List(mock.Anything).Return(hooks[1:2], nil)
+
+		require.NoError(t, newFanout(webhooks, repoMocks.NewMockShowRepository(t)).Publish(context.Background(), published))
+	})
+
+	t.Run("already queued and vanished show", func(t *testing.T) {
+		webhooks := repoMocks.NewMockWebhookRepository(t)
+		shows := repoMocks.NewMockShowRepository(t)
+		webhooks.EXPECT().List(mock.Anything).Return(hooks[:1], nil)
+		shows.EXPECT().Get(mock.Anything, "show/a").Return(nil, fmt.Errorf("show show/a: %w", apperror.ErrNotFound))
+		webhooks.EXPECT().AddDelivery(mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			var payload domain.WebhookPayload
+			return json.Unmarshal(d.Payload, &payload) == nil && payload.Show == nil
+		})).Return(fmt.Errorf("delivery x: %w", apperror.ErrConflict))
+
+		require.NoError(t, newFanout(webhooks, shows).Publish(context.Background(), published))
+	})
+
+	t.Run("failures are returned for the outbox to retry", func(t *testing.T) {
+		webhooks := repoMocks.NewMockWebhookRepository(t)
+		shows := repoMocks.NewMockShowRepository(t)
+		webhooks.EXPECT().List(mock.Anything).Return(hooks, nil)
+		shows.EXPECT().Get(mock.Anything, "show/a").Return(&domain.Show{Slug: "show/a"}, nil)
+		webhooks.EXPECT().AddDelivery(mock.Anything, mock.Anything).Return(apperror.ErrThrottled).Times(2)
+
+		err := newFanout(webhooks, shows).Publish(context.Background(), published)
+		require.ErrorIs(t, err, apperror.ErrThrottled)
+	})
+}
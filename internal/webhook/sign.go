@@ -0,0 +1,39 @@
+// Package webhook delivers show events to partner webhooks. Fanout turns
+// each change event from the outbox into one delivery per subscribed
+// webhook, and a Dispatcher sends due deliveries, signed with the webhook's
+// secret, retrying with backoff.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers sent with every delivery
+const (
+	SignatureHeader = "X-Webhook-Signature" // sha256=<hex>, see Sign
+	TimestampHeader = "X-Webhook-Timestamp" // Unix seconds when the request was signed
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the SignatureHeader value for body sent at timestamp: the
+// HMAC-SHA256, keyed by secret, of the timestamp, a dot and the body.
+// Covering the timestamp lets receivers reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the SignatureHeader value for body
+// sent at timestamp, comparing in constant time
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"e1"}`)
+
+	mac := hmac.New(sha256.New, []byte("0123456789abcdef"))
+	mac.Write([]byte(`1718413200.{"id":"e1"}`))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	require.Equal(t, want, Sign("0123456789abcdef", 1718413200, body))
+
+	require.True(t, Verify("0123456789abcdef", 1718413200, body, want))
+	require.False(t, Verify("another-secret-value", 1718413200, body, want))
+	require.False(t, Verify("0123456789abcdef", 1718413201, body, want))
+	require.False(t, Verify("0123456789abcdef", 1718413200, []byte(`{"id":"e2"}`), want))
+	require.False(t, Verify("0123456789abcdef", 1718413200, body, ""))
+}
@@ -12,18 +12,23 @@ java -jar DynamoDBLocal.jar -inMemory -port 8000 &
 echo "Waiting for DynamoDB Local to start..."
 sleep 5
 
-# Create the shows table with the correct structure for the application
-echo "Creating DynamoDB table with GSI..."
+# Create the shows table with the correct structure for the application. Its
+# stream feeds the service's changefeed consumer.
+echo "Creating DynamoDB table with GSI and stream..."
 aws dynamodb create-table \
   --table-name shows-local \
   --attribute-definitions \
     AttributeName=slug,AttributeType=S \
+    AttributeName=sk,AttributeType=S \
     AttributeName=drmKey,AttributeType=N \
     AttributeName=episodeCount,AttributeType=N \
-  --key-schema AttributeName=slug,KeyType=HASH \
+    AttributeName=airingKey,AttributeType=N \
+    AttributeName=nextEpisodeAt,AttributeType=S \
+  --key-schema AttributeName=slug,KeyType=HASH AttributeName=sk,KeyType=RANGE \
   --billing-mode PAY_PER_REQUEST \
+  --stream-specification StreamEnabled=true,StreamViewType=NEW_AND_OLD_IMAGES \
   --global-secondary-indexes \
-    "[{\"IndexName\": \"gsi_drm_episode\",\"KeySchema\": [{\"AttributeName\": \"drmKey\",\"KeyType\": \"HASH\"},{\"AttributeName\": \"episodeCount\",\"KeyType\": \"RANGE\"}],\"Projection\": {\"ProjectionType\": \"ALL\"}}]" \
+    "[{\"IndexName\": \"gsi_drm_episode\",\"KeySchema\": [{\"AttributeName\": \"drmKey\",\"KeyType\": \"HASH\"},{\"AttributeName\": \"episodeCount\",\"KeyType\": \"RANGE\"}],\"Projection\": {\"ProjectionType\": \"ALL\"}},{\"IndexName\": \"gsi_airing\",\"KeySchema\": [{\"AttributeName\": \"airingKey\",\"KeyType\": \"HASH\"},{\"AttributeName\": \"nextEpisodeAt\",\"KeyType\": \"RANGE\"}],\"Projection\": {\"ProjectionType\": \"ALL\"}}]" \
   --endpoint-url http://localhost:8000 \
   --region ap-southeast-2
 
@@ -34,5 +39,24 @@ else
     exit 1
 fi
 
+# Audit records, partitioned by UTC day
+echo "Creating DynamoDB audit table..."
+aws dynamodb create-table \
+  --table-name shows-audit-local \
+  --attribute-definitions \
+    AttributeName=day,AttributeType=S \
+    AttributeName=id,AttributeType=S \
+  --key-schema AttributeName=day,KeyType=HASH AttributeName=id,KeyType=RANGE \
+  --billing-mode PAY_PER_REQUEST \
+  --endpoint-url http://localhost:8000 \
+  --region ap-southeast-2
+
+if [ $? -eq 0 ]; then
+    echo "Table 'shows-audit-local' created successfully!"
+else
+    echo "Failed to create audit table"
+    exit 1
+fi
+
 # Keep the container running
 wait
\ No newline at end of file
@@ -0,0 +1,52 @@
+#!/bin/bash
+# Copy a shows table keyed by slug alone into one keyed by slug and sk.
+#
+# Seasons and episodes share their show's partition, so the shows table has
+# had a sort key, sk, since they were added; show items carry sk=SHOW. A key
+# schema cannot be changed in place, so create the new table first, with the
+# keys, indexes and stream of the one in scripts/entrypoint.sh, then run
+#
+#   scripts/migrate_sk.sh <old table> <new table> [aws options, e.g. --region]
+#
+# and point dynamodb.showsTable at the new table. Every item of the old table
+# is a show and is copied with sk=SHOW. Writes made to the old table while
+# the copy runs are not carried over, so stop writers first. Running it again
+# copies everything again, overwriting the same items. Needs the aws CLI v2
+# and jq.
+set -euo pipefail
+
+if [ $# -lt 2 ]; then
+    echo "usage: $0 <old table> <new table> [aws options]" >&2
+    exit 2
+fi
+from=$1
+to=$2
+shift 2
+
+copied=0
+next=""
+while :; do
+    page=$(aws dynamodb scan --table-name "$from" --max-items 100 ${next:+--starting-token "$next"} --output json "$@")
+
+    # BatchWriteItem takes at most 25 puts
+    while read -r batch; do
+        while [ -n "$batch" ] && [ "$batch" != "{}" ]; do
+            out=$(aws dynamodb batch-write-item --request-items "$batch" --output json "$@")
+            batch=$(jq -c '.UnprocessedItems // {}' <<<"$out")
+            if [ "$batch" != "{}" ]; then
+                sleep 1
+            fi
+        done
+    done < <(jq -c --arg to "$to" '
+        [.Items[] | {PutRequest: {Item: (. + {sk: {S: "SHOW"}})}}]
+        | range(0; length; 25) as $i | {($to): .[$i:$i + 25]}
+    ' <<<"$page")
+
+    copied=$((copied + $(jq '.Items | length' <<<"$page")))
+    next=$(jq -r '.NextToken // empty' <<<"$page")
+    if [ -z "$next" ]; then
+        break
+    fi
+done
+
+echo "Copied $copied items from $from to $to"
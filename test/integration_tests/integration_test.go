@@ -62,26 +62,14 @@ func testShowsIntegration(t *testing.T) {
 		t.Fatal("Request payload not found or invalid")
 	}
 
-	// Create expected response with only the fields returned by the API
-	var expectedResponseList []interface{}
-	for _, show := range payload {
-		showMap, ok := show.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		imageMap, ok := showMap["image"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-		responseShow := map[string]interface{}{
-			"image": imageMap["showImage"],
-			"slug":  showMap["slug"],
-			"title": showMap["title"],
-		}
-		expectedResponseList = append(expectedResponseList, responseShow)
+	// The shows listed publicly, with only the fields returned by the API
+	expectedBody, err := os.ReadFile("data/expected_complete_response.json")
+	if err != nil {
+		t.Fatalf("Failed to read expected_complete_response.json: %v", err)
 	}
-	expectedResponseMap := map[string]interface{}{
-		"response": expectedResponseList,
+	var expectedResponseMap map[string]interface{}
+	if err := json.Unmarshal(expectedBody, &expectedResponseMap); err != nil {
+		t.Fatalf("Failed to unmarshal expected response: %v", err)
 	}
 
 	tests := []struct {
@@ -90,6 +78,7 @@ func testShowsIntegration(t *testing.T) {
 		body           io.Reader
 		expectedStatus int
 		expectError    bool
+		before         func(t *testing.T)
 		validate       func(t *testing.T, resp *http.Response)
 	}{
 		{
@@ -122,6 +111,13 @@ func testShowsIntegration(t *testing.T) {
 			body:           nil,
 			expectedStatus: 200,
 			expectError:    false,
+			// Created shows are drafts, which public reads leave out
+			before: func(t *testing.T) {
+				for _, show := range payload {
+					slug, _ := show.(map[string]interface{})["slug"].(string)
+					publishShow(t, slug)
+				}
+			},
 			validate: func(t *testing.T, resp *http.Response) {
 				body, _ := io.ReadAll(resp.Body)
 				var actualResponse map[string]interface{}
@@ -149,6 +145,10 @@ func testShowsIntegration(t *testing.T) {
 					}
 				}
 
+				if len(responseList) != len(expectedMap) {
+					t.Errorf("Expected %d shows, got %d: %s", len(expectedMap), len(responseList), string(body))
+				}
+
 				// Iterate over response items and compare with expected by slug
 				for _, show := range responseList {
 					showMap, ok := show.(map[string]interface{})
@@ -186,16 +186,29 @@ func testShowsIntegration(t *testing.T) {
 			name:           "POST duplicate request",
 			method:         "POST",
 			body:           bytes.NewReader(requestBody),
-			expectedStatus: 500, // Expect error for duplicates
+			expectedStatus: 409,
 			expectError:    true,
 			validate: func(t *testing.T, resp *http.Response) {
-				// Expect error response
+				if ct := resp.Header.Get("Content-Type"); ct != "application/problem+json" {
+					t.Errorf("Expected Content-Type application/problem+json, got %q", ct)
+				}
+				var problem map[string]interface{}
+				if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+					t.Fatalf("Failed to decode problem: %v", err)
+				}
+				if problem["code"] != "duplicate_slug" || problem["status"] != float64(409) {
+					t.Errorf("Expected a duplicate_slug problem with status 409, got %+v", problem)
+				}
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.before != nil {
+				tt.before(t)
+			}
+
 			var resp *http.Response
 			var err error
 
@@ -221,3 +234,26 @@ func testShowsIntegration(t *testing.T) {
 		})
 	}
 }
+
+// publishShow moves a created show from draft through review to published.
+// Locally every caller is both an editor and a publisher.
+func publishShow(t *testing.T, slug string) {
+	t.Helper()
+	url := "http://localhost:8080/v1/shows/" + strings.TrimPrefix(slug, "show/") + "/status"
+	for _, status := range []string{"in_review", "published"} {
+		req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(`{"status":"`+status+`"}`))
+		if err != nil {
+			t.Fatalf("Failed to build request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Moving %s to %s: expected status 200, got %d. Response: %s", slug, status, resp.StatusCode, string(body))
+		}
+	}
+}